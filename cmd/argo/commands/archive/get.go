@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -13,12 +14,15 @@ import (
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/humanize"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 )
 
 func NewGetCommand() *cobra.Command {
-	var output = common.EnumFlagValue{
-		AllowedValues: []string{"json", "yaml", "wide"},
-		Value:         "wide",
+	var getArgs = common.GetFlags{
+		Output: common.EnumFlagValue{
+			AllowedValues: []string{"json", "yaml", "wide"},
+			Value:         "wide",
+		},
 	}
 	command := &cobra.Command{
 		Use:   "get UID",
@@ -29,6 +33,9 @@ func NewGetCommand() *cobra.Command {
 
 # Get information about an archived workflow in YAML format:
   argo archive get abc123-def456-ghi789-jkl012 -o yaml
+
+# Only show the failed nodes of an archived workflow:
+  argo archive get abc123-def456-ghi789-jkl012 --status Failed
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			uid := args[0]
@@ -41,18 +48,45 @@ func NewGetCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			wf, err := serviceClient.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: uid})
+			wf, err := getArchivedWorkflow(ctx, serviceClient, uid)
 			if err != nil {
 				return err
 			}
-			printWorkflow(wf, output.String())
+			printArchivedWorkflow(wf, getArgs)
 			return nil
 		},
 	}
-	command.Flags().VarP(&output, "output", "o", "Output format. "+output.Usage())
+	command.Flags().VarP(&getArgs.Output, "output", "o", "Output format. "+getArgs.Output.Usage())
+	command.Flags().BoolVar(&common.NoColor, "no-color", false, "Disable colorized output")
+	command.Flags().BoolVar(&common.NoUtf8, "no-utf8", false, "Use plain 7-bits ascii characters")
+	command.Flags().StringVar(&getArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error)")
+	command.Flags().StringVar(&getArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
 	return command
 }
 
+// getArchivedWorkflow gets the archived workflow with its node status, which the server hydrates if it was offloaded.
+func getArchivedWorkflow(ctx context.Context, serviceClient workflowarchivepkg.ArchivedWorkflowServiceClient, uid string) (*wfv1.Workflow, error) {
+	wf, err := serviceClient.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: uid, Compressed: true, Compact: true})
+	if err != nil {
+		return nil, err
+	}
+	// servers that do not support compression or compaction return the node status as-is, in which case these are no-ops
+	if err := packer.DecompressWorkflow(ctx, wf); err != nil {
+		return nil, err
+	}
+	packer.ExpandWorkflow(wf)
+	return wf, nil
+}
+
+// printArchivedWorkflow prints the workflow like `argo get`, including its nodes
+func printArchivedWorkflow(wf *wfv1.Workflow, getArgs common.GetFlags) {
+	if getArgs.Output.String() == "wide" {
+		fmt.Print(common.PrintWorkflowHelper(wf, getArgs))
+		return
+	}
+	printWorkflow(wf, getArgs.Output.String())
+}
+
 func printWorkflow(wf *wfv1.Workflow, output string) {
 
 	switch output {
@@ -0,0 +1,58 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	workflowarchivemocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
+)
+
+func Test_getArchivedWorkflow(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	newWorkflow := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "argo", UID: "my-uid"},
+			Status: wfv1.WorkflowStatus{
+				Phase: wfv1.WorkflowFailed,
+				Nodes: wfv1.Nodes{
+					"my-wf":   {ID: "my-wf", Name: "my-wf", DisplayName: "my-wf", Type: wfv1.NodeTypeDAG, Phase: wfv1.NodeFailed, Children: []string{"my-wf-1"}},
+					"my-wf-1": {ID: "my-wf-1", Name: "my-wf.main", DisplayName: "main", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "exit code 1", BoundaryID: "my-wf"},
+				},
+			},
+		}
+	}
+	getArgs := common.GetFlags{Output: common.EnumFlagValue{Value: "wide"}}
+	getOutput := func(t *testing.T, resp *wfv1.Workflow) string {
+		t.Helper()
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "my-uid", Compressed: true, Compact: true}).Return(resp, nil)
+		wf, err := getArchivedWorkflow(ctx, a, "my-uid")
+		require.NoError(t, err)
+		return common.PrintWorkflowHelper(wf, getArgs)
+	}
+
+	uncompressed := getOutput(t, newWorkflow())
+	assert.Contains(t, uncompressed, "main")
+	assert.Contains(t, uncompressed, "exit code 1")
+
+	// the server hydrates offloaded nodes, and then compresses them
+	compressed := newWorkflow()
+	require.NoError(t, packer.CompressWorkflow(ctx, compressed))
+	require.Empty(t, compressed.Status.Nodes)
+	assert.Equal(t, uncompressed, getOutput(t, compressed))
+
+	// and compacts them before compressing them
+	compacted := newWorkflow()
+	require.True(t, packer.CompactWorkflow(compacted))
+	require.NoError(t, packer.CompressWorkflow(ctx, compacted))
+	assert.Equal(t, uncompressed, getOutput(t, compacted))
+}
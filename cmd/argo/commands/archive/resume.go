@@ -0,0 +1,93 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/fields"
+
+	client "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func NewResumeCommand() *cobra.Command {
+	var nodeFieldSelector string
+	command := &cobra.Command{
+		Use:   "resume UID...",
+		Short: "resume one or more archived workflows that are still live in the cluster",
+		Example: `# Resume an archived workflow by its UID:
+
+  argo archive resume abc123-def456-ghi789-jkl012
+
+# Resume an archived workflow by node field selector:
+
+  argo archive resume abc123-def456-ghi789-jkl012 --node-field-selector inputs.paramaters.myparam.value=abc
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient := apiClient.NewWorkflowServiceClient(ctx)
+			archiveServiceClient, err := apiClient.NewArchivedWorkflowServiceClient()
+			if err != nil {
+				return err
+			}
+			return resumeArchivedWorkflows(ctx, archiveServiceClient, serviceClient, nodeFieldSelector, args)
+		},
+	}
+	command.Flags().StringVar(&nodeFieldSelector, "node-field-selector", "", "selector of node to resume, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
+	return command
+}
+
+// resumeArchivedWorkflows resumes the live workflows of the given archived workflow UIDs
+func resumeArchivedWorkflows(ctx context.Context, archiveServiceClient workflowarchivepkg.ArchivedWorkflowServiceClient, serviceClient workflowpkg.WorkflowServiceClient, nodeFieldSelector string, uids []string) error {
+	selector, err := fields.ParseSelector(nodeFieldSelector)
+	if err != nil {
+		return fmt.Errorf("unable to parse node field selector '%s': %s", nodeFieldSelector, err)
+	}
+	for _, uid := range uids {
+		wf, err := getLiveWorkflow(ctx, archiveServiceClient, serviceClient, uid)
+		if err != nil {
+			return err
+		}
+		_, err = serviceClient.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{
+			Name:              wf.Name,
+			Namespace:         wf.Namespace,
+			NodeFieldSelector: selector.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resume %s: %w", wf.Name, err)
+		}
+		fmt.Printf("workflow %s resumed\n", wf.Name)
+	}
+	return nil
+}
+
+// getLiveWorkflow resolves the UID of an archived workflow to the workflow that is still present in the cluster.
+// It errors if the workflow only exists in the archive, as such workflows cannot be resumed or suspended.
+func getLiveWorkflow(ctx context.Context, archiveServiceClient workflowarchivepkg.ArchivedWorkflowServiceClient, serviceClient workflowpkg.WorkflowServiceClient, uid string) (*wfv1.Workflow, error) {
+	archived, err := archiveServiceClient.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: uid, Compressed: true})
+	if err != nil {
+		return nil, err
+	}
+	wf, err := serviceClient.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{
+		Name:      archived.Name,
+		Namespace: archived.Namespace,
+		Fields:    "metadata.name,metadata.namespace,metadata.uid,metadata.labels",
+	})
+	if status.Code(err) == codes.NotFound || err == nil && (wf.UID != archived.UID || wf.Labels[wfcommon.LabelKeyWorkflowArchivingStatus] == "Persisted") {
+		return nil, fmt.Errorf("workflow %s/%s (%s) only exists in the archive and is not live in the cluster", archived.Namespace, archived.Name, uid)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
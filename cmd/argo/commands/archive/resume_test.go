@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	workflowarchivemocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	wfcommon "github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func Test_resumeArchivedWorkflows(t *testing.T) {
+	archived := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "argo", UID: "my-uid"}}
+
+	t.Run("Resume live workflow", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(archived, nil)
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(archived.DeepCopy(), nil)
+		c.On("ResumeWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := resumeArchivedWorkflows(ctx, a, c, "", []string{"my-uid"})
+		require.NoError(t, err)
+		c.AssertCalled(t, "ResumeWorkflow", mock.Anything, &workflowpkg.WorkflowResumeRequest{Name: "my-wf", Namespace: "argo"})
+	})
+
+	t.Run("Resume purely archived workflow", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(archived, nil)
+		persisted := archived.DeepCopy()
+		persisted.Labels = map[string]string{wfcommon.LabelKeyWorkflowArchivingStatus: "Persisted"}
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(persisted, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := resumeArchivedWorkflows(ctx, a, c, "", []string{"my-uid"})
+		require.EqualError(t, err, "workflow argo/my-wf (my-uid) only exists in the archive and is not live in the cluster")
+		c.AssertNotCalled(t, "ResumeWorkflow", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Resume deleted workflow", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(archived, nil)
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+
+		ctx := logging.TestContext(t.Context())
+		err := resumeArchivedWorkflows(ctx, a, c, "", []string{"my-uid"})
+		require.EqualError(t, err, "workflow argo/my-wf (my-uid) only exists in the archive and is not live in the cluster")
+	})
+}
+
+func Test_suspendArchivedWorkflows(t *testing.T) {
+	archived := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "argo", UID: "my-uid"}}
+
+	t.Run("Suspend live workflow", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(archived, nil)
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(archived.DeepCopy(), nil)
+		c.On("SuspendWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := suspendArchivedWorkflows(ctx, a, c, []string{"my-uid"})
+		require.NoError(t, err)
+		c.AssertCalled(t, "SuspendWorkflow", mock.Anything, &workflowpkg.WorkflowSuspendRequest{Name: "my-wf", Namespace: "argo"})
+	})
+
+	t.Run("Suspend workflow replaced by another with the same name", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(archived, nil)
+		other := archived.DeepCopy()
+		other.UID = "other-uid"
+		c.On("GetWorkflow", mock.Anything, mock.Anything).Return(other, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := suspendArchivedWorkflows(ctx, a, c, []string{"my-uid"})
+		require.Error(t, err)
+		c.AssertNotCalled(t, "SuspendWorkflow", mock.Anything, mock.Anything)
+	})
+}
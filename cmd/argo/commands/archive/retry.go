@@ -4,25 +4,38 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	client "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
 	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 )
 
+// retryBackoff is the backoff between attempts to retry an archived workflow when the server returns a transient error
+var retryBackoff = wait.Backoff{Duration: time.Second, Factor: 2}
+
 type retryOps struct {
-	nodeFieldSelector string // --node-field-selector
-	restartSuccessful bool   // --restart-successful
-	namespace         string // --namespace
-	labelSelector     string // --selector
-	fieldSelector     string // --field-selector
+	nodeFieldSelector string        // --node-field-selector
+	restartSuccessful bool          // --restart-successful
+	namespace         string        // --namespace
+	labelSelector     string        // --selector
+	fieldSelector     string        // --field-selector
+	timeout           time.Duration // --timeout
+	continueOnError   bool          // --continue-on-error
+	maxAttempts       int           // --max-attempts
+	targetNamespace   string        // --target-namespace
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -57,6 +70,10 @@ func NewRetryCommand() *cobra.Command {
 
   argo archive retry --field-selector metadata.namespace=argo
 
+# Retry into a different namespace:
+
+  argo archive retry --target-namespace my-other-namespace uid
+
 # Retry and wait for completion:
 
   argo archive retry --wait uid
@@ -100,6 +117,10 @@ func NewRetryCommand() *cobra.Command {
 	command.Flags().StringVar(&retryOpts.nodeFieldSelector, "node-field-selector", "", "selector of nodes to reset, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
 	command.Flags().StringVarP(&retryOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&retryOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
+	command.Flags().DurationVar(&retryOpts.timeout, "timeout", 0, "The maximum time to wait for each workflow to be retried, e.g. 30s or 1m. Defaults to no timeout.")
+	command.Flags().BoolVar(&retryOpts.continueOnError, "continue-on-error", false, "Continue retrying the remaining workflows if a workflow fails to be retried")
+	command.Flags().IntVar(&retryOpts.maxAttempts, "max-attempts", 3, "The maximum number of calls made to retry each workflow, backing off exponentially while the server is unavailable or the call times out")
+	command.Flags().StringVar(&retryOpts.targetNamespace, "target-namespace", "", "The namespace to retry the workflows into, which must already exist. Defaults to the namespace of each archived workflow.")
 	return command
 }
 
@@ -127,6 +148,7 @@ func retryArchivedWorkflows(ctx context.Context, archiveServiceClient workflowar
 	}
 
 	var lastRetried *wfv1.Workflow
+	var errs []error
 	retriedUids := make(map[string]bool)
 	for _, wf := range wfs {
 		if _, ok := retriedUids[string(wf.UID)]; ok {
@@ -135,22 +157,63 @@ func retryArchivedWorkflows(ctx context.Context, archiveServiceClient workflowar
 		}
 		retriedUids[string(wf.UID)] = true
 
-		lastRetried, err = archiveServiceClient.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{
+		retried, err := retryArchivedWorkflow(ctx, archiveServiceClient, retryOpts, &workflowarchivepkg.RetryArchivedWorkflowRequest{
 			Uid:               string(wf.UID),
 			Namespace:         wf.Namespace,
 			Name:              wf.Name,
 			RestartSuccessful: retryOpts.restartSuccessful,
 			NodeFieldSelector: selector.String(),
 			Parameters:        cliSubmitOpts.Parameters,
+			TargetNamespace:   retryOpts.targetNamespace,
 		})
 		if err != nil {
-			return err
+			if !retryOpts.continueOnError {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "failed to retry %s: %v\n", wf.UID, err)
+			errs = append(errs, err)
+			continue
 		}
+		lastRetried = retried
 		printWorkflow(lastRetried, cliSubmitOpts.Output.String())
 	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to retry %d of %d workflows: %w", len(errs), len(retriedUids), errors.Join(errs...))
+	}
 	if len(retriedUids) == 1 {
 		// watch or wait when there is only one workflow retried
 		return common.WaitWatchOrLog(ctx, serviceClient, lastRetried.Namespace, []string{lastRetried.Name}, cliSubmitOpts)
 	}
 	return nil
 }
+
+// retryArchivedWorkflow retries a single archived workflow. Each call is canceled if it exceeds the timeout, and
+// calls failing with a transient error are repeated with exponential backoff up to the maximum number of attempts.
+func retryArchivedWorkflow(ctx context.Context, archiveServiceClient workflowarchivepkg.ArchivedWorkflowServiceClient, retryOpts retryOps, req *workflowarchivepkg.RetryArchivedWorkflowRequest) (*wfv1.Workflow, error) {
+	backoff := retryBackoff
+	backoff.Steps = max(retryOpts.maxAttempts, 1)
+	var retried *wfv1.Workflow
+	err := waitutil.Backoff(backoff, func() (bool, error) {
+		var err error
+		retried, err = callRetryArchivedWorkflow(ctx, archiveServiceClient, retryOpts.timeout, req)
+		return !isTransientRPCErr(err), err
+	})
+	return retried, err
+}
+
+func callRetryArchivedWorkflow(ctx context.Context, archiveServiceClient workflowarchivepkg.ArchivedWorkflowServiceClient, timeout time.Duration, req *workflowarchivepkg.RetryArchivedWorkflowRequest) (*wfv1.Workflow, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return archiveServiceClient.RetryArchivedWorkflow(ctx, req)
+}
+
+func isTransientRPCErr(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package archive
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-workflows/v3/cmd/argo/commands/common"
+	workflowmocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow/mocks"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	workflowarchivemocks "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive/mocks"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+// slowRetry blocks until the call's context is done, simulating a hung server
+func slowRetry(ctx context.Context, _ *workflowarchivepkg.RetryArchivedWorkflowRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func Test_retryArchivedWorkflows(t *testing.T) {
+	defer func(backoff wait.Backoff) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2}
+
+	t.Run("Slow RPC is canceled at the timeout", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(slowRetry)
+
+		ctx := logging.TestContext(t.Context())
+		start := time.Now()
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", timeout: 100 * time.Millisecond}, common.NewCliSubmitOpts(), []string{"my-uid"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+	})
+
+	t.Run("Continue on error after a slow RPC", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "slow-uid", Namespace: "argo"}).Return(slowRetry)
+		a.On("RetryArchivedWorkflow", mock.Anything, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "fast-uid", Namespace: "argo"}).Return(&wfv1.Workflow{}, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", timeout: 100 * time.Millisecond, continueOnError: true}, common.NewCliSubmitOpts(), []string{"slow-uid", "fast-uid"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		a.AssertNumberOfCalls(t, "RetryArchivedWorkflow", 2)
+	})
+
+	t.Run("Transient errors are retried with backoff", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "unavailable")).Once()
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.DeadlineExceeded, "deadline exceeded")).Once()
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil).Once()
+
+		ctx := logging.TestContext(t.Context())
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", maxAttempts: 3}, common.NewCliSubmitOpts(), []string{"my-uid"})
+		require.NoError(t, err)
+		a.AssertNumberOfCalls(t, "RetryArchivedWorkflow", 3)
+	})
+
+	t.Run("Transient errors exhaust the attempts", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+
+		ctx := logging.TestContext(t.Context())
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", maxAttempts: 2}, common.NewCliSubmitOpts(), []string{"my-uid"})
+		require.ErrorContains(t, err, "unavailable")
+		a.AssertNumberOfCalls(t, "RetryArchivedWorkflow", 2)
+	})
+
+	t.Run("Non-transient errors fail immediately", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+
+		ctx := logging.TestContext(t.Context())
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", maxAttempts: 3}, common.NewCliSubmitOpts(), []string{"my-uid"})
+		require.Equal(t, codes.NotFound, status.Code(err))
+		a.AssertNumberOfCalls(t, "RetryArchivedWorkflow", 1)
+	})
+	t.Run("Target namespace is sent to the server", func(t *testing.T) {
+		a := &workflowarchivemocks.ArchivedWorkflowServiceClient{}
+		c := &workflowmocks.WorkflowServiceClient{}
+		a.On("RetryArchivedWorkflow", mock.Anything, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "my-uid", Namespace: "argo", TargetNamespace: "other"}).Return(&wfv1.Workflow{}, nil)
+
+		ctx := logging.TestContext(t.Context())
+		err := retryArchivedWorkflows(ctx, a, c, retryOps{namespace: "argo", targetNamespace: "other"}, common.NewCliSubmitOpts(), []string{"my-uid"})
+		require.NoError(t, err)
+		a.AssertNumberOfCalls(t, "RetryArchivedWorkflow", 1)
+	})
+}
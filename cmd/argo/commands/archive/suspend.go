@@ -0,0 +1,56 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	client "github.com/argoproj/argo-workflows/v3/cmd/argo/commands/client"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+)
+
+func NewSuspendCommand() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "suspend UID...",
+		Short: "suspend one or more archived workflows that are still live in the cluster",
+		Example: `# Suspend an archived workflow by its UID:
+
+  argo archive suspend abc123-def456-ghi789-jkl012
+`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, apiClient, err := client.NewAPIClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+			serviceClient := apiClient.NewWorkflowServiceClient(ctx)
+			archiveServiceClient, err := apiClient.NewArchivedWorkflowServiceClient()
+			if err != nil {
+				return err
+			}
+			return suspendArchivedWorkflows(ctx, archiveServiceClient, serviceClient, args)
+		},
+	}
+	return command
+}
+
+// suspendArchivedWorkflows suspends the live workflows of the given archived workflow UIDs
+func suspendArchivedWorkflows(ctx context.Context, archiveServiceClient workflowarchivepkg.ArchivedWorkflowServiceClient, serviceClient workflowpkg.WorkflowServiceClient, uids []string) error {
+	for _, uid := range uids {
+		wf, err := getLiveWorkflow(ctx, archiveServiceClient, serviceClient, uid)
+		if err != nil {
+			return err
+		}
+		_, err = serviceClient.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{
+			Name:      wf.Name,
+			Namespace: wf.Namespace,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to suspend %s: %w", wf.Name, err)
+		}
+		fmt.Printf("workflow %s suspended\n", wf.Name)
+	}
+	return nil
+}
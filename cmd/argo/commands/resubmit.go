@@ -13,11 +13,13 @@ import (
 )
 
 type resubmitOps struct {
-	priority      int32  // --priority
-	memoized      bool   // --memoized
-	namespace     string // --namespace
-	labelSelector string // --selector
-	fieldSelector string // --field-selector
+	priority       int32  // --priority
+	memoized       bool   // --memoized
+	failedOnly     bool   // --failed-only
+	serviceAccount string // --serviceaccount
+	namespace      string // --namespace
+	labelSelector  string // --selector
+	fieldSelector  string // --field-selector
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -68,6 +70,10 @@ func NewResubmitCommand() *cobra.Command {
 # Resubmit the latest workflow:
 
   argo resubmit @latest
+
+# Resubmit only the failed steps or tasks of a workflow, as a new workflow:
+
+  argo resubmit --failed-only my-wf
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if cmd.Flag("priority").Changed {
@@ -92,6 +98,8 @@ func NewResubmitCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Watch, "watch", false, "watch the workflow until it completes, only works when a single workflow is resubmitted")
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&resubmitOpts.memoized, "memoized", false, "re-use successful steps & outputs from the previous run")
+	command.Flags().BoolVar(&resubmitOpts.failedOnly, "failed-only", false, "only run the failed steps or tasks of the previous run again, as the entrypoint of the new workflow")
+	command.Flags().StringVar(&resubmitOpts.serviceAccount, "serviceaccount", "", "run the resubmitted workflow using the specified serviceaccount, rather than the original one")
 	command.Flags().StringVarP(&resubmitOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
 	command.Flags().StringVar(&resubmitOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
@@ -134,10 +142,12 @@ func resubmitWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowSe
 		resubmittedNames[wf.Name] = true
 
 		lastResubmitted, err = serviceClient.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{
-			Namespace:  wf.Namespace,
-			Name:       wf.Name,
-			Memoized:   resubmitOpts.memoized,
-			Parameters: cliSubmitOpts.Parameters,
+			Namespace:      wf.Namespace,
+			Name:           wf.Name,
+			Memoized:       resubmitOpts.memoized,
+			FailedOnly:     resubmitOpts.failedOnly,
+			Parameters:     cliSubmitOpts.Parameters,
+			ServiceAccount: resubmitOpts.serviceAccount,
 		})
 		if err != nil {
 			return err
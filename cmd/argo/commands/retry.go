@@ -16,11 +16,15 @@ import (
 )
 
 type retryOps struct {
-	nodeFieldSelector string // --node-field-selector
-	restartSuccessful bool   // --restart-successful
-	namespace         string // --namespace
-	labelSelector     string // --selector
-	fieldSelector     string // --field-selector
+	nodeFieldSelector string   // --node-field-selector
+	restartSuccessful bool     // --restart-successful
+	namespace         string   // --namespace
+	labelSelector     string   // --selector
+	fieldSelector     string   // --field-selector
+	retryLimits       []string // --retry-limit
+	clearOutputs      []string // --clear-output-parameter
+	entrypoint        string   // --entrypoint
+	keepPods          bool     // --keep-pods
 }
 
 // hasSelector returns true if the CLI arguments selects multiple workflows
@@ -74,6 +78,10 @@ func NewRetryCommand() *cobra.Command {
 
 # Restart node with id 5 on successful workflow, using node-field-selector
   argo retry my-wf --restart-successful --node-field-selector id=5
+
+# Retry with the retry limit of the flaky template raised to 5:
+
+  argo retry my-wf --retry-limit flaky=5
 `,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 && !retryOpts.hasSelector() {
@@ -101,6 +109,10 @@ func NewRetryCommand() *cobra.Command {
 	command.Flags().BoolVar(&retryOpts.restartSuccessful, "restart-successful", false, "indicates to restart successful nodes matching the --node-field-selector")
 	command.Flags().StringVar(&retryOpts.nodeFieldSelector, "node-field-selector", "", "selector of nodes to reset, eg: --node-field-selector inputs.paramaters.myparam.value=abc")
 	command.Flags().StringVarP(&retryOpts.labelSelector, "selector", "l", "", "Selector (label query) to filter on, not including uninitialized ones, supports '=', '==', and '!='.(e.g. -l key1=value1,key2=value2)")
+	command.Flags().StringArrayVar(&retryOpts.retryLimits, "retry-limit", []string{}, "raise the retry limit of a template, in the form TEMPLATE=LIMIT")
+	command.Flags().StringArrayVar(&retryOpts.clearOutputs, "clear-output-parameter", []string{}, "clear an output parameter of the workflow so that a stale value is not read, \"*\" clears all of them")
+	command.Flags().StringVar(&retryOpts.entrypoint, "entrypoint", "", "run the workflow again from this template rather than its entrypoint, re-running all the nodes")
+	command.Flags().BoolVar(&retryOpts.keepPods, "keep-pods", false, "keep the pods of the re-run nodes for inspection rather than deleting them, until the nodes need their names")
 	command.Flags().StringVar(&retryOpts.fieldSelector, "field-selector", "", "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	return command
 }
@@ -142,11 +154,15 @@ func retryWorkflows(ctx context.Context, serviceClient workflowpkg.WorkflowServi
 		retriedNames[wf.Name] = true
 
 		lastRetried, err = serviceClient.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{
-			Name:              wf.Name,
-			Namespace:         wf.Namespace,
-			RestartSuccessful: retryOpts.restartSuccessful,
-			NodeFieldSelector: selector.String(),
-			Parameters:        cliSubmitOpts.Parameters,
+			Name:                  wf.Name,
+			Namespace:             wf.Namespace,
+			RestartSuccessful:     retryOpts.restartSuccessful,
+			NodeFieldSelector:     selector.String(),
+			Parameters:            cliSubmitOpts.Parameters,
+			RetryLimits:           retryOpts.retryLimits,
+			ClearOutputParameters: retryOpts.clearOutputs,
+			Entrypoint:            retryOpts.entrypoint,
+			KeepPods:              retryOpts.keepPods,
 		})
 		if err != nil {
 			return err
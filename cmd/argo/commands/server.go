@@ -54,6 +54,7 @@ func NewServerCommand() *cobra.Command {
 		frameOptions             string
 		accessControlAllowOrigin string
 		apiRateLimit             uint64
+		maxConcurrentWatches     int
 		kubeAPIQPS               float32
 		kubeAPIBurst             int
 		allowedLinkProtocol      []string
@@ -171,6 +172,7 @@ See %s`, help.ArgoServer()),
 				XFrameOptions:            frameOptions,
 				AccessControlAllowOrigin: accessControlAllowOrigin,
 				APIRateLimit:             apiRateLimit,
+				MaxConcurrentWatches:     maxConcurrentWatches,
 				AllowedLinkProtocol:      allowedLinkProtocol,
 			}
 			browserOpenFunc := func(url string) {}
@@ -211,6 +213,7 @@ See %s`, help.ArgoServer()),
 	command.Flags().StringVar(&frameOptions, "x-frame-options", "DENY", "Set X-Frame-Options header in HTTP responses.")
 	command.Flags().StringVar(&accessControlAllowOrigin, "access-control-allow-origin", "", "Set Access-Control-Allow-Origin header in HTTP responses.")
 	command.Flags().Uint64Var(&apiRateLimit, "api-rate-limit", 1000, "Set limit per IP for api ratelimiter")
+	command.Flags().IntVar(&maxConcurrentWatches, "max-concurrent-watches", 0, "Maximum number of concurrent workflow and event watches, further watches fail with ResourceExhausted. 0 means unlimited.")
 	command.Flags().StringArrayVar(&allowedLinkProtocol, "allowed-link-protocol", []string{"http", "https"}, "Allowed protocols for links feature.")
 	command.Flags().StringVar(&logFormat, "log-format", "text", "The formatter to use for logs. One of: text|json")
 	command.Flags().Float32Var(&kubeAPIQPS, "kube-api-qps", 20.0, "QPS to use while talking with kube-apiserver.")
@@ -29,6 +29,7 @@ func NewSubmitCommand() *cobra.Command {
 		cliSubmitOpts  = common.NewCliSubmitOpts()
 		priority       int32
 		from           string
+		startAt        string
 	)
 	command := &cobra.Command{
 		Use:   "submit [FILE... | --from `kind/name]",
@@ -68,6 +69,13 @@ func NewSubmitCommand() *cobra.Command {
 			if cmd.Flag("priority").Changed {
 				cliSubmitOpts.Priority = &priority
 			}
+			if startAt != "" {
+				t, err := time.Parse(time.RFC3339, startAt)
+				if err != nil {
+					return fmt.Errorf("invalid --start-at %q, the time must be RFC3339: %w", startAt, err)
+				}
+				submitOpts.StartAt = &metav1.Time{Time: t}
+			}
 
 			ctx, apiClient, err := client.NewAPIClient(ctx)
 			if err != nil {
@@ -100,6 +108,8 @@ func NewSubmitCommand() *cobra.Command {
 	command.Flags().BoolVar(&cliSubmitOpts.Log, "log", false, "log the workflow until it completes")
 	command.Flags().BoolVar(&cliSubmitOpts.Strict, "strict", true, "perform strict workflow validation")
 	command.Flags().Int32Var(&priority, "priority", 0, "workflow priority")
+	command.Flags().BoolVar(&submitOpts.StartSuspended, "suspend", false, "submit the workflow suspended, it will not run until it is resumed")
+	command.Flags().StringVar(&startAt, "start-at", "", "submit the workflow suspended, annotated with the time it is to be resumed at. The time must be RFC3339")
 	command.Flags().StringVar(&from, "from", "", "Submit from an existing `kind/name` E.g., --from=cronwf/hello-world-cwf")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.Status, "status", "", "Filter by status (Pending, Running, Succeeded, Skipped, Failed, Error). Should only be used with --watch.")
 	command.Flags().StringVar(&cliSubmitOpts.GetArgs.NodeFieldSelectorString, "node-field-selector", "", "selector of node to display, eg: --node-field-selector phase=abc")
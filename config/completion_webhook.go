@@ -0,0 +1,21 @@
+package config
+
+// CompletionWebhook is a webhook the Argo Server posts workflows to when they complete
+type CompletionWebhook struct {
+	// URL is where a JSON payload of the workflow's namespace, name, UID, phase, message, start and finish times and
+	// labels is posted
+	URL string `json:"url"`
+	// Selector is a label selector of the workflows to post, empty for all workflows
+	Selector string `json:"selector,omitempty"`
+	// Retries is how many times a post that fails, or gets a response other than 2xx, is retried with exponential
+	// backoff, defaults to 3
+	Retries *int `json:"retries,omitempty"`
+}
+
+// GetRetries returns how many times a failed post is retried
+func (w CompletionWebhook) GetRetries() int {
+	if w.Retries == nil {
+		return 3
+	}
+	return *w.Retries
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math"
 	"net/url"
+	"strings"
 	"time"
 
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
@@ -11,6 +12,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -118,6 +120,75 @@ type Config struct {
 
 	// Synchronization via databases config
 	Synchronization *SyncConfig `json:"synchronization,omitempty"`
+
+	// NamespaceDeletePropagation is the propagation policy the Argo Server deletes the workflows of each namespace with,
+	// unless the request sets one, e.g. Orphan to keep the pods of deleted workflows in a namespace used for debugging.
+	// Namespaces not listed use the WF_DEL_PROPAGATION_POLICY environment variable, which defaults to Background.
+	NamespaceDeletePropagation map[string]metav1.DeletionPropagation `json:"namespaceDeletePropagation,omitempty"`
+
+	// MaxRequestSize is the maximum size in bytes of a workflow the Argo Server creates or lints, zero means unlimited.
+	// It also limits every request to every service of the Argo Server, not only workflows: the gRPC server rejects any
+	// message more than twice this size, capped by GRPC_MESSAGE_SIZE, before decoding it.
+	MaxRequestSize int `json:"maxRequestSize,omitempty"`
+
+	// SubmissionQuota limits the number of workflows each user can create or submit through the Argo Server per day.
+	// Requests without an authenticated subject, e.g. in the server auth mode, are not limited.
+	SubmissionQuota *SubmissionQuota `json:"submissionQuota,omitempty"`
+
+	// AllowedNamespaces are the only namespaces the Argo Server serves workflows, archived workflows, templates, cron
+	// workflows and events in. Requests for other namespaces, or for all namespaces, are denied regardless of RBAC.
+	// Cluster workflow templates are still served. Empty means all namespaces are served.
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+
+	// CompletionWebhooks are webhooks the Argo Server posts workflows to when they complete, for integrations that
+	// cannot watch workflows. Only one replica of the server posts each workflow, the one that claims it by annotating it.
+	CompletionWebhooks []CompletionWebhook `json:"completionWebhooks,omitempty"`
+
+	// CreateWorkflowGenerateNameRetries is how many times the Argo Server retries creating a workflow with a generated
+	// name that already exists, default 3.
+	CreateWorkflowGenerateNameRetries *int `json:"createWorkflowGenerateNameRetries,omitempty"`
+
+	// ValidationCacheSize is how many successfully validated workflows the Argo Server remembers, so that identical
+	// workflows created, submitted or linted again are not validated again. Zero validates every workflow.
+	ValidationCacheSize int `json:"validationCacheSize,omitempty"`
+
+	// HydrateMaxNodes is how many nodes a workflow the Argo Server gets or watches can have before a warning is logged,
+	// zero means unlimited. The nodes are counted before offloaded or compressed nodes are hydrated.
+	HydrateMaxNodes int `json:"hydrateMaxNodes,omitempty"`
+
+	// HydrateRefuseOverMaxNodes returns workflows with more nodes than HydrateMaxNodes without their nodes, rather than
+	// only logging a warning.
+	HydrateRefuseOverMaxNodes bool `json:"hydrateRefuseOverMaxNodes,omitempty"`
+
+	// InstanceIDMismatch is what the Argo Server does with workflows that have no instance ID when it has one, either
+	// reject (default), or ignore to log a warning and proceed, e.g. while migrating workflows to an instance. Workflows
+	// with the instance ID of another instance are always rejected.
+	InstanceIDMismatch string `json:"instanceIDMismatch,omitempty"`
+
+	// ResourceFitCheck is whether the Argo Server checks created and submitted workflows for pods that request more
+	// resources than any node can allocate, either off (default), warn, or reject.
+	ResourceFitCheck string `json:"resourceFitCheck,omitempty"`
+}
+
+// DefaultCreateWorkflowGenerateNameRetries is how many times a workflow with a generated name is retried by default
+const DefaultCreateWorkflowGenerateNameRetries = 3
+
+const (
+	InstanceIDMismatchReject = "reject"
+	InstanceIDMismatchIgnore = "ignore"
+)
+
+const (
+	ResourceFitCheckOff    = "off"
+	ResourceFitCheckWarn   = "warn"
+	ResourceFitCheckReject = "reject"
+)
+
+func (c Config) GetCreateWorkflowGenerateNameRetries() int {
+	if c.CreateWorkflowGenerateNameRetries != nil {
+		return *c.CreateWorkflowGenerateNameRetries
+	}
+	return DefaultCreateWorkflowGenerateNameRetries
 }
 
 func (c Config) GetExecutor() *apiv1.Container {
@@ -169,6 +240,65 @@ func (c *Config) Sanitize(allowedProtocol []string) error {
 			return err
 		}
 	}
+	for namespace, propagationPolicy := range c.NamespaceDeletePropagation {
+		switch propagationPolicy {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+		default:
+			return fmt.Errorf("delete propagation policy %q of namespace %s must be one of %s, %s or %s", propagationPolicy, namespace, metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground)
+		}
+	}
+	if c.MaxRequestSize < 0 {
+		return fmt.Errorf("max request size %d must not be negative", c.MaxRequestSize)
+	}
+	for _, namespace := range c.AllowedNamespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("allowed namespace %q is invalid: %s", namespace, strings.Join(errs, "; "))
+		}
+	}
+	for _, webhook := range c.CompletionWebhooks {
+		u, err := url.Parse(webhook.URL)
+		if err != nil {
+			return fmt.Errorf("completion webhook URL %q is invalid: %w", webhook.URL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("completion webhook URL %q must be an absolute http or https URL", webhook.URL)
+		}
+		if _, err := labels.Parse(webhook.Selector); err != nil {
+			return fmt.Errorf("completion webhook selector %q is invalid: %w", webhook.Selector, err)
+		}
+		if webhook.GetRetries() < 0 {
+			return fmt.Errorf("completion webhook retries %d must not be negative", webhook.GetRetries())
+		}
+	}
+	if retries := c.GetCreateWorkflowGenerateNameRetries(); retries < 0 {
+		return fmt.Errorf("create workflow generate name retries %d must not be negative", retries)
+	}
+	if c.ValidationCacheSize < 0 {
+		return fmt.Errorf("validation cache size %d must not be negative", c.ValidationCacheSize)
+	}
+	if c.HydrateMaxNodes < 0 {
+		return fmt.Errorf("hydrate max nodes %d must not be negative", c.HydrateMaxNodes)
+	}
+	switch c.InstanceIDMismatch {
+	case "", InstanceIDMismatchReject, InstanceIDMismatchIgnore:
+	default:
+		return fmt.Errorf("instance ID mismatch %q must be one of %s or %s", c.InstanceIDMismatch, InstanceIDMismatchReject, InstanceIDMismatchIgnore)
+	}
+	switch c.ResourceFitCheck {
+	case "", ResourceFitCheckOff, ResourceFitCheckWarn, ResourceFitCheckReject:
+	default:
+		return fmt.Errorf("resource fit check %q must be one of %s, %s or %s", c.ResourceFitCheck, ResourceFitCheckOff, ResourceFitCheckWarn, ResourceFitCheckReject)
+	}
+	if q := c.SubmissionQuota; q != nil {
+		if q.Daily < 0 {
+			return fmt.Errorf("daily submission quota %d must not be negative", q.Daily)
+		}
+		for user, daily := range q.Users {
+			if daily < 0 {
+				return fmt.Errorf("daily submission quota %d of user %s must not be negative", daily, user)
+			}
+		}
+	}
 	return nil
 }
 
@@ -208,6 +338,9 @@ type PersistConfig struct {
 	DBConfig
 	// NodeStatusOffload saves node status only to the persistence DB to avoid the 1MB limit in etcd
 	NodeStatusOffload bool `json:"nodeStatusOffLoad,omitempty"`
+	// NodeStatusOffloadCompression gzips the offloaded node status to reduce the size of the persistence DB.
+	// Offloaded node status is read whether or not it was compressed.
+	NodeStatusOffloadCompression bool `json:"nodeStatusOffloadCompression,omitempty"`
 	// Archive completed and Workflows to persistence so you can access them after they're
 	// removed from kubernetes
 	Archive bool `json:"archive,omitempty"`
@@ -215,6 +348,9 @@ type PersistConfig struct {
 	ArchiveLabelSelector *metav1.LabelSelector `json:"archiveLabelSelector,omitempty"`
 	// ArchiveTTL is the time to live for archived Workflows
 	ArchiveTTL TTL `json:"archiveTTL,omitempty"`
+	// ArchiveRetention limits the archived Workflows kept in each namespace, they are pruned as Workflows are archived,
+	// and by the archived workflow GC
+	ArchiveRetention *ArchiveRetention `json:"archiveRetention,omitempty"`
 	// ClusterName is the name of the cluster (or technically controller) for the persistence database
 	ClusterName string `json:"clusterName,omitempty"`
 	// SkipMigration skips database migration even if needed
@@ -228,6 +364,38 @@ func (c PersistConfig) GetArchiveLabelSelector() (labels.Selector, error) {
 	return metav1.LabelSelectorAsSelector(c.ArchiveLabelSelector)
 }
 
+// ArchiveRetentionPolicy limits the archived Workflows kept in a namespace, zero values are unlimited
+type ArchiveRetentionPolicy struct {
+	// MaxCount is the maximum number of archived Workflows kept, the least recently finished are removed first
+	MaxCount int `json:"maxCount,omitempty"`
+	// MaxAge is the maximum time since an archived Workflow finished before it is removed
+	MaxAge TTL `json:"maxAge,omitempty"`
+}
+
+// IsZero returns true if the policy does not limit the archived Workflows
+func (p ArchiveRetentionPolicy) IsZero() bool {
+	return p.MaxCount <= 0 && p.MaxAge <= 0
+}
+
+// ArchiveRetention is the retention policy of archived Workflows
+type ArchiveRetention struct {
+	// The policy for namespaces without their own
+	ArchiveRetentionPolicy
+	// Namespaces overrides the policy for individual namespaces
+	Namespaces map[string]ArchiveRetentionPolicy `json:"namespaces,omitempty"`
+}
+
+// ForNamespace returns the policy of the namespace
+func (r *ArchiveRetention) ForNamespace(namespace string) ArchiveRetentionPolicy {
+	if r == nil {
+		return ArchiveRetentionPolicy{}
+	}
+	if policy, ok := r.Namespaces[namespace]; ok {
+		return policy
+	}
+	return r.ArchiveRetentionPolicy
+}
+
 func (c PersistConfig) GetClusterName() string {
 	if c.ClusterName != "" {
 		return c.ClusterName
@@ -319,6 +487,8 @@ type MetricModifier struct {
 	// HistogramBuckets allow configuring of the buckets used in a histogram
 	// Has no effect on non-histogram buckets
 	HistogramBuckets []float64 `json:"histogramBuckets,omitempty"`
+	// MaxSeries overrides the maxSeries of the metrics config for this metric
+	MaxSeries int `json:"maxSeries,omitempty"`
 }
 
 // MetricsTemporality defines the temporality of OpenTelemetry metrics
@@ -348,12 +518,47 @@ type MetricsConfig struct {
 	IgnoreErrors bool `json:"ignoreErrors,omitempty"`
 	// Secure is a flag that starts the metrics servers using TLS, defaults to true
 	Secure *bool `json:"secure,omitempty"`
+	// TLSMinVersion is the minimum TLS version of the secure metrics server, e.g. 771 for v1.2.
+	// Defaults to the TLS_MIN_VERSION environment variable, else v1.2
+	TLSMinVersion uint16 `json:"tlsMinVersion,omitempty"`
+	// ExcludeGoCollectors omits the Go runtime (go_*) and process (process_*) metrics from the Prometheus metrics
+	ExcludeGoCollectors bool `json:"excludeGoCollectors,omitempty"`
+	// MaxSeries is the most series, distinct combinations of attribute values, recorded for each metric.
+	// Measurements that would add a series beyond it are dropped and counted by the metrics_dropped_series metric.
+	// Default is 0, unlimited
+	MaxSeries int `json:"maxSeries,omitempty"`
 	// Modifiers configure metrics by name
 	Modifiers map[string]MetricModifier `json:"modifiers,omitempty"`
 	// Temporality of the OpenTelemetry metrics.
 	// Enum of Cumulative or Delta, defaulting to Cumulative.
 	// No effect on Prometheus metrics, which are always Cumulative.
 	Temporality MetricsTemporality `json:"temporality,omitempty"`
+	// OTLP pushes the metrics to an OpenTelemetry collector, in addition to serving them to Prometheus
+	OTLP *OTLPMetricsConfig `json:"otlp,omitempty"`
+	// TextFile writes the Prometheus metrics to a file on an interval, in addition to serving them
+	TextFile *TextFileMetricsConfig `json:"textFile,omitempty"`
+}
+
+// OTLPMetricsConfig configures pushing metrics to an OpenTelemetry collector.
+// Anything not configured here is taken from the standard OTEL_EXPORTER_OTLP_* environment variables.
+type OTLPMetricsConfig struct {
+	// Endpoint is the host and port of the collector's OTLP gRPC receiver, e.g. otel-collector:4317
+	Endpoint string `json:"endpoint,omitempty"`
+	// Interval between pushes. Default is "60s"
+	Interval TTL `json:"interval,omitempty"`
+	// Headers are sent with every push, e.g. for authentication
+	Headers map[string]string `json:"headers,omitempty"`
+	// Insecure pushes without TLS
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// TextFileMetricsConfig configures writing the Prometheus metrics to a file, for the textfile collector of a
+// node_exporter to read where the metrics cannot be scraped, e.g. in air-gapped clusters
+type TextFileMetricsConfig struct {
+	// Path of the file, e.g. on a volume shared with the node_exporter. The file is replaced on each write
+	Path string `json:"path,omitempty"`
+	// Interval between writes. Default is "60s"
+	Interval TTL `json:"interval,omitempty"`
 }
 
 func (mc *MetricsConfig) GetSecure(defaultValue bool) bool {
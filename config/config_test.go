@@ -2,9 +2,13 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
@@ -22,6 +26,25 @@ func TestSanitize(t *testing.T) {
 		{Config{Links: []*wfv1.Link{{URL: "javascript:foo"}}}, "protocol javascript is not allowed"},
 		{Config{Links: []*wfv1.Link{{URL: "javASCRipt: //foo"}}}, "protocol javascript is not allowed"},
 		{Config{Links: []*wfv1.Link{{URL: "http://foo.bar/?foo=<script>abc</script>bar"}}}, ""},
+		{Config{NamespaceDeletePropagation: map[string]metav1.DeletionPropagation{"debug": metav1.DeletePropagationOrphan}}, ""},
+		{Config{NamespaceDeletePropagation: map[string]metav1.DeletionPropagation{"debug": "Never"}}, `delete propagation policy "Never" of namespace debug must be one of Orphan, Background or Foreground`},
+		{Config{MaxRequestSize: 1024}, ""},
+		{Config{MaxRequestSize: -1}, "max request size -1 must not be negative"},
+		{Config{SubmissionQuota: &SubmissionQuota{Daily: 10, Users: map[string]int{"ci": 0}}}, ""},
+		{Config{SubmissionQuota: &SubmissionQuota{Daily: -1}}, "daily submission quota -1 must not be negative"},
+		{Config{SubmissionQuota: &SubmissionQuota{Users: map[string]int{"ci": -1}}}, "daily submission quota -1 of user ci must not be negative"},
+		{Config{AllowedNamespaces: []string{"team-a", "team-b"}}, ""},
+		{Config{AllowedNamespaces: []string{"Team A"}}, `allowed namespace "Team A" is invalid: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`},
+		{Config{CompletionWebhooks: []CompletionWebhook{{URL: "https://example.com/hook", Selector: "team=a"}}}, ""},
+		{Config{CompletionWebhooks: []CompletionWebhook{{URL: "/hook"}}}, `completion webhook URL "/hook" must be an absolute http or https URL`},
+		{Config{CompletionWebhooks: []CompletionWebhook{{URL: "https://example.com/hook", Selector: "team in (a"}}}, `completion webhook selector "team in (a" is invalid: unable to parse requirement: found '', expected: ',' or ')'`},
+		{Config{CompletionWebhooks: []CompletionWebhook{{URL: "https://example.com/hook", Retries: ptr.To(-1)}}}, "completion webhook retries -1 must not be negative"},
+		{Config{CreateWorkflowGenerateNameRetries: ptr.To(0), ValidationCacheSize: 100, HydrateMaxNodes: 1000, InstanceIDMismatch: InstanceIDMismatchIgnore, ResourceFitCheck: ResourceFitCheckWarn}, ""},
+		{Config{CreateWorkflowGenerateNameRetries: ptr.To(-1)}, "create workflow generate name retries -1 must not be negative"},
+		{Config{ValidationCacheSize: -1}, "validation cache size -1 must not be negative"},
+		{Config{HydrateMaxNodes: -1}, "hydrate max nodes -1 must not be negative"},
+		{Config{InstanceIDMismatch: "warn"}, `instance ID mismatch "warn" must be one of reject or ignore`},
+		{Config{ResourceFitCheck: "on"}, `resource fit check "on" must be one of off, warn or reject`},
 	}
 	for _, tt := range tests {
 		err := tt.c.Sanitize([]string{"http", "https"})
@@ -32,3 +55,18 @@ func TestSanitize(t *testing.T) {
 		}
 	}
 }
+
+func TestArchiveRetention(t *testing.T) {
+	var c PersistConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+archiveRetention:
+  maxCount: 10
+  maxAge: 7d
+  namespaces:
+    my-ns:
+      maxCount: 2
+`), &c))
+	assert.Equal(t, ArchiveRetentionPolicy{MaxCount: 10, MaxAge: TTL(7 * 24 * time.Hour)}, c.ArchiveRetention.ForNamespace("other-ns"))
+	assert.Equal(t, ArchiveRetentionPolicy{MaxCount: 2}, c.ArchiveRetention.ForNamespace("my-ns"))
+	assert.True(t, (*ArchiveRetention)(nil).ForNamespace("my-ns").IsZero())
+}
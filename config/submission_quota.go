@@ -0,0 +1,21 @@
+package config
+
+// SubmissionQuota limits the number of workflows each user can create or submit through the Argo Server per day
+type SubmissionQuota struct {
+	// Daily is the number of workflows each user can create or submit per day, reset at midnight UTC,
+	// zero means unlimited
+	Daily int `json:"daily,omitempty"`
+	// Users overrides the daily quota of users by their subject, zero means unlimited
+	Users map[string]int `json:"users,omitempty"`
+}
+
+// GetDaily returns the daily quota of the user with the subject, zero means unlimited
+func (q *SubmissionQuota) GetDaily(subject string) int {
+	if q == nil {
+		return 0
+	}
+	if daily, ok := q.Users[subject]; ok {
+		return daily
+	}
+	return q.Daily
+}
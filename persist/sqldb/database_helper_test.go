@@ -0,0 +1,90 @@
+package sqldb
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	testcontainers "github.com/testcontainers/testcontainers-go"
+	testmysql "github.com/testcontainers/testcontainers-go/modules/mysql"
+	testpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"github.com/upper/db/v4"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+)
+
+const (
+	testDBName           = `archive`
+	testDBUser           = `user`
+	testDBPassword       = `pass`
+	testClusterName      = `default`
+	testOffloadTableName = `argo_workflows`
+)
+
+// testDBTypes are the databases the archive and the offloaded node status are stored in
+var testDBTypes = []sqldb.DBType{sqldb.Postgres, sqldb.MySQL}
+
+// createTestDBSession returns a session of a database of the type, running in a container, with the archive and offload
+// tables migrated. The test is skipped if containers cannot be run.
+func createTestDBSession(t *testing.T, dbType sqldb.DBType) db.Session {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := logging.TestContext(t.Context())
+	var dbConfig config.DBConfig
+	switch dbType {
+	case sqldb.Postgres:
+		container, err := testpostgres.Run(ctx,
+			"postgres:17.4-alpine",
+			testpostgres.WithDatabase(testDBName),
+			testpostgres.WithUsername(testDBUser),
+			testpostgres.WithPassword(testDBPassword),
+			testcontainers.WithWaitStrategy(
+				wait.ForLog("database system is ready to accept connections").
+					WithOccurrence(2).
+					WithStartupTimeout(15*time.Second)),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { terminateTestContainer(t, container) })
+		port, err := container.MappedPort(ctx, "5432/tcp")
+		require.NoError(t, err)
+		dbConfig.PostgreSQL = &config.PostgreSQLConfig{DatabaseConfig: testDatabaseConfig(ctx, t, container, port.Port())}
+	case sqldb.MySQL:
+		container, err := testmysql.Run(ctx,
+			"mysql:8.4.5",
+			testmysql.WithDatabase(testDBName),
+			testmysql.WithUsername(testDBUser),
+			testmysql.WithPassword(testDBPassword),
+		)
+		require.NoError(t, err)
+		t.Cleanup(func() { terminateTestContainer(t, container) })
+		port, err := container.MappedPort(ctx, "3306/tcp")
+		require.NoError(t, err)
+		dbConfig.MySQL = &config.MySQLConfig{DatabaseConfig: testDatabaseConfig(ctx, t, container, port.Port())}
+	default:
+		t.Fatalf("unsupported database type %s", dbType)
+	}
+	session, err := sqldb.CreateDBSessionWithCreds(dbConfig, testDBUser, testDBPassword)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	require.NoError(t, Migrate(ctx, session, testClusterName, testOffloadTableName))
+	return session
+}
+
+func testDatabaseConfig(ctx context.Context, t *testing.T, container testcontainers.Container, port string) config.DatabaseConfig {
+	host, err := container.Host(ctx)
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	return config.DatabaseConfig{Database: testDBName, Host: host, Port: portNumber}
+}
+
+func terminateTestContainer(t *testing.T, container testcontainers.Container) {
+	if err := testcontainers.TerminateContainer(container); err != nil {
+		t.Logf("failed to terminate container: %s", err)
+	}
+}
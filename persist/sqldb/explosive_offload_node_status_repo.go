@@ -18,6 +18,10 @@ func (n *explosiveOffloadNodeStatusRepo) IsEnabled() bool {
 	return false
 }
 
+func (n *explosiveOffloadNodeStatusRepo) Ping(context.Context) error {
+	return nil
+}
+
 func (n *explosiveOffloadNodeStatusRepo) Save(context.Context, string, string, wfv1.Nodes) (string, error) {
 	return "", ErrOffloadNotSupported
 }
@@ -26,6 +30,10 @@ func (n *explosiveOffloadNodeStatusRepo) Get(context.Context, string, string) (w
 	return nil, ErrOffloadNotSupported
 }
 
+func (n *explosiveOffloadNodeStatusRepo) Count(context.Context, string, string) (int, error) {
+	return 0, ErrOffloadNotSupported
+}
+
 func (n *explosiveOffloadNodeStatusRepo) List(context.Context, string) (map[UUIDVersion]wfv1.Nodes, error) {
 	return nil, ErrOffloadNotSupported
 }
@@ -16,6 +16,27 @@ type OffloadNodeStatusRepo struct {
 	mock.Mock
 }
 
+// Count provides a mock function with given fields: uid, version
+func (_m *OffloadNodeStatusRepo) Count(ctx context.Context, uid string, version string) (int, error) {
+	ret := _m.Called(uid, version)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, string) int); ok {
+		r0 = rf(uid, version)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(uid, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: uid, version
 func (_m *OffloadNodeStatusRepo) Delete(_ context.Context, uid string, version string) error {
 	ret := _m.Called(uid, version)
@@ -133,3 +154,17 @@ func (_m *OffloadNodeStatusRepo) Save(ctx context.Context, uid string, namespace
 
 	return r0, r1
 }
+
+// Ping provides a mock function with given fields:
+func (_m *OffloadNodeStatusRepo) Ping(_ context.Context) error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
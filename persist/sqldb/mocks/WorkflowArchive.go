@@ -8,6 +8,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/utils"
 	mock "github.com/stretchr/testify/mock"
@@ -432,6 +434,74 @@ func (_c *WorkflowArchive_GetWorkflowForEstimator_Call) RunAndReturn(run func(ct
 	return _c
 }
 
+// GroupWorkflowsByTemplate provides a mock function for the type WorkflowArchive
+func (_mock *WorkflowArchive) GroupWorkflowsByTemplate(ctx context.Context, options utils.ListOptions) ([]sqldb.TemplateGroup, error) {
+	ret := _mock.Called(ctx, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GroupWorkflowsByTemplate")
+	}
+
+	var r0 []sqldb.TemplateGroup
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, utils.ListOptions) ([]sqldb.TemplateGroup, error)); ok {
+		return returnFunc(ctx, options)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, utils.ListOptions) []sqldb.TemplateGroup); ok {
+		r0 = returnFunc(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]sqldb.TemplateGroup)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, utils.ListOptions) error); ok {
+		r1 = returnFunc(ctx, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowArchive_GroupWorkflowsByTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GroupWorkflowsByTemplate'
+type WorkflowArchive_GroupWorkflowsByTemplate_Call struct {
+	*mock.Call
+}
+
+// GroupWorkflowsByTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options utils.ListOptions
+func (_e *WorkflowArchive_Expecter) GroupWorkflowsByTemplate(ctx interface{}, options interface{}) *WorkflowArchive_GroupWorkflowsByTemplate_Call {
+	return &WorkflowArchive_GroupWorkflowsByTemplate_Call{Call: _e.mock.On("GroupWorkflowsByTemplate", ctx, options)}
+}
+
+func (_c *WorkflowArchive_GroupWorkflowsByTemplate_Call) Run(run func(ctx context.Context, options utils.ListOptions)) *WorkflowArchive_GroupWorkflowsByTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 utils.ListOptions
+		if args[1] != nil {
+			arg1 = args[1].(utils.ListOptions)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowArchive_GroupWorkflowsByTemplate_Call) Return(templateGroups []sqldb.TemplateGroup, err error) *WorkflowArchive_GroupWorkflowsByTemplate_Call {
+	_c.Call.Return(templateGroups, err)
+	return _c
+}
+
+func (_c *WorkflowArchive_GroupWorkflowsByTemplate_Call) RunAndReturn(run func(ctx context.Context, options utils.ListOptions) ([]sqldb.TemplateGroup, error)) *WorkflowArchive_GroupWorkflowsByTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsEnabled provides a mock function for the type WorkflowArchive
 func (_mock *WorkflowArchive) IsEnabled() bool {
 	ret := _mock.Called()
@@ -476,6 +546,68 @@ func (_c *WorkflowArchive_IsEnabled_Call) RunAndReturn(run func() bool) *Workflo
 	return _c
 }
 
+// ListWorkflowNamespaces provides a mock function for the type WorkflowArchive
+func (_mock *WorkflowArchive) ListWorkflowNamespaces(ctx context.Context) ([]string, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkflowNamespaces")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowArchive_ListWorkflowNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowNamespaces'
+type WorkflowArchive_ListWorkflowNamespaces_Call struct {
+	*mock.Call
+}
+
+// ListWorkflowNamespaces is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WorkflowArchive_Expecter) ListWorkflowNamespaces(ctx interface{}) *WorkflowArchive_ListWorkflowNamespaces_Call {
+	return &WorkflowArchive_ListWorkflowNamespaces_Call{Call: _e.mock.On("ListWorkflowNamespaces", ctx)}
+}
+
+func (_c *WorkflowArchive_ListWorkflowNamespaces_Call) Run(run func(ctx context.Context)) *WorkflowArchive_ListWorkflowNamespaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowArchive_ListWorkflowNamespaces_Call) Return(strings []string, err error) *WorkflowArchive_ListWorkflowNamespaces_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *WorkflowArchive_ListWorkflowNamespaces_Call) RunAndReturn(run func(ctx context.Context) ([]string, error)) *WorkflowArchive_ListWorkflowNamespaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListWorkflows provides a mock function for the type WorkflowArchive
 func (_mock *WorkflowArchive) ListWorkflows(ctx context.Context, options utils.ListOptions) (v1alpha1.Workflows, error) {
 	ret := _mock.Called(ctx, options)
@@ -544,6 +676,80 @@ func (_c *WorkflowArchive_ListWorkflows_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// ListWorkflowsContaining provides a mock function for the type WorkflowArchive
+func (_mock *WorkflowArchive) ListWorkflowsContaining(ctx context.Context, options utils.ListOptions, text string) (v1alpha1.Workflows, error) {
+	ret := _mock.Called(ctx, options, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkflowsContaining")
+	}
+
+	var r0 v1alpha1.Workflows
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, utils.ListOptions, string) (v1alpha1.Workflows, error)); ok {
+		return returnFunc(ctx, options, text)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, utils.ListOptions, string) v1alpha1.Workflows); ok {
+		r0 = returnFunc(ctx, options, text)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(v1alpha1.Workflows)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, utils.ListOptions, string) error); ok {
+		r1 = returnFunc(ctx, options, text)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowArchive_ListWorkflowsContaining_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowsContaining'
+type WorkflowArchive_ListWorkflowsContaining_Call struct {
+	*mock.Call
+}
+
+// ListWorkflowsContaining is a helper method to define mock.On call
+//   - ctx context.Context
+//   - options utils.ListOptions
+//   - text string
+func (_e *WorkflowArchive_Expecter) ListWorkflowsContaining(ctx interface{}, options interface{}, text interface{}) *WorkflowArchive_ListWorkflowsContaining_Call {
+	return &WorkflowArchive_ListWorkflowsContaining_Call{Call: _e.mock.On("ListWorkflowsContaining", ctx, options, text)}
+}
+
+func (_c *WorkflowArchive_ListWorkflowsContaining_Call) Run(run func(ctx context.Context, options utils.ListOptions, text string)) *WorkflowArchive_ListWorkflowsContaining_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 utils.ListOptions
+		if args[1] != nil {
+			arg1 = args[1].(utils.ListOptions)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowArchive_ListWorkflowsContaining_Call) Return(workflows v1alpha1.Workflows, err error) *WorkflowArchive_ListWorkflowsContaining_Call {
+	_c.Call.Return(workflows, err)
+	return _c
+}
+
+func (_c *WorkflowArchive_ListWorkflowsContaining_Call) RunAndReturn(run func(ctx context.Context, options utils.ListOptions, text string) (v1alpha1.Workflows, error)) *WorkflowArchive_ListWorkflowsContaining_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListWorkflowsLabelKeys provides a mock function for the type WorkflowArchive
 func (_mock *WorkflowArchive) ListWorkflowsLabelKeys(ctx context.Context) (*v1alpha1.LabelKeys, error) {
 	ret := _mock.Called(ctx)
@@ -673,3 +879,132 @@ func (_c *WorkflowArchive_ListWorkflowsLabelValues_Call) RunAndReturn(run func(c
 	_c.Call.Return(run)
 	return _c
 }
+
+// Ping provides a mock function for the type WorkflowArchive
+func (_mock *WorkflowArchive) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// WorkflowArchive_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type WorkflowArchive_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *WorkflowArchive_Expecter) Ping(ctx interface{}) *WorkflowArchive_Ping_Call {
+	return &WorkflowArchive_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *WorkflowArchive_Ping_Call) Run(run func(ctx context.Context)) *WorkflowArchive_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowArchive_Ping_Call) Return(err error) *WorkflowArchive_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *WorkflowArchive_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *WorkflowArchive_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneWorkflows provides a mock function for the type WorkflowArchive
+func (_mock *WorkflowArchive) PruneWorkflows(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool) (int64, error) {
+	ret := _mock.Called(ctx, namespace, policy, dryRun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneWorkflows")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, config.ArchiveRetentionPolicy, bool) (int64, error)); ok {
+		return returnFunc(ctx, namespace, policy, dryRun)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, config.ArchiveRetentionPolicy, bool) int64); ok {
+		r0 = returnFunc(ctx, namespace, policy, dryRun)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, config.ArchiveRetentionPolicy, bool) error); ok {
+		r1 = returnFunc(ctx, namespace, policy, dryRun)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowArchive_PruneWorkflows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneWorkflows'
+type WorkflowArchive_PruneWorkflows_Call struct {
+	*mock.Call
+}
+
+// PruneWorkflows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - namespace string
+//   - policy config.ArchiveRetentionPolicy
+//   - dryRun bool
+func (_e *WorkflowArchive_Expecter) PruneWorkflows(ctx interface{}, namespace interface{}, policy interface{}, dryRun interface{}) *WorkflowArchive_PruneWorkflows_Call {
+	return &WorkflowArchive_PruneWorkflows_Call{Call: _e.mock.On("PruneWorkflows", ctx, namespace, policy, dryRun)}
+}
+
+func (_c *WorkflowArchive_PruneWorkflows_Call) Run(run func(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool)) *WorkflowArchive_PruneWorkflows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 config.ArchiveRetentionPolicy
+		if args[2] != nil {
+			arg2 = args[2].(config.ArchiveRetentionPolicy)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowArchive_PruneWorkflows_Call) Return(n int64, err error) *WorkflowArchive_PruneWorkflows_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *WorkflowArchive_PruneWorkflows_Call) RunAndReturn(run func(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool) (int64, error)) *WorkflowArchive_PruneWorkflows_Call {
+	_c.Call.Return(run)
+	return _c
+}
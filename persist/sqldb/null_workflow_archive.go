@@ -7,6 +7,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/labels"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 )
@@ -19,6 +20,10 @@ func (r *nullWorkflowArchive) IsEnabled() bool {
 	return false
 }
 
+func (r *nullWorkflowArchive) Ping(ctx context.Context) error {
+	return nil
+}
+
 func (r *nullWorkflowArchive) ArchiveWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
 	return nil
 }
@@ -27,6 +32,10 @@ func (r *nullWorkflowArchive) ListWorkflows(ctx context.Context, options sutils.
 	return wfv1.Workflows{}, nil
 }
 
+func (r *nullWorkflowArchive) ListWorkflowsContaining(ctx context.Context, options sutils.ListOptions, text string) (wfv1.Workflows, error) {
+	return wfv1.Workflows{}, nil
+}
+
 func (r *nullWorkflowArchive) CountWorkflows(ctx context.Context, options sutils.ListOptions) (int64, error) {
 	return 0, nil
 }
@@ -47,6 +56,18 @@ func (r *nullWorkflowArchive) DeleteExpiredWorkflows(ctx context.Context, ttl ti
 	return nil
 }
 
+func (r *nullWorkflowArchive) PruneWorkflows(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool) (int64, error) {
+	return 0, nil
+}
+
+func (r *nullWorkflowArchive) ListWorkflowNamespaces(ctx context.Context) ([]string, error) {
+	return []string{}, nil
+}
+
+func (r *nullWorkflowArchive) GroupWorkflowsByTemplate(ctx context.Context, options sutils.ListOptions) ([]TemplateGroup, error) {
+	return []TemplateGroup{}, nil
+}
+
 func (r *nullWorkflowArchive) ListWorkflowsLabelKeys(ctx context.Context) (*wfv1.LabelKeys, error) {
 	return &wfv1.LabelKeys{}, nil
 }
@@ -3,6 +3,7 @@ package sqldb
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"strings"
@@ -12,11 +13,20 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/env"
+	"github.com/argoproj/argo-workflows/v3/util/file"
+	jsonutil "github.com/argoproj/argo-workflows/v3/util/json"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
 )
 
 const OffloadNodeStatusDisabled = "Workflow has offloaded nodes, but offloading has been disabled"
 
+// IsOffloadedNodesNotFound returns true if the error is because the offloaded node status does not exist.
+// The error may have been flattened by a retry, so the message is matched as well.
+func IsOffloadedNodesNotFound(err error) bool {
+	return err != nil && (errors.Is(err, db.ErrNoMoreRows) || strings.Contains(err.Error(), db.ErrNoMoreRows.Error()))
+}
+
 type UUIDVersion struct {
 	UID     string `db:"uid"`
 	Version string `db:"version"`
@@ -25,25 +35,33 @@ type UUIDVersion struct {
 type OffloadNodeStatusRepo interface {
 	Save(ctx context.Context, uid, namespace string, nodes wfv1.Nodes) (string, error)
 	Get(ctx context.Context, uid, version string) (wfv1.Nodes, error)
+	// Count returns the number of offloaded nodes, without unmarshalling them
+	Count(ctx context.Context, uid, version string) (int, error)
 	List(ctx context.Context, namespace string) (map[UUIDVersion]wfv1.Nodes, error)
 	ListOldOffloads(ctx context.Context, namespace string) (map[string][]string, error)
 	Delete(ctx context.Context, uid, version string) error
 	IsEnabled() bool
+	// Ping returns an error if the offload database cannot be reached
+	Ping(ctx context.Context) error
 }
 
-func NewOffloadNodeStatusRepo(ctx context.Context, log logging.Logger, session db.Session, clusterName, tableName string) (OffloadNodeStatusRepo, error) {
+// NewOffloadNodeStatusRepo returns a repo of offloaded node status. If compress is true, the node status is gzipped
+// when it is saved. Node status is read whether or not it was compressed, so compression can be turned on and off.
+func NewOffloadNodeStatusRepo(ctx context.Context, log logging.Logger, session db.Session, clusterName, tableName string, compress bool) (OffloadNodeStatusRepo, error) {
 	// this environment variable allows you to make Argo Workflows delete offloaded data more or less aggressively,
 	// useful for testing
 	ttl := env.LookupEnvDurationOr(ctx, "OFFLOAD_NODE_STATUS_TTL", 5*time.Minute)
-	log.WithField("ttl", ttl).Debug(ctx, "Node status offloading config")
-	return &nodeOffloadRepo{session: session, clusterName: clusterName, tableName: tableName, ttl: ttl, log: log}, nil
+	log.WithFields(logging.Fields{"ttl": ttl, "compress": compress}).Debug(ctx, "Node status offloading config")
+	return &nodeOffloadRepo{session: session, clusterName: clusterName, tableName: tableName, ttl: ttl, compress: compress, log: log}, nil
 }
 
 type nodesRecord struct {
 	ClusterName string `db:"clustername"`
 	UUIDVersion
 	Namespace string `db:"namespace"`
-	Nodes     string `db:"nodes"`
+	// the JSON of the node status, or, if it was compressed, its gzipped JSON, base64 encoded as a JSON string, as the
+	// column is JSON
+	Nodes string `db:"nodes"`
 }
 
 type nodeOffloadRepo struct {
@@ -52,13 +70,19 @@ type nodeOffloadRepo struct {
 	tableName   string
 	// time to live - at what ttl an offload becomes old
 	ttl time.Duration
-	log logging.Logger
+	// whether to compress the node status when it is saved
+	compress bool
+	log      logging.Logger
 }
 
 func (wdc *nodeOffloadRepo) IsEnabled() bool {
 	return true
 }
 
+func (wdc *nodeOffloadRepo) Ping(ctx context.Context) error {
+	return sqldb.Ping(ctx, wdc.session)
+}
+
 func nodeStatusVersion(s wfv1.Nodes) (string, string, error) {
 	marshalled, err := json.Marshal(s)
 	if err != nil {
@@ -70,11 +94,49 @@ func nodeStatusVersion(s wfv1.Nodes) (string, string, error) {
 	return string(marshalled), fmt.Sprintf("fnv:%v", h.Sum32()), nil
 }
 
+// encodeNodes returns the nodes column of marshalled node status, compressed if compress is true. The column is JSON, so
+// compressed node status is stored as a JSON string.
+func encodeNodes(ctx context.Context, marshalled string, compress bool) (string, error) {
+	if !compress {
+		return marshalled, nil
+	}
+	encoded, err := json.Marshal(file.CompressEncodeString(ctx, marshalled))
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// decodeNodes returns the node status of a nodes column, which is compressed if it is a JSON string, and otherwise is
+// the JSON of the node status, as it was before compression was supported
+func decodeNodes(ctx context.Context, nodes string) (wfv1.Nodes, error) {
+	if strings.HasPrefix(nodes, `"`) {
+		var compressed string
+		if err := json.Unmarshal([]byte(nodes), &compressed); err != nil {
+			return nil, err
+		}
+		decompressed, err := file.DecodeDecompressString(ctx, compressed)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress offloaded node status: %w", err)
+		}
+		nodes = decompressed
+	}
+	var res wfv1.Nodes
+	if err := json.Unmarshal([]byte(nodes), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (wdc *nodeOffloadRepo) Save(ctx context.Context, uid, namespace string, nodes wfv1.Nodes) (string, error) {
 	marshalled, version, err := nodeStatusVersion(nodes)
 	if err != nil {
 		return "", err
 	}
+	encoded, err := encodeNodes(ctx, marshalled, wdc.compress)
+	if err != nil {
+		return "", err
+	}
 
 	record := &nodesRecord{
 		ClusterName: wdc.clusterName,
@@ -83,7 +145,7 @@ func (wdc *nodeOffloadRepo) Save(ctx context.Context, uid, namespace string, nod
 			Version: version,
 		},
 		Namespace: namespace,
-		Nodes:     marshalled,
+		Nodes:     encoded,
 	}
 
 	logCtx := wdc.log.WithFields(logging.Fields{"uid": uid, "version": version})
@@ -126,12 +188,69 @@ func (wdc *nodeOffloadRepo) Get(ctx context.Context, uid, version string) (wfv1.
 	if err != nil {
 		return nil, err
 	}
-	nodes := &wfv1.Nodes{}
-	err = json.Unmarshal([]byte(r.Nodes), nodes)
+	return decodeNodes(ctx, r.Nodes)
+}
+
+func (wdc *nodeOffloadRepo) Count(ctx context.Context, uid, version string) (int, error) {
+	wdc.log.WithFields(logging.Fields{"uid": uid, "version": version}).Debug(ctx, "Counting offloaded nodes")
+	var count struct {
+		Count int `db:"count"`
+	}
+	err := wdc.session.SQL().
+		Select(db.Raw(wdc.nodeCount())).
+		From(wdc.tableName).
+		Where(db.Cond{"clustername": wdc.clusterName}).
+		And(db.Cond{"uid": uid}).
+		And(db.Cond{"version": version}).
+		One(&count)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	if count.Count >= 0 {
+		return count.Count, nil
+	}
+	// the node status was compressed, so the database cannot count it
+	r := &nodesRecord{}
+	err = wdc.session.SQL().
+		Select("nodes").
+		From(wdc.tableName).
+		Where(db.Cond{"clustername": wdc.clusterName}).
+		And(db.Cond{"uid": uid}).
+		And(db.Cond{"version": version}).
+		One(r)
+	if err != nil {
+		return 0, err
+	}
+	return countNodes(r.Nodes)
+}
+
+// nodeCount is the number of keys of the nodes column if it is an object, and -1 if it is not, i.e. it was compressed
+func (wdc *nodeOffloadRepo) nodeCount() string {
+	switch sqldb.DBTypeFor(wdc.session) {
+	case sqldb.MySQL:
+		return "case when json_type(nodes) = 'OBJECT' then json_length(nodes) else -1 end as count"
+	case sqldb.SQLite:
+		return "case when json_type(nodes) = 'object' then (select count(*) from json_each(nodes)) else -1 end as count"
+	default:
+		return "case when json_typeof(nodes) = 'object' then (select count(*) from json_object_keys(nodes)) else -1 end as count"
+	}
+}
+
+// countNodes returns the number of nodes of a nodes column, as decodeNodes would decode it, without unmarshalling them
+func countNodes(nodes string) (int, error) {
+	if !strings.HasPrefix(nodes, `"`) {
+		return jsonutil.CountKeys(strings.NewReader(nodes))
+	}
+	var compressed string
+	if err := json.Unmarshal([]byte(nodes), &compressed); err != nil {
+		return 0, err
+	}
+	reader, err := file.DecodeDecompressReader(compressed)
+	if err != nil {
+		return 0, fmt.Errorf("unable to decompress offloaded node status: %w", err)
 	}
-	return *nodes, nil
+	defer func() { _ = reader.Close() }()
+	return jsonutil.CountKeys(reader)
 }
 
 func (wdc *nodeOffloadRepo) List(ctx context.Context, namespace string) (map[UUIDVersion]wfv1.Nodes, error) {
@@ -149,12 +268,11 @@ func (wdc *nodeOffloadRepo) List(ctx context.Context, namespace string) (map[UUI
 
 	res := make(map[UUIDVersion]wfv1.Nodes)
 	for _, r := range records {
-		nodes := &wfv1.Nodes{}
-		err = json.Unmarshal([]byte(r.Nodes), nodes)
+		nodes, err := decodeNodes(ctx, r.Nodes)
 		if err != nil {
 			return nil, err
 		}
-		res[UUIDVersion{UID: r.UID, Version: r.Version}] = *nodes
+		res[UUIDVersion{UID: r.UID, Version: r.Version}] = nodes
 	}
 
 	return res, nil
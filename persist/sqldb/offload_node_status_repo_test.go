@@ -1,12 +1,15 @@
 package sqldb
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
 )
 
 func Test_nodeStatusVersion(t *testing.T) {
@@ -23,3 +26,97 @@ func Test_nodeStatusVersion(t *testing.T) {
 		assert.Equal(t, "fnv:2308444803", version)
 	})
 }
+
+func Test_encodeNodes(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	nodes := wfv1.Nodes{"my-node": wfv1.NodeStatus{ID: "my-node", Phase: wfv1.NodeSucceeded}}
+	marshalled, _, err := nodeStatusVersion(nodes)
+	require.NoError(t, err)
+	t.Run("Compressed", func(t *testing.T) {
+		encoded, err := encodeNodes(ctx, marshalled, true)
+		require.NoError(t, err)
+		assert.NotEqual(t, marshalled, encoded)
+		assert.True(t, json.Valid([]byte(encoded)), "the nodes column is JSON")
+		decoded, err := decodeNodes(ctx, encoded)
+		require.NoError(t, err)
+		assert.Equal(t, nodes, decoded)
+	})
+	t.Run("Uncompressed", func(t *testing.T) {
+		// rows written before compression was supported, or with it turned off
+		encoded, err := encodeNodes(ctx, marshalled, false)
+		require.NoError(t, err)
+		assert.Equal(t, marshalled, encoded)
+		decoded, err := decodeNodes(ctx, encoded)
+		require.NoError(t, err)
+		assert.Equal(t, nodes, decoded)
+	})
+	t.Run("Null", func(t *testing.T) {
+		marshalled, _, err := nodeStatusVersion(nil)
+		require.NoError(t, err)
+		for _, compress := range []bool{true, false} {
+			encoded, err := encodeNodes(ctx, marshalled, compress)
+			require.NoError(t, err)
+			decoded, err := decodeNodes(ctx, encoded)
+			require.NoError(t, err)
+			assert.Empty(t, decoded)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := decodeNodes(ctx, "not-compressed")
+		require.Error(t, err)
+		_, err = decodeNodes(ctx, `"not-compressed"`)
+		require.Error(t, err)
+	})
+}
+
+func Test_countNodes(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	marshalled := `{"a":{"id":"a"},"b":{"id":"b"}}`
+	for _, compress := range []bool{true, false} {
+		encoded, err := encodeNodes(ctx, marshalled, compress)
+		require.NoError(t, err)
+		count, err := countNodes(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	}
+	count, err := countNodes("null")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestNodeOffloadRepo(t *testing.T) {
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			session := createTestDBSession(t, dbType)
+			nodes := wfv1.Nodes{"my-node": wfv1.NodeStatus{ID: "my-node", Name: "my-wf", Phase: wfv1.NodeSucceeded, Message: `"quoted" & <escaped>`}}
+			repos := map[bool]OffloadNodeStatusRepo{}
+			versions := map[bool]string{}
+			for _, compress := range []bool{true, false} {
+				repo, err := NewOffloadNodeStatusRepo(ctx, logging.RequireLoggerFromContext(ctx), session, testClusterName, testOffloadTableName, compress)
+				require.NoError(t, err)
+				version, err := repo.Save(ctx, fmt.Sprintf("compress-%v", compress), "my-ns", nodes)
+				require.NoError(t, err, "the nodes column accepts the node status")
+				repos[compress] = repo
+				versions[compress] = version
+			}
+			// node status is read whether or not it was compressed
+			for _, repo := range repos {
+				for compress, version := range versions {
+					got, err := repo.Get(ctx, fmt.Sprintf("compress-%v", compress), version)
+					require.NoError(t, err)
+					assert.Equal(t, nodes, got)
+					count, err := repo.Count(ctx, fmt.Sprintf("compress-%v", compress), version)
+					require.NoError(t, err)
+					assert.Equal(t, len(nodes), count)
+				}
+				list, err := repo.List(ctx, "my-ns")
+				require.NoError(t, err)
+				assert.Len(t, list, 2)
+				for _, got := range list {
+					assert.Equal(t, nodes, got)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package sqldb
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/upper/db/v4"
@@ -14,7 +16,11 @@ func BuildArchivedWorkflowSelector(selector db.Selector, tableName, labelTableNa
 		And(namespaceEqual(options.Namespace)).
 		And(namePrefixClause(options.NamePrefix)).
 		And(startedAtFromClause(options.MinStartedAt)).
-		And(startedAtToClause(options.MaxStartedAt))
+		And(startedAtToClause(options.MaxStartedAt)).
+		And(phaseInClause(options.Phases))
+	for _, clause := range durationClauses(t, options.MinDuration, options.MaxDuration) {
+		selector = selector.And(clause)
+	}
 
 	if options.Name != "" {
 		nameFilter := options.NameFilter
@@ -45,12 +51,45 @@ func BuildArchivedWorkflowSelector(selector db.Selector, tableName, labelTableNa
 		options.Limit = -1
 		options.Offset = -1
 	}
+	column := options.OrderBy.Column()
+	if column == "" {
+		column = "startedat"
+	}
+	if options.After != nil {
+		if column != "startedat" {
+			return nil, fmt.Errorf("workflows can only be listed after a cursor in order of when they started, not %s", options.OrderBy.Field)
+		}
+		selector = selector.And(afterClause(*options.After, options.OrderBy.Ascending))
+	}
+	orderBy := []any{column}
+	// workflows that started at the same time are ordered by UID, so that they are paged through in the same order
+	if column == "startedat" {
+		orderBy = append(orderBy, "uid")
+	}
+	if !options.OrderBy.Ascending {
+		for i, column := range orderBy {
+			orderBy[i] = "-" + column.(string)
+		}
+	}
 	return selector.
-		OrderBy("-startedat").
+		OrderBy(orderBy...).
 		Limit(options.Limit).
 		Offset(options.Offset), nil
 }
 
+// afterClause matches the workflows after the cursor, in ascending or descending order of when they started, then their
+// UID
+func afterClause(after utils.Cursor, ascending bool) db.LogicalExpr {
+	op := "<"
+	if ascending {
+		op = ">"
+	}
+	return db.Or(
+		db.Cond{"startedat " + op: after.StartedAt.UTC()},
+		db.And(db.Cond{"startedat": after.StartedAt.UTC()}, db.Cond{"uid " + op: after.UID}),
+	)
+}
+
 func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName string, t sqldb.DBType, options utils.ListOptions, count bool) (out string, outArgs []any, err error) {
 	var clauses []*db.RawExpr
 	if options.Namespace != "" {
@@ -86,6 +125,13 @@ func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName st
 	if !options.MaxStartedAt.IsZero() {
 		clauses = append(clauses, db.Raw("startedat <= ?", options.MaxStartedAt))
 	}
+	if len(options.Phases) > 0 {
+		args := make([]any, len(options.Phases))
+		for i, phase := range options.Phases {
+			args[i] = phase
+		}
+		clauses = append(clauses, db.Raw("phase in (?"+strings.Repeat(", ?", len(args)-1)+")", args...))
+	}
 	for _, r := range options.LabelRequirements {
 		q, err := requirementToCondition(t, r, tableName, labelTableName, false)
 		if err != nil {
@@ -105,9 +151,14 @@ func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName st
 	if count {
 		return out, outArgs, nil
 	}
-	if options.StartedAtAscending {
+	switch column := options.OrderBy.Column(); {
+	case column != "" && options.OrderBy.Ascending:
+		out += " order by " + column + " asc"
+	case column != "":
+		out += " order by " + column + " desc"
+	case options.StartedAtAscending:
 		out += " order by startedat asc"
-	} else {
+	default:
 		out += " order by startedat desc"
 	}
 
@@ -123,3 +174,29 @@ func BuildWorkflowSelector(in string, inArgs []any, tableName, labelTableName st
 	outArgs = append(outArgs, options.Offset)
 	return out, outArgs, nil
 }
+
+// durationClauses matches the workflows that ran for at least minDuration and at most maxDuration, if they are not zero.
+// Archived workflows have always finished, so their duration is the time between startedat and finishedat.
+func durationClauses(t sqldb.DBType, minDuration, maxDuration time.Duration) []db.LogicalExpr {
+	duration := secondsBetween(t, "startedat", "finishedat")
+	var clauses []db.LogicalExpr
+	if minDuration > 0 {
+		clauses = append(clauses, db.Raw(duration+" >= ?", minDuration.Seconds()))
+	}
+	if maxDuration > 0 {
+		clauses = append(clauses, db.Raw(duration+" <= ?", maxDuration.Seconds()))
+	}
+	return clauses
+}
+
+// secondsBetween returns the expression of the seconds from one timestamp expression to another
+func secondsBetween(t sqldb.DBType, from, to string) string {
+	switch t {
+	case sqldb.MySQL:
+		return "timestampdiff(microsecond, " + from + ", " + to + ") / 1000000"
+	case sqldb.SQLite:
+		return "(julianday(" + to + ") - julianday(" + from + ")) * 86400"
+	default:
+		return "extract(epoch from " + to + " - " + from + ")"
+	}
+}
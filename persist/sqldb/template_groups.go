@@ -0,0 +1,93 @@
+package sqldb
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// TemplateGroup is the workflows started from the same template, see TemplateGroupColumn
+type TemplateGroup struct {
+	// Template is the template the workflows were started from, e.g. WorkflowTemplate/my-tmpl, or their entrypoint,
+	// e.g. entrypoint/main
+	Template string
+	// Count is the number of workflows of the group
+	Count int64
+	// Latest is the most recently started workflow of the group, with only its metadata and phase
+	Latest wfv1.Workflow
+}
+
+// TemplateGroupColumn returns the expression of the template group of the workflow stored as JSON in the workflow
+// column: the template it was started from, by its workflow template labels, else its workflow template reference, or
+// its entrypoint if it was not started from a template, else the empty string. Archived workflows are grouped the same
+// as live ones.
+func TemplateGroupColumn(t sqldb.DBType) string {
+	workflowTemplate := jsonText(t, "metadata", "labels", common.LabelKeyWorkflowTemplate)
+	clusterWorkflowTemplate := jsonText(t, "metadata", "labels", common.LabelKeyClusterWorkflowTemplate)
+	ref := jsonText(t, "spec", "workflowTemplateRef", "name")
+	entrypoint := jsonText(t, "spec", "entrypoint")
+	return fmt.Sprintf(`case
+  when coalesce(%[1]s, '') <> '' then %[2]s
+  when coalesce(%[3]s, '') <> '' then %[4]s
+  when coalesce(%[5]s, '') <> '' and %[6]s then %[7]s
+  when coalesce(%[5]s, '') <> '' then %[8]s
+  when coalesce(%[9]s, '') <> '' then %[10]s
+  else '' end`,
+		workflowTemplate, concatText(t, "'"+workflow.WorkflowTemplateKind+"/'", workflowTemplate),
+		clusterWorkflowTemplate, concatText(t, "'"+workflow.ClusterWorkflowTemplateKind+"/'", clusterWorkflowTemplate),
+		ref, jsonTrue(t, "spec", "workflowTemplateRef", "clusterScope"),
+		concatText(t, "'"+workflow.ClusterWorkflowTemplateKind+"/'", ref), concatText(t, "'"+workflow.WorkflowTemplateKind+"/'", ref),
+		entrypoint, concatText(t, "'entrypoint/'", entrypoint))
+}
+
+// TemplateGroupsQuery selects the columns of the most recently started workflow of each template group, joined with
+// the number of workflows of the group as g.total, from a common table expression named workflows, that has the
+// startedat, uid and TemplateGroupColumn as templategroup columns. The groups are ordered by their most recently started
+// workflow. Workflows of a group that started at the same time are all selected, in order of their UID.
+func TemplateGroupsQuery(columns string) string {
+	return `select ` + columns + ` from workflows w
+join (select templategroup, count(*) as total, max(startedat) as latest from workflows group by templategroup) g
+on w.templategroup = g.templategroup and w.startedat = g.latest
+order by g.latest desc, w.templategroup, w.uid`
+}
+
+// jsonText returns the expression of the text at the path of the workflow column, or null if it is missing. The
+// keys must not contain quotes.
+func jsonText(t sqldb.DBType, path ...string) string {
+	switch t {
+	case sqldb.Postgres:
+		parents := ""
+		for _, key := range path[:len(path)-1] {
+			parents += "->'" + key + "'"
+		}
+		return "workflow" + parents + "->>'" + path[len(path)-1] + "'"
+	case sqldb.MySQL:
+		return "workflow->>'" + jsonPath(path) + "'"
+	default:
+		return "json_extract(workflow, '" + jsonPath(path) + "')"
+	}
+}
+
+// jsonTrue returns the expression of whether the boolean at the path of the workflow column is true
+func jsonTrue(t sqldb.DBType, path ...string) string {
+	if t == sqldb.SQLite {
+		// json_extract returns booleans as integers
+		return "coalesce(json_type(workflow, '" + jsonPath(path) + "'), '') = 'true'"
+	}
+	return "coalesce(" + jsonText(t, path...) + ", '') = 'true'"
+}
+
+func jsonPath(path []string) string {
+	return `$."` + strings.Join(path, `"."`) + `"`
+}
+
+func concatText(t sqldb.DBType, a, b string) string {
+	if t == sqldb.SQLite {
+		return a + " || " + b
+	}
+	return "concat(" + a + ", " + b + ")"
+}
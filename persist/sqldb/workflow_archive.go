@@ -15,11 +15,13 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
@@ -63,6 +65,24 @@ type archivedWorkflowLabelRecord struct {
 	Value string `db:"value"`
 }
 
+type archivedWorkflowNamespace struct {
+	Namespace string `db:"namespace"`
+}
+
+type archivedWorkflowFinishedAt struct {
+	FinishedAt time.Time `db:"finishedat"`
+}
+
+type archivedTemplateGroup struct {
+	TemplateGroup string             `db:"templategroup"`
+	Total         int64              `db:"total"`
+	Name          string             `db:"name"`
+	Namespace     string             `db:"namespace"`
+	UID           string             `db:"uid"`
+	Phase         wfv1.WorkflowPhase `db:"phase"`
+	StartedAt     time.Time          `db:"startedat"`
+}
+
 type archivedWorkflowCount struct {
 	Total uint64 `db:"total,omitempty" json:"total"`
 }
@@ -72,11 +92,24 @@ type WorkflowArchive interface {
 	// list workflows, with the most recently started workflows at the beginning (i.e. index 0 is the most recent)
 	ListWorkflows(ctx context.Context, options sutils.ListOptions) (wfv1.Workflows, error)
 	CountWorkflows(ctx context.Context, options sutils.ListOptions) (int64, error)
+	// ListWorkflowsContaining lists whole workflows, including their spec and status, whose archived JSON contains the
+	// text, with the most recently started workflows at the beginning
+	ListWorkflowsContaining(ctx context.Context, options sutils.ListOptions, text string) (wfv1.Workflows, error)
+	// ListWorkflowNamespaces returns the distinct namespaces of the archived workflows, sorted
+	ListWorkflowNamespaces(ctx context.Context) ([]string, error)
+	// GroupWorkflowsByTemplate returns the number of archived workflows started from each template, and the most
+	// recently started of them, ordered by it, see TemplateGroupColumn
+	GroupWorkflowsByTemplate(ctx context.Context, options sutils.ListOptions) ([]TemplateGroup, error)
 	GetWorkflow(ctx context.Context, uid string, namespace string, name string) (*wfv1.Workflow, error)
 	GetWorkflowForEstimator(ctx context.Context, namespace string, requirements []labels.Requirement) (*wfv1.Workflow, error)
 	DeleteWorkflow(ctx context.Context, uid string) error
 	DeleteExpiredWorkflows(ctx context.Context, ttl time.Duration) error
+	// PruneWorkflows deletes the archived workflows of the namespace beyond the retention policy, returning how many
+	// were deleted, or would have been on a dry run
+	PruneWorkflows(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool) (int64, error)
 	IsEnabled() bool
+	// Ping returns an error if the archive database cannot be reached
+	Ping(ctx context.Context) error
 	ListWorkflowsLabelKeys(ctx context.Context) (*wfv1.LabelKeys, error)
 	ListWorkflowsLabelValues(ctx context.Context, key string) (*wfv1.LabelValues, error)
 }
@@ -93,6 +126,10 @@ func (r *workflowArchive) IsEnabled() bool {
 	return true
 }
 
+func (r *workflowArchive) Ping(ctx context.Context) error {
+	return sqldb.Ping(ctx, r.session)
+}
+
 // NewWorkflowArchive returns a new workflowArchive
 func NewWorkflowArchive(session db.Session, clusterName, managedNamespace string, instanceIDService instanceid.Service) WorkflowArchive {
 	return &workflowArchive{session: session, clusterName: clusterName, managedNamespace: managedNamespace, instanceIDService: instanceIDService, dbType: sqldb.DBTypeFor(session)}
@@ -162,6 +199,8 @@ func (r *workflowArchive) ArchiveWorkflow(ctx context.Context, wf *wfv1.Workflow
 }
 
 func (r *workflowArchive) ListWorkflows(ctx context.Context, options sutils.ListOptions) (wfv1.Workflows, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveListWorkflows", options.Namespace)
+	defer span.End()
 	var archivedWfs []archivedWorkflowMetadata
 	var baseSelector = r.session.SQL().Select("name", "namespace", "uid", "phase", "startedat", "finishedat")
 
@@ -278,7 +317,66 @@ func (r *workflowArchive) ListWorkflows(ctx context.Context, options sutils.List
 	return wfs, nil
 }
 
+func (r *workflowArchive) ListWorkflowsContaining(ctx context.Context, options sutils.ListOptions, text string) (wfv1.Workflows, error) {
+	selector := r.session.SQL().
+		Select("workflow").
+		From(archiveTableName).
+		Where(r.clusterManagedNamespaceAndInstanceID()).
+		And(workflowContainsClause(r.dbType, text))
+	selector, err := BuildArchivedWorkflowSelector(selector, archiveTableName, archiveLabelsTableName, r.dbType, options, false)
+	if err != nil {
+		return nil, err
+	}
+	var records []archivedWorkflowRecord
+	if err := selector.All(&records); err != nil {
+		return nil, err
+	}
+	wfs := make(wfv1.Workflows, len(records))
+	for i, record := range records {
+		wf, err := r.unmarshalWorkflow(record)
+		if err != nil {
+			return nil, err
+		}
+		wfs[i] = *wf
+	}
+	return wfs, nil
+}
+
+// workflowContainsClause matches the workflows whose JSON contains the text, as part of a string. Both databases store
+// the workflow as JSON, so it is matched as text, with the text escaped as it is in a JSON string. The workflow is
+// marshalled with &, < and > escaped, e.g. as \u0026, which the database may or may not keep, so either is matched.
+func workflowContainsClause(t sqldb.DBType, text string) db.LogicalExpr {
+	column := "workflow::text"
+	if t == sqldb.MySQL {
+		column = "cast(workflow as char)"
+	}
+	var clauses []db.LogicalExpr
+	for _, escaped := range jsonStringContents(text) {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(escaped) + "%"
+		clauses = append(clauses, db.Raw(column+" like ?", pattern))
+	}
+	return db.Or(clauses...)
+}
+
+// jsonStringContents returns the text as it is between the quotes of a JSON string, without and with &, < and > escaped
+func jsonStringContents(text string) []string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	// strings are always encoded
+	_ = encoder.Encode(text)
+	unescaped := strings.TrimSuffix(buf.String(), "\n")
+	escaped, _ := json.Marshal(text)
+	contents := []string{unescaped[1 : len(unescaped)-1]}
+	if string(escaped) != unescaped {
+		contents = append(contents, string(escaped[1:len(escaped)-1]))
+	}
+	return contents
+}
+
 func (r *workflowArchive) CountWorkflows(ctx context.Context, options sutils.ListOptions) (int64, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveCountWorkflows", options.Namespace)
+	defer span.End()
 	total := &archivedWorkflowCount{}
 
 	selector := r.session.SQL().
@@ -298,6 +396,62 @@ func (r *workflowArchive) CountWorkflows(ctx context.Context, options sutils.Lis
 	return int64(total.Total), nil
 }
 
+// ListWorkflowNamespaces returns distinct namespace from argo_archived_workflows table
+// SELECT DISTINCT namespace FROM argo_archived_workflows WHERE clustername=? AND instanceid=?
+func (r *workflowArchive) ListWorkflowNamespaces(ctx context.Context) ([]string, error) {
+	var records []archivedWorkflowNamespace
+	err := r.session.SQL().
+		Select(db.Raw("DISTINCT namespace")).
+		From(archiveTableName).
+		Where(r.clusterManagedNamespaceAndInstanceID()).
+		OrderBy("namespace").
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make([]string, len(records))
+	for i, record := range records {
+		namespaces[i] = record.Namespace
+	}
+	return namespaces, nil
+}
+
+func (r *workflowArchive) GroupWorkflowsByTemplate(ctx context.Context, options sutils.ListOptions) ([]TemplateGroup, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveGroupWorkflowsByTemplate", options.Namespace)
+	defer span.End()
+	cteSelector := r.session.SQL().
+		Select("name", "namespace", "uid", "phase", "startedat", db.Raw(TemplateGroupColumn(r.dbType)+" as templategroup")).
+		From(archiveTableName).
+		Where(r.clusterManagedNamespaceAndInstanceID())
+	cteSelector, err := BuildArchivedWorkflowSelector(cteSelector, archiveTableName, archiveLabelsTableName, r.dbType, options, true)
+	if err != nil {
+		return nil, err
+	}
+	var records []archivedTemplateGroup
+	err = r.session.SQL().
+		Iterator("WITH workflows AS ? "+TemplateGroupsQuery("w.templategroup, g.total, w.name, w.namespace, w.uid, w.phase, w.startedat"), cteSelector).
+		All(&records)
+	if err != nil {
+		return nil, err
+	}
+	groups := []TemplateGroup{}
+	for i, record := range records {
+		// of the workflows of a group that started at the same time, only the first is the latest
+		if i > 0 && record.TemplateGroup == records[i-1].TemplateGroup {
+			continue
+		}
+		groups = append(groups, TemplateGroup{
+			Template: record.TemplateGroup,
+			Count:    record.Total,
+			Latest: wfv1.Workflow{
+				ObjectMeta: v1.ObjectMeta{Name: record.Name, Namespace: record.Namespace, UID: types.UID(record.UID)},
+				Status:     wfv1.WorkflowStatus{Phase: record.Phase, StartedAt: v1.Time{Time: record.StartedAt}},
+			},
+		})
+	}
+	return groups, nil
+}
+
 func (r *workflowArchive) clusterManagedNamespaceAndInstanceID() *db.AndExpr {
 	return db.And(
 		db.Cond{"clustername": r.clusterName},
@@ -320,6 +474,13 @@ func startedAtToClause(to time.Time) db.Cond {
 	return db.Cond{}
 }
 
+func phaseInClause(phases []string) db.Cond {
+	if len(phases) > 0 {
+		return db.Cond{"phase IN": phases}
+	}
+	return db.Cond{}
+}
+
 func namespaceEqual(namespace string) db.Cond {
 	if namespace != "" {
 		return db.Cond{"namespace": namespace}
@@ -356,6 +517,8 @@ func phaseEqual(phase string) db.Cond {
 }
 
 func (r *workflowArchive) GetWorkflow(ctx context.Context, uid string, namespace string, name string) (*wfv1.Workflow, error) {
+	ctx, span := telemetry.StartSpan(ctx, "ArchiveGetWorkflow", namespace)
+	defer span.End()
 	var err error
 	archivedWf := &archivedWorkflowRecord{}
 	if uid != "" {
@@ -399,11 +562,15 @@ func (r *workflowArchive) GetWorkflow(ctx context.Context, uid string, namespace
 		}
 		return nil, err
 	}
+	return r.unmarshalWorkflow(*archivedWf)
+}
+
+func (r *workflowArchive) unmarshalWorkflow(record archivedWorkflowRecord) (*wfv1.Workflow, error) {
 	var wf *wfv1.Workflow
 	if r.dbType == sqldb.Postgres {
-		archivedWf.Workflow = strings.ReplaceAll(archivedWf.Workflow, postgresNullReplacement, "\\u0000")
+		record.Workflow = strings.ReplaceAll(record.Workflow, postgresNullReplacement, "\\u0000")
 	}
-	err = json.Unmarshal([]byte(archivedWf.Workflow), &wf)
+	err := json.Unmarshal([]byte(record.Workflow), &wf)
 	if err != nil {
 		return nil, err
 	}
@@ -487,3 +654,74 @@ func (r *workflowArchive) DeleteExpiredWorkflows(ctx context.Context, ttl time.D
 	logger.WithFields(logging.Fields{"rowsAffected": rowsAffected}).Info(ctx, "Deleted archived workflows")
 	return nil
 }
+
+func (r *workflowArchive) PruneWorkflows(ctx context.Context, namespace string, policy config.ArchiveRetentionPolicy, dryRun bool) (int64, error) {
+	var oldestKept *time.Time
+	if policy.MaxCount > 0 {
+		record := &archivedWorkflowFinishedAt{}
+		err := r.session.SQL().
+			Select("finishedat").
+			From(archiveTableName).
+			Where(r.clusterManagedNamespaceAndInstanceID()).
+			And(namespaceEqual(namespace)).
+			OrderBy("-finishedat").
+			Limit(1).
+			Offset(policy.MaxCount - 1).
+			One(record)
+		switch {
+		case err == db.ErrNoMoreRows:
+			// there are no more than the max count
+		case err != nil:
+			return 0, err
+		default:
+			oldestKept = &record.FinishedAt
+		}
+	}
+	clauses := pruneClauses(r.dbType, time.Duration(policy.MaxAge), oldestKept)
+	if len(clauses) == 0 {
+		return 0, nil
+	}
+	clause := db.Or(clauses...)
+	if dryRun {
+		total := &archivedWorkflowCount{}
+		err := r.session.SQL().
+			Select(db.Raw("count(*) as total")).
+			From(archiveTableName).
+			Where(r.clusterManagedNamespaceAndInstanceID()).
+			And(namespaceEqual(namespace)).
+			And(clause).
+			One(total)
+		if err != nil {
+			return 0, err
+		}
+		return int64(total.Total), nil
+	}
+	rs, err := r.session.SQL().
+		DeleteFrom(archiveTableName).
+		Where(r.clusterManagedNamespaceAndInstanceID()).
+		And(namespaceEqual(namespace)).
+		And(clause).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := rs.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": namespace, "rowsAffected": rowsAffected}).Info(ctx, "Pruned archived workflows")
+	return rowsAffected, nil
+}
+
+// pruneClauses match the archived workflows that finished longer ago than the max age, or before the oldest workflow
+// kept by the max count. Any of them matching means the workflow is pruned.
+func pruneClauses(t sqldb.DBType, maxAge time.Duration, oldestKept *time.Time) []db.LogicalExpr {
+	var clauses []db.LogicalExpr
+	if maxAge > 0 {
+		clauses = append(clauses, db.Raw(secondsBetween(t, "finishedat", "current_timestamp")+" > ?", maxAge.Seconds()))
+	}
+	if oldestKept != nil {
+		clauses = append(clauses, db.Cond{"finishedat <": *oldestKept})
+	}
+	return clauses
+}
@@ -0,0 +1,217 @@
+package sqldb
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/upper/db/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// archiveTestWorkflows archives a workflow in the namespace for each of the ages, named after how long ago it finished
+func archiveTestWorkflows(t *testing.T, archive WorkflowArchive, namespace string, ages ...time.Duration) {
+	ctx := logging.TestContext(t.Context())
+	now := time.Now().UTC().Truncate(time.Second)
+	for _, age := range ages {
+		name := fmt.Sprintf("finished-%s-ago", age)
+		require.NoError(t, archive.ArchiveWorkflow(ctx, &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "-" + name), Labels: map[string]string{}},
+			Status: wfv1.WorkflowStatus{
+				Phase:      wfv1.WorkflowSucceeded,
+				StartedAt:  metav1.NewTime(now.Add(-age - time.Minute)),
+				FinishedAt: metav1.NewTime(now.Add(-age)),
+			},
+		}))
+	}
+}
+
+// archivedWorkflowNames returns the names of the archived workflows of the namespace, most recently started first
+func archivedWorkflowNames(t *testing.T, archive WorkflowArchive, namespace string) []string {
+	ctx := logging.TestContext(t.Context())
+	wfs, err := archive.ListWorkflows(ctx, sutils.ListOptions{Namespace: namespace})
+	require.NoError(t, err)
+	var names []string
+	for _, wf := range wfs {
+		names = append(names, wf.Name)
+	}
+	return names
+}
+
+func Test_pruneClauses(t *testing.T) {
+	oldestKept := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, pruneClauses(sqldb.Postgres, 0, nil))
+	})
+	t.Run("MaxAge", func(t *testing.T) {
+		assert.Equal(t, []db.LogicalExpr{db.Raw("extract(epoch from current_timestamp - finishedat) > ?", 3600.0)}, pruneClauses(sqldb.Postgres, time.Hour, nil))
+		assert.Equal(t, []db.LogicalExpr{db.Raw("timestampdiff(microsecond, finishedat, current_timestamp) / 1000000 > ?", 3600.0)}, pruneClauses(sqldb.MySQL, time.Hour, nil))
+	})
+	t.Run("MaxCount", func(t *testing.T) {
+		assert.Equal(t, []db.LogicalExpr{db.Cond{"finishedat <": oldestKept}}, pruneClauses(sqldb.Postgres, 0, &oldestKept))
+	})
+	t.Run("Both", func(t *testing.T) {
+		assert.Equal(t, []db.LogicalExpr{
+			db.Raw("extract(epoch from current_timestamp - finishedat) > ?", 86400.0),
+			db.Cond{"finishedat <": oldestKept},
+		}, pruneClauses(sqldb.Postgres, 24*time.Hour, &oldestKept))
+	})
+}
+
+func Test_durationClauses(t *testing.T) {
+	assert.Empty(t, durationClauses(sqldb.Postgres, 0, 0))
+	assert.Equal(t, []db.LogicalExpr{
+		db.Raw("extract(epoch from finishedat - startedat) >= ?", 600.0),
+		db.Raw("extract(epoch from finishedat - startedat) <= ?", 3600.0),
+	}, durationClauses(sqldb.Postgres, 10*time.Minute, time.Hour))
+	assert.Equal(t, []db.LogicalExpr{
+		db.Raw("timestampdiff(microsecond, startedat, finishedat) / 1000000 <= ?", 1.5),
+	}, durationClauses(sqldb.MySQL, 0, 1500*time.Millisecond))
+}
+
+func TestPruneWorkflows(t *testing.T) {
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			session := createTestDBSession(t, dbType)
+			for _, tt := range []struct {
+				name   string
+				policy config.ArchiveRetentionPolicy
+				dryRun bool
+				pruned int64
+				kept   []string
+			}{
+				{"None", config.ArchiveRetentionPolicy{}, false, 0, []string{"finished-10m0s-ago", "finished-1h0m0s-ago", "finished-2h0m0s-ago", "finished-3h0m0s-ago"}},
+				{"MaxAge", config.ArchiveRetentionPolicy{MaxAge: config.TTL(90 * time.Minute)}, false, 2, []string{"finished-10m0s-ago", "finished-1h0m0s-ago"}},
+				{"MaxCount", config.ArchiveRetentionPolicy{MaxCount: 3}, false, 1, []string{"finished-10m0s-ago", "finished-1h0m0s-ago", "finished-2h0m0s-ago"}},
+				{"Both", config.ArchiveRetentionPolicy{MaxAge: config.TTL(150 * time.Minute), MaxCount: 1}, false, 3, []string{"finished-10m0s-ago"}},
+				{"DryRun", config.ArchiveRetentionPolicy{MaxCount: 1}, true, 3, []string{"finished-10m0s-ago", "finished-1h0m0s-ago", "finished-2h0m0s-ago", "finished-3h0m0s-ago"}},
+			} {
+				t.Run(tt.name, func(t *testing.T) {
+					clusterName := fmt.Sprintf("%s-%s", testClusterName, tt.name)
+					archive := NewWorkflowArchive(session, clusterName, "", instanceid.NewService(""))
+					archiveTestWorkflows(t, archive, "my-ns", 10*time.Minute, time.Hour, 2*time.Hour, 3*time.Hour)
+					archiveTestWorkflows(t, archive, "other-ns", 3*time.Hour)
+					pruned, err := archive.PruneWorkflows(ctx, "my-ns", tt.policy, tt.dryRun)
+					require.NoError(t, err)
+					assert.Equal(t, tt.pruned, pruned)
+					assert.Equal(t, tt.kept, archivedWorkflowNames(t, archive, "my-ns"))
+					assert.Equal(t, []string{"finished-3h0m0s-ago"}, archivedWorkflowNames(t, archive, "other-ns"), "other namespaces are not pruned")
+				})
+			}
+		})
+	}
+}
+
+func TestListWorkflowsAfter(t *testing.T) {
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			archive := NewWorkflowArchive(createTestDBSession(t, dbType), testClusterName, "", instanceid.NewService(""))
+			startedAt := time.Now().UTC().Truncate(time.Second)
+			// the last two workflows started at the same time, so are ordered by UID
+			for i, started := range []time.Time{startedAt.Add(-time.Minute), startedAt, startedAt} {
+				require.NoError(t, archive.ArchiveWorkflow(ctx, &wfv1.Workflow{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("wf-%d", i), Namespace: "my-ns", UID: types.UID(fmt.Sprintf("uid-%d", 2-i)), Labels: map[string]string{}},
+					Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded, StartedAt: metav1.NewTime(started), FinishedAt: metav1.NewTime(started.Add(time.Second))},
+				}))
+			}
+			options := sutils.ListOptions{Namespace: "my-ns", OrderBy: sutils.OrderBy{Field: sutils.OrderByStartedAt, Ascending: true}, Limit: 1}
+			var uids []string
+			for {
+				page, err := archive.ListWorkflows(ctx, options)
+				require.NoError(t, err)
+				if len(page) == 0 {
+					break
+				}
+				last := page[len(page)-1]
+				uids = append(uids, string(last.UID))
+				options = options.WithAfter(&sutils.Cursor{StartedAt: last.Status.StartedAt.Time, UID: string(last.UID)})
+			}
+			assert.Equal(t, []string{"uid-2", "uid-0", "uid-1"}, uids)
+		})
+	}
+}
+
+func TestGroupWorkflowsByTemplate(t *testing.T) {
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			archive := NewWorkflowArchive(createTestDBSession(t, dbType), testClusterName, "", instanceid.NewService(""))
+			startedAt := time.Now().UTC().Truncate(time.Second)
+			for i, wf := range []struct {
+				labels  map[string]string
+				spec    wfv1.WorkflowSpec
+				started time.Time
+			}{
+				{map[string]string{common.LabelKeyWorkflowTemplate: "my-tmpl"}, wfv1.WorkflowSpec{}, startedAt.Add(-time.Hour)},
+				{map[string]string{}, wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-tmpl"}}, startedAt.Add(-time.Minute)},
+				{map[string]string{}, wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-tmpl", ClusterScope: true}}, startedAt.Add(-2 * time.Hour)},
+				{map[string]string{"team": "a"}, wfv1.WorkflowSpec{Entrypoint: "main"}, startedAt},
+				{map[string]string{}, wfv1.WorkflowSpec{Entrypoint: "main"}, startedAt},
+			} {
+				require.NoError(t, archive.ArchiveWorkflow(ctx, &wfv1.Workflow{
+					ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("wf-%d", i), Namespace: "my-ns", UID: types.UID(fmt.Sprintf("uid-%d", 9-i)), Labels: wf.labels},
+					Spec:       wf.spec,
+					Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded, StartedAt: metav1.NewTime(wf.started), FinishedAt: metav1.NewTime(wf.started.Add(time.Second))},
+				}))
+			}
+			summary := func(groups []TemplateGroup) []string {
+				var res []string
+				for _, group := range groups {
+					res = append(res, fmt.Sprintf("%s %s %d", group.Template, group.Latest.Name, group.Count))
+				}
+				return res
+			}
+			groups, err := archive.GroupWorkflowsByTemplate(ctx, sutils.ListOptions{Namespace: "my-ns"})
+			require.NoError(t, err)
+			// of the workflows that started at the same time, that of the first UID is the latest
+			assert.Equal(t, []string{"entrypoint/main wf-4 2", "WorkflowTemplate/my-tmpl wf-1 2", "ClusterWorkflowTemplate/my-tmpl wf-2 1"}, summary(groups))
+			assert.Equal(t, wfv1.WorkflowSucceeded, groups[0].Latest.Status.Phase)
+
+			requirements, err := labels.ParseToRequirements("team=a")
+			require.NoError(t, err)
+			groups, err = archive.GroupWorkflowsByTemplate(ctx, sutils.ListOptions{Namespace: "my-ns", LabelRequirements: requirements})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"entrypoint/main wf-3 1"}, summary(groups))
+		})
+	}
+}
+
+func Test_jsonStringContents(t *testing.T) {
+	assert.Equal(t, []string{"path/to/my-file.tgz"}, jsonStringContents("path/to/my-file.tgz"))
+	assert.Equal(t, []string{`https://example.com/file?a=1&b=<2>`, `https://example.com/file?a=1\u0026b=\u003c2\u003e`}, jsonStringContents("https://example.com/file?a=1&b=<2>"))
+	assert.Equal(t, []string{`a \"quoted\" \\ path`}, jsonStringContents(`a "quoted" \ path`))
+}
+
+func TestListWorkflowsContaining(t *testing.T) {
+	for _, dbType := range testDBTypes {
+		t.Run(string(dbType), func(t *testing.T) {
+			ctx := logging.TestContext(t.Context())
+			archive := NewWorkflowArchive(createTestDBSession(t, dbType), testClusterName, "", instanceid.NewService(""))
+			url := "https://example.com/file?a=1&b=2"
+			require.NoError(t, archive.ArchiveWorkflow(ctx, &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", UID: "my-uid", Labels: map[string]string{}},
+				Spec:       wfv1.WorkflowSpec{Arguments: wfv1.Arguments{Artifacts: wfv1.Artifacts{{Name: "file", ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: url}}}}}},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded, StartedAt: metav1.Now(), FinishedAt: metav1.Now()},
+			}))
+			for text, found := range map[string]bool{url: true, "a=1&b=3": false, "a=1_b": false} {
+				wfs, err := archive.ListWorkflowsContaining(ctx, sutils.ListOptions{Namespace: "my-ns"}, text)
+				require.NoError(t, err)
+				assert.Equal(t, found, len(wfs) == 1, text)
+			}
+		})
+	}
+}
@@ -24,10 +24,62 @@ func (c *argoKubeWorkflowServiceClient) GetWorkflow(ctx context.Context, req *wo
 	return c.delegate.GetWorkflow(ctx, req)
 }
 
+func (c *argoKubeWorkflowServiceClient) GetWorkflowGraph(ctx context.Context, req *workflowpkg.WorkflowGraphRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowGraph, error) {
+	return c.delegate.GetWorkflowGraph(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowManifest(ctx context.Context, req *workflowpkg.WorkflowManifestRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowManifest, error) {
+	return c.delegate.GetWorkflowManifest(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*v1alpha1.Outputs, error) {
+	return c.delegate.GetWorkflowOutputs(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowAllowedVerbs(ctx context.Context, req *workflowpkg.WorkflowAllowedVerbsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowAllowedVerbs, error) {
+	return c.delegate.GetWorkflowAllowedVerbs(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowLineage(ctx context.Context, req *workflowpkg.WorkflowLineageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowLineage, error) {
+	return c.delegate.GetWorkflowLineage(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowPodResources(ctx context.Context, req *workflowpkg.WorkflowPodResourcesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPodResources, error) {
+	return c.delegate.GetWorkflowPodResources(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowPendingApprovals(ctx context.Context, req *workflowpkg.WorkflowPendingApprovalsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingApprovals, error) {
+	return c.delegate.GetWorkflowPendingApprovals(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowPendingDiagnostic(ctx context.Context, req *workflowpkg.WorkflowPendingDiagnosticRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingDiagnostic, error) {
+	return c.delegate.GetWorkflowPendingDiagnostic(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowResourceUsage(ctx context.Context, req *workflowpkg.WorkflowResourceUsageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowResourceUsage, error) {
+	return c.delegate.GetWorkflowResourceUsage(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetWorkflowCallStacks(ctx context.Context, req *workflowpkg.WorkflowCallStacksRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCallStacks, error) {
+	return c.delegate.GetWorkflowCallStacks(ctx, req)
+}
+
 func (c *argoKubeWorkflowServiceClient) ListWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowList, error) {
 	return c.delegate.ListWorkflows(ctx, req)
 }
 
+func (c *argoKubeWorkflowServiceClient) ListWorkflowProgress(ctx context.Context, req *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowProgressList, error) {
+	return c.delegate.ListWorkflowProgress(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) ListWorkflowTemplateGroups(ctx context.Context, req *workflowpkg.WorkflowTemplateGroupsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowTemplateGroupList, error) {
+	return c.delegate.ListWorkflowTemplateGroups(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) ListWorkflowNamespaces(ctx context.Context, req *workflowpkg.ListWorkflowNamespacesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowNamespaceList, error) {
+	return c.delegate.ListWorkflowNamespaces(ctx, req)
+}
+
 func (c *argoKubeWorkflowServiceClient) WatchWorkflows(ctx context.Context, req *workflowpkg.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowsClient, error) {
 	intermediary := newWorkflowWatchIntermediary(ctx)
 	go func() {
@@ -42,6 +94,20 @@ func (c *argoKubeWorkflowServiceClient) WatchWorkflows(ctx context.Context, req
 	return intermediary, nil
 }
 
+func (c *argoKubeWorkflowServiceClient) WatchWorkflowNodes(ctx context.Context, req *workflowpkg.WatchWorkflowNodesRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowNodesClient, error) {
+	intermediary := newWorkflowNodesWatchIntermediary(ctx)
+	go func() {
+		defer intermediary.cancel()
+		err := c.delegate.WatchWorkflowNodes(req, intermediary)
+		if err != nil {
+			intermediary.error <- err
+		} else {
+			intermediary.error <- io.EOF
+		}
+	}()
+	return intermediary, nil
+}
+
 func (c *argoKubeWorkflowServiceClient) WatchEvents(ctx context.Context, req *workflowpkg.WatchEventsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchEventsClient, error) {
 	intermediary := newEventWatchIntermediary(ctx)
 	go func() {
@@ -60,6 +126,22 @@ func (c *argoKubeWorkflowServiceClient) DeleteWorkflow(ctx context.Context, req
 	return c.delegate.DeleteWorkflow(ctx, req)
 }
 
+func (c *argoKubeWorkflowServiceClient) DeleteWorkflows(ctx context.Context, req *workflowpkg.WorkflowsDeleteRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowsDeleteResponse, error) {
+	return c.delegate.DeleteWorkflows(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) CancelOperation(ctx context.Context, req *workflowpkg.CancelOperationRequest, _ ...grpc.CallOption) (*workflowpkg.CancelOperationResponse, error) {
+	return c.delegate.CancelOperation(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) GetMaintenanceMode(ctx context.Context, req *workflowpkg.GetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	return c.delegate.GetMaintenanceMode(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) SetMaintenanceMode(ctx context.Context, req *workflowpkg.SetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	return c.delegate.SetMaintenanceMode(ctx, req)
+}
+
 func (c *argoKubeWorkflowServiceClient) RetryWorkflow(ctx context.Context, req *workflowpkg.WorkflowRetryRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	return c.delegate.RetryWorkflow(ctx, req)
 }
@@ -84,6 +166,10 @@ func (c *argoKubeWorkflowServiceClient) SetWorkflow(ctx context.Context, req *wo
 	return c.delegate.SetWorkflow(ctx, req)
 }
 
+func (c *argoKubeWorkflowServiceClient) ArchiveWorkflow(ctx context.Context, req *workflowpkg.WorkflowArchiveRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	return c.delegate.ArchiveWorkflow(ctx, req)
+}
+
 func (c *argoKubeWorkflowServiceClient) TerminateWorkflow(ctx context.Context, req *workflowpkg.WorkflowTerminateRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	return c.delegate.TerminateWorkflow(ctx, req)
 }
@@ -92,6 +178,14 @@ func (c *argoKubeWorkflowServiceClient) LintWorkflow(ctx context.Context, req *w
 	return c.delegate.LintWorkflow(ctx, req)
 }
 
+func (c *argoKubeWorkflowServiceClient) EstimateWorkflowCost(ctx context.Context, req *workflowpkg.WorkflowCostEstimateRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCostEstimate, error) {
+	return c.delegate.EstimateWorkflowCost(ctx, req)
+}
+
+func (c *argoKubeWorkflowServiceClient) DiffWorkflows(ctx context.Context, req *workflowpkg.WorkflowDiffRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowDiff, error) {
+	return c.delegate.DiffWorkflows(ctx, req)
+}
+
 func (c *argoKubeWorkflowServiceClient) logs(ctx context.Context, req *workflowpkg.WorkflowLogRequest, f func(*workflowpkg.WorkflowLogRequest, *logsIntermediary) error) (workflowpkg.WorkflowService_PodLogsClient, error) {
 	intermediary := newLogsIntermediary(ctx)
 	go func() {
@@ -118,6 +212,20 @@ func (c *argoKubeWorkflowServiceClient) WorkflowLogs(ctx context.Context, req *w
 	})
 }
 
+func (c *argoKubeWorkflowServiceClient) GetWorkflowLogArchive(ctx context.Context, req *workflowpkg.WorkflowLogArchiveRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_GetWorkflowLogArchiveClient, error) {
+	intermediary := newLogArchiveIntermediary(ctx)
+	go func() {
+		defer intermediary.cancel()
+		err := c.delegate.GetWorkflowLogArchive(req, intermediary)
+		if err != nil {
+			intermediary.error <- err
+		} else {
+			intermediary.error <- io.EOF
+		}
+	}()
+	return intermediary, nil
+}
+
 func (c *argoKubeWorkflowServiceClient) SubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowSubmitRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	return c.delegate.SubmitWorkflow(ctx, req)
 }
@@ -26,16 +26,86 @@ func (c *errorTranslatingWorkflowServiceClient) GetWorkflow(ctx context.Context,
 	return workflow, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowGraph(ctx context.Context, req *workflowpkg.WorkflowGraphRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowGraph, error) {
+	graph, err := c.delegate.GetWorkflowGraph(ctx, req)
+	return graph, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowManifest(ctx context.Context, req *workflowpkg.WorkflowManifestRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowManifest, error) {
+	manifest, err := c.delegate.GetWorkflowManifest(ctx, req)
+	return manifest, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*v1alpha1.Outputs, error) {
+	outputs, err := c.delegate.GetWorkflowOutputs(ctx, req)
+	return outputs, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowAllowedVerbs(ctx context.Context, req *workflowpkg.WorkflowAllowedVerbsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowAllowedVerbs, error) {
+	workflowAllowedVerbs, err := c.delegate.GetWorkflowAllowedVerbs(ctx, req)
+	return workflowAllowedVerbs, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowLineage(ctx context.Context, req *workflowpkg.WorkflowLineageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowLineage, error) {
+	workflowLineage, err := c.delegate.GetWorkflowLineage(ctx, req)
+	return workflowLineage, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowPodResources(ctx context.Context, req *workflowpkg.WorkflowPodResourcesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPodResources, error) {
+	workflowPodResources, err := c.delegate.GetWorkflowPodResources(ctx, req)
+	return workflowPodResources, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowPendingApprovals(ctx context.Context, req *workflowpkg.WorkflowPendingApprovalsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingApprovals, error) {
+	workflowPendingApprovals, err := c.delegate.GetWorkflowPendingApprovals(ctx, req)
+	return workflowPendingApprovals, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowPendingDiagnostic(ctx context.Context, req *workflowpkg.WorkflowPendingDiagnosticRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingDiagnostic, error) {
+	workflowPendingDiagnostic, err := c.delegate.GetWorkflowPendingDiagnostic(ctx, req)
+	return workflowPendingDiagnostic, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowResourceUsage(ctx context.Context, req *workflowpkg.WorkflowResourceUsageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowResourceUsage, error) {
+	workflowResourceUsage, err := c.delegate.GetWorkflowResourceUsage(ctx, req)
+	return workflowResourceUsage, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowCallStacks(ctx context.Context, req *workflowpkg.WorkflowCallStacksRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCallStacks, error) {
+	workflowCallStacks, err := c.delegate.GetWorkflowCallStacks(ctx, req)
+	return workflowCallStacks, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) ListWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*v1alpha1.WorkflowList, error) {
 	workflows, err := c.delegate.ListWorkflows(ctx, req)
 	return workflows, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) ListWorkflowProgress(ctx context.Context, req *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowProgressList, error) {
+	workflowProgressList, err := c.delegate.ListWorkflowProgress(ctx, req)
+	return workflowProgressList, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) ListWorkflowTemplateGroups(ctx context.Context, req *workflowpkg.WorkflowTemplateGroupsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowTemplateGroupList, error) {
+	workflowTemplateGroupList, err := c.delegate.ListWorkflowTemplateGroups(ctx, req)
+	return workflowTemplateGroupList, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) ListWorkflowNamespaces(ctx context.Context, req *workflowpkg.ListWorkflowNamespacesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowNamespaceList, error) {
+	namespaces, err := c.delegate.ListWorkflowNamespaces(ctx, req)
+	return namespaces, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) WatchWorkflows(ctx context.Context, req *workflowpkg.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowsClient, error) {
 	workflows, err := c.delegate.WatchWorkflows(ctx, req)
 	return workflows, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) WatchWorkflowNodes(ctx context.Context, req *workflowpkg.WatchWorkflowNodesRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowNodesClient, error) {
+	nodes, err := c.delegate.WatchWorkflowNodes(ctx, req)
+	return nodes, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) WatchEvents(ctx context.Context, req *workflowpkg.WatchEventsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchEventsClient, error) {
 	events, err := c.delegate.WatchEvents(ctx, req)
 	return events, grpcutil.TranslateError(err)
@@ -46,6 +116,26 @@ func (c *errorTranslatingWorkflowServiceClient) DeleteWorkflow(ctx context.Conte
 	return workflow, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) DeleteWorkflows(ctx context.Context, req *workflowpkg.WorkflowsDeleteRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowsDeleteResponse, error) {
+	res, err := c.delegate.DeleteWorkflows(ctx, req)
+	return res, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) CancelOperation(ctx context.Context, req *workflowpkg.CancelOperationRequest, _ ...grpc.CallOption) (*workflowpkg.CancelOperationResponse, error) {
+	res, err := c.delegate.CancelOperation(ctx, req)
+	return res, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) GetMaintenanceMode(ctx context.Context, req *workflowpkg.GetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	mode, err := c.delegate.GetMaintenanceMode(ctx, req)
+	return mode, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) SetMaintenanceMode(ctx context.Context, req *workflowpkg.SetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	mode, err := c.delegate.SetMaintenanceMode(ctx, req)
+	return mode, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) RetryWorkflow(ctx context.Context, req *workflowpkg.WorkflowRetryRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	workflow, err := c.delegate.RetryWorkflow(ctx, req)
 	return workflow, grpcutil.TranslateError(err)
@@ -71,6 +161,11 @@ func (c *errorTranslatingWorkflowServiceClient) SetWorkflow(ctx context.Context,
 	return workflow, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) ArchiveWorkflow(ctx context.Context, req *workflowpkg.WorkflowArchiveRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	workflow, err := c.delegate.ArchiveWorkflow(ctx, req)
+	return workflow, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) StopWorkflow(ctx context.Context, req *workflowpkg.WorkflowStopRequest, _ ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	workflow, err := c.delegate.StopWorkflow(ctx, req)
 	return workflow, grpcutil.TranslateError(err)
@@ -86,6 +181,16 @@ func (c *errorTranslatingWorkflowServiceClient) LintWorkflow(ctx context.Context
 	return workflow, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) EstimateWorkflowCost(ctx context.Context, req *workflowpkg.WorkflowCostEstimateRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCostEstimate, error) {
+	estimate, err := c.delegate.EstimateWorkflowCost(ctx, req)
+	return estimate, grpcutil.TranslateError(err)
+}
+
+func (c *errorTranslatingWorkflowServiceClient) DiffWorkflows(ctx context.Context, req *workflowpkg.WorkflowDiffRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowDiff, error) {
+	diff, err := c.delegate.DiffWorkflows(ctx, req)
+	return diff, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) PodLogs(ctx context.Context, req *workflowpkg.WorkflowLogRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_PodLogsClient, error) {
 	logs, err := c.delegate.PodLogs(ctx, req)
 	return logs, grpcutil.TranslateError(err)
@@ -96,6 +201,11 @@ func (c *errorTranslatingWorkflowServiceClient) WorkflowLogs(ctx context.Context
 	return logs, grpcutil.TranslateError(err)
 }
 
+func (c *errorTranslatingWorkflowServiceClient) GetWorkflowLogArchive(ctx context.Context, req *workflowpkg.WorkflowLogArchiveRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_GetWorkflowLogArchiveClient, error) {
+	chunks, err := c.delegate.GetWorkflowLogArchive(ctx, req)
+	return chunks, grpcutil.TranslateError(err)
+}
+
 func (c *errorTranslatingWorkflowServiceClient) SubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowSubmitRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	workflow, err := c.delegate.SubmitWorkflow(ctx, req)
 	return workflow, grpcutil.TranslateError(err)
@@ -56,3 +56,21 @@ func (h ArchivedWorkflowsServiceClient) ResubmitArchivedWorkflow(ctx context.Con
 	out := &wfv1.Workflow{}
 	return out, h.Put(ctx, in, out, "/api/v1/archived-workflows/{uid}/resubmit")
 }
+
+func (h ArchivedWorkflowsServiceClient) PruneArchivedWorkflows(ctx context.Context, in *workflowarchivepkg.PruneArchivedWorkflowsRequest, _ ...grpc.CallOption) (*workflowarchivepkg.PruneArchivedWorkflowsResponse, error) {
+	out := &workflowarchivepkg.PruneArchivedWorkflowsResponse{}
+	return out, h.Post(ctx, in, out, "/api/v1/archived-workflows/prune")
+}
+
+func (h ArchivedWorkflowsServiceClient) StreamArchivedWorkflows(ctx context.Context, in *workflowarchivepkg.StreamArchivedWorkflowsRequest, _ ...grpc.CallOption) (workflowarchivepkg.ArchivedWorkflowService_StreamArchivedWorkflowsClient, error) {
+	reader, err := h.EventStreamReader(ctx, in, "/api/v1/stream/archived-workflows")
+	if err != nil {
+		return nil, err
+	}
+	return streamArchivedWorkflowsClient{serverSentEventsClient{ctx, reader}}, nil
+}
+
+func (h ArchivedWorkflowsServiceClient) FindWorkflowsByArtifact(ctx context.Context, in *workflowarchivepkg.FindWorkflowsByArtifactRequest, _ ...grpc.CallOption) (*workflowarchivepkg.FindWorkflowsByArtifactResponse, error) {
+	out := &workflowarchivepkg.FindWorkflowsByArtifactResponse{}
+	return out, h.Get(ctx, in, out, "/api/v1/archived-workflows-by-artifact")
+}
@@ -10,3 +10,10 @@ func (f *podLogsClient) Recv() (*workflowpkg.LogEntry, error) {
 	v := &workflowpkg.LogEntry{}
 	return v, f.RecvEvent(v)
 }
+
+type logArchiveClient struct{ serverSentEventsClient }
+
+func (f *logArchiveClient) Recv() (*workflowpkg.LogArchiveChunk, error) {
+	v := &workflowpkg.LogArchiveChunk{}
+	return v, f.RecvEvent(v)
+}
@@ -2,6 +2,7 @@ package http1
 
 import (
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 )
 
 type watchWorkflowsClient struct{ serverSentEventsClient }
@@ -10,3 +11,17 @@ func (f watchWorkflowsClient) Recv() (*workflowpkg.WorkflowWatchEvent, error) {
 	v := &workflowpkg.WorkflowWatchEvent{}
 	return v, f.RecvEvent(v)
 }
+
+type watchWorkflowNodesClient struct{ serverSentEventsClient }
+
+func (f watchWorkflowNodesClient) Recv() (*workflowpkg.WorkflowNodeDelta, error) {
+	v := &workflowpkg.WorkflowNodeDelta{}
+	return v, f.RecvEvent(v)
+}
+
+type streamArchivedWorkflowsClient struct{ serverSentEventsClient }
+
+func (f streamArchivedWorkflowsClient) Recv() (*wfv1.Workflow, error) {
+	v := &wfv1.Workflow{}
+	return v, f.RecvEvent(v)
+}
@@ -21,11 +21,76 @@ func (h WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflowpkg.
 	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}")
 }
 
+func (h WorkflowServiceClient) GetWorkflowGraph(ctx context.Context, in *workflowpkg.WorkflowGraphRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowGraph, error) {
+	out := &workflowpkg.WorkflowGraph{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/graph")
+}
+
+func (h WorkflowServiceClient) GetWorkflowManifest(ctx context.Context, in *workflowpkg.WorkflowManifestRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowManifest, error) {
+	out := &workflowpkg.WorkflowManifest{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/manifest")
+}
+
+func (h WorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, in *workflowpkg.WorkflowOutputsRequest, _ ...grpc.CallOption) (*wfv1.Outputs, error) {
+	out := &wfv1.Outputs{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/outputs")
+}
+
+func (h WorkflowServiceClient) GetWorkflowAllowedVerbs(ctx context.Context, in *workflowpkg.WorkflowAllowedVerbsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowAllowedVerbs, error) {
+	out := &workflowpkg.WorkflowAllowedVerbs{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/allowed-verbs")
+}
+
+func (h WorkflowServiceClient) GetWorkflowLineage(ctx context.Context, in *workflowpkg.WorkflowLineageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowLineage, error) {
+	out := &workflowpkg.WorkflowLineage{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/lineage")
+}
+
+func (h WorkflowServiceClient) GetWorkflowPodResources(ctx context.Context, in *workflowpkg.WorkflowPodResourcesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPodResources, error) {
+	out := &workflowpkg.WorkflowPodResources{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/pod-resources")
+}
+
+func (h WorkflowServiceClient) GetWorkflowPendingApprovals(ctx context.Context, in *workflowpkg.WorkflowPendingApprovalsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingApprovals, error) {
+	out := &workflowpkg.WorkflowPendingApprovals{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/pending-approvals")
+}
+
+func (h WorkflowServiceClient) GetWorkflowPendingDiagnostic(ctx context.Context, in *workflowpkg.WorkflowPendingDiagnosticRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowPendingDiagnostic, error) {
+	out := &workflowpkg.WorkflowPendingDiagnostic{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/pending-diagnostic")
+}
+
+func (h WorkflowServiceClient) GetWorkflowResourceUsage(ctx context.Context, in *workflowpkg.WorkflowResourceUsageRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowResourceUsage, error) {
+	out := &workflowpkg.WorkflowResourceUsage{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/resource-usage")
+}
+
+func (h WorkflowServiceClient) GetWorkflowCallStacks(ctx context.Context, in *workflowpkg.WorkflowCallStacksRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCallStacks, error) {
+	out := &workflowpkg.WorkflowCallStacks{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/call-stacks")
+}
+
 func (h WorkflowServiceClient) ListWorkflows(ctx context.Context, in *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
 	out := &wfv1.WorkflowList{}
 	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}")
 }
 
+func (h WorkflowServiceClient) ListWorkflowProgress(ctx context.Context, in *workflowpkg.WorkflowListRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowProgressList, error) {
+	out := &workflowpkg.WorkflowProgressList{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflow-progress/{namespace}")
+}
+
+func (h WorkflowServiceClient) ListWorkflowTemplateGroups(ctx context.Context, in *workflowpkg.WorkflowTemplateGroupsRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowTemplateGroupList, error) {
+	out := &workflowpkg.WorkflowTemplateGroupList{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflow-template-groups/{namespace}")
+}
+
+func (h WorkflowServiceClient) ListWorkflowNamespaces(ctx context.Context, in *workflowpkg.ListWorkflowNamespacesRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowNamespaceList, error) {
+	out := &workflowpkg.WorkflowNamespaceList{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflow-namespaces")
+}
+
 func (h WorkflowServiceClient) WatchWorkflows(ctx context.Context, in *workflowpkg.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowsClient, error) {
 	reader, err := h.EventStreamReader(ctx, in, "/api/v1/workflow-events/{namespace}")
 	if err != nil {
@@ -34,6 +99,14 @@ func (h WorkflowServiceClient) WatchWorkflows(ctx context.Context, in *workflowp
 	return watchWorkflowsClient{serverSentEventsClient{ctx, reader}}, nil
 }
 
+func (h WorkflowServiceClient) WatchWorkflowNodes(ctx context.Context, in *workflowpkg.WatchWorkflowNodesRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowNodesClient, error) {
+	reader, err := h.EventStreamReader(ctx, in, "/api/v1/workflow-events/{namespace}/{name}/nodes")
+	if err != nil {
+		return nil, err
+	}
+	return watchWorkflowNodesClient{serverSentEventsClient{ctx, reader}}, nil
+}
+
 func (h WorkflowServiceClient) WatchEvents(ctx context.Context, in *workflowpkg.WatchEventsRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_WatchEventsClient, error) {
 	reader, err := h.EventStreamReader(ctx, in, "/api/v1/stream/events/{namespace}")
 	if err != nil {
@@ -47,6 +120,26 @@ func (h WorkflowServiceClient) DeleteWorkflow(ctx context.Context, in *workflowp
 	return out, h.Delete(ctx, in, out, "/api/v1/workflows/{namespace}/{name}")
 }
 
+func (h WorkflowServiceClient) DeleteWorkflows(ctx context.Context, in *workflowpkg.WorkflowsDeleteRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowsDeleteResponse, error) {
+	out := &workflowpkg.WorkflowsDeleteResponse{}
+	return out, h.Post(ctx, in, out, "/api/v1/workflows/{namespace}/delete")
+}
+
+func (h WorkflowServiceClient) CancelOperation(ctx context.Context, in *workflowpkg.CancelOperationRequest, _ ...grpc.CallOption) (*workflowpkg.CancelOperationResponse, error) {
+	out := &workflowpkg.CancelOperationResponse{}
+	return out, h.Post(ctx, in, out, "/api/v1/workflows/{namespace}/operations/{operationId}/cancel")
+}
+
+func (h WorkflowServiceClient) GetMaintenanceMode(ctx context.Context, in *workflowpkg.GetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	out := &workflowpkg.MaintenanceMode{}
+	return out, h.Get(ctx, in, out, "/api/v1/maintenance-mode")
+}
+
+func (h WorkflowServiceClient) SetMaintenanceMode(ctx context.Context, in *workflowpkg.SetMaintenanceModeRequest, _ ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	out := &workflowpkg.MaintenanceMode{}
+	return out, h.Put(ctx, in, out, "/api/v1/maintenance-mode")
+}
+
 func (h WorkflowServiceClient) RetryWorkflow(ctx context.Context, in *workflowpkg.WorkflowRetryRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
 	out := &wfv1.Workflow{}
 	return out, h.Put(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/retry")
@@ -82,11 +175,26 @@ func (h WorkflowServiceClient) SetWorkflow(ctx context.Context, in *workflowpkg.
 	return out, h.Put(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/set")
 }
 
+func (h WorkflowServiceClient) ArchiveWorkflow(ctx context.Context, in *workflowpkg.WorkflowArchiveRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	out := &wfv1.Workflow{}
+	return out, h.Put(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/archive")
+}
+
 func (h WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workflowpkg.WorkflowLintRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
 	out := &wfv1.Workflow{}
 	return out, h.Post(ctx, in, out, "/api/v1/workflows/{namespace}/lint")
 }
 
+func (h WorkflowServiceClient) EstimateWorkflowCost(ctx context.Context, in *workflowpkg.WorkflowCostEstimateRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowCostEstimate, error) {
+	out := &workflowpkg.WorkflowCostEstimate{}
+	return out, h.Post(ctx, in, out, "/api/v1/workflows/{namespace}/estimate-cost")
+}
+
+func (h WorkflowServiceClient) DiffWorkflows(ctx context.Context, in *workflowpkg.WorkflowDiffRequest, _ ...grpc.CallOption) (*workflowpkg.WorkflowDiff, error) {
+	out := &workflowpkg.WorkflowDiff{}
+	return out, h.Get(ctx, in, out, "/api/v1/workflows/{namespace}/{name}/diff")
+}
+
 func (h WorkflowServiceClient) PodLogs(ctx context.Context, in *workflowpkg.WorkflowLogRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_PodLogsClient, error) {
 	reader, err := h.EventStreamReader(ctx, in, "/api/v1/workflows/{namespace}/{name}/{podName}/log")
 	if err != nil {
@@ -103,6 +211,14 @@ func (h WorkflowServiceClient) WorkflowLogs(ctx context.Context, in *workflowpkg
 	return &podLogsClient{serverSentEventsClient{ctx, reader}}, nil
 }
 
+func (h WorkflowServiceClient) GetWorkflowLogArchive(ctx context.Context, in *workflowpkg.WorkflowLogArchiveRequest, _ ...grpc.CallOption) (workflowpkg.WorkflowService_GetWorkflowLogArchiveClient, error) {
+	reader, err := h.EventStreamReader(ctx, in, "/api/v1/workflows/{namespace}/{name}/log-archive")
+	if err != nil {
+		return nil, err
+	}
+	return &logArchiveClient{serverSentEventsClient{ctx, reader}}, nil
+}
+
 func (h WorkflowServiceClient) SubmitWorkflow(ctx context.Context, in *workflowpkg.WorkflowSubmitRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
 	out := &wfv1.Workflow{}
 	return out, h.Post(ctx, in, out, "/api/v1/workflows/{namespace}/submit")
@@ -37,3 +37,26 @@ func (c *logsIntermediary) SendHeader(metadata.MD) error {
 func newLogsIntermediary(ctx context.Context) *logsIntermediary {
 	return &logsIntermediary{newAbstractIntermediary(ctx), make(chan *workflowpkg.LogEntry)}
 }
+
+type logArchiveIntermediary struct {
+	abstractIntermediary
+	chunks chan *workflowpkg.LogArchiveChunk
+}
+
+func (c *logArchiveIntermediary) Send(chunk *workflowpkg.LogArchiveChunk) error {
+	c.chunks <- chunk
+	return nil
+}
+
+func (c *logArchiveIntermediary) Recv() (*workflowpkg.LogArchiveChunk, error) {
+	select {
+	case err := <-c.error:
+		return nil, err
+	case chunk := <-c.chunks:
+		return chunk, nil
+	}
+}
+
+func newLogArchiveIntermediary(ctx context.Context) *logArchiveIntermediary {
+	return &logArchiveIntermediary{newAbstractIntermediary(ctx), make(chan *workflowpkg.LogArchiveChunk)}
+}
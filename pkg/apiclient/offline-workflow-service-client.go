@@ -26,14 +26,70 @@ func (o OfflineWorkflowServiceClient) GetWorkflow(context.Context, *workflowpkg.
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) GetWorkflowGraph(context.Context, *workflowpkg.WorkflowGraphRequest, ...grpc.CallOption) (*workflowpkg.WorkflowGraph, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowManifest(context.Context, *workflowpkg.WorkflowManifestRequest, ...grpc.CallOption) (*workflowpkg.WorkflowManifest, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowOutputs(context.Context, *workflowpkg.WorkflowOutputsRequest, ...grpc.CallOption) (*wfv1.Outputs, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowAllowedVerbs(context.Context, *workflowpkg.WorkflowAllowedVerbsRequest, ...grpc.CallOption) (*workflowpkg.WorkflowAllowedVerbs, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowLineage(context.Context, *workflowpkg.WorkflowLineageRequest, ...grpc.CallOption) (*workflowpkg.WorkflowLineage, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowPodResources(context.Context, *workflowpkg.WorkflowPodResourcesRequest, ...grpc.CallOption) (*workflowpkg.WorkflowPodResources, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowPendingApprovals(context.Context, *workflowpkg.WorkflowPendingApprovalsRequest, ...grpc.CallOption) (*workflowpkg.WorkflowPendingApprovals, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowPendingDiagnostic(context.Context, *workflowpkg.WorkflowPendingDiagnosticRequest, ...grpc.CallOption) (*workflowpkg.WorkflowPendingDiagnostic, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowResourceUsage(context.Context, *workflowpkg.WorkflowResourceUsageRequest, ...grpc.CallOption) (*workflowpkg.WorkflowResourceUsage, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetWorkflowCallStacks(context.Context, *workflowpkg.WorkflowCallStacksRequest, ...grpc.CallOption) (*workflowpkg.WorkflowCallStacks, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) ListWorkflows(context.Context, *workflowpkg.WorkflowListRequest, ...grpc.CallOption) (*wfv1.WorkflowList, error) {
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) ListWorkflowProgress(context.Context, *workflowpkg.WorkflowListRequest, ...grpc.CallOption) (*workflowpkg.WorkflowProgressList, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) ListWorkflowTemplateGroups(context.Context, *workflowpkg.WorkflowTemplateGroupsRequest, ...grpc.CallOption) (*workflowpkg.WorkflowTemplateGroupList, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) ListWorkflowNamespaces(context.Context, *workflowpkg.ListWorkflowNamespacesRequest, ...grpc.CallOption) (*workflowpkg.WorkflowNamespaceList, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) WatchWorkflows(context.Context, *workflowpkg.WatchWorkflowsRequest, ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowsClient, error) {
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) WatchWorkflowNodes(context.Context, *workflowpkg.WatchWorkflowNodesRequest, ...grpc.CallOption) (workflowpkg.WorkflowService_WatchWorkflowNodesClient, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) WatchEvents(context.Context, *workflowpkg.WatchEventsRequest, ...grpc.CallOption) (workflowpkg.WorkflowService_WatchEventsClient, error) {
 	return nil, ErrOffline
 }
@@ -42,6 +98,22 @@ func (o OfflineWorkflowServiceClient) DeleteWorkflow(context.Context, *workflowp
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) DeleteWorkflows(context.Context, *workflowpkg.WorkflowsDeleteRequest, ...grpc.CallOption) (*workflowpkg.WorkflowsDeleteResponse, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) CancelOperation(context.Context, *workflowpkg.CancelOperationRequest, ...grpc.CallOption) (*workflowpkg.CancelOperationResponse, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) GetMaintenanceMode(context.Context, *workflowpkg.GetMaintenanceModeRequest, ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) SetMaintenanceMode(context.Context, *workflowpkg.SetMaintenanceModeRequest, ...grpc.CallOption) (*workflowpkg.MaintenanceMode, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) RetryWorkflow(context.Context, *workflowpkg.WorkflowRetryRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
 	return nil, ErrOffline
 }
@@ -70,6 +142,10 @@ func (o OfflineWorkflowServiceClient) SetWorkflow(context.Context, *workflowpkg.
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) ArchiveWorkflow(context.Context, *workflowpkg.WorkflowArchiveRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) LintWorkflow(ctx context.Context, req *workflowpkg.WorkflowLintRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
 	err := validate.ValidateWorkflow(ctx, o.namespacedWorkflowTemplateGetterMap.GetNamespaceGetter(req.Namespace), o.clusterWorkflowTemplateGetter, req.Workflow, nil, validate.ValidateOpts{Lint: true})
 	if err != nil {
@@ -78,6 +154,14 @@ func (o OfflineWorkflowServiceClient) LintWorkflow(ctx context.Context, req *wor
 	return req.Workflow, nil
 }
 
+func (o OfflineWorkflowServiceClient) EstimateWorkflowCost(context.Context, *workflowpkg.WorkflowCostEstimateRequest, ...grpc.CallOption) (*workflowpkg.WorkflowCostEstimate, error) {
+	return nil, ErrOffline
+}
+
+func (o OfflineWorkflowServiceClient) DiffWorkflows(context.Context, *workflowpkg.WorkflowDiffRequest, ...grpc.CallOption) (*workflowpkg.WorkflowDiff, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) PodLogs(context.Context, *workflowpkg.WorkflowLogRequest, ...grpc.CallOption) (workflowpkg.WorkflowService_PodLogsClient, error) {
 	return nil, ErrOffline
 }
@@ -86,6 +170,10 @@ func (o OfflineWorkflowServiceClient) WorkflowLogs(context.Context, *workflowpkg
 	return nil, ErrOffline
 }
 
+func (o OfflineWorkflowServiceClient) GetWorkflowLogArchive(context.Context, *workflowpkg.WorkflowLogArchiveRequest, ...grpc.CallOption) (workflowpkg.WorkflowService_GetWorkflowLogArchiveClient, error) {
+	return nil, ErrOffline
+}
+
 func (o OfflineWorkflowServiceClient) SubmitWorkflow(context.Context, *workflowpkg.WorkflowSubmitRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
 	return nil, ErrOffline
 }
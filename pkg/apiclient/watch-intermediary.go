@@ -39,6 +39,34 @@ func newWorkflowWatchIntermediary(ctx context.Context) *workflowWatchIntermediar
 	return &workflowWatchIntermediary{newAbstractIntermediary(ctx), make(chan *workflowpkg.WorkflowWatchEvent)}
 }
 
+type workflowNodesWatchIntermediary struct {
+	abstractIntermediary
+	deltas chan *workflowpkg.WorkflowNodeDelta
+}
+
+func (w workflowNodesWatchIntermediary) Send(d *workflowpkg.WorkflowNodeDelta) error {
+	w.deltas <- d
+	return nil
+}
+
+func (w workflowNodesWatchIntermediary) Recv() (*workflowpkg.WorkflowNodeDelta, error) {
+	select {
+	case e := <-w.error:
+		return nil, e
+	case delta := <-w.deltas:
+		return delta, nil
+	}
+}
+
+func (w *workflowNodesWatchIntermediary) SendHeader(metadata.MD) error {
+	// see workflowWatchIntermediary.SendHeader
+	return nil
+}
+
+func newWorkflowNodesWatchIntermediary(ctx context.Context) *workflowNodesWatchIntermediary {
+	return &workflowNodesWatchIntermediary{newAbstractIntermediary(ctx), make(chan *workflowpkg.WorkflowNodeDelta)}
+}
+
 type eventWatchIntermediary struct {
 	abstractIntermediary
 	events chan *v1.Event
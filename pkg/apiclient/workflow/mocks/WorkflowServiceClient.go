@@ -40,6 +40,180 @@ func (_m *WorkflowServiceClient) EXPECT() *WorkflowServiceClient_Expecter {
 	return &WorkflowServiceClient_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveWorkflow provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) ArchiveWorkflow(ctx context.Context, in *workflow.WorkflowArchiveRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveWorkflow")
+	}
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArchiveRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowArchiveRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowArchiveRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_ArchiveWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveWorkflow'
+type WorkflowServiceClient_ArchiveWorkflow_Call struct {
+	*mock.Call
+}
+
+// ArchiveWorkflow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowArchiveRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) ArchiveWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_ArchiveWorkflow_Call {
+	return &WorkflowServiceClient_ArchiveWorkflow_Call{Call: _e.mock.On("ArchiveWorkflow",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_ArchiveWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowArchiveRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_ArchiveWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowArchiveRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowArchiveRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_ArchiveWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_ArchiveWorkflow_Call {
+	_c.Call.Return(workflow1, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_ArchiveWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowArchiveRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_ArchiveWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CancelOperation provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) CancelOperation(ctx context.Context, in *workflow.CancelOperationRequest, opts ...grpc.CallOption) (*workflow.CancelOperationResponse, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelOperation")
+	}
+
+	var r0 *workflow.CancelOperationResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.CancelOperationRequest, ...grpc.CallOption) (*workflow.CancelOperationResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.CancelOperationRequest, ...grpc.CallOption) *workflow.CancelOperationResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.CancelOperationResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.CancelOperationRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_CancelOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelOperation'
+type WorkflowServiceClient_CancelOperation_Call struct {
+	*mock.Call
+}
+
+// CancelOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.CancelOperationRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) CancelOperation(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_CancelOperation_Call {
+	return &WorkflowServiceClient_CancelOperation_Call{Call: _e.mock.On("CancelOperation",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_CancelOperation_Call) Run(run func(ctx context.Context, in *workflow.CancelOperationRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_CancelOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.CancelOperationRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.CancelOperationRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_CancelOperation_Call) Return(cancelOperationResponse *workflow.CancelOperationResponse, err error) *WorkflowServiceClient_CancelOperation_Call {
+	_c.Call.Return(cancelOperationResponse, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_CancelOperation_Call) RunAndReturn(run func(ctx context.Context, in *workflow.CancelOperationRequest, opts ...grpc.CallOption) (*workflow.CancelOperationResponse, error)) *WorkflowServiceClient_CancelOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateWorkflow provides a mock function for the type WorkflowServiceClient
 func (_mock *WorkflowServiceClient) CreateWorkflow(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	// grpc.CallOption
@@ -53,22 +227,1588 @@ func (_mock *WorkflowServiceClient) CreateWorkflow(ctx context.Context, in *work
 	ret := _mock.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateWorkflow")
+		panic("no return value specified for CreateWorkflow")
+	}
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_CreateWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkflow'
+type WorkflowServiceClient_CreateWorkflow_Call struct {
+	*mock.Call
+}
+
+// CreateWorkflow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowCreateRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) CreateWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_CreateWorkflow_Call {
+	return &WorkflowServiceClient_CreateWorkflow_Call{Call: _e.mock.On("CreateWorkflow",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_CreateWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_CreateWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowCreateRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowCreateRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_CreateWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_CreateWorkflow_Call {
+	_c.Call.Return(workflow1, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_CreateWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_CreateWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkflow provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) DeleteWorkflow(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkflow")
+	}
+
+	var r0 *workflow.WorkflowDeleteResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) *workflow.WorkflowDeleteResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowDeleteResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_DeleteWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkflow'
+type WorkflowServiceClient_DeleteWorkflow_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkflow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowDeleteRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) DeleteWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_DeleteWorkflow_Call {
+	return &WorkflowServiceClient_DeleteWorkflow_Call{Call: _e.mock.On("DeleteWorkflow",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_DeleteWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowDeleteRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowDeleteRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflow_Call) Return(workflowDeleteResponse *workflow.WorkflowDeleteResponse, err error) *WorkflowServiceClient_DeleteWorkflow_Call {
+	_c.Call.Return(workflowDeleteResponse, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error)) *WorkflowServiceClient_DeleteWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWorkflows provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) DeleteWorkflows(ctx context.Context, in *workflow.WorkflowsDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowsDeleteResponse, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWorkflows")
+	}
+
+	var r0 *workflow.WorkflowsDeleteResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowsDeleteRequest, ...grpc.CallOption) (*workflow.WorkflowsDeleteResponse, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowsDeleteRequest, ...grpc.CallOption) *workflow.WorkflowsDeleteResponse); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowsDeleteResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowsDeleteRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_DeleteWorkflows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkflows'
+type WorkflowServiceClient_DeleteWorkflows_Call struct {
+	*mock.Call
+}
+
+// DeleteWorkflows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowsDeleteRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) DeleteWorkflows(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_DeleteWorkflows_Call {
+	return &WorkflowServiceClient_DeleteWorkflows_Call{Call: _e.mock.On("DeleteWorkflows",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflows_Call) Run(run func(ctx context.Context, in *workflow.WorkflowsDeleteRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_DeleteWorkflows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowsDeleteRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowsDeleteRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflows_Call) Return(workflowsDeleteResponse *workflow.WorkflowsDeleteResponse, err error) *WorkflowServiceClient_DeleteWorkflows_Call {
+	_c.Call.Return(workflowsDeleteResponse, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DeleteWorkflows_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowsDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowsDeleteResponse, error)) *WorkflowServiceClient_DeleteWorkflows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DiffWorkflows provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) DiffWorkflows(ctx context.Context, in *workflow.WorkflowDiffRequest, opts ...grpc.CallOption) (*workflow.WorkflowDiff, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DiffWorkflows")
+	}
+
+	var r0 *workflow.WorkflowDiff
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDiffRequest, ...grpc.CallOption) (*workflow.WorkflowDiff, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDiffRequest, ...grpc.CallOption) *workflow.WorkflowDiff); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowDiff)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowDiffRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_DiffWorkflows_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DiffWorkflows'
+type WorkflowServiceClient_DiffWorkflows_Call struct {
+	*mock.Call
+}
+
+// DiffWorkflows is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowDiffRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) DiffWorkflows(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_DiffWorkflows_Call {
+	return &WorkflowServiceClient_DiffWorkflows_Call{Call: _e.mock.On("DiffWorkflows",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_DiffWorkflows_Call) Run(run func(ctx context.Context, in *workflow.WorkflowDiffRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_DiffWorkflows_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowDiffRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowDiffRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DiffWorkflows_Call) Return(workflowDiff *workflow.WorkflowDiff, err error) *WorkflowServiceClient_DiffWorkflows_Call {
+	_c.Call.Return(workflowDiff, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_DiffWorkflows_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowDiffRequest, opts ...grpc.CallOption) (*workflow.WorkflowDiff, error)) *WorkflowServiceClient_DiffWorkflows_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EstimateWorkflowCost provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) EstimateWorkflowCost(ctx context.Context, in *workflow.WorkflowCostEstimateRequest, opts ...grpc.CallOption) (*workflow.WorkflowCostEstimate, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateWorkflowCost")
+	}
+
+	var r0 *workflow.WorkflowCostEstimate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCostEstimateRequest, ...grpc.CallOption) (*workflow.WorkflowCostEstimate, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCostEstimateRequest, ...grpc.CallOption) *workflow.WorkflowCostEstimate); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowCostEstimate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowCostEstimateRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_EstimateWorkflowCost_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EstimateWorkflowCost'
+type WorkflowServiceClient_EstimateWorkflowCost_Call struct {
+	*mock.Call
+}
+
+// EstimateWorkflowCost is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowCostEstimateRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) EstimateWorkflowCost(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_EstimateWorkflowCost_Call {
+	return &WorkflowServiceClient_EstimateWorkflowCost_Call{Call: _e.mock.On("EstimateWorkflowCost",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_EstimateWorkflowCost_Call) Run(run func(ctx context.Context, in *workflow.WorkflowCostEstimateRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_EstimateWorkflowCost_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowCostEstimateRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowCostEstimateRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_EstimateWorkflowCost_Call) Return(workflowCostEstimate *workflow.WorkflowCostEstimate, err error) *WorkflowServiceClient_EstimateWorkflowCost_Call {
+	_c.Call.Return(workflowCostEstimate, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_EstimateWorkflowCost_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowCostEstimateRequest, opts ...grpc.CallOption) (*workflow.WorkflowCostEstimate, error)) *WorkflowServiceClient_EstimateWorkflowCost_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMaintenanceMode provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetMaintenanceMode(ctx context.Context, in *workflow.GetMaintenanceModeRequest, opts ...grpc.CallOption) (*workflow.MaintenanceMode, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaintenanceMode")
+	}
+
+	var r0 *workflow.MaintenanceMode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.GetMaintenanceModeRequest, ...grpc.CallOption) (*workflow.MaintenanceMode, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.GetMaintenanceModeRequest, ...grpc.CallOption) *workflow.MaintenanceMode); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.MaintenanceMode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.GetMaintenanceModeRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetMaintenanceMode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaintenanceMode'
+type WorkflowServiceClient_GetMaintenanceMode_Call struct {
+	*mock.Call
+}
+
+// GetMaintenanceMode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.GetMaintenanceModeRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetMaintenanceMode(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetMaintenanceMode_Call {
+	return &WorkflowServiceClient_GetMaintenanceMode_Call{Call: _e.mock.On("GetMaintenanceMode",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetMaintenanceMode_Call) Run(run func(ctx context.Context, in *workflow.GetMaintenanceModeRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetMaintenanceMode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.GetMaintenanceModeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.GetMaintenanceModeRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetMaintenanceMode_Call) Return(maintenanceMode *workflow.MaintenanceMode, err error) *WorkflowServiceClient_GetMaintenanceMode_Call {
+	_c.Call.Return(maintenanceMode, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetMaintenanceMode_Call) RunAndReturn(run func(ctx context.Context, in *workflow.GetMaintenanceModeRequest, opts ...grpc.CallOption) (*workflow.MaintenanceMode, error)) *WorkflowServiceClient_GetMaintenanceMode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflow provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflow")
+	}
+
+	var r0 *v1alpha1.Workflow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Workflow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflow'
+type WorkflowServiceClient_GetWorkflow_Call struct {
+	*mock.Call
+}
+
+// GetWorkflow is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowGetRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflow_Call {
+	return &WorkflowServiceClient_GetWorkflow_Call{Call: _e.mock.On("GetWorkflow",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowGetRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowGetRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_GetWorkflow_Call {
+	_c.Call.Return(workflow1, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_GetWorkflow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowAllowedVerbs provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowAllowedVerbs(ctx context.Context, in *workflow.WorkflowAllowedVerbsRequest, opts ...grpc.CallOption) (*workflow.WorkflowAllowedVerbs, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowAllowedVerbs")
+	}
+
+	var r0 *workflow.WorkflowAllowedVerbs
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowAllowedVerbsRequest, ...grpc.CallOption) (*workflow.WorkflowAllowedVerbs, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowAllowedVerbsRequest, ...grpc.CallOption) *workflow.WorkflowAllowedVerbs); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowAllowedVerbs)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowAllowedVerbsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowAllowedVerbs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowAllowedVerbs'
+type WorkflowServiceClient_GetWorkflowAllowedVerbs_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowAllowedVerbs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowAllowedVerbsRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowAllowedVerbs(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call {
+	return &WorkflowServiceClient_GetWorkflowAllowedVerbs_Call{Call: _e.mock.On("GetWorkflowAllowedVerbs",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call) Run(run func(ctx context.Context, in *workflow.WorkflowAllowedVerbsRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowAllowedVerbsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowAllowedVerbsRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call) Return(workflowAllowedVerbs *workflow.WorkflowAllowedVerbs, err error) *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call {
+	_c.Call.Return(workflowAllowedVerbs, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowAllowedVerbsRequest, opts ...grpc.CallOption) (*workflow.WorkflowAllowedVerbs, error)) *WorkflowServiceClient_GetWorkflowAllowedVerbs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowCallStacks provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowCallStacks(ctx context.Context, in *workflow.WorkflowCallStacksRequest, opts ...grpc.CallOption) (*workflow.WorkflowCallStacks, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowCallStacks")
+	}
+
+	var r0 *workflow.WorkflowCallStacks
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCallStacksRequest, ...grpc.CallOption) (*workflow.WorkflowCallStacks, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCallStacksRequest, ...grpc.CallOption) *workflow.WorkflowCallStacks); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowCallStacks)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowCallStacksRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowCallStacks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowCallStacks'
+type WorkflowServiceClient_GetWorkflowCallStacks_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowCallStacks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowCallStacksRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowCallStacks(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowCallStacks_Call {
+	return &WorkflowServiceClient_GetWorkflowCallStacks_Call{Call: _e.mock.On("GetWorkflowCallStacks",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowCallStacks_Call) Run(run func(ctx context.Context, in *workflow.WorkflowCallStacksRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowCallStacks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowCallStacksRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowCallStacksRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowCallStacks_Call) Return(workflowCallStacks *workflow.WorkflowCallStacks, err error) *WorkflowServiceClient_GetWorkflowCallStacks_Call {
+	_c.Call.Return(workflowCallStacks, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowCallStacks_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowCallStacksRequest, opts ...grpc.CallOption) (*workflow.WorkflowCallStacks, error)) *WorkflowServiceClient_GetWorkflowCallStacks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowGraph provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowGraph(ctx context.Context, in *workflow.WorkflowGraphRequest, opts ...grpc.CallOption) (*workflow.WorkflowGraph, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowGraph")
+	}
+
+	var r0 *workflow.WorkflowGraph
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGraphRequest, ...grpc.CallOption) (*workflow.WorkflowGraph, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGraphRequest, ...grpc.CallOption) *workflow.WorkflowGraph); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowGraph)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowGraphRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowGraph'
+type WorkflowServiceClient_GetWorkflowGraph_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowGraph is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowGraphRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowGraph(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowGraph_Call {
+	return &WorkflowServiceClient_GetWorkflowGraph_Call{Call: _e.mock.On("GetWorkflowGraph",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowGraph_Call) Run(run func(ctx context.Context, in *workflow.WorkflowGraphRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowGraphRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowGraphRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowGraph_Call) Return(workflowGraph *workflow.WorkflowGraph, err error) *WorkflowServiceClient_GetWorkflowGraph_Call {
+	_c.Call.Return(workflowGraph, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowGraph_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowGraphRequest, opts ...grpc.CallOption) (*workflow.WorkflowGraph, error)) *WorkflowServiceClient_GetWorkflowGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowManifest provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowManifest(ctx context.Context, in *workflow.WorkflowManifestRequest, opts ...grpc.CallOption) (*workflow.WorkflowManifest, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowManifest")
+	}
+
+	var r0 *workflow.WorkflowManifest
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowManifestRequest, ...grpc.CallOption) (*workflow.WorkflowManifest, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowManifestRequest, ...grpc.CallOption) *workflow.WorkflowManifest); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowManifest)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowManifestRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowManifest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowManifest'
+type WorkflowServiceClient_GetWorkflowManifest_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowManifest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowManifestRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowManifest(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowManifest_Call {
+	return &WorkflowServiceClient_GetWorkflowManifest_Call{Call: _e.mock.On("GetWorkflowManifest",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowManifest_Call) Run(run func(ctx context.Context, in *workflow.WorkflowManifestRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowManifest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowManifestRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowManifestRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowManifest_Call) Return(workflowManifest *workflow.WorkflowManifest, err error) *WorkflowServiceClient_GetWorkflowManifest_Call {
+	_c.Call.Return(workflowManifest, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowManifest_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowManifestRequest, opts ...grpc.CallOption) (*workflow.WorkflowManifest, error)) *WorkflowServiceClient_GetWorkflowManifest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowLineage provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowLineage(ctx context.Context, in *workflow.WorkflowLineageRequest, opts ...grpc.CallOption) (*workflow.WorkflowLineage, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowLineage")
+	}
+
+	var r0 *workflow.WorkflowLineage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLineageRequest, ...grpc.CallOption) (*workflow.WorkflowLineage, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLineageRequest, ...grpc.CallOption) *workflow.WorkflowLineage); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowLineage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowLineageRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowLineage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowLineage'
+type WorkflowServiceClient_GetWorkflowLineage_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowLineage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowLineageRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowLineage(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowLineage_Call {
+	return &WorkflowServiceClient_GetWorkflowLineage_Call{Call: _e.mock.On("GetWorkflowLineage",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLineage_Call) Run(run func(ctx context.Context, in *workflow.WorkflowLineageRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowLineage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowLineageRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowLineageRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLineage_Call) Return(workflowLineage *workflow.WorkflowLineage, err error) *WorkflowServiceClient_GetWorkflowLineage_Call {
+	_c.Call.Return(workflowLineage, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLineage_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowLineageRequest, opts ...grpc.CallOption) (*workflow.WorkflowLineage, error)) *WorkflowServiceClient_GetWorkflowLineage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowLogArchive provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowLogArchive(ctx context.Context, in *workflow.WorkflowLogArchiveRequest, opts ...grpc.CallOption) (workflow.WorkflowService_GetWorkflowLogArchiveClient, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowLogArchive")
+	}
+
+	var r0 workflow.WorkflowService_GetWorkflowLogArchiveClient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLogArchiveRequest, ...grpc.CallOption) (workflow.WorkflowService_GetWorkflowLogArchiveClient, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLogArchiveRequest, ...grpc.CallOption) workflow.WorkflowService_GetWorkflowLogArchiveClient); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(workflow.WorkflowService_GetWorkflowLogArchiveClient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowLogArchiveRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowLogArchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowLogArchive'
+type WorkflowServiceClient_GetWorkflowLogArchive_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowLogArchive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowLogArchiveRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowLogArchive(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowLogArchive_Call {
+	return &WorkflowServiceClient_GetWorkflowLogArchive_Call{Call: _e.mock.On("GetWorkflowLogArchive",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLogArchive_Call) Run(run func(ctx context.Context, in *workflow.WorkflowLogArchiveRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowLogArchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowLogArchiveRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowLogArchiveRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLogArchive_Call) Return(workflowService_GetWorkflowLogArchiveClient workflow.WorkflowService_GetWorkflowLogArchiveClient, err error) *WorkflowServiceClient_GetWorkflowLogArchive_Call {
+	_c.Call.Return(workflowService_GetWorkflowLogArchiveClient, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowLogArchive_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowLogArchiveRequest, opts ...grpc.CallOption) (workflow.WorkflowService_GetWorkflowLogArchiveClient, error)) *WorkflowServiceClient_GetWorkflowLogArchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowOutputs provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowOutputs(ctx context.Context, in *workflow.WorkflowOutputsRequest, opts ...grpc.CallOption) (*v1alpha1.Outputs, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowOutputs")
+	}
+
+	var r0 *v1alpha1.Outputs
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) (*v1alpha1.Outputs, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) *v1alpha1.Outputs); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.Outputs)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowOutputsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowOutputs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowOutputs'
+type WorkflowServiceClient_GetWorkflowOutputs_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowOutputs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowOutputsRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowOutputs(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowOutputs_Call {
+	return &WorkflowServiceClient_GetWorkflowOutputs_Call{Call: _e.mock.On("GetWorkflowOutputs",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowOutputs_Call) Run(run func(ctx context.Context, in *workflow.WorkflowOutputsRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowOutputs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowOutputsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowOutputsRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowOutputs_Call) Return(outputs *v1alpha1.Outputs, err error) *WorkflowServiceClient_GetWorkflowOutputs_Call {
+	_c.Call.Return(outputs, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowOutputs_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowOutputsRequest, opts ...grpc.CallOption) (*v1alpha1.Outputs, error)) *WorkflowServiceClient_GetWorkflowOutputs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowPendingApprovals provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowPendingApprovals(ctx context.Context, in *workflow.WorkflowPendingApprovalsRequest, opts ...grpc.CallOption) (*workflow.WorkflowPendingApprovals, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowPendingApprovals")
+	}
+
+	var r0 *workflow.WorkflowPendingApprovals
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPendingApprovalsRequest, ...grpc.CallOption) (*workflow.WorkflowPendingApprovals, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPendingApprovalsRequest, ...grpc.CallOption) *workflow.WorkflowPendingApprovals); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowPendingApprovals)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowPendingApprovalsRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowPendingApprovals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowPendingApprovals'
+type WorkflowServiceClient_GetWorkflowPendingApprovals_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowPendingApprovals is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowPendingApprovalsRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowPendingApprovals(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowPendingApprovals_Call {
+	return &WorkflowServiceClient_GetWorkflowPendingApprovals_Call{Call: _e.mock.On("GetWorkflowPendingApprovals",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingApprovals_Call) Run(run func(ctx context.Context, in *workflow.WorkflowPendingApprovalsRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowPendingApprovals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowPendingApprovalsRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowPendingApprovalsRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingApprovals_Call) Return(workflowPendingApprovals *workflow.WorkflowPendingApprovals, err error) *WorkflowServiceClient_GetWorkflowPendingApprovals_Call {
+	_c.Call.Return(workflowPendingApprovals, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingApprovals_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowPendingApprovalsRequest, opts ...grpc.CallOption) (*workflow.WorkflowPendingApprovals, error)) *WorkflowServiceClient_GetWorkflowPendingApprovals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowPendingDiagnostic provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowPendingDiagnostic(ctx context.Context, in *workflow.WorkflowPendingDiagnosticRequest, opts ...grpc.CallOption) (*workflow.WorkflowPendingDiagnostic, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowPendingDiagnostic")
+	}
+
+	var r0 *workflow.WorkflowPendingDiagnostic
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPendingDiagnosticRequest, ...grpc.CallOption) (*workflow.WorkflowPendingDiagnostic, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPendingDiagnosticRequest, ...grpc.CallOption) *workflow.WorkflowPendingDiagnostic); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowPendingDiagnostic)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowPendingDiagnosticRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowPendingDiagnostic'
+type WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowPendingDiagnostic is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowPendingDiagnosticRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowPendingDiagnostic(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call {
+	return &WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call{Call: _e.mock.On("GetWorkflowPendingDiagnostic",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call) Run(run func(ctx context.Context, in *workflow.WorkflowPendingDiagnosticRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowPendingDiagnosticRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowPendingDiagnosticRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call) Return(workflowPendingDiagnostic *workflow.WorkflowPendingDiagnostic, err error) *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call {
+	_c.Call.Return(workflowPendingDiagnostic, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowPendingDiagnosticRequest, opts ...grpc.CallOption) (*workflow.WorkflowPendingDiagnostic, error)) *WorkflowServiceClient_GetWorkflowPendingDiagnostic_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowPodResources provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowPodResources(ctx context.Context, in *workflow.WorkflowPodResourcesRequest, opts ...grpc.CallOption) (*workflow.WorkflowPodResources, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowPodResources")
+	}
+
+	var r0 *workflow.WorkflowPodResources
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPodResourcesRequest, ...grpc.CallOption) (*workflow.WorkflowPodResources, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowPodResourcesRequest, ...grpc.CallOption) *workflow.WorkflowPodResources); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowPodResources)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowPodResourcesRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowPodResources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowPodResources'
+type WorkflowServiceClient_GetWorkflowPodResources_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowPodResources is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowPodResourcesRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowPodResources(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowPodResources_Call {
+	return &WorkflowServiceClient_GetWorkflowPodResources_Call{Call: _e.mock.On("GetWorkflowPodResources",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPodResources_Call) Run(run func(ctx context.Context, in *workflow.WorkflowPodResourcesRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowPodResources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowPodResourcesRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowPodResourcesRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPodResources_Call) Return(workflowPodResources *workflow.WorkflowPodResources, err error) *WorkflowServiceClient_GetWorkflowPodResources_Call {
+	_c.Call.Return(workflowPodResources, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowPodResources_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowPodResourcesRequest, opts ...grpc.CallOption) (*workflow.WorkflowPodResources, error)) *WorkflowServiceClient_GetWorkflowPodResources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkflowResourceUsage provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) GetWorkflowResourceUsage(ctx context.Context, in *workflow.WorkflowResourceUsageRequest, opts ...grpc.CallOption) (*workflow.WorkflowResourceUsage, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkflowResourceUsage")
+	}
+
+	var r0 *workflow.WorkflowResourceUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowResourceUsageRequest, ...grpc.CallOption) (*workflow.WorkflowResourceUsage, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowResourceUsageRequest, ...grpc.CallOption) *workflow.WorkflowResourceUsage); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.WorkflowResourceUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowResourceUsageRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_GetWorkflowResourceUsage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflowResourceUsage'
+type WorkflowServiceClient_GetWorkflowResourceUsage_Call struct {
+	*mock.Call
+}
+
+// GetWorkflowResourceUsage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WorkflowResourceUsageRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) GetWorkflowResourceUsage(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflowResourceUsage_Call {
+	return &WorkflowServiceClient_GetWorkflowResourceUsage_Call{Call: _e.mock.On("GetWorkflowResourceUsage",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowResourceUsage_Call) Run(run func(ctx context.Context, in *workflow.WorkflowResourceUsageRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflowResourceUsage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WorkflowResourceUsageRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WorkflowResourceUsageRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowResourceUsage_Call) Return(workflowResourceUsage *workflow.WorkflowResourceUsage, err error) *WorkflowServiceClient_GetWorkflowResourceUsage_Call {
+	_c.Call.Return(workflowResourceUsage, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_GetWorkflowResourceUsage_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowResourceUsageRequest, opts ...grpc.CallOption) (*workflow.WorkflowResourceUsage, error)) *WorkflowServiceClient_GetWorkflowResourceUsage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LintWorkflow provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LintWorkflow")
 	}
 
 	var r0 *v1alpha1.Workflow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
 		return returnFunc(ctx, in, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
 		r0 = returnFunc(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*v1alpha1.Workflow)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) error); ok {
 		r1 = returnFunc(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -76,29 +1816,29 @@ func (_mock *WorkflowServiceClient) CreateWorkflow(ctx context.Context, in *work
 	return r0, r1
 }
 
-// WorkflowServiceClient_CreateWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkflow'
-type WorkflowServiceClient_CreateWorkflow_Call struct {
+// WorkflowServiceClient_LintWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LintWorkflow'
+type WorkflowServiceClient_LintWorkflow_Call struct {
 	*mock.Call
 }
 
-// CreateWorkflow is a helper method to define mock.On call
+// LintWorkflow is a helper method to define mock.On call
 //   - ctx context.Context
-//   - in *workflow.WorkflowCreateRequest
+//   - in *workflow.WorkflowLintRequest
 //   - opts ...grpc.CallOption
-func (_e *WorkflowServiceClient_Expecter) CreateWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_CreateWorkflow_Call {
-	return &WorkflowServiceClient_CreateWorkflow_Call{Call: _e.mock.On("CreateWorkflow",
+func (_e *WorkflowServiceClient_Expecter) LintWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_LintWorkflow_Call {
+	return &WorkflowServiceClient_LintWorkflow_Call{Call: _e.mock.On("LintWorkflow",
 		append([]interface{}{ctx, in}, opts...)...)}
 }
 
-func (_c *WorkflowServiceClient_CreateWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_CreateWorkflow_Call {
+func (_c *WorkflowServiceClient_LintWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_LintWorkflow_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *workflow.WorkflowCreateRequest
+		var arg1 *workflow.WorkflowLintRequest
 		if args[1] != nil {
-			arg1 = args[1].(*workflow.WorkflowCreateRequest)
+			arg1 = args[1].(*workflow.WorkflowLintRequest)
 		}
 		var arg2 []grpc.CallOption
 		variadicArgs := make([]grpc.CallOption, len(args)-2)
@@ -117,18 +1857,18 @@ func (_c *WorkflowServiceClient_CreateWorkflow_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *WorkflowServiceClient_CreateWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_CreateWorkflow_Call {
+func (_c *WorkflowServiceClient_LintWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_LintWorkflow_Call {
 	_c.Call.Return(workflow1, err)
 	return _c
 }
 
-func (_c *WorkflowServiceClient_CreateWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_CreateWorkflow_Call {
+func (_c *WorkflowServiceClient_LintWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_LintWorkflow_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteWorkflow provides a mock function for the type WorkflowServiceClient
-func (_mock *WorkflowServiceClient) DeleteWorkflow(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error) {
+// ListWorkflowNamespaces provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) ListWorkflowNamespaces(ctx context.Context, in *workflow.ListWorkflowNamespacesRequest, opts ...grpc.CallOption) (*workflow.WorkflowNamespaceList, error) {
 	// grpc.CallOption
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
@@ -140,22 +1880,22 @@ func (_mock *WorkflowServiceClient) DeleteWorkflow(ctx context.Context, in *work
 	ret := _mock.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWorkflow")
+		panic("no return value specified for ListWorkflowNamespaces")
 	}
 
-	var r0 *workflow.WorkflowDeleteResponse
+	var r0 *workflow.WorkflowNamespaceList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.ListWorkflowNamespacesRequest, ...grpc.CallOption) (*workflow.WorkflowNamespaceList, error)); ok {
 		return returnFunc(ctx, in, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) *workflow.WorkflowDeleteResponse); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.ListWorkflowNamespacesRequest, ...grpc.CallOption) *workflow.WorkflowNamespaceList); ok {
 		r0 = returnFunc(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*workflow.WorkflowDeleteResponse)
+			r0 = ret.Get(0).(*workflow.WorkflowNamespaceList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.ListWorkflowNamespacesRequest, ...grpc.CallOption) error); ok {
 		r1 = returnFunc(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -163,29 +1903,29 @@ func (_mock *WorkflowServiceClient) DeleteWorkflow(ctx context.Context, in *work
 	return r0, r1
 }
 
-// WorkflowServiceClient_DeleteWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWorkflow'
-type WorkflowServiceClient_DeleteWorkflow_Call struct {
+// WorkflowServiceClient_ListWorkflowNamespaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowNamespaces'
+type WorkflowServiceClient_ListWorkflowNamespaces_Call struct {
 	*mock.Call
 }
 
-// DeleteWorkflow is a helper method to define mock.On call
+// ListWorkflowNamespaces is a helper method to define mock.On call
 //   - ctx context.Context
-//   - in *workflow.WorkflowDeleteRequest
+//   - in *workflow.ListWorkflowNamespacesRequest
 //   - opts ...grpc.CallOption
-func (_e *WorkflowServiceClient_Expecter) DeleteWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_DeleteWorkflow_Call {
-	return &WorkflowServiceClient_DeleteWorkflow_Call{Call: _e.mock.On("DeleteWorkflow",
+func (_e *WorkflowServiceClient_Expecter) ListWorkflowNamespaces(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_ListWorkflowNamespaces_Call {
+	return &WorkflowServiceClient_ListWorkflowNamespaces_Call{Call: _e.mock.On("ListWorkflowNamespaces",
 		append([]interface{}{ctx, in}, opts...)...)}
 }
 
-func (_c *WorkflowServiceClient_DeleteWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_DeleteWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowNamespaces_Call) Run(run func(ctx context.Context, in *workflow.ListWorkflowNamespacesRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_ListWorkflowNamespaces_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *workflow.WorkflowDeleteRequest
+		var arg1 *workflow.ListWorkflowNamespacesRequest
 		if args[1] != nil {
-			arg1 = args[1].(*workflow.WorkflowDeleteRequest)
+			arg1 = args[1].(*workflow.ListWorkflowNamespacesRequest)
 		}
 		var arg2 []grpc.CallOption
 		variadicArgs := make([]grpc.CallOption, len(args)-2)
@@ -204,18 +1944,18 @@ func (_c *WorkflowServiceClient_DeleteWorkflow_Call) Run(run func(ctx context.Co
 	return _c
 }
 
-func (_c *WorkflowServiceClient_DeleteWorkflow_Call) Return(workflowDeleteResponse *workflow.WorkflowDeleteResponse, err error) *WorkflowServiceClient_DeleteWorkflow_Call {
-	_c.Call.Return(workflowDeleteResponse, err)
+func (_c *WorkflowServiceClient_ListWorkflowNamespaces_Call) Return(workflowNamespaceList *workflow.WorkflowNamespaceList, err error) *WorkflowServiceClient_ListWorkflowNamespaces_Call {
+	_c.Call.Return(workflowNamespaceList, err)
 	return _c
 }
 
-func (_c *WorkflowServiceClient_DeleteWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error)) *WorkflowServiceClient_DeleteWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowNamespaces_Call) RunAndReturn(run func(ctx context.Context, in *workflow.ListWorkflowNamespacesRequest, opts ...grpc.CallOption) (*workflow.WorkflowNamespaceList, error)) *WorkflowServiceClient_ListWorkflowNamespaces_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWorkflow provides a mock function for the type WorkflowServiceClient
-func (_mock *WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+// ListWorkflowProgress provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) ListWorkflowProgress(ctx context.Context, in *workflow.WorkflowListRequest, opts ...grpc.CallOption) (*workflow.WorkflowProgressList, error) {
 	// grpc.CallOption
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
@@ -227,22 +1967,22 @@ func (_mock *WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflo
 	ret := _mock.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetWorkflow")
+		panic("no return value specified for ListWorkflowProgress")
 	}
 
-	var r0 *v1alpha1.Workflow
+	var r0 *workflow.WorkflowProgressList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowListRequest, ...grpc.CallOption) (*workflow.WorkflowProgressList, error)); ok {
 		return returnFunc(ctx, in, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowListRequest, ...grpc.CallOption) *workflow.WorkflowProgressList); ok {
 		r0 = returnFunc(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1alpha1.Workflow)
+			r0 = ret.Get(0).(*workflow.WorkflowProgressList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowGetRequest, ...grpc.CallOption) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowListRequest, ...grpc.CallOption) error); ok {
 		r1 = returnFunc(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -250,29 +1990,29 @@ func (_mock *WorkflowServiceClient) GetWorkflow(ctx context.Context, in *workflo
 	return r0, r1
 }
 
-// WorkflowServiceClient_GetWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkflow'
-type WorkflowServiceClient_GetWorkflow_Call struct {
+// WorkflowServiceClient_ListWorkflowProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowProgress'
+type WorkflowServiceClient_ListWorkflowProgress_Call struct {
 	*mock.Call
 }
 
-// GetWorkflow is a helper method to define mock.On call
+// ListWorkflowProgress is a helper method to define mock.On call
 //   - ctx context.Context
-//   - in *workflow.WorkflowGetRequest
+//   - in *workflow.WorkflowListRequest
 //   - opts ...grpc.CallOption
-func (_e *WorkflowServiceClient_Expecter) GetWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_GetWorkflow_Call {
-	return &WorkflowServiceClient_GetWorkflow_Call{Call: _e.mock.On("GetWorkflow",
+func (_e *WorkflowServiceClient_Expecter) ListWorkflowProgress(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_ListWorkflowProgress_Call {
+	return &WorkflowServiceClient_ListWorkflowProgress_Call{Call: _e.mock.On("ListWorkflowProgress",
 		append([]interface{}{ctx, in}, opts...)...)}
 }
 
-func (_c *WorkflowServiceClient_GetWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_GetWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowProgress_Call) Run(run func(ctx context.Context, in *workflow.WorkflowListRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_ListWorkflowProgress_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *workflow.WorkflowGetRequest
+		var arg1 *workflow.WorkflowListRequest
 		if args[1] != nil {
-			arg1 = args[1].(*workflow.WorkflowGetRequest)
+			arg1 = args[1].(*workflow.WorkflowListRequest)
 		}
 		var arg2 []grpc.CallOption
 		variadicArgs := make([]grpc.CallOption, len(args)-2)
@@ -291,18 +2031,18 @@ func (_c *WorkflowServiceClient_GetWorkflow_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *WorkflowServiceClient_GetWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_GetWorkflow_Call {
-	_c.Call.Return(workflow1, err)
+func (_c *WorkflowServiceClient_ListWorkflowProgress_Call) Return(workflowProgressList *workflow.WorkflowProgressList, err error) *WorkflowServiceClient_ListWorkflowProgress_Call {
+	_c.Call.Return(workflowProgressList, err)
 	return _c
 }
 
-func (_c *WorkflowServiceClient_GetWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_GetWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowProgress_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowListRequest, opts ...grpc.CallOption) (*workflow.WorkflowProgressList, error)) *WorkflowServiceClient_ListWorkflowProgress_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LintWorkflow provides a mock function for the type WorkflowServiceClient
-func (_mock *WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
+// ListWorkflowTemplateGroups provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) ListWorkflowTemplateGroups(ctx context.Context, in *workflow.WorkflowTemplateGroupsRequest, opts ...grpc.CallOption) (*workflow.WorkflowTemplateGroupList, error) {
 	// grpc.CallOption
 	_va := make([]interface{}, len(opts))
 	for _i := range opts {
@@ -314,22 +2054,22 @@ func (_mock *WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workfl
 	ret := _mock.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LintWorkflow")
+		panic("no return value specified for ListWorkflowTemplateGroups")
 	}
 
-	var r0 *v1alpha1.Workflow
+	var r0 *workflow.WorkflowTemplateGroupList
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) (*v1alpha1.Workflow, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowTemplateGroupsRequest, ...grpc.CallOption) (*workflow.WorkflowTemplateGroupList, error)); ok {
 		return returnFunc(ctx, in, opts...)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) *v1alpha1.Workflow); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WorkflowTemplateGroupsRequest, ...grpc.CallOption) *workflow.WorkflowTemplateGroupList); ok {
 		r0 = returnFunc(ctx, in, opts...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v1alpha1.Workflow)
+			r0 = ret.Get(0).(*workflow.WorkflowTemplateGroupList)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WorkflowTemplateGroupsRequest, ...grpc.CallOption) error); ok {
 		r1 = returnFunc(ctx, in, opts...)
 	} else {
 		r1 = ret.Error(1)
@@ -337,29 +2077,29 @@ func (_mock *WorkflowServiceClient) LintWorkflow(ctx context.Context, in *workfl
 	return r0, r1
 }
 
-// WorkflowServiceClient_LintWorkflow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LintWorkflow'
-type WorkflowServiceClient_LintWorkflow_Call struct {
+// WorkflowServiceClient_ListWorkflowTemplateGroups_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWorkflowTemplateGroups'
+type WorkflowServiceClient_ListWorkflowTemplateGroups_Call struct {
 	*mock.Call
 }
 
-// LintWorkflow is a helper method to define mock.On call
+// ListWorkflowTemplateGroups is a helper method to define mock.On call
 //   - ctx context.Context
-//   - in *workflow.WorkflowLintRequest
+//   - in *workflow.WorkflowTemplateGroupsRequest
 //   - opts ...grpc.CallOption
-func (_e *WorkflowServiceClient_Expecter) LintWorkflow(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_LintWorkflow_Call {
-	return &WorkflowServiceClient_LintWorkflow_Call{Call: _e.mock.On("LintWorkflow",
+func (_e *WorkflowServiceClient_Expecter) ListWorkflowTemplateGroups(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_ListWorkflowTemplateGroups_Call {
+	return &WorkflowServiceClient_ListWorkflowTemplateGroups_Call{Call: _e.mock.On("ListWorkflowTemplateGroups",
 		append([]interface{}{ctx, in}, opts...)...)}
 }
 
-func (_c *WorkflowServiceClient_LintWorkflow_Call) Run(run func(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_LintWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowTemplateGroups_Call) Run(run func(ctx context.Context, in *workflow.WorkflowTemplateGroupsRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_ListWorkflowTemplateGroups_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *workflow.WorkflowLintRequest
+		var arg1 *workflow.WorkflowTemplateGroupsRequest
 		if args[1] != nil {
-			arg1 = args[1].(*workflow.WorkflowLintRequest)
+			arg1 = args[1].(*workflow.WorkflowTemplateGroupsRequest)
 		}
 		var arg2 []grpc.CallOption
 		variadicArgs := make([]grpc.CallOption, len(args)-2)
@@ -378,12 +2118,12 @@ func (_c *WorkflowServiceClient_LintWorkflow_Call) Run(run func(ctx context.Cont
 	return _c
 }
 
-func (_c *WorkflowServiceClient_LintWorkflow_Call) Return(workflow1 *v1alpha1.Workflow, err error) *WorkflowServiceClient_LintWorkflow_Call {
-	_c.Call.Return(workflow1, err)
+func (_c *WorkflowServiceClient_ListWorkflowTemplateGroups_Call) Return(workflowTemplateGroupList *workflow.WorkflowTemplateGroupList, err error) *WorkflowServiceClient_ListWorkflowTemplateGroups_Call {
+	_c.Call.Return(workflowTemplateGroupList, err)
 	return _c
 }
 
-func (_c *WorkflowServiceClient_LintWorkflow_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)) *WorkflowServiceClient_LintWorkflow_Call {
+func (_c *WorkflowServiceClient_ListWorkflowTemplateGroups_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WorkflowTemplateGroupsRequest, opts ...grpc.CallOption) (*workflow.WorkflowTemplateGroupList, error)) *WorkflowServiceClient_ListWorkflowTemplateGroups_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -823,6 +2563,93 @@ func (_c *WorkflowServiceClient_RetryWorkflow_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// SetMaintenanceMode provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) SetMaintenanceMode(ctx context.Context, in *workflow.SetMaintenanceModeRequest, opts ...grpc.CallOption) (*workflow.MaintenanceMode, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMaintenanceMode")
+	}
+
+	var r0 *workflow.MaintenanceMode
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.SetMaintenanceModeRequest, ...grpc.CallOption) (*workflow.MaintenanceMode, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.SetMaintenanceModeRequest, ...grpc.CallOption) *workflow.MaintenanceMode); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*workflow.MaintenanceMode)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.SetMaintenanceModeRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_SetMaintenanceMode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetMaintenanceMode'
+type WorkflowServiceClient_SetMaintenanceMode_Call struct {
+	*mock.Call
+}
+
+// SetMaintenanceMode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.SetMaintenanceModeRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) SetMaintenanceMode(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_SetMaintenanceMode_Call {
+	return &WorkflowServiceClient_SetMaintenanceMode_Call{Call: _e.mock.On("SetMaintenanceMode",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_SetMaintenanceMode_Call) Run(run func(ctx context.Context, in *workflow.SetMaintenanceModeRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_SetMaintenanceMode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.SetMaintenanceModeRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.SetMaintenanceModeRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_SetMaintenanceMode_Call) Return(maintenanceMode *workflow.MaintenanceMode, err error) *WorkflowServiceClient_SetMaintenanceMode_Call {
+	_c.Call.Return(maintenanceMode, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_SetMaintenanceMode_Call) RunAndReturn(run func(ctx context.Context, in *workflow.SetMaintenanceModeRequest, opts ...grpc.CallOption) (*workflow.MaintenanceMode, error)) *WorkflowServiceClient_SetMaintenanceMode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SetWorkflow provides a mock function for the type WorkflowServiceClient
 func (_mock *WorkflowServiceClient) SetWorkflow(ctx context.Context, in *workflow.WorkflowSetRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error) {
 	// grpc.CallOption
@@ -1345,6 +3172,93 @@ func (_c *WorkflowServiceClient_WatchEvents_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// WatchWorkflowNodes provides a mock function for the type WorkflowServiceClient
+func (_mock *WorkflowServiceClient) WatchWorkflowNodes(ctx context.Context, in *workflow.WatchWorkflowNodesRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowNodesClient, error) {
+	// grpc.CallOption
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _mock.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchWorkflowNodes")
+	}
+
+	var r0 workflow.WorkflowService_WatchWorkflowNodesClient
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WatchWorkflowNodesRequest, ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowNodesClient, error)); ok {
+		return returnFunc(ctx, in, opts...)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *workflow.WatchWorkflowNodesRequest, ...grpc.CallOption) workflow.WorkflowService_WatchWorkflowNodesClient); ok {
+		r0 = returnFunc(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(workflow.WorkflowService_WatchWorkflowNodesClient)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *workflow.WatchWorkflowNodesRequest, ...grpc.CallOption) error); ok {
+		r1 = returnFunc(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// WorkflowServiceClient_WatchWorkflowNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchWorkflowNodes'
+type WorkflowServiceClient_WatchWorkflowNodes_Call struct {
+	*mock.Call
+}
+
+// WatchWorkflowNodes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - in *workflow.WatchWorkflowNodesRequest
+//   - opts ...grpc.CallOption
+func (_e *WorkflowServiceClient_Expecter) WatchWorkflowNodes(ctx interface{}, in interface{}, opts ...interface{}) *WorkflowServiceClient_WatchWorkflowNodes_Call {
+	return &WorkflowServiceClient_WatchWorkflowNodes_Call{Call: _e.mock.On("WatchWorkflowNodes",
+		append([]interface{}{ctx, in}, opts...)...)}
+}
+
+func (_c *WorkflowServiceClient_WatchWorkflowNodes_Call) Run(run func(ctx context.Context, in *workflow.WatchWorkflowNodesRequest, opts ...grpc.CallOption)) *WorkflowServiceClient_WatchWorkflowNodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *workflow.WatchWorkflowNodesRequest
+		if args[1] != nil {
+			arg1 = args[1].(*workflow.WatchWorkflowNodesRequest)
+		}
+		var arg2 []grpc.CallOption
+		variadicArgs := make([]grpc.CallOption, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(grpc.CallOption)
+			}
+		}
+		arg2 = variadicArgs
+		run(
+			arg0,
+			arg1,
+			arg2...,
+		)
+	})
+	return _c
+}
+
+func (_c *WorkflowServiceClient_WatchWorkflowNodes_Call) Return(workflowService_WatchWorkflowNodesClient workflow.WorkflowService_WatchWorkflowNodesClient, err error) *WorkflowServiceClient_WatchWorkflowNodes_Call {
+	_c.Call.Return(workflowService_WatchWorkflowNodesClient, err)
+	return _c
+}
+
+func (_c *WorkflowServiceClient_WatchWorkflowNodes_Call) RunAndReturn(run func(ctx context.Context, in *workflow.WatchWorkflowNodesRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowNodesClient, error)) *WorkflowServiceClient_WatchWorkflowNodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WatchWorkflows provides a mock function for the type WorkflowServiceClient
 func (_mock *WorkflowServiceClient) WatchWorkflows(ctx context.Context, in *workflow.WatchWorkflowsRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowsClient, error) {
 	// grpc.CallOption
@@ -1 +1,14 @@
 package workflow
+
+const (
+	// SourceHeader is returned by GetWorkflow, and is SourceLive, or SourceArchived when the workflow was not found in
+	// the cluster and was read from the workflow archive, so that clients can disable the operations that need a live workflow
+	SourceHeader   = "workflow-source"
+	SourceLive     = "live"
+	SourceArchived = "archived"
+
+	// NodeStatusUnavailableHeader is returned by GetWorkflow when the workflow is returned without its node status, and
+	// is why, e.g. as the offloaded node status could not be loaded in degraded mode, or the workflow has more nodes than
+	// the server hydrates
+	NodeStatusUnavailableHeader = "node-status-unavailable"
+)
@@ -102,9 +102,14 @@ func (m *ListArchivedWorkflowsRequest) GetNameFilter() string {
 }
 
 type GetArchivedWorkflowRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Uid       string `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// If true, the node status is returned gzip-compressed in status.compressedNodes and must be decompressed by the client
+	Compressed bool `protobuf:"varint,4,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	// If true, the node status is returned compacted, without the parts of each node that can be derived from the workflow,
+	// such as its ID, which is its key. The client must expand it. Applied before compression.
+	Compact              bool     `protobuf:"varint,5,opt,name=compact,proto3" json:"compact,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -164,6 +169,20 @@ func (m *GetArchivedWorkflowRequest) GetName() string {
 	return ""
 }
 
+func (m *GetArchivedWorkflowRequest) GetCompressed() bool {
+	if m != nil {
+		return m.Compressed
+	}
+	return false
+}
+
+func (m *GetArchivedWorkflowRequest) GetCompact() bool {
+	if m != nil {
+		return m.Compact
+	}
+	return false
+}
+
 type DeleteArchivedWorkflowRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	Namespace            string   `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
@@ -363,12 +382,15 @@ func (m *ListArchivedWorkflowLabelValuesRequest) GetNamespace() string {
 }
 
 type RetryArchivedWorkflowRequest struct {
-	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Namespace            string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
-	RestartSuccessful    bool     `protobuf:"varint,4,opt,name=restartSuccessful,proto3" json:"restartSuccessful,omitempty"`
-	NodeFieldSelector    string   `protobuf:"bytes,5,opt,name=nodeFieldSelector,proto3" json:"nodeFieldSelector,omitempty"`
-	Parameters           []string `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	Uid               string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name              string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace         string   `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	RestartSuccessful bool     `protobuf:"varint,4,opt,name=restartSuccessful,proto3" json:"restartSuccessful,omitempty"`
+	NodeFieldSelector string   `protobuf:"bytes,5,opt,name=nodeFieldSelector,proto3" json:"nodeFieldSelector,omitempty"`
+	Parameters        []string `protobuf:"bytes,6,rep,name=parameters,proto3" json:"parameters,omitempty"`
+	// Create the retried workflow in this namespace rather than the namespace it was archived from, e.g. when that namespace no longer exists.
+	// The namespace must exist.
+	TargetNamespace      string   `protobuf:"bytes,7,opt,name=targetNamespace,proto3" json:"targetNamespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -449,6 +471,13 @@ func (m *RetryArchivedWorkflowRequest) GetParameters() []string {
 	return nil
 }
 
+func (m *RetryArchivedWorkflowRequest) GetTargetNamespace() string {
+	if m != nil {
+		return m.TargetNamespace
+	}
+	return ""
+}
+
 type ResubmitArchivedWorkflowRequest struct {
 	Uid                  string   `protobuf:"bytes,1,opt,name=uid,proto3" json:"uid,omitempty"`
 	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -528,6 +557,364 @@ func (m *ResubmitArchivedWorkflowRequest) GetParameters() []string {
 	return nil
 }
 
+type PruneArchivedWorkflowsRequest struct {
+	// The namespace to prune, or all namespaces if empty
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Count the archived workflows that would be deleted without deleting them
+	DryRun               bool     `protobuf:"varint,2,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneArchivedWorkflowsRequest) Reset()         { *m = PruneArchivedWorkflowsRequest{} }
+func (m *PruneArchivedWorkflowsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneArchivedWorkflowsRequest) ProtoMessage()    {}
+func (*PruneArchivedWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{8}
+}
+func (m *PruneArchivedWorkflowsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PruneArchivedWorkflowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PruneArchivedWorkflowsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PruneArchivedWorkflowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneArchivedWorkflowsRequest.Merge(m, src)
+}
+func (m *PruneArchivedWorkflowsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PruneArchivedWorkflowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneArchivedWorkflowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneArchivedWorkflowsRequest proto.InternalMessageInfo
+
+func (m *PruneArchivedWorkflowsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *PruneArchivedWorkflowsRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+type PruneArchivedWorkflowsResponse struct {
+	// The number of archived workflows deleted, or that would be deleted on a dry run
+	Deleted              int64    `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneArchivedWorkflowsResponse) Reset()         { *m = PruneArchivedWorkflowsResponse{} }
+func (m *PruneArchivedWorkflowsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneArchivedWorkflowsResponse) ProtoMessage()    {}
+func (*PruneArchivedWorkflowsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{9}
+}
+func (m *PruneArchivedWorkflowsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PruneArchivedWorkflowsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PruneArchivedWorkflowsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PruneArchivedWorkflowsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneArchivedWorkflowsResponse.Merge(m, src)
+}
+func (m *PruneArchivedWorkflowsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PruneArchivedWorkflowsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneArchivedWorkflowsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneArchivedWorkflowsResponse proto.InternalMessageInfo
+
+func (m *PruneArchivedWorkflowsResponse) GetDeleted() int64 {
+	if m != nil {
+		return m.Deleted
+	}
+	return 0
+}
+
+type StreamArchivedWorkflowsRequest struct {
+	// The label and field selectors to match. The limit is the most archived workflows to send, and continue the offset to
+	// start from, as in ListArchivedWorkflowsRequest.
+	ListOptions *v1.ListOptions `protobuf:"bytes,1,opt,name=listOptions,proto3" json:"listOptions,omitempty"`
+	NamePrefix  string          `protobuf:"bytes,2,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
+	Namespace   string          `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Filter type used for name filtering. Exact | Contains | Prefix. Default to Exact
+	NameFilter string `protobuf:"bytes,4,opt,name=nameFilter,proto3" json:"nameFilter,omitempty"`
+	// How many archived workflows to read from the archive at a time. Defaults to, and is at most, 500.
+	PageSize             int32    `protobuf:"varint,5,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StreamArchivedWorkflowsRequest) Reset()         { *m = StreamArchivedWorkflowsRequest{} }
+func (m *StreamArchivedWorkflowsRequest) String() string { return proto.CompactTextString(m) }
+func (*StreamArchivedWorkflowsRequest) ProtoMessage()    {}
+func (*StreamArchivedWorkflowsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{10}
+}
+func (m *StreamArchivedWorkflowsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StreamArchivedWorkflowsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StreamArchivedWorkflowsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StreamArchivedWorkflowsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamArchivedWorkflowsRequest.Merge(m, src)
+}
+func (m *StreamArchivedWorkflowsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *StreamArchivedWorkflowsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamArchivedWorkflowsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamArchivedWorkflowsRequest proto.InternalMessageInfo
+
+func (m *StreamArchivedWorkflowsRequest) GetListOptions() *v1.ListOptions {
+	if m != nil {
+		return m.ListOptions
+	}
+	return nil
+}
+
+func (m *StreamArchivedWorkflowsRequest) GetNamePrefix() string {
+	if m != nil {
+		return m.NamePrefix
+	}
+	return ""
+}
+
+func (m *StreamArchivedWorkflowsRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StreamArchivedWorkflowsRequest) GetNameFilter() string {
+	if m != nil {
+		return m.NameFilter
+	}
+	return ""
+}
+
+func (m *StreamArchivedWorkflowsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type FindWorkflowsByArtifactRequest struct {
+	// The namespace to search, or all namespaces if empty
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// The key or URL of the artifact, e.g. path/to/my-file.tgz of an S3 artifact, or the URL of an HTTP artifact
+	Artifact string `protobuf:"bytes,2,opt,name=artifact,proto3" json:"artifact,omitempty"`
+	// The most workflows to return, most recently started first. Defaults to 100, and can be at most 500.
+	Limit                int32    `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FindWorkflowsByArtifactRequest) Reset()         { *m = FindWorkflowsByArtifactRequest{} }
+func (m *FindWorkflowsByArtifactRequest) String() string { return proto.CompactTextString(m) }
+func (*FindWorkflowsByArtifactRequest) ProtoMessage()    {}
+func (*FindWorkflowsByArtifactRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{11}
+}
+func (m *FindWorkflowsByArtifactRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindWorkflowsByArtifactRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindWorkflowsByArtifactRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FindWorkflowsByArtifactRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindWorkflowsByArtifactRequest.Merge(m, src)
+}
+func (m *FindWorkflowsByArtifactRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindWorkflowsByArtifactRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindWorkflowsByArtifactRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindWorkflowsByArtifactRequest proto.InternalMessageInfo
+
+func (m *FindWorkflowsByArtifactRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *FindWorkflowsByArtifactRequest) GetArtifact() string {
+	if m != nil {
+		return m.Artifact
+	}
+	return ""
+}
+
+func (m *FindWorkflowsByArtifactRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type ArtifactWorkflow struct {
+	Workflow *v1alpha1.Workflow `protobuf:"bytes,1,opt,name=workflow,proto3" json:"workflow,omitempty"`
+	// The roles of the artifact in the workflow, producer if one of its nodes output it, and consumer if it was an
+	// argument or an input of one of its nodes
+	Roles                []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ArtifactWorkflow) Reset()         { *m = ArtifactWorkflow{} }
+func (m *ArtifactWorkflow) String() string { return proto.CompactTextString(m) }
+func (*ArtifactWorkflow) ProtoMessage()    {}
+func (*ArtifactWorkflow) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{12}
+}
+func (m *ArtifactWorkflow) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ArtifactWorkflow) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ArtifactWorkflow.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ArtifactWorkflow) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ArtifactWorkflow.Merge(m, src)
+}
+func (m *ArtifactWorkflow) XXX_Size() int {
+	return m.Size()
+}
+func (m *ArtifactWorkflow) XXX_DiscardUnknown() {
+	xxx_messageInfo_ArtifactWorkflow.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ArtifactWorkflow proto.InternalMessageInfo
+
+func (m *ArtifactWorkflow) GetWorkflow() *v1alpha1.Workflow {
+	if m != nil {
+		return m.Workflow
+	}
+	return nil
+}
+
+func (m *ArtifactWorkflow) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type FindWorkflowsByArtifactResponse struct {
+	Items                []*ArtifactWorkflow `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FindWorkflowsByArtifactResponse) Reset()         { *m = FindWorkflowsByArtifactResponse{} }
+func (m *FindWorkflowsByArtifactResponse) String() string { return proto.CompactTextString(m) }
+func (*FindWorkflowsByArtifactResponse) ProtoMessage()    {}
+func (*FindWorkflowsByArtifactResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_95ca9a2d33e8bb19, []int{13}
+}
+func (m *FindWorkflowsByArtifactResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FindWorkflowsByArtifactResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FindWorkflowsByArtifactResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FindWorkflowsByArtifactResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FindWorkflowsByArtifactResponse.Merge(m, src)
+}
+func (m *FindWorkflowsByArtifactResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *FindWorkflowsByArtifactResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FindWorkflowsByArtifactResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FindWorkflowsByArtifactResponse proto.InternalMessageInfo
+
+func (m *FindWorkflowsByArtifactResponse) GetItems() []*ArtifactWorkflow {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListArchivedWorkflowsRequest)(nil), "workflowarchive.ListArchivedWorkflowsRequest")
 	proto.RegisterType((*GetArchivedWorkflowRequest)(nil), "workflowarchive.GetArchivedWorkflowRequest")
@@ -537,6 +924,12 @@ func init() {
 	proto.RegisterType((*ListArchivedWorkflowLabelValuesRequest)(nil), "workflowarchive.ListArchivedWorkflowLabelValuesRequest")
 	proto.RegisterType((*RetryArchivedWorkflowRequest)(nil), "workflowarchive.RetryArchivedWorkflowRequest")
 	proto.RegisterType((*ResubmitArchivedWorkflowRequest)(nil), "workflowarchive.ResubmitArchivedWorkflowRequest")
+	proto.RegisterType((*PruneArchivedWorkflowsRequest)(nil), "workflowarchive.PruneArchivedWorkflowsRequest")
+	proto.RegisterType((*PruneArchivedWorkflowsResponse)(nil), "workflowarchive.PruneArchivedWorkflowsResponse")
+	proto.RegisterType((*StreamArchivedWorkflowsRequest)(nil), "workflowarchive.StreamArchivedWorkflowsRequest")
+	proto.RegisterType((*FindWorkflowsByArtifactRequest)(nil), "workflowarchive.FindWorkflowsByArtifactRequest")
+	proto.RegisterType((*ArtifactWorkflow)(nil), "workflowarchive.ArtifactWorkflow")
+	proto.RegisterType((*FindWorkflowsByArtifactResponse)(nil), "workflowarchive.FindWorkflowsByArtifactResponse")
 }
 
 func init() {
@@ -544,58 +937,77 @@ func init() {
 }
 
 var fileDescriptor_95ca9a2d33e8bb19 = []byte{
-	// 802 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x96, 0x4d, 0x6f, 0xe3, 0x44,
-	0x18, 0xc7, 0x35, 0xe9, 0xee, 0x6a, 0x3b, 0x3d, 0x00, 0x83, 0x16, 0x22, 0x2b, 0x9b, 0x06, 0x0b,
-	0x76, 0xfb, 0x42, 0xc6, 0x4d, 0x5b, 0x04, 0xea, 0x09, 0x50, 0x55, 0x24, 0xfa, 0x86, 0x1c, 0x09,
-	0x24, 0x2e, 0x30, 0xb1, 0x9f, 0x26, 0x43, 0x6c, 0x8f, 0x99, 0x19, 0xbb, 0x14, 0xc4, 0x05, 0x3e,
-	0x02, 0x47, 0x4e, 0x48, 0x7c, 0x08, 0xc4, 0x1d, 0x89, 0x13, 0xe2, 0xe5, 0xc6, 0x01, 0xa1, 0x8a,
-	0x0f, 0x82, 0x6c, 0xc7, 0x71, 0xeb, 0x38, 0x2f, 0xd2, 0xa6, 0xb7, 0x99, 0x67, 0x66, 0x9e, 0xe7,
-	0xff, 0xf7, 0x3c, 0xfe, 0x69, 0xf0, 0x7e, 0x38, 0xec, 0x5b, 0x2c, 0xe4, 0x8e, 0xc7, 0x21, 0xd0,
-	0xd6, 0xa5, 0x90, 0xc3, 0x0b, 0x4f, 0x5c, 0x32, 0xe9, 0x0c, 0x78, 0x0c, 0xe3, 0x79, 0x7b, 0x14,
-	0xa0, 0xa1, 0x14, 0x5a, 0x90, 0xe7, 0x4a, 0xfb, 0x8c, 0x46, 0x5f, 0x88, 0xbe, 0x07, 0x49, 0x26,
-	0x8b, 0x05, 0x81, 0xd0, 0x4c, 0x73, 0x11, 0xa8, 0x6c, 0xbb, 0xb1, 0x3f, 0x7c, 0x4b, 0x51, 0x2e,
-	0x92, 0x55, 0x9f, 0x39, 0x03, 0x1e, 0x80, 0xbc, 0xb2, 0x46, 0x85, 0x95, 0xe5, 0x83, 0x66, 0x56,
-	0xdc, 0xb1, 0xfa, 0x10, 0x80, 0x64, 0x1a, 0xdc, 0xd1, 0xa9, 0xd3, 0x3e, 0xd7, 0x83, 0xa8, 0x47,
-	0x1d, 0xe1, 0x5b, 0x4c, 0xf6, 0x45, 0x28, 0xc5, 0x67, 0xe9, 0xa0, 0x9d, 0x57, 0x57, 0x45, 0x92,
-	0x3c, 0x64, 0xc5, 0x1d, 0xe6, 0x85, 0x03, 0x36, 0x91, 0xce, 0xfc, 0x03, 0xe1, 0xc6, 0x09, 0x57,
-	0xfa, 0x9d, 0x4c, 0xb2, 0xfb, 0x51, 0x9e, 0xc4, 0x86, 0xcf, 0x23, 0x50, 0x9a, 0x74, 0xf1, 0x9a,
-	0xc7, 0x95, 0x3e, 0x0f, 0x53, 0xe9, 0x75, 0xd4, 0x42, 0x1b, 0x6b, 0xbb, 0x1d, 0x9a, 0x69, 0xa7,
-	0x37, 0xb5, 0xd3, 0x70, 0xd8, 0x4f, 0x02, 0x8a, 0x26, 0xda, 0x69, 0xdc, 0xa1, 0x27, 0xc5, 0x41,
-	0xfb, 0x66, 0x16, 0xd2, 0xc4, 0x38, 0x60, 0x3e, 0x7c, 0x20, 0xe1, 0x82, 0x7f, 0x51, 0xaf, 0xb5,
-	0xd0, 0xc6, 0xaa, 0x7d, 0x23, 0x42, 0x1a, 0x78, 0x35, 0x99, 0xa9, 0x90, 0x39, 0x50, 0x5f, 0x49,
-	0x97, 0x8b, 0x40, 0x7e, 0xfa, 0x88, 0x7b, 0x1a, 0x64, 0xfd, 0x5e, 0x71, 0x3a, 0x8b, 0x98, 0x9f,
-	0x62, 0xe3, 0x3d, 0x98, 0x70, 0x94, 0x1b, 0x7a, 0x1e, 0xaf, 0x44, 0xdc, 0x4d, 0x8d, 0xac, 0xda,
-	0xc9, 0xf0, 0x76, 0xb5, 0x5a, 0xb9, 0x1a, 0xc1, 0xf7, 0x92, 0xc9, 0x48, 0x46, 0x3a, 0x36, 0xcf,
-	0xf1, 0xe3, 0x43, 0xf0, 0x40, 0xc3, 0x92, 0x8a, 0x98, 0xaf, 0xe0, 0xf5, 0x72, 0xaa, 0xac, 0x80,
-	0x6b, 0x83, 0x0a, 0x45, 0xa0, 0xc0, 0x3c, 0xc4, 0xaf, 0x56, 0x5d, 0xd4, 0x09, 0xeb, 0x81, 0x77,
-	0x0c, 0x57, 0xe3, 0x0b, 0xbb, 0x55, 0x08, 0x95, 0x0b, 0x7d, 0x8f, 0xf0, 0x93, 0xa9, 0x69, 0x3e,
-	0x64, 0x5e, 0x04, 0x77, 0x7b, 0xf3, 0xb3, 0x3f, 0xc3, 0x3f, 0x08, 0x37, 0x6c, 0xd0, 0xf2, 0x6a,
-	0xf1, 0xef, 0x9a, 0x5f, 0x4f, 0xad, 0xb8, 0x9e, 0x39, 0xed, 0xf3, 0x3a, 0x7e, 0x41, 0x82, 0xd2,
-	0x4c, 0xea, 0x6e, 0xe4, 0x38, 0xa0, 0xd4, 0x45, 0xe4, 0xa5, 0x5d, 0xf4, 0xd0, 0x9e, 0x5c, 0x48,
-	0x76, 0x07, 0xc2, 0x85, 0x23, 0x0e, 0x9e, 0xdb, 0x05, 0x0f, 0x1c, 0x2d, 0x64, 0xfd, 0x7e, 0x9a,
-	0x73, 0x72, 0x21, 0x69, 0xcd, 0x90, 0x49, 0xe6, 0x83, 0x06, 0xa9, 0xea, 0x0f, 0x5a, 0x2b, 0x49,
-	0x6b, 0x16, 0x11, 0xf3, 0x07, 0x84, 0xd7, 0x6d, 0x50, 0x51, 0xcf, 0xe7, 0xfa, 0x2e, 0x3d, 0x1a,
-	0xf8, 0xa1, 0x0f, 0xbe, 0xe0, 0x5f, 0x82, 0x3b, 0xb2, 0x36, 0x9e, 0x97, 0x34, 0xde, 0x2f, 0x6b,
-	0xdc, 0xfd, 0x76, 0x0d, 0xbf, 0x5c, 0xd6, 0xd6, 0x05, 0x19, 0x73, 0x07, 0xc8, 0xcf, 0x08, 0x3f,
-	0xaa, 0xc4, 0x05, 0x69, 0xd3, 0x12, 0xfd, 0xe8, 0x2c, 0xac, 0x18, 0x67, 0xb4, 0xe0, 0x18, 0xcd,
-	0x39, 0x96, 0x0e, 0x3e, 0x19, 0x73, 0x8c, 0xc6, 0x7b, 0x45, 0x67, 0xe5, 0x51, 0x9a, 0xa3, 0x8c,
-	0x8e, 0x5b, 0x97, 0x2b, 0x6d, 0x9a, 0xdf, 0xfc, 0xf5, 0xdf, 0x77, 0xb5, 0x06, 0x31, 0x52, 0xd8,
-	0xc6, 0x1d, 0x6b, 0xa4, 0xc2, 0x2d, 0xb0, 0x48, 0x7e, 0x42, 0xf8, 0xc5, 0x0a, 0x30, 0x90, 0xed,
-	0x09, 0xe9, 0xd3, 0xf1, 0x61, 0xbc, 0xbf, 0x3c, 0xe1, 0xe6, 0x46, 0x2a, 0xda, 0x24, 0xad, 0xe9,
-	0xa2, 0xad, 0xaf, 0x22, 0xee, 0x7e, 0x4d, 0x7e, 0x44, 0xf8, 0xa5, 0x6a, 0xe2, 0x10, 0x3a, 0xa1,
-	0x7e, 0x26, 0x9a, 0x8c, 0x9d, 0x89, 0xfd, 0xf3, 0xc8, 0x33, 0x92, 0xb9, 0x35, 0x5f, 0xe6, 0x9f,
-	0x08, 0x3f, 0x9e, 0x09, 0x29, 0xf2, 0xc6, 0x42, 0x6d, 0x52, 0x86, 0x9a, 0x71, 0xfc, 0xec, 0x5f,
-	0x7d, 0x9c, 0xd3, 0x6c, 0xa7, 0x7e, 0x9e, 0x92, 0xd7, 0xa6, 0xfb, 0x69, 0x7b, 0xc9, 0xee, 0xf6,
-	0x30, 0x91, 0xfc, 0x37, 0xc2, 0xeb, 0x73, 0x90, 0x49, 0xde, 0x5c, 0xdc, 0xd6, 0x2d, 0xc8, 0x1a,
-	0xa7, 0x4b, 0x32, 0x96, 0x65, 0x35, 0xad, 0xd4, 0xda, 0x26, 0x79, 0x3a, 0xd7, 0x5a, 0x9c, 0x09,
-	0xff, 0x05, 0xe1, 0x47, 0x95, 0xc4, 0xad, 0xf8, 0xa1, 0x67, 0x91, 0x79, 0xa9, 0xff, 0x45, 0x27,
-	0x75, 0xb1, 0x6d, 0x3c, 0x99, 0xd7, 0x70, 0x96, 0x4c, 0x24, 0x1d, 0xa0, 0x2d, 0xf2, 0x1b, 0xc2,
-	0xf5, 0x69, 0x60, 0x25, 0x3b, 0x15, 0x56, 0x66, 0x32, 0x78, 0xa9, 0x6e, 0xf6, 0x53, 0x37, 0xd4,
-	0xd8, 0x5c, 0xc0, 0x4d, 0xa6, 0xea, 0x00, 0x6d, 0xbd, 0x7b, 0xf6, 0xeb, 0x75, 0x13, 0xfd, 0x7e,
-	0xdd, 0x44, 0xff, 0x5e, 0x37, 0xd1, 0xc7, 0x6f, 0x2f, 0xfe, 0xea, 0xab, 0x7e, 0xb3, 0xf6, 0x1e,
-	0xa4, 0xef, 0xbd, 0xbd, 0xff, 0x03, 0x00, 0x00, 0xff, 0xff, 0xfd, 0x00, 0xca, 0x0c, 0xdb, 0x0a,
-	0x00, 0x00,
+	// 1107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0xcd, 0x6f, 0x1b, 0x45,
+	0x14, 0xd7, 0x38, 0x75, 0x9a, 0x4c, 0x0e, 0x29, 0x03, 0x4d, 0xac, 0x55, 0xe2, 0x24, 0x0b, 0x6d,
+	0x9d, 0x06, 0xef, 0xc6, 0x69, 0x50, 0x51, 0x4f, 0xb4, 0xaa, 0x82, 0x44, 0xd3, 0xb4, 0x5a, 0x0b,
+	0x90, 0x7a, 0x41, 0x93, 0xdd, 0x17, 0x67, 0xc8, 0x7e, 0x31, 0x33, 0x76, 0x71, 0x11, 0x17, 0x4e,
+	0x1c, 0x91, 0xb8, 0xc1, 0xa9, 0x12, 0xe2, 0xc4, 0x1f, 0x80, 0xb8, 0x22, 0x24, 0x4e, 0x88, 0x8f,
+	0x1b, 0x27, 0x14, 0x71, 0xe7, 0x5f, 0x40, 0x33, 0xbb, 0x6b, 0x27, 0xeb, 0xf5, 0xc6, 0x12, 0xee,
+	0x85, 0xdb, 0xbe, 0x37, 0x33, 0xef, 0xfd, 0x7e, 0xef, 0x23, 0xef, 0x39, 0x78, 0x37, 0x3e, 0xe9,
+	0xd8, 0x34, 0x66, 0xae, 0xcf, 0x20, 0x94, 0xf6, 0xd3, 0x88, 0x9f, 0x1c, 0xf9, 0xd1, 0x53, 0xca,
+	0xdd, 0x63, 0xd6, 0x83, 0x81, 0xdc, 0x4c, 0x15, 0x56, 0xcc, 0x23, 0x19, 0x91, 0xc5, 0xdc, 0x3d,
+	0x63, 0xa5, 0x13, 0x45, 0x1d, 0x1f, 0x94, 0x25, 0x9b, 0x86, 0x61, 0x24, 0xa9, 0x64, 0x51, 0x28,
+	0x92, 0xeb, 0xc6, 0xee, 0xc9, 0x9b, 0xc2, 0x62, 0x91, 0x3a, 0x0d, 0xa8, 0x7b, 0xcc, 0x42, 0xe0,
+	0x7d, 0x3b, 0x75, 0x2c, 0xec, 0x00, 0x24, 0xb5, 0x7b, 0x2d, 0xbb, 0x03, 0x21, 0x70, 0x2a, 0xc1,
+	0x4b, 0x5f, 0x3d, 0xec, 0x30, 0x79, 0xdc, 0x3d, 0xb4, 0xdc, 0x28, 0xb0, 0x29, 0xef, 0x44, 0x31,
+	0x8f, 0x3e, 0xd4, 0x1f, 0xcd, 0xcc, 0xbb, 0x18, 0x1a, 0xc9, 0x54, 0x76, 0xaf, 0x45, 0xfd, 0xf8,
+	0x98, 0x8e, 0x98, 0x33, 0x7f, 0x43, 0x78, 0x65, 0x9f, 0x09, 0x79, 0x37, 0x81, 0xec, 0xbd, 0x9f,
+	0x19, 0x71, 0xe0, 0xa3, 0x2e, 0x08, 0x49, 0xda, 0x78, 0xc1, 0x67, 0x42, 0x3e, 0x8a, 0x35, 0xf4,
+	0x1a, 0x5a, 0x47, 0x8d, 0x85, 0x9d, 0x96, 0x95, 0x60, 0xb7, 0xce, 0x62, 0xb7, 0xe2, 0x93, 0x8e,
+	0x52, 0x08, 0x4b, 0x61, 0xb7, 0x7a, 0x2d, 0x6b, 0x7f, 0xf8, 0xd0, 0x39, 0x6b, 0x85, 0xd4, 0x31,
+	0x0e, 0x69, 0x00, 0x8f, 0x39, 0x1c, 0xb1, 0x8f, 0x6b, 0x95, 0x75, 0xd4, 0x98, 0x77, 0xce, 0x68,
+	0xc8, 0x0a, 0x9e, 0x57, 0x92, 0x88, 0xa9, 0x0b, 0xb5, 0x19, 0x7d, 0x3c, 0x54, 0x64, 0xaf, 0xf7,
+	0x98, 0x2f, 0x81, 0xd7, 0x2e, 0x0d, 0x5f, 0x27, 0x1a, 0xf3, 0x2b, 0x84, 0x8d, 0xb7, 0x61, 0x84,
+	0x52, 0xc6, 0xe8, 0x0a, 0x9e, 0xe9, 0x32, 0x4f, 0x33, 0x99, 0x77, 0xd4, 0xe7, 0x79, 0x77, 0x95,
+	0xbc, 0x3b, 0x82, 0x2f, 0x29, 0x21, 0xc5, 0xa1, 0xbf, 0x15, 0x04, 0x37, 0x0a, 0x62, 0x0e, 0x42,
+	0x80, 0xa7, 0x21, 0xcc, 0x39, 0x67, 0x34, 0xa4, 0x86, 0x2f, 0x2b, 0x89, 0xba, 0xb2, 0x56, 0xd5,
+	0x87, 0x99, 0x68, 0x3e, 0xc2, 0xab, 0xf7, 0xc1, 0x07, 0x09, 0x53, 0x82, 0x67, 0x6e, 0xe0, 0xb5,
+	0xbc, 0xa9, 0xc4, 0x81, 0xe7, 0x80, 0x88, 0xa3, 0x50, 0x80, 0x79, 0x1f, 0xbf, 0x56, 0x94, 0xe3,
+	0x7d, 0x7a, 0x08, 0xfe, 0x03, 0xe8, 0x0f, 0x72, 0x7d, 0xce, 0x11, 0xca, 0x3b, 0xfa, 0x1a, 0xe1,
+	0xeb, 0x63, 0xcd, 0xbc, 0x47, 0xfd, 0x2e, 0xbc, 0xd8, 0xa2, 0x29, 0x0f, 0xc3, 0xe7, 0x15, 0xbc,
+	0xe2, 0x80, 0xe4, 0xfd, 0xc9, 0xe3, 0x9a, 0x25, 0xb6, 0x72, 0x26, 0xb1, 0xe5, 0x95, 0xf7, 0x3a,
+	0x7e, 0x89, 0x83, 0x90, 0x94, 0xcb, 0x76, 0xd7, 0x75, 0x41, 0x88, 0xa3, 0xae, 0x9f, 0x66, 0x7f,
+	0xf4, 0x40, 0xdd, 0x0e, 0x23, 0x0f, 0xf6, 0x18, 0xf8, 0x5e, 0x1b, 0x7c, 0x70, 0x65, 0xc4, 0x75,
+	0x39, 0xcc, 0x3b, 0xa3, 0x07, 0xaa, 0xa4, 0x62, 0xca, 0x69, 0x00, 0x12, 0xb8, 0xa8, 0xcd, 0xae,
+	0xcf, 0xa8, 0xaa, 0x1e, 0x6a, 0x48, 0x03, 0x2f, 0x4a, 0xca, 0x3b, 0x20, 0x0f, 0x06, 0xf8, 0x2e,
+	0x6b, 0x5b, 0x79, 0xb5, 0xf9, 0x1c, 0xe1, 0x35, 0x07, 0x44, 0xf7, 0x30, 0x60, 0xf2, 0x45, 0x46,
+	0xc3, 0xc0, 0x73, 0x01, 0x04, 0x11, 0x7b, 0x36, 0x68, 0x81, 0x81, 0x9c, 0x63, 0x53, 0xcd, 0xb3,
+	0x31, 0xdf, 0xc5, 0xab, 0x8f, 0x79, 0x37, 0x84, 0xb1, 0x7f, 0x77, 0x4a, 0x6b, 0x91, 0x2c, 0xe1,
+	0x59, 0x8f, 0xf7, 0x9d, 0x6e, 0xa8, 0xe1, 0xce, 0x39, 0xa9, 0x64, 0xde, 0xc1, 0xf5, 0x71, 0x66,
+	0x93, 0x5e, 0x50, 0x9d, 0xe9, 0x25, 0xed, 0xa1, 0xad, 0xce, 0x38, 0x99, 0x68, 0xfe, 0x83, 0x70,
+	0xbd, 0x2d, 0x39, 0xd0, 0xe0, 0x7f, 0xf4, 0xc7, 0x50, 0x25, 0x29, 0xa6, 0x1d, 0x68, 0xb3, 0x67,
+	0xa0, 0x6b, 0xaf, 0xea, 0x0c, 0x64, 0x33, 0xc6, 0xf5, 0x3d, 0x16, 0x0e, 0x69, 0xde, 0xeb, 0xdf,
+	0xe5, 0x92, 0x1d, 0x51, 0x57, 0x4e, 0x96, 0x05, 0x03, 0xcf, 0xd1, 0xf4, 0x41, 0x8a, 0x7b, 0x20,
+	0x93, 0x57, 0x70, 0xd5, 0x67, 0x01, 0x93, 0x1a, 0x71, 0xd5, 0x49, 0x04, 0xf3, 0x0b, 0x84, 0xaf,
+	0x64, 0x3e, 0x32, 0xb7, 0xe4, 0x08, 0xcf, 0x65, 0x83, 0x2a, 0x0d, 0xe9, 0x3b, 0xd6, 0x70, 0xca,
+	0x59, 0xd9, 0x94, 0xd3, 0x1f, 0x1f, 0x64, 0x37, 0x85, 0xd5, 0xbb, 0x35, 0x0c, 0x72, 0xa6, 0xb5,
+	0xb2, 0x41, 0x67, 0x0d, 0x0a, 0x7e, 0x60, 0x5b, 0x41, 0xe2, 0x91, 0x0f, 0xa2, 0x56, 0xd1, 0xe5,
+	0x98, 0x08, 0xe6, 0x13, 0xbc, 0x36, 0x36, 0x08, 0x69, 0xcd, 0xdc, 0xc6, 0x55, 0x26, 0x21, 0x50,
+	0x09, 0x9f, 0x69, 0x2c, 0xec, 0x6c, 0x58, 0xb9, 0x41, 0x6f, 0xe5, 0x29, 0x39, 0xc9, 0xfd, 0x9d,
+	0xe7, 0x8b, 0x78, 0x39, 0x5f, 0x4c, 0x6d, 0xe0, 0x3d, 0xe6, 0x02, 0xf9, 0x01, 0xe1, 0xab, 0x85,
+	0x93, 0x97, 0x34, 0x47, 0xec, 0x97, 0x4d, 0x68, 0xe3, 0x60, 0x7a, 0xc1, 0x52, 0x7e, 0x4c, 0xf3,
+	0xb3, 0x3f, 0xfe, 0xfe, 0xb2, 0xb2, 0x42, 0x0c, 0xbd, 0xb7, 0xf4, 0x5a, 0x76, 0x8a, 0xc2, 0x1b,
+	0x6e, 0x18, 0xe4, 0x7b, 0x84, 0x5f, 0x2e, 0x18, 0xb1, 0x64, 0x6b, 0x04, 0xfa, 0xf8, 0x41, 0x6c,
+	0x4c, 0x31, 0xcb, 0x66, 0x43, 0x83, 0x36, 0xc9, 0xfa, 0x78, 0xd0, 0xf6, 0x27, 0x5d, 0xe6, 0x7d,
+	0x4a, 0xbe, 0x41, 0x78, 0xa9, 0x78, 0x02, 0x13, 0x6b, 0x04, 0x7d, 0xe9, 0xa8, 0x36, 0xb6, 0x0b,
+	0x0a, 0xa1, 0x7c, 0x12, 0xa7, 0x30, 0x6f, 0x5e, 0x0c, 0xf3, 0x77, 0x84, 0x57, 0x4b, 0x87, 0x36,
+	0x79, 0x63, 0xa2, 0x32, 0xc9, 0x0f, 0x79, 0xe3, 0xc1, 0x7f, 0x8f, 0xfa, 0xc0, 0xa6, 0xd9, 0xd4,
+	0x7c, 0x6e, 0x90, 0x6b, 0xe3, 0xf9, 0x34, 0x7d, 0x75, 0xbb, 0x79, 0xa2, 0x20, 0xff, 0x89, 0xf0,
+	0xda, 0x05, 0x2b, 0x04, 0xb9, 0x3d, 0x39, 0xad, 0x73, 0x4b, 0x87, 0xf1, 0x70, 0x4a, 0xc4, 0x12,
+	0xab, 0xa6, 0xad, 0xa9, 0x6d, 0x92, 0x1b, 0x17, 0x52, 0xeb, 0x25, 0xc0, 0x7f, 0x42, 0xf8, 0x6a,
+	0xe1, 0x06, 0x52, 0xd0, 0xd0, 0x65, 0x9b, 0xca, 0x54, 0xfb, 0xa2, 0xa5, 0x59, 0x6c, 0x19, 0xd7,
+	0x2f, 0x2a, 0x38, 0x9b, 0x2b, 0x48, 0x77, 0xd0, 0x4d, 0xf2, 0x0b, 0xc2, 0xb5, 0x71, 0xeb, 0x03,
+	0xd9, 0x2e, 0xa0, 0x52, 0xba, 0x69, 0x4c, 0x95, 0xcd, 0xae, 0x66, 0x63, 0x19, 0x9b, 0x13, 0xb0,
+	0x49, 0x50, 0x29, 0x42, 0xdf, 0x22, 0xbc, 0x54, 0xbc, 0x15, 0x14, 0x74, 0x7c, 0xe9, 0x56, 0x62,
+	0xd8, 0x13, 0xdf, 0x4f, 0x1b, 0x7e, 0x4b, 0x23, 0xbe, 0x66, 0x96, 0x35, 0x7c, 0xac, 0x4c, 0x28,
+	0xa0, 0x3f, 0x22, 0xbc, 0x3c, 0x66, 0x03, 0x21, 0xa3, 0x9e, 0xcb, 0x77, 0x95, 0xa9, 0xc6, 0x7d,
+	0x53, 0xb3, 0x78, 0x95, 0x6c, 0x64, 0x2c, 0x84, 0xf6, 0x5d, 0x40, 0x66, 0x1b, 0x91, 0xef, 0x10,
+	0x5e, 0x1e, 0x33, 0x51, 0x0b, 0x58, 0x94, 0x2f, 0x20, 0xc6, 0xf6, 0xe4, 0x0f, 0xd2, 0x88, 0x5b,
+	0x1a, 0x6b, 0x83, 0x94, 0x54, 0x7c, 0xf3, 0xb0, 0xdf, 0xcc, 0x16, 0x95, 0x7b, 0x07, 0x3f, 0x9f,
+	0xd6, 0xd1, 0xaf, 0xa7, 0x75, 0xf4, 0xd7, 0x69, 0x1d, 0x3d, 0x79, 0x6b, 0xf2, 0x9f, 0xd7, 0xc5,
+	0xff, 0x1c, 0x38, 0x9c, 0xd5, 0x3f, 0xac, 0x6f, 0xfd, 0x3b, 0x00, 0x73, 0x50, 0x76, 0xa1, 0x44,
+	0x10, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -617,6 +1029,13 @@ type ArchivedWorkflowServiceClient interface {
 	ListArchivedWorkflowLabelValues(ctx context.Context, in *ListArchivedWorkflowLabelValuesRequest, opts ...grpc.CallOption) (*v1alpha1.LabelValues, error)
 	RetryArchivedWorkflow(ctx context.Context, in *RetryArchivedWorkflowRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)
 	ResubmitArchivedWorkflow(ctx context.Context, in *ResubmitArchivedWorkflowRequest, opts ...grpc.CallOption) (*v1alpha1.Workflow, error)
+	PruneArchivedWorkflows(ctx context.Context, in *PruneArchivedWorkflowsRequest, opts ...grpc.CallOption) (*PruneArchivedWorkflowsResponse, error)
+	// StreamArchivedWorkflows sends the archived workflows that match, oldest first, one at a time, reading them from the
+	// archive a page at a time so that exporting the whole archive needs bounded memory.
+	StreamArchivedWorkflows(ctx context.Context, in *StreamArchivedWorkflowsRequest, opts ...grpc.CallOption) (ArchivedWorkflowService_StreamArchivedWorkflowsClient, error)
+	// FindWorkflowsByArtifact returns the archived workflows that produced or consumed the artifact, with their roles.
+	// Artifacts in node status that was offloaded when the workflow was archived are not searched.
+	FindWorkflowsByArtifact(ctx context.Context, in *FindWorkflowsByArtifactRequest, opts ...grpc.CallOption) (*FindWorkflowsByArtifactResponse, error)
 }
 
 type archivedWorkflowServiceClient struct {
@@ -690,22 +1109,79 @@ func (c *archivedWorkflowServiceClient) ResubmitArchivedWorkflow(ctx context.Con
 	return out, nil
 }
 
-// ArchivedWorkflowServiceServer is the server API for ArchivedWorkflowService service.
-type ArchivedWorkflowServiceServer interface {
-	ListArchivedWorkflows(context.Context, *ListArchivedWorkflowsRequest) (*v1alpha1.WorkflowList, error)
-	GetArchivedWorkflow(context.Context, *GetArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
-	DeleteArchivedWorkflow(context.Context, *DeleteArchivedWorkflowRequest) (*ArchivedWorkflowDeletedResponse, error)
-	ListArchivedWorkflowLabelKeys(context.Context, *ListArchivedWorkflowLabelKeysRequest) (*v1alpha1.LabelKeys, error)
-	ListArchivedWorkflowLabelValues(context.Context, *ListArchivedWorkflowLabelValuesRequest) (*v1alpha1.LabelValues, error)
-	RetryArchivedWorkflow(context.Context, *RetryArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
-	ResubmitArchivedWorkflow(context.Context, *ResubmitArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
-}
-
-// UnimplementedArchivedWorkflowServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedArchivedWorkflowServiceServer struct {
+func (c *archivedWorkflowServiceClient) PruneArchivedWorkflows(ctx context.Context, in *PruneArchivedWorkflowsRequest, opts ...grpc.CallOption) (*PruneArchivedWorkflowsResponse, error) {
+	out := new(PruneArchivedWorkflowsResponse)
+	err := c.cc.Invoke(ctx, "/workflowarchive.ArchivedWorkflowService/PruneArchivedWorkflows", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (*UnimplementedArchivedWorkflowServiceServer) ListArchivedWorkflows(ctx context.Context, req *ListArchivedWorkflowsRequest) (*v1alpha1.WorkflowList, error) {
+func (c *archivedWorkflowServiceClient) StreamArchivedWorkflows(ctx context.Context, in *StreamArchivedWorkflowsRequest, opts ...grpc.CallOption) (ArchivedWorkflowService_StreamArchivedWorkflowsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_ArchivedWorkflowService_serviceDesc.Streams[0], "/workflowarchive.ArchivedWorkflowService/StreamArchivedWorkflows", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &archivedWorkflowServiceStreamArchivedWorkflowsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ArchivedWorkflowService_StreamArchivedWorkflowsClient interface {
+	Recv() (*v1alpha1.Workflow, error)
+	grpc.ClientStream
+}
+
+type archivedWorkflowServiceStreamArchivedWorkflowsClient struct {
+	grpc.ClientStream
+}
+
+func (x *archivedWorkflowServiceStreamArchivedWorkflowsClient) Recv() (*v1alpha1.Workflow, error) {
+	m := new(v1alpha1.Workflow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *archivedWorkflowServiceClient) FindWorkflowsByArtifact(ctx context.Context, in *FindWorkflowsByArtifactRequest, opts ...grpc.CallOption) (*FindWorkflowsByArtifactResponse, error) {
+	out := new(FindWorkflowsByArtifactResponse)
+	err := c.cc.Invoke(ctx, "/workflowarchive.ArchivedWorkflowService/FindWorkflowsByArtifact", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArchivedWorkflowServiceServer is the server API for ArchivedWorkflowService service.
+type ArchivedWorkflowServiceServer interface {
+	ListArchivedWorkflows(context.Context, *ListArchivedWorkflowsRequest) (*v1alpha1.WorkflowList, error)
+	GetArchivedWorkflow(context.Context, *GetArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
+	DeleteArchivedWorkflow(context.Context, *DeleteArchivedWorkflowRequest) (*ArchivedWorkflowDeletedResponse, error)
+	ListArchivedWorkflowLabelKeys(context.Context, *ListArchivedWorkflowLabelKeysRequest) (*v1alpha1.LabelKeys, error)
+	ListArchivedWorkflowLabelValues(context.Context, *ListArchivedWorkflowLabelValuesRequest) (*v1alpha1.LabelValues, error)
+	RetryArchivedWorkflow(context.Context, *RetryArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
+	ResubmitArchivedWorkflow(context.Context, *ResubmitArchivedWorkflowRequest) (*v1alpha1.Workflow, error)
+	PruneArchivedWorkflows(context.Context, *PruneArchivedWorkflowsRequest) (*PruneArchivedWorkflowsResponse, error)
+	// StreamArchivedWorkflows sends the archived workflows that match, oldest first, one at a time, reading them from the
+	// archive a page at a time so that exporting the whole archive needs bounded memory.
+	StreamArchivedWorkflows(*StreamArchivedWorkflowsRequest, ArchivedWorkflowService_StreamArchivedWorkflowsServer) error
+	// FindWorkflowsByArtifact returns the archived workflows that produced or consumed the artifact, with their roles.
+	// Artifacts in node status that was offloaded when the workflow was archived are not searched.
+	FindWorkflowsByArtifact(context.Context, *FindWorkflowsByArtifactRequest) (*FindWorkflowsByArtifactResponse, error)
+}
+
+// UnimplementedArchivedWorkflowServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedArchivedWorkflowServiceServer struct {
+}
+
+func (*UnimplementedArchivedWorkflowServiceServer) ListArchivedWorkflows(ctx context.Context, req *ListArchivedWorkflowsRequest) (*v1alpha1.WorkflowList, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedWorkflows not implemented")
 }
 func (*UnimplementedArchivedWorkflowServiceServer) GetArchivedWorkflow(ctx context.Context, req *GetArchivedWorkflowRequest) (*v1alpha1.Workflow, error) {
@@ -726,6 +1202,15 @@ func (*UnimplementedArchivedWorkflowServiceServer) RetryArchivedWorkflow(ctx con
 func (*UnimplementedArchivedWorkflowServiceServer) ResubmitArchivedWorkflow(ctx context.Context, req *ResubmitArchivedWorkflowRequest) (*v1alpha1.Workflow, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResubmitArchivedWorkflow not implemented")
 }
+func (*UnimplementedArchivedWorkflowServiceServer) PruneArchivedWorkflows(ctx context.Context, req *PruneArchivedWorkflowsRequest) (*PruneArchivedWorkflowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneArchivedWorkflows not implemented")
+}
+func (*UnimplementedArchivedWorkflowServiceServer) StreamArchivedWorkflows(req *StreamArchivedWorkflowsRequest, srv ArchivedWorkflowService_StreamArchivedWorkflowsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamArchivedWorkflows not implemented")
+}
+func (*UnimplementedArchivedWorkflowServiceServer) FindWorkflowsByArtifact(ctx context.Context, req *FindWorkflowsByArtifactRequest) (*FindWorkflowsByArtifactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindWorkflowsByArtifact not implemented")
+}
 
 func RegisterArchivedWorkflowServiceServer(s *grpc.Server, srv ArchivedWorkflowServiceServer) {
 	s.RegisterService(&_ArchivedWorkflowService_serviceDesc, srv)
@@ -857,6 +1342,63 @@ func _ArchivedWorkflowService_ResubmitArchivedWorkflow_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArchivedWorkflowService_PruneArchivedWorkflows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneArchivedWorkflowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArchivedWorkflowServiceServer).PruneArchivedWorkflows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowarchive.ArchivedWorkflowService/PruneArchivedWorkflows",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArchivedWorkflowServiceServer).PruneArchivedWorkflows(ctx, req.(*PruneArchivedWorkflowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArchivedWorkflowService_StreamArchivedWorkflows_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamArchivedWorkflowsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ArchivedWorkflowServiceServer).StreamArchivedWorkflows(m, &archivedWorkflowServiceStreamArchivedWorkflowsServer{stream})
+}
+
+type ArchivedWorkflowService_StreamArchivedWorkflowsServer interface {
+	Send(*v1alpha1.Workflow) error
+	grpc.ServerStream
+}
+
+type archivedWorkflowServiceStreamArchivedWorkflowsServer struct {
+	grpc.ServerStream
+}
+
+func (x *archivedWorkflowServiceStreamArchivedWorkflowsServer) Send(m *v1alpha1.Workflow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ArchivedWorkflowService_FindWorkflowsByArtifact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindWorkflowsByArtifactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArchivedWorkflowServiceServer).FindWorkflowsByArtifact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/workflowarchive.ArchivedWorkflowService/FindWorkflowsByArtifact",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArchivedWorkflowServiceServer).FindWorkflowsByArtifact(ctx, req.(*FindWorkflowsByArtifactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ArchivedWorkflowService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "workflowarchive.ArchivedWorkflowService",
 	HandlerType: (*ArchivedWorkflowServiceServer)(nil),
@@ -889,8 +1431,22 @@ var _ArchivedWorkflowService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ResubmitArchivedWorkflow",
 			Handler:    _ArchivedWorkflowService_ResubmitArchivedWorkflow_Handler,
 		},
+		{
+			MethodName: "PruneArchivedWorkflows",
+			Handler:    _ArchivedWorkflowService_PruneArchivedWorkflows_Handler,
+		},
+		{
+			MethodName: "FindWorkflowsByArtifact",
+			Handler:    _ArchivedWorkflowService_FindWorkflowsByArtifact_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamArchivedWorkflows",
+			Handler:       _ArchivedWorkflowService_StreamArchivedWorkflows_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiclient/workflowarchive/workflow-archive.proto",
 }
 
@@ -978,6 +1534,26 @@ func (m *GetArchivedWorkflowRequest) MarshalToSizedBuffer(dAtA []byte) (int, err
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Compact {
+		i--
+		if m.Compact {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Compressed {
+		i--
+		if m.Compressed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
 	if len(m.Name) > 0 {
 		i -= len(m.Name)
 		copy(dAtA[i:], m.Name)
@@ -1174,6 +1750,13 @@ func (m *RetryArchivedWorkflowRequest) MarshalToSizedBuffer(dAtA []byte) (int, e
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.TargetNamespace) > 0 {
+		i -= len(m.TargetNamespace)
+		copy(dAtA[i:], m.TargetNamespace)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.TargetNamespace)))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.Parameters) > 0 {
 		for iNdEx := len(m.Parameters) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Parameters[iNdEx])
@@ -1291,128 +1874,312 @@ func (m *ResubmitArchivedWorkflowRequest) MarshalToSizedBuffer(dAtA []byte) (int
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintWorkflowArchive(dAtA []byte, offset int, v uint64) int {
-	offset -= sovWorkflowArchive(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *PruneArchivedWorkflowsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ListArchivedWorkflowsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.ListOptions != nil {
-		l = m.ListOptions.Size()
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	l = len(m.NamePrefix)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	l = len(m.NameFilter)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+
+func (m *PruneArchivedWorkflowsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *GetArchivedWorkflowRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *PruneArchivedWorkflowsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
+	if m.DryRun {
+		i--
+		if m.DryRun {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *DeleteArchivedWorkflowRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Uid)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PruneArchivedWorkflowsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *ArchivedWorkflowDeletedResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
-	}
-	return n
+func (m *PruneArchivedWorkflowsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *ListArchivedWorkflowLabelKeysRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *PruneArchivedWorkflowsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	l = len(m.Namespace)
-	if l > 0 {
-		n += 1 + l + sovWorkflowArchive(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	return n
+	if m.Deleted != 0 {
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(m.Deleted))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
 }
 
-func (m *ListArchivedWorkflowLabelValuesRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *StreamArchivedWorkflowsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamArchivedWorkflowsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamArchivedWorkflowsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.ListOptions != nil {
-		l = m.ListOptions.Size()
-		n += 1 + l + sovWorkflowArchive(uint64(l))
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
 	}
-	l = len(m.Namespace)
+	if m.PageSize != 0 {
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(m.PageSize))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.NameFilter) > 0 {
+		i -= len(m.NameFilter)
+		copy(dAtA[i:], m.NameFilter)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.NameFilter)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.NamePrefix) > 0 {
+		i -= len(m.NamePrefix)
+		copy(dAtA[i:], m.NamePrefix)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.NamePrefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.ListOptions != nil {
+		{
+			size, err := m.ListOptions.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflowArchive(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FindWorkflowsByArtifactRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindWorkflowsByArtifactRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FindWorkflowsByArtifactRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Limit != 0 {
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Artifact) > 0 {
+		i -= len(m.Artifact)
+		copy(dAtA[i:], m.Artifact)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Artifact)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ArtifactWorkflow) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ArtifactWorkflow) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ArtifactWorkflow) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Roles) > 0 {
+		for iNdEx := len(m.Roles) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Roles[iNdEx])
+			copy(dAtA[i:], m.Roles[iNdEx])
+			i = encodeVarintWorkflowArchive(dAtA, i, uint64(len(m.Roles[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Workflow != nil {
+		{
+			size, err := m.Workflow.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintWorkflowArchive(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FindWorkflowsByArtifactResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FindWorkflowsByArtifactResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FindWorkflowsByArtifactResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintWorkflowArchive(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintWorkflowArchive(dAtA []byte, offset int, v uint64) int {
+	offset -= sovWorkflowArchive(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ListArchivedWorkflowsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ListOptions != nil {
+		l = m.ListOptions.Size()
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.NamePrefix)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.NameFilter)
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
@@ -1422,7 +2189,7 @@ func (m *ListArchivedWorkflowLabelValuesRequest) Size() (n int) {
 	return n
 }
 
-func (m *RetryArchivedWorkflowRequest) Size() (n int) {
+func (m *GetArchivedWorkflowRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1432,26 +2199,87 @@ func (m *RetryArchivedWorkflowRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
 	l = len(m.Name)
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
+	if m.Compressed {
+		n += 2
+	}
+	if m.Compact {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *DeleteArchivedWorkflowRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
 	l = len(m.Namespace)
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
-	if m.RestartSuccessful {
-		n += 2
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	l = len(m.NodeFieldSelector)
+	return n
+}
+
+func (m *ArchivedWorkflowDeletedResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListArchivedWorkflowLabelKeysRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
-	if len(m.Parameters) > 0 {
-		for _, s := range m.Parameters {
-			l = len(s)
-			n += 1 + l + sovWorkflowArchive(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ListArchivedWorkflowLabelValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ListOptions != nil {
+		l = m.ListOptions.Size()
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -1459,7 +2287,7 @@ func (m *RetryArchivedWorkflowRequest) Size() (n int) {
 	return n
 }
 
-func (m *ResubmitArchivedWorkflowRequest) Size() (n int) {
+func (m *RetryArchivedWorkflowRequest) Size() (n int) {
 	if m == nil {
 		return 0
 	}
@@ -1477,16 +2305,185 @@ func (m *ResubmitArchivedWorkflowRequest) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovWorkflowArchive(uint64(l))
 	}
-	if m.Memoized {
+	if m.RestartSuccessful {
 		n += 2
 	}
+	l = len(m.NodeFieldSelector)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
 	if len(m.Parameters) > 0 {
 		for _, s := range m.Parameters {
 			l = len(s)
 			n += 1 + l + sovWorkflowArchive(uint64(l))
 		}
 	}
-	if m.XXX_unrecognized != nil {
+	l = len(m.TargetNamespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResubmitArchivedWorkflowRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Uid)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if m.Memoized {
+		n += 2
+	}
+	if len(m.Parameters) > 0 {
+		for _, s := range m.Parameters {
+			l = len(s)
+			n += 1 + l + sovWorkflowArchive(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PruneArchivedWorkflowsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if m.DryRun {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PruneArchivedWorkflowsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Deleted != 0 {
+		n += 1 + sovWorkflowArchive(uint64(m.Deleted))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *StreamArchivedWorkflowsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ListOptions != nil {
+		l = m.ListOptions.Size()
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.NamePrefix)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.NameFilter)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if m.PageSize != 0 {
+		n += 1 + sovWorkflowArchive(uint64(m.PageSize))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindWorkflowsByArtifactRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	l = len(m.Artifact)
+	if l > 0 {
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovWorkflowArchive(uint64(m.Limit))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ArtifactWorkflow) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Workflow != nil {
+		l = m.Workflow.Size()
+		n += 1 + l + sovWorkflowArchive(uint64(l))
+	}
+	if len(m.Roles) > 0 {
+		for _, s := range m.Roles {
+			l = len(s)
+			n += 1 + l + sovWorkflowArchive(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FindWorkflowsByArtifactResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovWorkflowArchive(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
@@ -1541,31 +2538,849 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ListOptions == nil {
+				m.ListOptions = &v1.ListOptions{}
+			}
+			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePrefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NamePrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NameFilter", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NameFilter = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetArchivedWorkflowRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compressed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compressed = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compact", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Compact = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteArchivedWorkflowRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ArchivedWorkflowDeletedResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ArchivedWorkflowDeletedResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ArchivedWorkflowDeletedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListArchivedWorkflowLabelKeysRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListArchivedWorkflowLabelKeysRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListArchivedWorkflowLabelKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListArchivedWorkflowLabelValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListArchivedWorkflowLabelValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListArchivedWorkflowLabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ListOptions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ListOptions == nil {
+				m.ListOptions = &v1.ListOptions{}
+			}
+			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RetryArchivedWorkflowRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RetryArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Uid = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthWorkflowArchive
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthWorkflowArchive
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ListOptions == nil {
-				m.ListOptions = &v1.ListOptions{}
+			m.Namespace = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RestartSuccessful", wireType)
 			}
-			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 2:
+			m.RestartSuccessful = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NamePrefix", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NodeFieldSelector", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1593,11 +3408,11 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NamePrefix = string(dAtA[iNdEx:postIndex])
+			m.NodeFieldSelector = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 3:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1625,11 +3440,11 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
-		case 4:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NameFilter", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TargetNamespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1657,7 +3472,7 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NameFilter = string(dAtA[iNdEx:postIndex])
+			m.TargetNamespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1681,7 +3496,7 @@ func (m *ListArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1704,10 +3519,10 @@ func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetArchivedWorkflowRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ResubmitArchivedWorkflowRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ResubmitArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1744,7 +3559,7 @@ func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1772,11 +3587,11 @@ func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1804,64 +3619,13 @@ func (m *GetArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *DeleteArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWorkflowArchive
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteArchivedWorkflowRequest: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Memoized", wireType)
 			}
-			var stringLen uint64
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -1871,27 +3635,15 @@ func (m *DeleteArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				v |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
+			m.Memoized = bool(v != 0)
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1919,7 +3671,7 @@ func (m *DeleteArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
+			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1943,58 +3695,7 @@ func (m *DeleteArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ArchivedWorkflowDeletedResponse) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowWorkflowArchive
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: ArchivedWorkflowDeletedResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ArchivedWorkflowDeletedResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		default:
-			iNdEx = preIndex
-			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *ListArchivedWorkflowLabelKeysRequest) Unmarshal(dAtA []byte) error {
+func (m *PruneArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2017,10 +3718,10 @@ func (m *ListArchivedWorkflowLabelKeysRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListArchivedWorkflowLabelKeysRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PruneArchivedWorkflowsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListArchivedWorkflowLabelKeysRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PruneArchivedWorkflowsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2055,6 +3756,26 @@ func (m *ListArchivedWorkflowLabelKeysRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DryRun", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DryRun = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
@@ -2077,7 +3798,7 @@ func (m *ListArchivedWorkflowLabelKeysRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListArchivedWorkflowLabelValuesRequest) Unmarshal(dAtA []byte) error {
+func (m *PruneArchivedWorkflowsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2100,53 +3821,17 @@ func (m *ListArchivedWorkflowLabelValuesRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListArchivedWorkflowLabelValuesRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PruneArchivedWorkflowsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListArchivedWorkflowLabelValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PruneArchivedWorkflowsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ListOptions", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWorkflowArchive
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.ListOptions == nil {
-				m.ListOptions = &v1.ListOptions{}
-			}
-			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
 			}
-			var stringLen uint64
+			m.Deleted = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -2156,24 +3841,11 @@ func (m *ListArchivedWorkflowLabelValuesRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Deleted |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
@@ -2196,7 +3868,7 @@ func (m *ListArchivedWorkflowLabelValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+func (m *StreamArchivedWorkflowsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2219,17 +3891,17 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: RetryArchivedWorkflowRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: StreamArchivedWorkflowsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: RetryArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: StreamArchivedWorkflowsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ListOptions", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -2239,27 +3911,31 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthWorkflowArchive
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthWorkflowArchive
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
+			if m.ListOptions == nil {
+				m.ListOptions = &v1.ListOptions{}
+			}
+			if err := m.ListOptions.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NamePrefix", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2287,7 +3963,7 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.NamePrefix = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
@@ -2322,28 +3998,8 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RestartSuccessful", wireType)
-			}
-			var v int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowWorkflowArchive
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				v |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			m.RestartSuccessful = bool(v != 0)
-		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NodeFieldSelector", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NameFilter", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2371,13 +4027,13 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.NodeFieldSelector = string(dAtA[iNdEx:postIndex])
+			m.NameFilter = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
 			}
-			var stringLen uint64
+			m.PageSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -2387,24 +4043,11 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.PageSize |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthWorkflowArchive
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
@@ -2427,7 +4070,7 @@ func (m *RetryArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
+func (m *FindWorkflowsByArtifactRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2450,15 +4093,15 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ResubmitArchivedWorkflowRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: FindWorkflowsByArtifactRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ResubmitArchivedWorkflowRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FindWorkflowsByArtifactRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Uid", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2486,11 +4129,11 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Uid = string(dAtA[iNdEx:postIndex])
+			m.Namespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Artifact", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2518,13 +4161,13 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Artifact = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
 			}
-			var stringLen uint64
+			m.Limit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -2534,29 +4177,67 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Limit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthWorkflowArchive
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthWorkflowArchive
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Namespace = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Memoized", wireType)
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ArtifactWorkflow) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
 			}
-			var v int
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ArtifactWorkflow: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ArtifactWorkflow: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Workflow", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowWorkflowArchive
@@ -2566,15 +4247,31 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Memoized = bool(v != 0)
-		case 5:
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Workflow == nil {
+				m.Workflow = &v1alpha1.Workflow{}
+			}
+			if err := m.Workflow.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Parameters", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Roles", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2602,7 +4299,92 @@ func (m *ResubmitArchivedWorkflowRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Parameters = append(m.Parameters, string(dAtA[iNdEx:postIndex]))
+			m.Roles = append(m.Roles, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipWorkflowArchive(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FindWorkflowsByArtifactResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowWorkflowArchive
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FindWorkflowsByArtifactResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FindWorkflowsByArtifactResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowWorkflowArchive
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthWorkflowArchive
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &ArtifactWorkflow{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
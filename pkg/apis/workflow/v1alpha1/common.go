@@ -72,4 +72,8 @@ type SubmitOpts struct {
 	// Priority is used if controller is configured to process limited number of workflows in parallel, higher priority workflows
 	// are processed first.
 	Priority *int32 `json:"priority,omitempty" protobuf:"bytes,14,opt,name=priority"`
+	// StartSuspended creates the workflow with spec.suspend set, so it does not run until it is resumed
+	StartSuspended bool `json:"startSuspended,omitempty" protobuf:"varint,15,opt,name=startSuspended"`
+	// StartAt submits the workflow suspended, annotated with the time it is to be resumed at in workflows.argoproj.io/start-at
+	StartAt *metav1.Time `json:"startAt,omitempty" protobuf:"bytes,16,opt,name=startAt"`
 }
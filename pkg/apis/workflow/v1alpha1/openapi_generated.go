@@ -6166,11 +6166,24 @@ func schema_pkg_apis_workflow_v1alpha1_SubmitOpts(ref common.ReferenceCallback)
 							Format:      "int32",
 						},
 					},
+					"startSuspended": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartSuspended creates the workflow with spec.suspend set, so it does not run until it is resumed",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"startAt": {
+						SchemaProps: spec.SchemaProps{
+							Description: "StartAt submits the workflow suspended, annotated with the time it is to be resumed at in workflows.argoproj.io/start-at",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.OwnerReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
 
@@ -48,6 +48,27 @@ func (in Progress) IsValid() bool {
 	return in != "" && in.N() >= 0 && in.N() <= in.M() && in.M() > 0
 }
 
+// Percent returns the percentage of tasks that are complete, rounded down, and whether the progress could be parsed.
+// Progress with no tasks, i.e. "0/0", is 0 percent complete.
+func (in Progress) Percent() (int64, bool) {
+	parts := in.parts()
+	if len(parts) != 2 {
+		return 0, false
+	}
+	n, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	m, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || n < 0 || n > m {
+		return 0, false
+	}
+	if m == 0 {
+		return 0, true
+	}
+	return n * 100 / m, true
+}
+
 func parseInt64(s string) int64 {
 	v, _ := strconv.ParseInt(s, 10, 64)
 	return v
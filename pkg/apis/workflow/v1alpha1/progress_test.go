@@ -28,4 +28,15 @@ func TestProgress(t *testing.T) {
 	t.Run("Complete", func(t *testing.T) {
 		assert.Equal(t, Progress("100/100"), Progress("0/100").Complete())
 	})
+	t.Run("Percent", func(t *testing.T) {
+		for progress, want := range map[Progress]int64{"0/0": 0, "0/1": 0, "1/2": 50, "1/3": 33, "2/3": 66, "3/3": 100, "12/1000": 1} {
+			percent, ok := progress.Percent()
+			assert.True(t, ok, progress)
+			assert.Equal(t, want, percent, progress)
+		}
+		for _, progress := range []Progress{"", "1", "a/b", "1/", "/1", "2/1", "-1/1"} {
+			_, ok := progress.Percent()
+			assert.False(t, ok, progress)
+		}
+	})
 }
@@ -3007,6 +3007,10 @@ func (in *SubmitOpts) DeepCopyInto(out *SubmitOpts) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.StartAt != nil {
+		in, out := &in.StartAt, &out.StartAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -45,10 +45,12 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth/webhook"
 	"github.com/argoproj/argo-workflows/v3/server/cache"
 	"github.com/argoproj/argo-workflows/v3/server/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/server/completionwebhook"
 	"github.com/argoproj/argo-workflows/v3/server/cronworkflow"
 	"github.com/argoproj/argo-workflows/v3/server/event"
 	"github.com/argoproj/argo-workflows/v3/server/eventsource"
 	"github.com/argoproj/argo-workflows/v3/server/info"
+	"github.com/argoproj/argo-workflows/v3/server/metrics"
 	"github.com/argoproj/argo-workflows/v3/server/sensor"
 	"github.com/argoproj/argo-workflows/v3/server/static"
 	"github.com/argoproj/argo-workflows/v3/server/types"
@@ -63,6 +65,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/util/logging"
 	rbacutil "github.com/argoproj/argo-workflows/v3/util/rbac"
 	"github.com/argoproj/argo-workflows/v3/util/sqldb"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
 	"github.com/argoproj/argo-workflows/v3/workflow/artifactrepositories"
 	"github.com/argoproj/argo-workflows/v3/workflow/events"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
@@ -95,6 +98,7 @@ type argoServer struct {
 	allowedLinkProtocol      []string
 	cache                    *cache.ResourceCache
 	restConfig               *rest.Config
+	maxConcurrentWatches     int
 }
 
 type ArgoServerOpts struct {
@@ -117,6 +121,8 @@ type ArgoServerOpts struct {
 	AccessControlAllowOrigin string
 	APIRateLimit             uint64
 	AllowedLinkProtocol      []string
+	// MaxConcurrentWatches limits the number of concurrent WatchWorkflows, WatchWorkflowNodes and WatchEvents streams, zero means unlimited
+	MaxConcurrentWatches int
 }
 
 func init() {
@@ -189,6 +195,7 @@ func NewArgoServer(ctx context.Context, opts ArgoServerOpts) (*argoServer, error
 		allowedLinkProtocol:      opts.AllowedLinkProtocol,
 		cache:                    resourceCache,
 		restConfig:               opts.RestConfig,
+		maxConcurrentWatches:     opts.MaxConcurrentWatches,
 	}, nil
 }
 
@@ -225,7 +232,7 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 		}
 		// we always enable node offload, as this is read-only for the Argo Server, i.e. you can turn it off if you
 		// like and the controller won't offload newly created workflows, but you can still read them
-		offloadRepo, err = persist.NewOffloadNodeStatusRepo(ctx, log, session, persistence.GetClusterName(), tableName)
+		offloadRepo, err = persist.NewOffloadNodeStatusRepo(ctx, log, session, persistence.GetClusterName(), tableName, persistence.NodeStatusOffloadCompression)
 		if err != nil {
 			log.WithError(err).WithFatal().Error(ctx, err.Error())
 		}
@@ -250,14 +257,42 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	artifactRepositories := artifactrepositories.New(as.clients.Kubernetes, as.managedNamespace, &config.ArtifactRepository)
 	artifactServer := artifacts.NewArtifactServer(as.gatekeeper, hydrator.New(offloadRepo), wfArchive, instanceIDService, artifactRepositories, log)
 	eventServer := event.NewController(ctx, instanceIDService, eventRecorderManager, as.eventQueueSize, as.eventWorkerCount, as.eventAsyncDispatch)
-	wfArchiveServer := workflowarchive.NewWorkflowArchiveServer(wfArchive, offloadRepo, config.WorkflowDefaults)
+	maintenance := workflow.NewMaintenance(as.clients.Kubernetes, as.configController.GetNamespace(), as.configController.GetName())
+	wfArchiveServer := workflowarchive.NewWorkflowArchiveServer(wfArchive, offloadRepo, config.WorkflowDefaults, persistence, maintenance)
 	wfStore, err := store.NewSQLiteStore(instanceIDService)
 	if err != nil {
 		log.WithFatal().Error(ctx, err.Error())
 	}
-	workflowServer := workflow.NewWorkflowServer(ctx, instanceIDService, offloadRepo, wfArchive, as.clients.Workflow, wfStore, wfStore, wftmplStore, cwftmplInformer, config.WorkflowDefaults, &resourceCacheNamespace)
-	grpcServer := as.newGRPCServer(ctx, instanceIDService, workflowServer, wftmplStore, cwftmplInformer, wfArchiveServer, eventServer, config.Links, config.Columns, config.NavColor, config.WorkflowDefaults)
-	httpServer := as.newHTTPServer(ctx, port, artifactServer)
+	serverMetrics, err := metrics.New(ctx, "argo-server", "argo_workflows", &telemetry.Config{Enabled: true})
+	if err != nil {
+		log.WithFatal().Error(ctx, err.Error())
+	}
+	shutdownTracing, err := telemetry.InitTracing(ctx, "argo-server")
+	if err != nil {
+		log.WithFatal().Error(ctx, err.Error())
+	}
+	defer func() {
+		if err := shutdownTracing(context.WithoutCancel(ctx)); err != nil {
+			log.WithError(err).Warn(ctx, "failed to flush traces")
+		}
+	}()
+	workflowServer := workflow.NewWorkflowServer(ctx, instanceIDService, offloadRepo, wfArchive, as.clients.Workflow, wfStore, wfStore, wftmplStore, cwftmplInformer, config.WorkflowDefaults, &resourceCacheNamespace, workflow.WorkflowServerOptions{
+		MaxConcurrentWatches:       as.maxConcurrentWatches,
+		Metrics:                    serverMetrics,
+		NamespaceDeletePropagation: config.NamespaceDeletePropagation,
+		MaxRequestSize:             config.MaxRequestSize,
+		SubmissionQuota:            config.SubmissionQuota,
+		AllowedNamespaces:          config.AllowedNamespaces,
+		Maintenance:                maintenance,
+		GenerateNameRetries:        config.GetCreateWorkflowGenerateNameRetries(),
+		ValidationCacheSize:        config.ValidationCacheSize,
+		HydrateMaxNodes:            config.HydrateMaxNodes,
+		HydrateRefuseOverMaxNodes:  config.HydrateRefuseOverMaxNodes,
+		InstanceIDMismatch:         config.InstanceIDMismatch,
+		ResourceFitCheck:           config.ResourceFitCheck,
+	})
+	grpcServer := as.newGRPCServer(ctx, instanceIDService, workflowServer, wftmplStore, cwftmplInformer, wfArchiveServer, eventServer, config.Links, config.Columns, config.NavColor, config.WorkflowDefaults, config.MaxRequestSize, auth.NewAllowedNamespaces(config.AllowedNamespaces))
+	httpServer := as.newHTTPServer(ctx, port, artifactServer, workflowServer.Readyz)
 
 	// Start listener
 	var conn net.Listener
@@ -288,6 +323,18 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	}
 	go eventServer.Run(ctx, as.stopCh)
 	go workflowServer.Run(as.stopCh)
+	if len(config.CompletionWebhooks) > 0 {
+		dispatcher, err := completionwebhook.NewDispatcher(config.CompletionWebhooks)
+		if err != nil {
+			log.WithFatal().Error(ctx, err.Error())
+		}
+		// do not wait for the workflows to be listed before serving
+		go func() {
+			if err := dispatcher.Run(ctx, as.clients.Workflow, resourceCacheNamespace, instanceIDService, as.stopCh); err != nil {
+				log.WithError(err).Error(ctx, "Unable to run completion webhook dispatcher")
+			}
+		}()
+	}
 	go func() { as.checkServeErr(ctx, "httpServer", http.Serve(conn, handler)) }()
 	url := "http://localhost" + address
 	if as.tlsConfig != nil {
@@ -302,7 +349,7 @@ func (as *argoServer) Run(ctx context.Context, port int, browserOpenFunc func(st
 	<-as.stopCh
 }
 
-func (as *argoServer) newGRPCServer(ctx context.Context, instanceIDService instanceid.Service, workflowServer workflowpkg.WorkflowServiceServer, wftmplStore types.WorkflowTemplateStore, cwftmplStore types.ClusterWorkflowTemplateStore, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer, eventServer *event.Controller, links []*v1alpha1.Link, columns []*v1alpha1.Column, navColor string, wfDefaults *v1alpha1.Workflow) *grpc.Server {
+func (as *argoServer) newGRPCServer(ctx context.Context, instanceIDService instanceid.Service, workflowServer workflowpkg.WorkflowServiceServer, wftmplStore types.WorkflowTemplateStore, cwftmplStore types.ClusterWorkflowTemplateStore, wfArchiveServer workflowarchivepkg.ArchivedWorkflowServiceServer, eventServer *event.Controller, links []*v1alpha1.Link, columns []*v1alpha1.Column, navColor string, wfDefaults *v1alpha1.Workflow, maxRequestSize int, allowedNamespaces auth.AllowedNamespaces) *grpc.Server {
 	serverLog := logging.RequireLoggerFromContext(ctx)
 
 	// "Prometheus histograms are a great way to measure latency distributions of your RPCs. However, since it is bad practice to have metrics of high cardinality the latency monitoring metrics are disabled by default. To enable them please call the following in your server initialization code:"
@@ -312,24 +359,28 @@ func (as *argoServer) newGRPCServer(ctx context.Context, instanceIDService insta
 		// Set both the send and receive the bytes limit to be 100MB or GRPC_MESSAGE_SIZE
 		// The proper way to achieve high performance is to have pagination
 		// while we work toward that, we can have high limit first
-		grpc.MaxRecvMsgSize(MaxGRPCMessageSize),
+		grpc.MaxRecvMsgSize(maxRecvMsgSize(maxRequestSize)),
 		grpc.MaxSendMsgSize(MaxGRPCMessageSize),
 		grpc.ConnectionTimeout(300 * time.Second),
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
+			grpcutil.TracingUnaryServerInterceptor(),
 			grpc_prometheus.UnaryServerInterceptor,
 			grpcutil.LoggerUnaryServerInterceptor(serverLog),
 			grpcutil.PanicLoggerUnaryServerInterceptor(serverLog),
 			grpcutil.ErrorTranslationUnaryServerInterceptor,
 			as.gatekeeper.UnaryServerInterceptor(),
+			allowedNamespaces.UnaryServerInterceptor(),
 			grpcutil.RatelimitUnaryServerInterceptor(as.apiRateLimiter),
 			grpcutil.SetVersionHeaderUnaryServerInterceptor(argo.GetVersion()),
 		)),
 		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpcutil.TracingStreamServerInterceptor(),
 			grpc_prometheus.StreamServerInterceptor,
 			grpcutil.LoggerStreamServerInterceptor(serverLog),
 			grpcutil.PanicLoggerStreamServerInterceptor(serverLog),
 			grpcutil.ErrorTranslationStreamServerInterceptor,
 			as.gatekeeper.StreamServerInterceptor(),
+			allowedNamespaces.StreamServerInterceptor(),
 			grpcutil.RatelimitStreamServerInterceptor(as.apiRateLimiter),
 			grpcutil.SetVersionHeaderStreamServerInterceptor(argo.GetVersion()),
 		)),
@@ -349,9 +400,19 @@ func (as *argoServer) newGRPCServer(ctx context.Context, instanceIDService insta
 	return grpcServer
 }
 
+// maxRecvMsgSize returns the maximum size of a message the gRPC server receives, for every RPC of every service. It
+// leaves headroom above the maximum request size, so the workflow server rejects workflows just over it with a clear
+// error rather than the transport.
+func maxRecvMsgSize(maxRequestSize int) int {
+	if maxRequestSize <= 0 || maxRequestSize > MaxGRPCMessageSize/2 {
+		return MaxGRPCMessageSize
+	}
+	return 2 * maxRequestSize
+}
+
 // newHTTPServer returns the HTTP handler to serve HTTP/HTTPS requests. This is implemented
 // using grpc-gateway as a proxy to the gRPC server.
-func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer) http.Handler {
+func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServer *artifacts.ArtifactServer, readyz http.HandlerFunc) http.Handler {
 	log := logging.RequireLoggerFromContext(ctx)
 	endpoint := fmt.Sprintf("localhost:%d", port)
 	ipKeyFunc := httplimit.IPKeyFunc()
@@ -389,7 +450,9 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 	// time.Time, but does not support custom UnmarshalJSON() and MarshalJSON() methods. Therefore
 	// we use our own Marshaler
 	gwMuxOpts := runtime.WithMarshalerOption(runtime.MIMEWildcard, new(json.JSONMarshaler))
+	// clients that accept text/event-stream, e.g. browsers using EventSource, get streams as Server-Sent Events
 	gwmux := runtime.NewServeMux(gwMuxOpts,
+		runtime.WithMarshalerOption("text/event-stream", new(json.SSEMarshaler)),
 		runtime.WithIncomingHeaderMatcher(grpcutil.IncomingHeaderMatcher),
 		runtime.WithProtoErrorHandler(runtime.DefaultHTTPProtoErrorHandler),
 	)
@@ -417,6 +480,9 @@ func (as *argoServer) newHTTPServer(ctx context.Context, port int, artifactServe
 		mux.HandleFunc("/input-artifacts-by-uid/", artifactServer.GetInputArtifactByUID)
 		mux.HandleFunc("/artifact-files/", artifactServer.GetArtifactFile)
 	}
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		readyz(w, r.WithContext(logging.WithLogger(r.Context(), log)))
+	})
 	mux.Handle("/oauth2/redirect", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleRedirect)))
 	mux.Handle("/oauth2/callback", handlers.ProxyHeaders(http.HandlerFunc(as.oAuth2Service.HandleCallback)))
 	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	servertypes "github.com/argoproj/argo-workflows/v3/server/types"
+)
+
+// AllowedNamespaces are the only namespaces the server serves, as a guardrail on top of RBAC. Nil allows all
+// namespaces.
+type AllowedNamespaces map[string]bool
+
+func NewAllowedNamespaces(namespaces []string) AllowedNamespaces {
+	if len(namespaces) == 0 {
+		return nil
+	}
+	allowed := AllowedNamespaces{}
+	for _, namespace := range namespaces {
+		allowed[namespace] = true
+	}
+	return allowed
+}
+
+// Check returns a PermissionDenied error unless the namespace is allowed. An empty namespace, which is all namespaces,
+// is only allowed when every namespace is.
+func (a AllowedNamespaces) Check(namespace string) error {
+	if a == nil || a[namespace] {
+		return nil
+	}
+	if namespace == "" {
+		return status.Error(codes.PermissionDenied, "this server only serves some namespaces, specify one of them")
+	}
+	return status.Errorf(codes.PermissionDenied, "this server does not serve namespace %q", namespace)
+}
+
+// Filter returns the namespaces that are allowed
+func (a AllowedNamespaces) Filter(namespaces []string) []string {
+	if a == nil {
+		return namespaces
+	}
+	var filtered []string
+	for _, namespace := range namespaces {
+		if a[namespace] {
+			filtered = append(filtered, namespace)
+		}
+	}
+	return filtered
+}
+
+// targetNamespacedRequest is a request that creates its resource in another namespace than the one it reads from
+type targetNamespacedRequest interface {
+	GetTargetNamespace() string
+}
+
+// CheckRequest checks the namespace of a namespaced request, and the namespace it targets, if any. Requests that are
+// not namespaced, e.g. for cluster workflow templates, are not checked.
+func (a AllowedNamespaces) CheckRequest(req interface{}) error {
+	if a == nil || req == nil {
+		return nil
+	}
+	if namespaced, ok := req.(servertypes.NamespacedRequest); ok {
+		if err := a.Check(namespaced.GetNamespace()); err != nil {
+			return err
+		}
+	}
+	if targeted, ok := req.(targetNamespacedRequest); ok && targeted.GetTargetNamespace() != "" {
+		return a.Check(targeted.GetTargetNamespace())
+	}
+	return nil
+}
+
+func (a AllowedNamespaces) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.CheckRequest(req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a AllowedNamespaces) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if a == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &namespaceCheckingServerStream{ServerStream: ss, allowed: a})
+	}
+}
+
+// namespaceCheckingServerStream checks the namespace of each request received on the stream
+type namespaceCheckingServerStream struct {
+	grpc.ServerStream
+	allowed AllowedNamespaces
+}
+
+func (s *namespaceCheckingServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.allowed.CheckRequest(m)
+}
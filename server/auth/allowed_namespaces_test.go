@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	clusterwftemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/clusterworkflowtemplate"
+	cronworkflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/cronworkflow"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
+	workflowtemplatepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowtemplate"
+	servertypes "github.com/argoproj/argo-workflows/v3/server/types"
+)
+
+func TestAllowedNamespacesCheck(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		allowed := NewAllowedNamespaces(nil)
+		require.NoError(t, allowed.Check("my-ns"))
+		require.NoError(t, allowed.Check(""))
+		assert.Equal(t, []string{"my-ns", "other-ns"}, allowed.Filter([]string{"my-ns", "other-ns"}))
+	})
+	t.Run("Some", func(t *testing.T) {
+		allowed := NewAllowedNamespaces([]string{"my-ns"})
+		require.NoError(t, allowed.Check("my-ns"))
+		err := allowed.Check("other-ns")
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), `this server does not serve namespace "other-ns"`)
+		assert.Equal(t, codes.PermissionDenied, status.Code(allowed.Check("")))
+		assert.Equal(t, []string{"my-ns"}, allowed.Filter([]string{"my-ns", "other-ns"}))
+	})
+}
+
+func TestAllowedNamespacesCheckRequest(t *testing.T) {
+	allowed := NewAllowedNamespaces([]string{"my-ns"})
+	t.Run("Namespaced", func(t *testing.T) {
+		require.NoError(t, allowed.CheckRequest(servertypes.NamespaceHolder("my-ns")))
+		assert.Equal(t, codes.PermissionDenied, status.Code(allowed.CheckRequest(servertypes.NamespaceHolder("other-ns"))))
+		assert.Equal(t, codes.PermissionDenied, status.Code(allowed.CheckRequest(servertypes.NamespaceHolder(""))), "all namespaces")
+	})
+	t.Run("NotNamespaced", func(t *testing.T) {
+		require.NoError(t, allowed.CheckRequest(struct{}{}))
+		require.NoError(t, allowed.CheckRequest(nil))
+	})
+	t.Run("TargetNamespace", func(t *testing.T) {
+		require.NoError(t, allowed.CheckRequest(&workflowarchivepkg.RetryArchivedWorkflowRequest{Namespace: "my-ns"}))
+		require.NoError(t, allowed.CheckRequest(&workflowarchivepkg.RetryArchivedWorkflowRequest{Namespace: "my-ns", TargetNamespace: "my-ns"}))
+		err := allowed.CheckRequest(&workflowarchivepkg.RetryArchivedWorkflowRequest{Namespace: "my-ns", TargetNamespace: "other-ns"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("AllAllowed", func(t *testing.T) {
+		require.NoError(t, NewAllowedNamespaces(nil).CheckRequest(servertypes.NamespaceHolder("")))
+	})
+}
+
+func TestAllowedNamespacesUnaryServerInterceptor(t *testing.T) {
+	interceptor := NewAllowedNamespaces([]string{"my-ns"}).UnaryServerInterceptor()
+	intercept := func(req interface{}) (bool, error) {
+		handled := false
+		_, err := interceptor(t.Context(), req, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled = true
+			return nil, nil
+		})
+		return handled, err
+	}
+	for name, req := range map[string]interface{}{
+		"Workflow":         &workflowpkg.WorkflowGetRequest{Namespace: "other-ns"},
+		"CostEstimate":     &workflowpkg.WorkflowCostEstimateRequest{Namespace: "other-ns"},
+		"ArchivedWorkflow": &workflowarchivepkg.GetArchivedWorkflowRequest{Namespace: "other-ns"},
+		"ArchivedByUID":    &workflowarchivepkg.DeleteArchivedWorkflowRequest{Uid: "my-uid"},
+		"Prune":            &workflowarchivepkg.PruneArchivedWorkflowsRequest{},
+		"ByArtifact":       &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "other-ns"},
+		"CronWorkflow":     &cronworkflowpkg.ListCronWorkflowsRequest{Namespace: "other-ns"},
+		"WorkflowTemplate": &workflowtemplatepkg.WorkflowTemplateListRequest{Namespace: "other-ns"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			handled, err := intercept(req)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+			assert.False(t, handled)
+		})
+	}
+	t.Run("Allowed", func(t *testing.T) {
+		handled, err := intercept(&workflowpkg.WorkflowGetRequest{Namespace: "my-ns"})
+		require.NoError(t, err)
+		assert.True(t, handled)
+	})
+	t.Run("ClusterScoped", func(t *testing.T) {
+		handled, err := intercept(&clusterwftemplatepkg.ClusterWorkflowTemplateListRequest{})
+		require.NoError(t, err)
+		assert.True(t, handled)
+	})
+}
+
+// fakeServerStream receives the request
+type fakeServerStream struct {
+	grpc.ServerStream
+	req *workflowpkg.WatchWorkflowsRequest
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	*m.(*workflowpkg.WatchWorkflowsRequest) = *s.req
+	return nil
+}
+
+func TestAllowedNamespacesStreamServerInterceptor(t *testing.T) {
+	interceptor := NewAllowedNamespaces([]string{"my-ns"}).StreamServerInterceptor()
+	recv := func(namespace string) error {
+		return interceptor(nil, &fakeServerStream{req: &workflowpkg.WatchWorkflowsRequest{Namespace: namespace}}, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&workflowpkg.WatchWorkflowsRequest{})
+		})
+	}
+	require.NoError(t, recv("my-ns"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(recv("other-ns")))
+}
@@ -14,3 +14,8 @@ func CanI(ctx context.Context, verb, resource, namespace, name string) (bool, er
 	}
 	return allowed, nil
 }
+
+// CanINamed is CanI for the named resource, honouring RBAC rules restricted to it by resourceNames
+func CanINamed(ctx context.Context, verb, resource, namespace, name string) (bool, error) {
+	return authUtil.CanIArgoNamed(ctx, GetKubeClient(ctx), verb, resource, namespace, name)
+}
@@ -0,0 +1,283 @@
+package completionwebhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	// workers is how many completed workflows are posted at once
+	workers = 4
+	// queueSize is how many completed workflows wait to be posted before the watch waits for them
+	queueSize = 1024
+)
+
+// Payload is the JSON posted to a webhook when a workflow completes
+type Payload struct {
+	Namespace  string             `json:"namespace"`
+	Name       string             `json:"name"`
+	UID        string             `json:"uid"`
+	Phase      wfv1.WorkflowPhase `json:"phase"`
+	Message    string             `json:"message,omitempty"`
+	StartedAt  metav1.Time        `json:"startedAt"`
+	FinishedAt metav1.Time        `json:"finishedAt"`
+	Labels     map[string]string  `json:"labels,omitempty"`
+}
+
+type webhook struct {
+	url      string
+	selector labels.Selector
+	retries  int
+}
+
+// completion is a completed workflow to post to the webhooks whose selectors it matches
+type completion struct {
+	payload  Payload
+	webhooks []webhook
+}
+
+// Dispatcher watches workflows and posts those that complete to the webhooks whose selectors they match. Only
+// workflows that complete while it is watching are posted, not those that completed before the server started. Every
+// replica of the server watches, but only the replica that claims a completed workflow, by annotating it, posts it.
+type Dispatcher struct {
+	webhooks []webhook
+	client   *http.Client
+	// backoff is between the retries of a failed post, its steps are set by each webhook's retries
+	backoff wait.Backoff
+	// holder is the value of the claim annotation of the workflows this replica posts
+	holder   string
+	wfClient versioned.Interface
+	queue    chan completion
+}
+
+func NewDispatcher(webhooks []config.CompletionWebhook) (*Dispatcher, error) {
+	holder, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the host name to claim completed workflows with: %w", err)
+	}
+	d := &Dispatcher{
+		client:  &http.Client{Timeout: 10 * time.Second},
+		backoff: wait.Backoff{Duration: time.Second, Factor: 2, Jitter: 0.1, Cap: time.Minute},
+		holder:  holder,
+		queue:   make(chan completion, queueSize),
+	}
+	for _, w := range webhooks {
+		selector, err := labels.Parse(w.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("completion webhook selector %q is invalid: %w", w.Selector, err)
+		}
+		d.webhooks = append(d.webhooks, webhook{url: w.URL, selector: selector, retries: w.GetRetries()})
+	}
+	return d, nil
+}
+
+// Run watches the workflows of the namespace, or all namespaces if it is empty, and posts them until stopCh is
+// closed. It returns once the workflows have been listed.
+func (d *Dispatcher) Run(ctx context.Context, wfClient versioned.Interface, namespace string, instanceIDService instanceid.Service, stopCh <-chan struct{}) error {
+	d.wfClient = wfClient
+	informer := cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			instanceIDService.With(&options)
+			return wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			instanceIDService.With(&options)
+			return wfClient.ArgoprojV1alpha1().Workflows(namespace).Watch(ctx, options)
+		},
+	}, &wfv1.Workflow{}, 0, cache.Indexers{})
+	// only the metadata and phase are needed, so do not keep the nodes and spec of every workflow in memory
+	if err := informer.SetTransform(func(obj any) (any, error) {
+		if wf, ok := obj.(*wfv1.Workflow); ok {
+			wf.Spec = wfv1.WorkflowSpec{}
+			wf.Status = wfv1.WorkflowStatus{Phase: wf.Status.Phase, Message: wf.Status.Message, StartedAt: wf.Status.StartedAt, FinishedAt: wf.Status.FinishedAt}
+			wf.ManagedFields = nil
+		}
+		return obj, nil
+	}); err != nil {
+		return err
+	}
+	_, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj any) {
+			old, ok := oldObj.(*wfv1.Workflow)
+			if !ok {
+				return
+			}
+			wf, ok := newObj.(*wfv1.Workflow)
+			if !ok {
+				return
+			}
+			d.dispatch(ctx, old, wf)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": namespace, "webhooks": len(d.webhooks)}).Info(ctx, "Starting completion webhook dispatcher")
+	for range workers {
+		go d.work(ctx, stopCh)
+	}
+	go informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, informer.HasSynced) {
+		return fmt.Errorf("completion webhook dispatcher stopped before its workflows were listed")
+	}
+	return nil
+}
+
+// dispatch queues the workflow to be posted to its webhooks if it has just completed. It waits while the queue is
+// full, rather than dropping the workflow.
+func (d *Dispatcher) dispatch(ctx context.Context, old, wf *wfv1.Workflow) {
+	if old.Status.Fulfilled() || !wf.Status.Fulfilled() {
+		return
+	}
+	c := completion{payload: Payload{
+		Namespace:  wf.Namespace,
+		Name:       wf.Name,
+		UID:        string(wf.UID),
+		Phase:      wf.Status.Phase,
+		Message:    wf.Status.Message,
+		StartedAt:  wf.Status.StartedAt,
+		FinishedAt: wf.Status.FinishedAt,
+		Labels:     wf.Labels,
+	}}
+	for _, w := range d.webhooks {
+		if w.selector.Matches(labels.Set(wf.Labels)) {
+			c.webhooks = append(c.webhooks, w)
+		}
+	}
+	if len(c.webhooks) == 0 {
+		return
+	}
+	select {
+	case d.queue <- c:
+	case <-ctx.Done():
+	}
+}
+
+// work posts the queued workflows this replica claims until stopCh is closed
+func (d *Dispatcher) work(ctx context.Context, stopCh <-chan struct{}) {
+	for {
+		select {
+		case c := <-d.queue:
+			log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": c.payload.Namespace, "workflow": c.payload.Name})
+			claimed, err := d.claim(ctx, c.payload)
+			if err != nil {
+				log.WithError(err).Error(ctx, "Unable to claim completed workflow to post to completion webhooks")
+				continue
+			}
+			if !claimed {
+				log.Debug(ctx, "Completed workflow is posted to completion webhooks by another replica")
+				continue
+			}
+			for _, w := range c.webhooks {
+				d.post(ctx, w, c.payload)
+			}
+		case <-stopCh:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// claim annotates the workflow as posted by this replica, returning false if another replica already has, or the
+// workflow has since been deleted. The annotation is patched at the workflow's resource version, so that only one
+// replica claims it.
+func (d *Dispatcher) claim(ctx context.Context, payload Payload) (bool, error) {
+	claimed := false
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		wf, err := d.wfClient.ArgoprojV1alpha1().Workflows(payload.Namespace).Get(ctx, payload.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if wf.UID != types.UID(payload.UID) || wf.Annotations[common.AnnotationKeyCompletionWebhookClaim] != "" {
+			return nil
+		}
+		patch, err := json.Marshal(map[string]any{"metadata": map[string]any{
+			"resourceVersion": wf.ResourceVersion,
+			"annotations":     map[string]string{common.AnnotationKeyCompletionWebhookClaim: d.holder},
+		}})
+		if err != nil {
+			return err
+		}
+		_, err = d.wfClient.ArgoprojV1alpha1().Workflows(payload.Namespace).Patch(ctx, payload.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+		claimed = true
+		return nil
+	})
+	if apierr.IsNotFound(err) {
+		return false, nil
+	}
+	return claimed, err
+}
+
+// post posts the payload to the webhook, retrying if it fails
+func (d *Dispatcher) post(ctx context.Context, w webhook, payload Payload) {
+	log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": payload.Namespace, "workflow": payload.Name, "url": w.url})
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.WithError(err).Error(ctx, "Unable to marshal completion webhook payload")
+		return
+	}
+	backoff := d.backoff
+	backoff.Steps = w.retries + 1
+	var lastErr error
+	err = wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		lastErr = d.send(ctx, w.url, body)
+		if lastErr != nil {
+			log.WithError(lastErr).Warn(ctx, "Unable to post to completion webhook, retrying")
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		if lastErr == nil {
+			lastErr = err
+		}
+		log.WithError(lastErr).Error(ctx, "Unable to post to completion webhook, giving up")
+		return
+	}
+	log.Debug(ctx, "Posted to completion webhook")
+}
+
+func (d *Dispatcher) send(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
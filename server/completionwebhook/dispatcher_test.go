@@ -0,0 +1,101 @@
+package completionwebhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestDispatcher(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	payloads := make(chan Payload, 10)
+	failures := 1
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var payload Payload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads <- payload
+	}))
+	defer webhook.Close()
+
+	started := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	newWorkflow := func(name, team string) *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "my-ns", UID: types.UID("uid-" + name), Labels: map[string]string{"team": team}},
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, StartedAt: started},
+		}
+	}
+	// another replica has claimed posting this workflow
+	claimedWf := newWorkflow("claimed-wf", "a")
+	claimedWf.Annotations = map[string]string{common.AnnotationKeyCompletionWebhookClaim: "other-replica"}
+	wfClient := fake.NewSimpleClientset(newWorkflow("my-wf", "a"), newWorkflow("other-wf", "b"), claimedWf)
+	d, err := NewDispatcher([]config.CompletionWebhook{{URL: webhook.URL, Selector: "team=a", Retries: ptr.To(1)}})
+	require.NoError(t, err)
+	d.backoff.Duration = time.Millisecond
+	require.NoError(t, d.Run(ctx, wfClient, "my-ns", instanceid.NewService(""), ctx.Done()))
+
+	complete := func(name string) {
+		wf, err := wfClient.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, name, metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Phase = wfv1.WorkflowFailed
+		wf.Status.Message = "child failed"
+		wf.Status.FinishedAt = metav1.NewTime(started.Add(time.Minute))
+		_, err = wfClient.ArgoprojV1alpha1().Workflows("my-ns").Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+	}
+	complete("other-wf")
+	complete("claimed-wf")
+	complete("my-wf")
+
+	select {
+	case payload := <-payloads:
+		assert.Equal(t, "my-ns", payload.Namespace)
+		assert.Equal(t, "my-wf", payload.Name)
+		assert.Equal(t, "uid-my-wf", payload.UID)
+		assert.Equal(t, wfv1.WorkflowFailed, payload.Phase)
+		assert.Equal(t, "child failed", payload.Message)
+		assert.True(t, started.Equal(&payload.StartedAt))
+		assert.Equal(t, time.Minute, payload.FinishedAt.Sub(payload.StartedAt.Time))
+		assert.Equal(t, map[string]string{"team": "a"}, payload.Labels)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the webhook was not posted to")
+	}
+	// completing is only posted once, and neither the workflow that does not match the selector nor the one claimed by
+	// another replica is posted
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows("my-ns").Get(ctx, "my-wf", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, d.holder, wf.Annotations[common.AnnotationKeyCompletionWebhookClaim])
+	wf.Labels["updated"] = "true"
+	_, err = wfClient.ArgoprojV1alpha1().Workflows("my-ns").Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	select {
+	case payload := <-payloads:
+		t.Fatalf("unexpected post of %s", payload.Name)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNewDispatcher(t *testing.T) {
+	_, err := NewDispatcher([]config.CompletionWebhook{{URL: "http://localhost", Selector: "team in (a"}})
+	require.Error(t, err)
+}
@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// Causes of failing to hydrate offloaded node status
+const (
+	HydrationFailed    = "HydrationFailed"
+	OffloadDataMissing = "OffloadDataMissing"
+)
+
+func addOffloadHydrationFailureCounter(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentOffloadHydrationFailures)
+}
+
+// OffloadHydrationFailed records a failure to hydrate the offloaded node status of a workflow during the named
+// server operation. It is safe to call on nil Metrics, such as when the server is embedded in the CLI.
+func (m *Metrics) OffloadHydrationFailed(ctx context.Context, operation, cause string) {
+	if m == nil {
+		return
+	}
+	m.AddInt(ctx, telemetry.InstrumentOffloadHydrationFailures.Name(), 1, telemetry.InstAttribs{
+		{Name: telemetry.AttribServerOperation, Value: operation},
+		{Name: telemetry.AttribHydrationFailureCause, Value: cause},
+	})
+}
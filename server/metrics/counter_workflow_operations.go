@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"context"
+
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// maxOperationNamespaces bounds the number of namespaces the workflow operations counter records, as each is a time series
+const maxOperationNamespaces = 100
+
+// OtherNamespace is recorded as the namespace of workflow operations once maxOperationNamespaces have been recorded
+const OtherNamespace = "other"
+
+func addWorkflowOperationsCounter(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentWorkflowOperations)
+}
+
+// WorkflowOperation records an operation on a workflow, such as RetryWorkflow, with the gRPC status code of the error
+// it returned as its outcome. It is safe to call on nil Metrics, such as when the server is embedded in the CLI.
+func (m *Metrics) WorkflowOperation(ctx context.Context, operation, namespace string, err error) {
+	if m == nil {
+		return
+	}
+	m.AddInt(ctx, telemetry.InstrumentWorkflowOperations.Name(), 1, telemetry.InstAttribs{
+		{Name: telemetry.AttribServerOperation, Value: operation},
+		{Name: telemetry.AttribWorkflowNamespace, Value: m.operationNamespace(namespace)},
+		{Name: telemetry.AttribOperationOutcome, Value: status.Code(err).String()},
+	})
+}
+
+func (m *Metrics) operationNamespace(namespace string) string {
+	m.operationNamespacesMu.Lock()
+	defer m.operationNamespacesMu.Unlock()
+	if !m.operationNamespaces[namespace] {
+		if len(m.operationNamespaces) >= maxOperationNamespaces {
+			return OtherNamespace
+		}
+		m.operationNamespaces[namespace] = true
+	}
+	return namespace
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func TestWorkflowOperation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	m, te, err := CreateDefaultTestMetrics(ctx)
+	require.NoError(t, err)
+	count := func(namespace, outcome string) int64 {
+		attribs := attribute.NewSet(
+			attribute.String(telemetry.AttribServerOperation, "RetryWorkflow"),
+			attribute.String(telemetry.AttribWorkflowNamespace, namespace),
+			attribute.String(telemetry.AttribOperationOutcome, outcome),
+		)
+		val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentWorkflowOperations.Name(), &attribs)
+		require.NoError(t, err)
+		return val
+	}
+	t.Run("Outcome", func(t *testing.T) {
+		m.WorkflowOperation(ctx, "RetryWorkflow", "ns-0", nil)
+		m.WorkflowOperation(ctx, "RetryWorkflow", "ns-0", status.Error(codes.NotFound, "not found"))
+		m.WorkflowOperation(ctx, "RetryWorkflow", "ns-0", status.Error(codes.NotFound, "not found"))
+		assert.Equal(t, int64(1), count("ns-0", "OK"))
+		assert.Equal(t, int64(2), count("ns-0", "NotFound"))
+	})
+	t.Run("BoundedNamespaces", func(t *testing.T) {
+		for i := range maxOperationNamespaces + 2 {
+			m.WorkflowOperation(ctx, "RetryWorkflow", fmt.Sprintf("ns-%d", i), nil)
+		}
+		assert.Equal(t, int64(2), count("ns-0", "OK"))
+		assert.Equal(t, int64(1), count(fmt.Sprintf("ns-%d", maxOperationNamespaces-1), "OK"))
+		assert.Equal(t, int64(2), count(OtherNamespace, "OK"))
+		// namespaces recorded before the bound was reached still are
+		m.WorkflowOperation(ctx, "RetryWorkflow", "ns-1", nil)
+		assert.Equal(t, int64(2), count("ns-1", "OK"))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var m *Metrics
+		m.WorkflowOperation(ctx, "RetryWorkflow", "ns-0", nil)
+	})
+}
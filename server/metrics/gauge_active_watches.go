@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func addActiveWatchesGauge(_ context.Context, m *Metrics) error {
+	return m.CreateBuiltinInstrument(telemetry.InstrumentActiveWatches)
+}
+
+// WatchStarted records that a watch stream of the named server operation has been opened.
+// It is safe to call on nil Metrics, such as when the server is embedded in the CLI.
+func (m *Metrics) WatchStarted(ctx context.Context, operation string) {
+	m.addActiveWatches(ctx, operation, 1)
+}
+
+// WatchFinished records that a watch stream of the named server operation has been closed.
+func (m *Metrics) WatchFinished(ctx context.Context, operation string) {
+	m.addActiveWatches(ctx, operation, -1)
+}
+
+func (m *Metrics) addActiveWatches(ctx context.Context, operation string, val int64) {
+	if m == nil {
+		return
+	}
+	m.AddInt(ctx, telemetry.InstrumentActiveWatches.Name(), val, telemetry.InstAttribs{
+		{Name: telemetry.AttribServerOperation, Value: operation},
+	})
+}
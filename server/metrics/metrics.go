@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// Metrics are the metrics emitted by the Argo Server, which are served by its /metrics endpoint
+type Metrics struct {
+	*telemetry.Metrics
+
+	// the namespaces recorded by the workflow operations counter, which is bounded by maxOperationNamespaces
+	operationNamespacesMu sync.Mutex
+	operationNamespaces   map[string]bool
+}
+
+func New(ctx context.Context, serviceName, prometheusName string, config *telemetry.Config, extraOpts ...metricsdk.Option) (*Metrics, error) {
+	m, err := telemetry.NewMetrics(ctx, serviceName, prometheusName, config, extraOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &Metrics{
+		Metrics:             m,
+		operationNamespaces: map[string]bool{},
+	}
+
+	err = metrics.populate(ctx,
+		addActiveWatchesGauge,
+		addOffloadHydrationFailureCounter,
+		addWorkflowOperationsCounter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+type addMetric func(context.Context, *Metrics) error
+
+func (m *Metrics) populate(ctx context.Context, adders ...addMetric) error {
+	for _, adder := range adders {
+		if err := adder(ctx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/sdk/metric"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// CreateDefaultTestMetrics creates metrics with a test exporter, suitable for many tests
+func CreateDefaultTestMetrics(ctx context.Context) (*Metrics, *telemetry.TestMetricsExporter, error) {
+	te := telemetry.NewTestMetricsExporter()
+	m, err := New(ctx, telemetry.TestScopeName, telemetry.TestScopeName, &telemetry.Config{Enabled: true}, metric.WithReader(te))
+	return m, te, err
+}
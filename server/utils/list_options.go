@@ -18,9 +18,105 @@ type ListOptions struct {
 	MinStartedAt, MaxStartedAt   time.Time
 	CreatedAfter, FinishedBefore time.Time
 	LabelRequirements            labels.Requirements
-	Limit, Offset                int
-	ShowRemainingItemCount       bool
-	StartedAtAscending           bool
+	// only list workflows in one of these phases, any phase if empty
+	Phases []string
+	// only list workflows that ran for at least and at most these durations, if they are not zero
+	MinDuration, MaxDuration time.Duration
+	Limit, Offset            int
+	ShowRemainingItemCount   bool
+	StartedAtAscending       bool
+	OrderBy                  OrderBy
+	// After, if not nil, only lists the workflows after it, in order of when they started, so that workflows can be
+	// paged through without an offset
+	After *Cursor
+}
+
+// Cursor is the last workflow of a page of workflows listed in order of when they started, then their UID, which
+// breaks ties
+type Cursor struct {
+	StartedAt time.Time
+	UID       string
+}
+
+const (
+	OrderByStartedAt  = "startedAt"
+	OrderByFinishedAt = "finishedAt"
+	OrderByName       = "name"
+)
+
+// orderByColumns maps the fields that can be ordered by to their database column.
+var orderByColumns = map[string]string{
+	OrderByStartedAt:  "startedat",
+	OrderByFinishedAt: "finishedat",
+	OrderByName:       "name",
+}
+
+// OrderBy is the order in which workflows are listed. The zero value is the default order.
+type OrderBy struct {
+	Field     string
+	Ascending bool
+}
+
+// ParseOrderBy parses an order such as "startedAt desc" or "name asc". If the direction is omitted,
+// timestamps are ordered descending and names ascending.
+func ParseOrderBy(orderBy string) (OrderBy, error) {
+	parts := strings.Fields(orderBy)
+	if len(parts) == 0 {
+		return OrderBy{}, nil
+	}
+	if len(parts) > 2 {
+		return OrderBy{}, status.Errorf(codes.InvalidArgument, "orderBy %q must be a field optionally followed by asc or desc", orderBy)
+	}
+	field := parts[0]
+	if _, ok := orderByColumns[field]; !ok {
+		return OrderBy{}, status.Errorf(codes.InvalidArgument, "orderBy field %q is not one of %s, %s or %s", field, OrderByStartedAt, OrderByFinishedAt, OrderByName)
+	}
+	ascending := field == OrderByName
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+			ascending = true
+		case "desc":
+			ascending = false
+		default:
+			return OrderBy{}, status.Errorf(codes.InvalidArgument, "orderBy direction %q must be asc or desc", parts[1])
+		}
+	}
+	return OrderBy{Field: field, Ascending: ascending}, nil
+}
+
+// Column returns the database column to order by, or an empty string for the default order.
+func (o OrderBy) Column() string {
+	return orderByColumns[o.Field]
+}
+
+// WithDurations parses the minimum and maximum durations, such as "10m", either of which may be empty.
+func (l ListOptions) WithDurations(minDuration, maxDuration string) (ListOptions, error) {
+	var err error
+	if l.MinDuration, err = parseDuration("minDuration", minDuration); err != nil {
+		return l, err
+	}
+	if l.MaxDuration, err = parseDuration("maxDuration", maxDuration); err != nil {
+		return l, err
+	}
+	if l.MaxDuration > 0 && l.MinDuration > l.MaxDuration {
+		return l, status.Errorf(codes.InvalidArgument, "minDuration %v must not be greater than maxDuration %v", l.MinDuration, l.MaxDuration)
+	}
+	return l, nil
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "%s %q is not a duration: %v", name, value, err)
+	}
+	if d < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%s %q must not be negative", name, value)
+	}
+	return d, nil
 }
 
 func (l ListOptions) WithLimit(limit int) ListOptions {
@@ -33,6 +129,11 @@ func (l ListOptions) WithOffset(offset int) ListOptions {
 	return l
 }
 
+func (l ListOptions) WithAfter(after *Cursor) ListOptions {
+	l.After = after
+	return l
+}
+
 func (l ListOptions) WithShowRemainingItemCount(showRemainingItemCount bool) ListOptions {
 	l.ShowRemainingItemCount = showRemainingItemCount
 	return l
@@ -53,6 +154,11 @@ func (l ListOptions) WithStartedAtAscending(ascending bool) ListOptions {
 	return l
 }
 
+func (l ListOptions) WithOrderBy(orderBy OrderBy) ListOptions {
+	l.OrderBy = orderBy
+	return l
+}
+
 func BuildListOptions(options metav1.ListOptions, ns, namePrefix, nameFilter, createdAfter, finishedBefore string) (ListOptions, error) {
 	if options.Continue == "" {
 		options.Continue = "0"
@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	for orderBy, expected := range map[string]OrderBy{
+		"":                {},
+		"startedAt":       {Field: OrderByStartedAt},
+		"startedAt asc":   {Field: OrderByStartedAt, Ascending: true},
+		"finishedAt DESC": {Field: OrderByFinishedAt},
+		"finishedAt asc":  {Field: OrderByFinishedAt, Ascending: true},
+		"name":            {Field: OrderByName, Ascending: true},
+		" name  desc ":    {Field: OrderByName},
+	} {
+		t.Run(orderBy, func(t *testing.T) {
+			actual, err := ParseOrderBy(orderBy)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+	for _, orderBy := range []string{"phase", "name up", "name asc desc"} {
+		t.Run(orderBy, func(t *testing.T) {
+			_, err := ParseOrderBy(orderBy)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+	assert.Equal(t, "finishedat", OrderBy{Field: OrderByFinishedAt}.Column())
+	assert.Empty(t, OrderBy{}.Column())
+}
+
+func TestListOptions_WithDurations(t *testing.T) {
+	options, err := ListOptions{}.WithDurations("10m", "1h")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, options.MinDuration)
+	assert.Equal(t, time.Hour, options.MaxDuration)
+	options, err = ListOptions{}.WithDurations("", "")
+	require.NoError(t, err)
+	assert.Zero(t, options.MinDuration)
+	assert.Zero(t, options.MaxDuration)
+	for _, durations := range [][2]string{{"10", ""}, {"", "-1m"}, {"1h", "10m"}} {
+		t.Run(durations[0]+" "+durations[1], func(t *testing.T) {
+			_, err := ListOptions{}.WithDurations(durations[0], durations[1])
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/fields"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+// ValidateRetryOptions returns an InvalidArgument error if the node field selector of a retry is invalid. Setting
+// restartSuccessful without a selector restarts no successful nodes, so it is deprecated, but it is still accepted, with
+// a warning, as older clients set it by default.
+func ValidateRetryOptions(ctx context.Context, restartSuccessful bool, nodeFieldSelector string) error {
+	if nodeFieldSelector != "" {
+		if _, err := fields.ParseSelector(nodeFieldSelector); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid nodeFieldSelector %q: %v", nodeFieldSelector, err)
+		}
+	}
+	if restartSuccessful && nodeFieldSelector == "" {
+		logging.RequireLoggerFromContext(ctx).Warn(ctx, "restartSuccessful without a nodeFieldSelector is deprecated and restarts no successful nodes, set a nodeFieldSelector to choose them, e.g. templateName=my-template")
+	}
+	return nil
+}
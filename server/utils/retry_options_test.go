@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestValidateRetryOptions(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, ValidateRetryOptions(ctx, false, ""))
+	require.NoError(t, ValidateRetryOptions(ctx, true, "templateName=my-template"))
+	require.NoError(t, ValidateRetryOptions(ctx, false, "templateName=my-template"))
+	require.NoError(t, ValidateRetryOptions(ctx, true, ""), "deprecated, but still accepted")
+	err := ValidateRetryOptions(ctx, true, "templateName")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), `invalid nodeFieldSelector "templateName"`)
+}
@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"context"
+	"sort"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+// workflowVerbs is the known set of verbs a user may be allowed on a workflow, each mapped to the RBAC verb it requires
+// on the workflow. Those that change the workflow require update, and resubmit requires create, as it creates a new one.
+var workflowVerbs = map[string]string{
+	"get":       "get",
+	"delete":    "delete",
+	"retry":     "update",
+	"resume":    "update",
+	"suspend":   "update",
+	"stop":      "update",
+	"terminate": "update",
+	"set":       "update",
+	"resubmit":  "create",
+}
+
+// allowedWorkflowVerbs returns the sorted verbs the user is allowed on the workflow. Each RBAC verb is only checked once.
+func allowedWorkflowVerbs(ctx context.Context, wf *wfv1.Workflow) ([]string, error) {
+	allowedRBACVerbs := map[string]bool{}
+	verbs := []string{}
+	for verb, rbacVerb := range workflowVerbs {
+		allowed, checked := allowedRBACVerbs[rbacVerb]
+		if !checked {
+			name := wf.Name
+			if rbacVerb == "create" {
+				// the new workflow's name is not known yet
+				name = ""
+			}
+			var err error
+			allowed, err = auth.CanINamed(ctx, rbacVerb, workflow.WorkflowPlural, wf.Namespace, name)
+			if err != nil {
+				return nil, err
+			}
+			allowedRBACVerbs[rbacVerb] = allowed
+		}
+		if allowed {
+			verbs = append(verbs, verb)
+		}
+	}
+	sort.Strings(verbs)
+	return verbs, nil
+}
@@ -0,0 +1,67 @@
+package workflow
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestAllowedWorkflowVerbs(t *testing.T) {
+	allowedVerbs := func(t *testing.T, allowed ...string) ([]string, []authorizationv1.ResourceAttributes) {
+		t.Helper()
+		var reviews []authorizationv1.ResourceAttributes
+		kubeClient := fake.NewSimpleClientset()
+		kubeClient.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (bool, runtime.Object, error) {
+			attributes := *action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+			reviews = append(reviews, attributes)
+			return true, &authorizationv1.SelfSubjectAccessReview{
+				Status: authorizationv1.SubjectAccessReviewStatus{Allowed: slices.Contains(allowed, attributes.Verb)},
+			}, nil
+		})
+		ctx := context.WithValue(logging.TestContext(t.Context()), auth.KubeKey, kubeClient)
+		verbs, err := allowedWorkflowVerbs(ctx, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}})
+		require.NoError(t, err)
+		return verbs, reviews
+	}
+	t.Run("ReadOnly", func(t *testing.T) {
+		verbs, reviews := allowedVerbs(t, "get")
+		assert.Equal(t, []string{"get"}, verbs)
+		// each RBAC verb is only reviewed once
+		assert.Len(t, reviews, 4)
+		for _, review := range reviews {
+			assert.Equal(t, "my-ns", review.Namespace)
+			assert.Equal(t, "workflows", review.Resource)
+		}
+	})
+	t.Run("Update", func(t *testing.T) {
+		verbs, _ := allowedVerbs(t, "get", "update")
+		assert.Equal(t, []string{"get", "resume", "retry", "set", "stop", "suspend", "terminate"}, verbs)
+	})
+	t.Run("All", func(t *testing.T) {
+		verbs, reviews := allowedVerbs(t, "get", "update", "create", "delete")
+		assert.Equal(t, []string{"delete", "get", "resubmit", "resume", "retry", "set", "stop", "suspend", "terminate"}, verbs)
+		for _, review := range reviews {
+			if review.Verb == "create" {
+				assert.Empty(t, review.Name)
+			} else {
+				assert.Equal(t, "my-wf", review.Name)
+			}
+		}
+	})
+	t.Run("None", func(t *testing.T) {
+		verbs, _ := allowedVerbs(t)
+		assert.Empty(t, verbs)
+	})
+}
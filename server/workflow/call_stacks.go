@@ -0,0 +1,48 @@
+package workflow
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// callStacks returns the chain of templates that led to each node, outermost first and ending with the node's own
+// template. Each node is within the steps or DAG node of its boundary ID, so the chain is that of its boundary node
+// followed by its own template. Templates referenced from workflow templates are named "<workflow template>/<template>".
+// Nodes of no template of their own, such as step groups, have the chain of their boundary node.
+func callStacks(nodes wfv1.Nodes) map[string][]string {
+	stacks := make(map[string][]string, len(nodes))
+	visiting := make(map[string]bool)
+	var stackOf func(id string) []string
+	stackOf = func(id string) []string {
+		if stack, ok := stacks[id]; ok {
+			return stack
+		}
+		node, ok := nodes[id]
+		// the boundary node may be missing, e.g. if the workflow was partially offloaded, and a cycle is never
+		// expected but would otherwise not terminate, so the chain starts here in either case
+		if !ok || visiting[id] {
+			return nil
+		}
+		visiting[id] = true
+		var stack []string
+		if node.BoundaryID != "" {
+			stack = append(stack, stackOf(node.BoundaryID)...)
+		}
+		if name := callStackTemplateName(node); name != "" {
+			stack = append(stack, name)
+		}
+		delete(visiting, id)
+		stacks[id] = stack
+		return stack
+	}
+	for id := range nodes {
+		stackOf(id)
+	}
+	return stacks
+}
+
+func callStackTemplateName(node wfv1.NodeStatus) string {
+	if ref := node.TemplateRef; ref != nil {
+		return ref.Name + "/" + ref.Template
+	}
+	return node.TemplateName
+}
@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+// nestedNodes are those of main (steps) -> group -> fan-out (DAG) -> process (steps from a workflow template) ->
+// group -> retry -> echo (pod)
+var nestedNodes = wfv1.Nodes{
+	"wf":            {ID: "wf", Type: wfv1.NodeTypeSteps, TemplateName: "main"},
+	"wf-group":      {ID: "wf-group", Type: wfv1.NodeTypeStepGroup, BoundaryID: "wf"},
+	"fan-out":       {ID: "fan-out", Type: wfv1.NodeTypeDAG, TemplateName: "fan-out", BoundaryID: "wf"},
+	"process":       {ID: "process", Type: wfv1.NodeTypeSteps, TemplateRef: &wfv1.TemplateRef{Name: "library", Template: "process"}, BoundaryID: "fan-out"},
+	"process-group": {ID: "process-group", Type: wfv1.NodeTypeStepGroup, BoundaryID: "process"},
+	"retry":         {ID: "retry", Type: wfv1.NodeTypeRetry, TemplateName: "echo", BoundaryID: "process"},
+	"echo":          {ID: "echo", Type: wfv1.NodeTypePod, TemplateName: "echo", BoundaryID: "process"},
+}
+
+func TestCallStacks(t *testing.T) {
+	t.Run("Nested", func(t *testing.T) {
+		stacks := callStacks(nestedNodes)
+		assert.Len(t, stacks, len(nestedNodes))
+		assert.Equal(t, []string{"main"}, stacks["wf"])
+		assert.Equal(t, []string{"main"}, stacks["wf-group"])
+		assert.Equal(t, []string{"main", "fan-out"}, stacks["fan-out"])
+		assert.Equal(t, []string{"main", "fan-out", "library/process"}, stacks["process-group"])
+		assert.Equal(t, []string{"main", "fan-out", "library/process", "echo"}, stacks["retry"])
+		assert.Equal(t, []string{"main", "fan-out", "library/process", "echo"}, stacks["echo"])
+	})
+	t.Run("MissingBoundary", func(t *testing.T) {
+		stacks := callStacks(wfv1.Nodes{"echo": {ID: "echo", TemplateName: "echo", BoundaryID: "gone"}})
+		assert.Equal(t, []string{"echo"}, stacks["echo"])
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		stacks := callStacks(wfv1.Nodes{
+			"a": {ID: "a", TemplateName: "a", BoundaryID: "b"},
+			"b": {ID: "b", TemplateName: "b", BoundaryID: "a"},
+		})
+		assert.Len(t, stacks, 2)
+	})
+}
+
+func TestGetWorkflowCallStacks(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+	wf, err := wfClient.Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Status.Nodes = nestedNodes
+	_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	stacks, err := server.GetWorkflowCallStacks(ctx, &workflowpkg.WorkflowCallStacksRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "fan-out", "library/process", "echo"}, stacks.Nodes["echo"].Templates)
+}
@@ -0,0 +1,33 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+const (
+	cloudEventSpecVersion = "1.0"
+	cloudEventTypePrefix  = "io.argoproj.workflow."
+)
+
+// newCloudEvent wraps a watch event of the workflow as a CloudEvent. The id and subject are taken from the workflow as
+// it was received, as data may have had those fields cleaned from it.
+func newCloudEvent(eventType watch.EventType, wf *wfv1.Workflow, data *wfv1.Workflow) *workflowpkg.CloudEvent {
+	now := metav1.Now()
+	return &workflowpkg.CloudEvent{
+		Specversion:     cloudEventSpecVersion,
+		Id:              fmt.Sprintf("%s/%s", wf.UID, wf.ResourceVersion),
+		Source:          "/api/v1/workflows/" + wf.Namespace,
+		Type:            cloudEventTypePrefix + strings.ToLower(string(eventType)),
+		Subject:         wf.Name,
+		Time:            &now,
+		Datacontenttype: "application/json",
+		Data:            data,
+	}
+}
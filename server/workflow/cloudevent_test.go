@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestNewCloudEvent(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", UID: "my-uid", ResourceVersion: "123"},
+		Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, Nodes: wfv1.Nodes{"my-wf": {ID: "my-wf", Phase: wfv1.NodeRunning}}},
+	}
+	event := newCloudEvent(watch.Modified, wf, wf)
+	assert.Equal(t, "1.0", event.Specversion)
+	assert.Equal(t, "my-uid/123", event.Id)
+	assert.Equal(t, "/api/v1/workflows/my-ns", event.Source)
+	assert.Equal(t, "io.argoproj.workflow.modified", event.Type)
+	assert.Equal(t, "my-wf", event.Subject)
+	assert.False(t, event.Time.IsZero())
+	assert.Equal(t, "application/json", event.Datacontenttype)
+
+	t.Run("CleanedData", func(t *testing.T) {
+		// the id and subject do not depend on the fields the client asked for
+		event := newCloudEvent(watch.Added, wf, &wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning}})
+		assert.Equal(t, "my-uid/123", event.Id)
+		assert.Equal(t, "my-wf", event.Subject)
+		assert.Equal(t, "io.argoproj.workflow.added", event.Type)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(&workflowpkg.WorkflowWatchEvent{CloudEvent: event})
+		require.NoError(t, err)
+		// the envelope is in the structured format, with the workflow as JSON data
+		var structured map[string]map[string]any
+		require.NoError(t, json.Unmarshal(data, &structured))
+		assert.Equal(t, "my-wf", structured["cloudEvent"]["subject"])
+		assert.Equal(t, "my-wf", structured["cloudEvent"]["data"].(map[string]any)["metadata"].(map[string]any)["name"])
+		var roundTripped workflowpkg.WorkflowWatchEvent
+		require.NoError(t, json.Unmarshal(data, &roundTripped))
+		assert.Equal(t, wf, roundTripped.CloudEvent.Data)
+		assert.Equal(t, event.Id, roundTripped.CloudEvent.Id)
+	})
+	t.Run("Protobuf", func(t *testing.T) {
+		data, err := event.Marshal()
+		require.NoError(t, err)
+		roundTripped := &workflowpkg.CloudEvent{}
+		require.NoError(t, roundTripped.Unmarshal(data))
+		assert.Equal(t, wf, roundTripped.Data)
+		assert.Equal(t, event.Type, roundTripped.Type)
+		assert.Equal(t, event.Time.Unix(), roundTripped.Time.Unix())
+	})
+}
@@ -0,0 +1,135 @@
+package workflow
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// costEstimateHistoryLimit is how many of the most recent archived runs of a workflow its cost estimate is based on
+const costEstimateHistoryLimit = 10
+
+// costEstimateHistoryOptions returns the options to list the archived, successful, runs of the workflow: those of the
+// same workflow template, cluster workflow template or cron workflow, or else with the same generated name.
+// It returns nil if there is no way to tell which archived workflows are runs of the workflow.
+func costEstimateHistoryOptions(namespace string, wf *wfv1.Workflow) (*sutils.ListOptions, error) {
+	options := &sutils.ListOptions{Namespace: namespace, Phases: []string{string(wfv1.WorkflowSucceeded)}, Limit: costEstimateHistoryLimit}
+	selector := ""
+	for _, key := range []string{common.LabelKeyWorkflowTemplate, common.LabelKeyClusterWorkflowTemplate, common.LabelKeyCronWorkflow} {
+		if value, ok := wf.Labels[key]; ok {
+			selector = key + "=" + value
+			break
+		}
+	}
+	// a workflow submitted from a template is only labelled once created, so we label it the same way here
+	if ref := wf.Spec.WorkflowTemplateRef; selector == "" && ref != nil {
+		if ref.ClusterScope {
+			selector = common.LabelKeyClusterWorkflowTemplate + "=" + ref.Name
+		} else {
+			selector = common.LabelKeyWorkflowTemplate + "=" + ref.Name
+		}
+	}
+	switch {
+	case selector != "":
+		requirements, err := labels.ParseToRequirements(selector)
+		if err != nil {
+			return nil, err
+		}
+		options.LabelRequirements = requirements
+	case wf.GenerateName != "":
+		options.NamePrefix = wf.GenerateName
+	default:
+		return nil, nil
+	}
+	return options, nil
+}
+
+// templateRequests sums the resources requested by the template's containers, including its sidecars.
+func templateRequests(tmpl wfv1.Template) corev1.ResourceList {
+	var containers []corev1.Container
+	if tmpl.Container != nil {
+		containers = append(containers, *tmpl.Container)
+	}
+	if tmpl.Script != nil {
+		containers = append(containers, tmpl.Script.Container)
+	}
+	if tmpl.ContainerSet != nil {
+		containers = append(containers, tmpl.ContainerSet.GetContainers()...)
+	}
+	for _, sidecar := range tmpl.Sidecars {
+		containers = append(containers, sidecar.Container)
+	}
+	requests := corev1.ResourceList{}
+	for _, c := range containers {
+		for name, quantity := range c.Resources.Requests {
+			sum := requests[name]
+			sum.Add(quantity)
+			requests[name] = sum
+		}
+	}
+	return requests
+}
+
+// templateDurations returns how long the finished pods of each template ran for in total, e.g. a template run by
+// three steps of a minute each ran for three minutes.
+func templateDurations(nodes wfv1.Nodes) map[string]time.Duration {
+	durations := map[string]time.Duration{}
+	for _, node := range nodes {
+		if node.Type != wfv1.NodeTypePod || node.TemplateName == "" || node.StartedAt.IsZero() || node.FinishedAt.IsZero() {
+			continue
+		}
+		durations[node.TemplateName] += node.FinishedAt.Sub(node.StartedAt.Time)
+	}
+	return durations
+}
+
+// workflowCostEstimate estimates the cost of the templates that request resources, by multiplying their requests by
+// how long they ran for, on average, in the (hydrated) archived workflows. Templates that never ran in them are
+// estimated to cost nothing, but are still listed with their requests.
+func workflowCostEstimate(templates []wfv1.Template, history []*wfv1.Workflow) *workflowpkg.WorkflowCostEstimate {
+	totals := map[string]time.Duration{}
+	runs := map[string]int64{}
+	for _, wf := range history {
+		for name, d := range templateDurations(wf.Status.Nodes) {
+			totals[name] += d
+			runs[name]++
+		}
+	}
+	estimate := &workflowpkg.WorkflowCostEstimate{
+		Templates:         []*workflowpkg.TemplateCostEstimate{},
+		ResourcesDuration: map[string]int64{},
+		Workflows:         int64(len(history)),
+	}
+	seen := map[string]bool{}
+	for _, tmpl := range templates {
+		requests := templateRequests(tmpl)
+		if len(requests) == 0 || seen[tmpl.Name] {
+			continue
+		}
+		seen[tmpl.Name] = true
+		t := &workflowpkg.TemplateCostEstimate{
+			Template:          tmpl.Name,
+			Requests:          map[string]string{},
+			Runs:              runs[tmpl.Name],
+			ResourcesDuration: map[string]int64{},
+		}
+		if t.Runs > 0 {
+			t.AverageDurationSeconds = int64((totals[tmpl.Name] / time.Duration(t.Runs)).Seconds())
+		}
+		for name, quantity := range requests {
+			// the same units as util/resource, so the estimate can be compared with the resources duration of a run
+			d := quantity.MilliValue() * t.AverageDurationSeconds / wfv1.ResourceQuantityDenominator(name).MilliValue()
+			t.Requests[string(name)] = quantity.String()
+			t.ResourcesDuration[string(name)] = d
+			estimate.ResourcesDuration[string(name)] += d
+		}
+		estimate.Templates = append(estimate.Templates, t)
+	}
+	return estimate
+}
@@ -0,0 +1,115 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func requesting(cpu, memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+	}}
+}
+
+func podNode(templateName string, d time.Duration) wfv1.NodeStatus {
+	startedAt := metav1.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return wfv1.NodeStatus{Type: wfv1.NodeTypePod, TemplateName: templateName, StartedAt: startedAt, FinishedAt: metav1.NewTime(startedAt.Add(d))}
+}
+
+func TestCostEstimateHistoryOptions(t *testing.T) {
+	t.Run("WorkflowTemplateLabel", func(t *testing.T) {
+		options, err := costEstimateHistoryOptions("my-ns", &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: "my-wf-", Labels: map[string]string{"workflows.argoproj.io/workflow-template": "my-wftmpl"}}})
+		require.NoError(t, err)
+		assert.Equal(t, "my-ns", options.Namespace)
+		assert.Equal(t, []string{"Succeeded"}, options.Phases)
+		assert.Equal(t, costEstimateHistoryLimit, options.Limit)
+		require.Len(t, options.LabelRequirements, 1)
+		assert.Equal(t, "workflows.argoproj.io/workflow-template=my-wftmpl", options.LabelRequirements[0].String())
+		assert.Empty(t, options.NamePrefix)
+	})
+	t.Run("ClusterWorkflowTemplateRef", func(t *testing.T) {
+		options, err := costEstimateHistoryOptions("my-ns", &wfv1.Workflow{Spec: wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-cwftmpl", ClusterScope: true}}})
+		require.NoError(t, err)
+		require.Len(t, options.LabelRequirements, 1)
+		assert.Equal(t, "workflows.argoproj.io/cluster-workflow-template=my-cwftmpl", options.LabelRequirements[0].String())
+	})
+	t.Run("GenerateName", func(t *testing.T) {
+		options, err := costEstimateHistoryOptions("my-ns", &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{GenerateName: "my-wf-"}})
+		require.NoError(t, err)
+		assert.Equal(t, "my-wf-", options.NamePrefix)
+		assert.Empty(t, options.LabelRequirements)
+	})
+	t.Run("NoHistory", func(t *testing.T) {
+		options, err := costEstimateHistoryOptions("my-ns", &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}})
+		require.NoError(t, err)
+		assert.Nil(t, options)
+	})
+}
+
+func TestTemplateRequests(t *testing.T) {
+	requests := templateRequests(wfv1.Template{
+		Container: &corev1.Container{Resources: requesting("500m", "1Gi")},
+		Sidecars:  []wfv1.UserContainer{{Container: corev1.Container{Resources: requesting("250m", "512Mi")}}},
+	})
+	assert.Equal(t, "750m", requests.Cpu().String())
+	assert.Equal(t, "1536Mi", requests.Memory().String())
+	assert.Empty(t, templateRequests(wfv1.Template{Container: &corev1.Container{}}))
+}
+
+func TestWorkflowCostEstimate(t *testing.T) {
+	templates := []wfv1.Template{
+		{Name: "main", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{{Name: "a", Template: "build"}, {Name: "b", Template: "test"}}}}},
+		{Name: "build", Container: &corev1.Container{Resources: requesting("2", "1Gi")}},
+		{Name: "test", Script: &wfv1.ScriptTemplate{Container: corev1.Container{Resources: requesting("500m", "200Mi")}}},
+		{Name: "deploy", Container: &corev1.Container{Resources: requesting("1", "100Mi")}},
+	}
+	history := []*wfv1.Workflow{
+		{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"main":   {Type: wfv1.NodeTypeSteps, TemplateName: "main"},
+			"build":  podNode("build", 10*time.Minute),
+			"test-1": podNode("test", time.Minute),
+			"test-2": podNode("test", 2*time.Minute),
+		}}},
+		{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+			"build":   podNode("build", 20*time.Minute),
+			"test-1":  podNode("test", 3*time.Minute),
+			"running": {Type: wfv1.NodeTypePod, TemplateName: "test", StartedAt: metav1.Now()},
+		}}},
+	}
+	estimate := workflowCostEstimate(templates, history)
+	assert.Equal(t, int64(2), estimate.Workflows)
+	require.Len(t, estimate.Templates, 3)
+	// build ran for 10m and 20m, so 15m on average, with 2 CPU and 1Gi (~10*100Mi)
+	assert.Equal(t, &workflowpkg.TemplateCostEstimate{
+		Template:               "build",
+		Requests:               map[string]string{"cpu": "2", "memory": "1Gi"},
+		Runs:                   2,
+		AverageDurationSeconds: 900,
+		ResourcesDuration:      map[string]int64{"cpu": 1800, "memory": 9216},
+	}, estimate.Templates[0])
+	// test ran for 3m in each workflow, split across two pods in the first
+	assert.Equal(t, &workflowpkg.TemplateCostEstimate{
+		Template:               "test",
+		Requests:               map[string]string{"cpu": "500m", "memory": "200Mi"},
+		Runs:                   2,
+		AverageDurationSeconds: 180,
+		ResourcesDuration:      map[string]int64{"cpu": 90, "memory": 360},
+	}, estimate.Templates[1])
+	// deploy never ran, so there is nothing to base its estimate on
+	assert.Equal(t, &workflowpkg.TemplateCostEstimate{
+		Template:          "deploy",
+		Requests:          map[string]string{"cpu": "1", "memory": "100Mi"},
+		ResourcesDuration: map[string]int64{"cpu": 0, "memory": 0},
+	}, estimate.Templates[2])
+	assert.Equal(t, map[string]int64{"cpu": 1890, "memory": 9576}, estimate.ResourcesDuration)
+}
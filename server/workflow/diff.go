@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// identifier is a map key that can be in a path without quoting
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// nodeIDFields are the fields of a node that are IDs, which always differ between workflows, so are not compared
+var nodeIDFields = []string{"id", "name", "displayName", "boundaryID", "children", "outboundNodes"}
+
+// diffWorkflows returns the values that differ between the workflows' specs, and their statuses if withStatus
+func diffWorkflows(from, to *wfv1.Workflow, withStatus bool) ([]*workflowpkg.WorkflowDifference, error) {
+	var differences []*workflowpkg.WorkflowDifference
+	fromSpec, err := toJSONValue(from.Spec)
+	if err != nil {
+		return nil, err
+	}
+	toSpec, err := toJSONValue(to.Spec)
+	if err != nil {
+		return nil, err
+	}
+	diffValues("spec", fromSpec, toSpec, &differences)
+	if withStatus {
+		fromStatus, err := comparableStatus(from)
+		if err != nil {
+			return nil, err
+		}
+		toStatus, err := comparableStatus(to)
+		if err != nil {
+			return nil, err
+		}
+		diffValues("status", fromStatus, toStatus, &differences)
+	}
+	return differences, nil
+}
+
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var value any
+	return value, json.Unmarshal(data, &value)
+}
+
+// comparableStatus returns the workflow's status with its nodes keyed by their names relative to the workflow, e.g.
+// "[0].step1", and without their IDs, so that the same nodes of different workflows are compared
+func comparableStatus(wf *wfv1.Workflow) (any, error) {
+	value, err := toJSONValue(wf.Status)
+	if err != nil {
+		return nil, err
+	}
+	status := value.(map[string]any)
+	// keyed by node ID
+	delete(status, "taskResultsCompletionStatus")
+	if len(wf.Status.Nodes) == 0 {
+		return status, nil
+	}
+	nodes := map[string]any{}
+	for _, node := range wf.Status.Nodes {
+		value, err := toJSONValue(node)
+		if err != nil {
+			return nil, err
+		}
+		fields := value.(map[string]any)
+		for _, field := range nodeIDFields {
+			delete(fields, field)
+		}
+		nodes[strings.TrimPrefix(strings.TrimPrefix(node.Name, wf.Name), ".")] = fields
+	}
+	status["nodes"] = nodes
+	return status, nil
+}
+
+// diffValues appends the differences between two JSON values at the path
+func diffValues(path string, from, to any, differences *[]*workflowpkg.WorkflowDifference) {
+	switch fromValue := from.(type) {
+	case map[string]any:
+		if toValue, ok := to.(map[string]any); ok {
+			diffMaps(path, fromValue, toValue, differences)
+			return
+		}
+	case []any:
+		if toValue, ok := to.([]any); ok {
+			diffLists(path, fromValue, toValue, differences)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*differences = append(*differences, &workflowpkg.WorkflowDifference{Path: path, From: jsonString(from), To: jsonString(to)})
+	}
+}
+
+func diffMaps(path string, from, to map[string]any, differences *[]*workflowpkg.WorkflowDifference) {
+	keys := map[string]bool{}
+	for key := range from {
+		keys[key] = true
+	}
+	for key := range to {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		diffValues(keyPath(path, key), from[key], to[key], differences)
+	}
+}
+
+// diffLists compares lists of named objects, e.g. templates or parameters, by name, so that adding or moving one
+// is a single difference, and other lists by index
+func diffLists(path string, from, to []any, differences *[]*workflowpkg.WorkflowDifference) {
+	fromNames, fromNamed := names(from)
+	toNames, toNamed := names(to)
+	if !fromNamed || !toNamed {
+		for i := 0; i < max(len(from), len(to)); i++ {
+			var fromValue, toValue any
+			if i < len(from) {
+				fromValue = from[i]
+			}
+			if i < len(to) {
+				toValue = to[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), fromValue, toValue, differences)
+		}
+		return
+	}
+	for i, name := range fromNames {
+		var toValue any
+		if j := slices.Index(toNames, name); j >= 0 {
+			toValue = to[j]
+		}
+		diffValues(fmt.Sprintf("%s[name=%s]", path, name), from[i], toValue, differences)
+	}
+	for j, name := range toNames {
+		if !slices.Contains(fromNames, name) {
+			diffValues(fmt.Sprintf("%s[name=%s]", path, name), nil, to[j], differences)
+		}
+	}
+}
+
+// names returns the names of the list's elements, and whether they are all objects with unique names
+func names(list []any) ([]string, bool) {
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		object, ok := item.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok || name == "" || slices.Contains(result, name) {
+			return nil, false
+		}
+		result = append(result, name)
+	}
+	return result, true
+}
+
+func keyPath(path, key string) string {
+	if identifier.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+// jsonString returns the value as JSON, or empty if there is no value
+func jsonString(value any) string {
+	if value == nil {
+		return ""
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
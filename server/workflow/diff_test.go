@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_diffValues(t *testing.T) {
+	diff := func(from, to any) []*workflowpkg.WorkflowDifference {
+		var differences []*workflowpkg.WorkflowDifference
+		diffValues("spec", from, to, &differences)
+		return differences
+	}
+	t.Run("Equal", func(t *testing.T) {
+		assert.Empty(t, diff(map[string]any{"a": []any{"b"}}, map[string]any{"a": []any{"b"}}))
+	})
+	t.Run("Map", func(t *testing.T) {
+		assert.Equal(t, []*workflowpkg.WorkflowDifference{
+			{Path: "spec.a", From: "1", To: "2"},
+			{Path: `spec["b.c"]`, To: `"d"`},
+			{Path: "spec.e", From: "true"},
+		}, diff(map[string]any{"a": 1.0, "e": true}, map[string]any{"a": 2.0, "b.c": "d"}))
+	})
+	t.Run("List", func(t *testing.T) {
+		assert.Equal(t, []*workflowpkg.WorkflowDifference{
+			{Path: "spec[1]", From: `"b"`, To: `"c"`},
+			{Path: "spec[2]", To: `"d"`},
+		}, diff([]any{"a", "b"}, []any{"a", "c", "d"}))
+	})
+	t.Run("NamedList", func(t *testing.T) {
+		assert.Equal(t, []*workflowpkg.WorkflowDifference{
+			{Path: "spec[name=a]", From: `{"name":"a"}`},
+			{Path: "spec[name=b].value", From: "1", To: "2"},
+			{Path: "spec[name=c]", To: `{"name":"c"}`},
+		}, diff(
+			[]any{map[string]any{"name": "a"}, map[string]any{"name": "b", "value": 1.0}},
+			[]any{map[string]any{"name": "c"}, map[string]any{"name": "b", "value": 2.0}},
+		))
+	})
+	t.Run("Type", func(t *testing.T) {
+		assert.Equal(t, []*workflowpkg.WorkflowDifference{{Path: "spec", From: `{"a":"b"}`, To: `["a"]`}}, diff(map[string]any{"a": "b"}, []any{"a"}))
+	})
+}
+
+func Test_diffWorkflows(t *testing.T) {
+	newWorkflow := func(name string, phase wfv1.NodePhase) *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       wfv1.WorkflowSpec{Entrypoint: "main"},
+			Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+				name:        {ID: name, Name: name, DisplayName: name, Phase: wfv1.NodeSucceeded, Children: []string{name + "-1"}},
+				name + "-1": {ID: name + "-1", Name: name + ".step", DisplayName: "step", Phase: phase, BoundaryID: name},
+			}},
+		}
+	}
+	t.Run("Spec", func(t *testing.T) {
+		differences, err := diffWorkflows(newWorkflow("a", wfv1.NodeSucceeded), newWorkflow("b", wfv1.NodeFailed), false)
+		require.NoError(t, err)
+		assert.Empty(t, differences)
+	})
+	t.Run("Status", func(t *testing.T) {
+		differences, err := diffWorkflows(newWorkflow("a", wfv1.NodeSucceeded), newWorkflow("b", wfv1.NodeFailed), true)
+		require.NoError(t, err)
+		assert.Equal(t, []*workflowpkg.WorkflowDifference{{Path: "status.nodes.step.phase", From: `"Succeeded"`, To: `"Failed"`}}, differences)
+	})
+}
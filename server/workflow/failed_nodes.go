@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// failedNodes returns only the Failed and Error nodes, along with their ancestors, so that the failures can be found
+// in the workflow. The children and outbound nodes of the returned nodes are pruned to the nodes that are returned.
+func failedNodes(nodes wfv1.Nodes) wfv1.Nodes {
+	parents := make(map[string][]string)
+	for id, node := range nodes {
+		for _, child := range node.Children {
+			parents[child] = append(parents[child], id)
+		}
+	}
+	keep := make(map[string]bool)
+	var visit func(id string)
+	visit = func(id string) {
+		if keep[id] {
+			return
+		}
+		keep[id] = true
+		for _, parent := range parents[id] {
+			visit(parent)
+		}
+	}
+	for id, node := range nodes {
+		if node.FailedOrError() {
+			visit(id)
+		}
+	}
+	pruned := make(wfv1.Nodes, len(keep))
+	for id := range keep {
+		node := nodes[id]
+		node.Children = keptNodeIDs(node.Children, keep)
+		node.OutboundNodes = keptNodeIDs(node.OutboundNodes, keep)
+		pruned[id] = node
+	}
+	return pruned
+}
+
+func keptNodeIDs(ids []string, keep map[string]bool) []string {
+	var kept []string
+	for _, id := range ids {
+		if keep[id] {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
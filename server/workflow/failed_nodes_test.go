@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestFailedNodes(t *testing.T) {
+	// wf -> (a, b), a -> (a-1, a-2), b -> b-1
+	nodes := wfv1.Nodes{
+		"wf":  {ID: "wf", Type: wfv1.NodeTypeDAG, Phase: wfv1.NodeFailed, Children: []string{"a", "b"}, OutboundNodes: []string{"a-2", "b-1"}},
+		"a":   {ID: "a", Type: wfv1.NodeTypeRetry, Phase: wfv1.NodeFailed, Children: []string{"a-1", "a-2"}},
+		"a-1": {ID: "a-1", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed},
+		"a-2": {ID: "a-2", Type: wfv1.NodeTypePod, Phase: wfv1.NodeError},
+		"b":   {ID: "b", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, Children: []string{"b-1"}},
+		"b-1": {ID: "b-1", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded},
+	}
+	t.Run("Mixed", func(t *testing.T) {
+		pruned := failedNodes(nodes)
+		assert.ElementsMatch(t, []string{"wf", "a", "a-1", "a-2"}, nodeIDs(pruned))
+		assert.Equal(t, []string{"a"}, pruned["wf"].Children)
+		assert.Equal(t, []string{"a-2"}, pruned["wf"].OutboundNodes)
+		assert.Equal(t, []string{"a-1", "a-2"}, pruned["a"].Children)
+		assert.Equal(t, []string{"a", "b"}, nodes["wf"].Children, "the original nodes are unchanged")
+	})
+	t.Run("Ancestors", func(t *testing.T) {
+		pruned := failedNodes(wfv1.Nodes{
+			"wf":    {ID: "wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning, Children: []string{"group"}},
+			"group": {ID: "group", Type: wfv1.NodeTypeStepGroup, Phase: wfv1.NodeRunning, Children: []string{"ok", "bad"}},
+			"ok":    {ID: "ok", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded},
+			"bad":   {ID: "bad", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed},
+		})
+		assert.ElementsMatch(t, []string{"wf", "group", "bad"}, nodeIDs(pruned))
+		assert.Equal(t, []string{"bad"}, pruned["group"].Children)
+	})
+	t.Run("NoFailures", func(t *testing.T) {
+		assert.Empty(t, failedNodes(wfv1.Nodes{"wf": {ID: "wf", Phase: wfv1.NodeSucceeded}}))
+	})
+}
+
+func nodeIDs(nodes wfv1.Nodes) []string {
+	var ids []string
+	for id := range nodes {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,45 @@
+package workflow
+
+import (
+	"sort"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// workflowGraph derives the graph of the workflow from its (hydrated) node status. Each node is a vertex, and each
+// parent/child relationship an edge, so a DAG task points to the tasks that depend on it, and a step group to its steps.
+// Vertices and edges are sorted so that the response is stable.
+func workflowGraph(nodes wfv1.Nodes) *workflowpkg.WorkflowGraph {
+	graph := &workflowpkg.WorkflowGraph{
+		Vertices: make([]*workflowpkg.WorkflowGraphVertex, 0, len(nodes)),
+		Edges:    []*workflowpkg.WorkflowGraphEdge{},
+	}
+	for id, node := range nodes {
+		graph.Vertices = append(graph.Vertices, &workflowpkg.WorkflowGraphVertex{
+			Id:           id,
+			Name:         node.Name,
+			DisplayName:  node.DisplayName,
+			Type:         string(node.Type),
+			Phase:        string(node.Phase),
+			TemplateName: node.TemplateName,
+			TemplateRef:  node.TemplateRef,
+		})
+		for _, child := range node.Children {
+			// children that are not in the node status (e.g. not yet created) are not part of the graph
+			if _, ok := nodes[child]; ok {
+				graph.Edges = append(graph.Edges, &workflowpkg.WorkflowGraphEdge{From: id, To: child})
+			}
+		}
+	}
+	sort.Slice(graph.Vertices, func(i, j int) bool {
+		return graph.Vertices[i].Id < graph.Vertices[j].Id
+	})
+	sort.Slice(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return graph.Edges[i].From < graph.Edges[j].From
+		}
+		return graph.Edges[i].To < graph.Edges[j].To
+	})
+	return graph
+}
@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestWorkflowGraph(t *testing.T) {
+	t.Run("DAG", func(t *testing.T) {
+		// A -> (B, C) -> D
+		nodes := wfv1.Nodes{
+			"dag":   {ID: "dag", Name: "dag", DisplayName: "dag", Type: wfv1.NodeTypeDAG, Phase: wfv1.NodeRunning, TemplateName: "diamond", Children: []string{"dag-a"}},
+			"dag-a": {ID: "dag-a", Name: "dag.A", DisplayName: "A", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, TemplateName: "echo", Children: []string{"dag-b", "dag-c"}},
+			"dag-b": {ID: "dag-b", Name: "dag.B", DisplayName: "B", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, TemplateName: "echo", Children: []string{"dag-d"}},
+			"dag-c": {ID: "dag-c", Name: "dag.C", DisplayName: "C", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning, TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "echo"}, Children: []string{"dag-d"}},
+			"dag-d": {ID: "dag-d", Name: "dag.D", DisplayName: "D", Type: wfv1.NodeTypePod, Phase: wfv1.NodePending, TemplateName: "echo"},
+		}
+		graph := workflowGraph(nodes)
+		require.Len(t, graph.Vertices, 5)
+		assert.Equal(t, &workflowpkg.WorkflowGraphVertex{Id: "dag", Name: "dag", DisplayName: "dag", Type: "DAG", Phase: "Running", TemplateName: "diamond"}, graph.Vertices[0])
+		assert.Equal(t, "dag-c", graph.Vertices[3].Id)
+		assert.Equal(t, &wfv1.TemplateRef{Name: "my-wftmpl", Template: "echo"}, graph.Vertices[3].TemplateRef)
+		assert.Equal(t, []*workflowpkg.WorkflowGraphEdge{
+			{From: "dag", To: "dag-a"},
+			{From: "dag-a", To: "dag-b"},
+			{From: "dag-a", To: "dag-c"},
+			{From: "dag-b", To: "dag-d"},
+			{From: "dag-c", To: "dag-d"},
+		}, graph.Edges)
+	})
+	t.Run("Steps", func(t *testing.T) {
+		nodes := wfv1.Nodes{
+			"steps":   {ID: "steps", Name: "steps", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeSucceeded, TemplateName: "main", Children: []string{"steps-0"}},
+			"steps-0": {ID: "steps-0", Name: "steps[0]", Type: wfv1.NodeTypeStepGroup, Phase: wfv1.NodeSucceeded, TemplateName: "main", Children: []string{"steps-a"}},
+			"steps-a": {ID: "steps-a", Name: "steps[0].a", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, TemplateName: "echo", Children: []string{"steps-1"}},
+			"steps-1": {ID: "steps-1", Name: "steps[1]", Type: wfv1.NodeTypeStepGroup, Phase: wfv1.NodeSucceeded, TemplateName: "main", Children: []string{"steps-b"}},
+			"steps-b": {ID: "steps-b", Name: "steps[1].b", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, TemplateName: "echo"},
+		}
+		graph := workflowGraph(nodes)
+		require.Len(t, graph.Vertices, 5)
+		assert.Equal(t, []*workflowpkg.WorkflowGraphEdge{
+			{From: "steps", To: "steps-0"},
+			{From: "steps-0", To: "steps-a"},
+			{From: "steps-1", To: "steps-b"},
+			{From: "steps-a", To: "steps-1"},
+		}, graph.Edges)
+	})
+	t.Run("MissingChild", func(t *testing.T) {
+		graph := workflowGraph(wfv1.Nodes{"a": {ID: "a", Children: []string{"b"}}})
+		require.Len(t, graph.Vertices, 1)
+		assert.Empty(t, graph.Edges)
+	})
+}
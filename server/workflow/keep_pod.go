@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"context"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// keepPod takes a pod out of its workflow rather than deleting it, so that it can be inspected after the workflow is
+// retried. Pods cannot be renamed, so the pod keeps its name, and the controller deletes it if a re-run node needs it.
+// The pod is still owned by the workflow, so it is deleted with it.
+func keepPod(ctx context.Context, kubeClient kubernetes.Interface, namespace, podName, workflowName string) error {
+	podInterface := kubeClient.CoreV1().Pods(namespace)
+	pod, err := podInterface.Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	// without this label, the controller no longer watches the pod, and will not mistake it for the pod of a re-run node
+	delete(pod.Labels, common.LabelKeyWorkflow)
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[common.LabelKeyRetriedWorkflow] = workflowName
+	// the controller no longer removes its finalizer, which would stop the pod from ever being deleted
+	pod.Finalizers = slices.DeleteFunc(pod.Finalizers, func(s string) bool { return s == common.FinalizerPodStatus })
+	_, err = podInterface.Update(ctx, pod, metav1.UpdateOptions{})
+	return err
+}
@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"context"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// getWorkflowLineage resolves the workflows related to the workflow, so that clients do not need to look each one up
+func getWorkflowLineage(ctx context.Context, wfClient versioned.Interface, wf *wfv1.Workflow) (*workflowpkg.WorkflowLineage, error) {
+	lineage := &workflowpkg.WorkflowLineage{
+		Previous:     wf.Labels[common.LabelKeyPreviousWorkflowName],
+		CronWorkflow: wf.Labels[common.LabelKeyCronWorkflow],
+	}
+	for _, ref := range wf.OwnerReferences {
+		if ref.Kind == workflow.CronWorkflowKind {
+			lineage.CronWorkflow = ref.Name
+		}
+	}
+	// a name that cannot be a label value cannot have been resubmitted from
+	if len(validation.IsValidLabelValue(wf.Name)) > 0 {
+		return lineage, nil
+	}
+	children, err := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeyPreviousWorkflowName + "=" + wf.Name})
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children.Items {
+		lineage.Children = append(lineage.Children, child.Name)
+	}
+	sort.Strings(lineage.Children)
+	return lineage, nil
+}
@@ -0,0 +1,59 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// configMapParameterWarnings returns a warning for each parameter of the workflow's arguments or templates' inputs
+// whose valueFrom.configMapKeyRef refers to a config map, or key, that does not exist in the namespace. References
+// that are optional, or have a default, are not warned about, as they do not fail the workflow. Each config map is
+// only read once, and one that cannot be read is warned about rather than failing the lint.
+func configMapParameterWarnings(ctx context.Context, kubeClient kubernetes.Interface, namespace string, wf *wfv1.Workflow) []string {
+	configMaps := map[string]*corev1.ConfigMap{}
+	readErrs := map[string]error{}
+	var warnings []string
+	check := func(where string, params []wfv1.Parameter) {
+		for _, param := range params {
+			if param.ValueFrom == nil || param.ValueFrom.ConfigMapKeyRef == nil {
+				continue
+			}
+			ref := param.ValueFrom.ConfigMapKeyRef
+			if (ref.Optional != nil && *ref.Optional) || param.ValueFrom.Default != nil {
+				continue
+			}
+			configMap, read := configMaps[ref.Name]
+			if !read {
+				var err error
+				configMap, err = kubeClient.CoreV1().ConfigMaps(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+				if err != nil {
+					configMap = nil
+					readErrs[ref.Name] = err
+				}
+				configMaps[ref.Name] = configMap
+			}
+			switch {
+			case configMap == nil && apierr.IsNotFound(readErrs[ref.Name]):
+				warnings = append(warnings, fmt.Sprintf("%s parameter %s: config map %s not found", where, param.Name, ref.Name))
+			case configMap == nil:
+				warnings = append(warnings, fmt.Sprintf("%s parameter %s: unable to read config map %s: %v", where, param.Name, ref.Name, readErrs[ref.Name]))
+			default:
+				if _, ok := configMap.Data[ref.Key]; !ok {
+					warnings = append(warnings, fmt.Sprintf("%s parameter %s: config map %s has no key %s", where, param.Name, ref.Name, ref.Key))
+				}
+			}
+		}
+	}
+	check("workflow", wf.Spec.Arguments.Parameters)
+	for _, tmpl := range wf.Spec.Templates {
+		check(fmt.Sprintf("template %s input", tmpl.Name), tmpl.Inputs.Parameters)
+	}
+	return warnings
+}
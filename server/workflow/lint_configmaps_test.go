@@ -0,0 +1,49 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestConfigMapParameterWarnings(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-ns"},
+		Data:       map[string]string{"present": "value"},
+	})
+	fromConfigMap := func(name, configMap, key string) wfv1.Parameter {
+		return wfv1.Parameter{Name: name, ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
+			Key:                  key,
+		}}}
+	}
+	optional := fromConfigMap("optional", "my-cm", "missing")
+	optional.ValueFrom.ConfigMapKeyRef.Optional = ptr.To(true)
+	withDefault := fromConfigMap("with-default", "not-found", "present")
+	withDefault.ValueFrom.Default = wfv1.AnyStringPtr("default")
+	wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{
+		Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{
+			{Name: "literal", Value: wfv1.AnyStringPtr("value")},
+			fromConfigMap("present", "my-cm", "present"),
+			fromConfigMap("missing-key", "my-cm", "missing"),
+			optional,
+			withDefault,
+		}},
+		Templates: []wfv1.Template{
+			{Name: "main", Inputs: wfv1.Inputs{Parameters: []wfv1.Parameter{fromConfigMap("missing-config-map", "not-found", "present")}}},
+		},
+	}}
+	assert.Equal(t, []string{
+		"workflow parameter missing-key: config map my-cm has no key missing",
+		"template main input parameter missing-config-map: config map not-found not found",
+	}, configMapParameterWarnings(ctx, kubeClient, "my-ns", wf))
+	assert.Empty(t, configMapParameterWarnings(ctx, kubeClient, "my-ns", &wfv1.Workflow{}))
+}
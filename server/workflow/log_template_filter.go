@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// templatePodFilter matches the pods of the nodes that ran a template, from the workflow's node status
+type templatePodFilter struct {
+	templateName string
+	// refresh, if not nil, gets the latest node status, for pods of nodes that were not in the node status yet
+	refresh func(ctx context.Context) (wfv1.Nodes, error)
+	mu      sync.Mutex
+	// whether each pod node ran the template
+	nodes map[string]bool
+}
+
+func newTemplatePodFilter(templateName string, nodes wfv1.Nodes) *templatePodFilter {
+	f := &templatePodFilter{templateName: templateName}
+	f.setNodes(nodes)
+	return f
+}
+
+func (f *templatePodFilter) setNodes(nodes wfv1.Nodes) {
+	f.nodes = map[string]bool{}
+	for id, node := range nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		f.nodes[id] = node.TemplateName == f.templateName || (node.TemplateRef != nil && node.TemplateRef.Template == f.templateName)
+	}
+}
+
+// any returns whether any node ran the template
+func (f *templatePodFilter) any() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, matched := range f.nodes {
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *templatePodFilter) matches(ctx context.Context, pod *corev1.Pod) bool {
+	nodeID := pod.Annotations[common.AnnotationKeyNodeID]
+	if nodeID == "" {
+		// pods created before node IDs were annotated are named after their node
+		nodeID = pod.Name
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	matched, ok := f.nodes[nodeID]
+	if !ok && f.refresh != nil {
+		// the pod may be created before its node is persisted, so unknown nodes are not remembered
+		nodes, err := f.refresh(ctx)
+		if err != nil {
+			logging.RequireLoggerFromContext(ctx).WithField("podName", pod.Name).WithError(err).Warn(ctx, "Unable to get the node status to find the template of the pod")
+			return false
+		}
+		f.setNodes(nodes)
+		matched = f.nodes[nodeID]
+	}
+	return matched
+}
@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestTemplatePodFilter(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	pod := func(name, nodeID string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: map[string]string{common.AnnotationKeyNodeID: nodeID}}}
+	}
+	nodes := wfv1.Nodes{
+		"train": {ID: "train", Type: wfv1.NodeTypePod, TemplateName: "train"},
+		"eval":  {ID: "eval", Type: wfv1.NodeTypePod, TemplateName: "eval"},
+	}
+	filter := newTemplatePodFilter("train", nodes)
+	assert.True(t, filter.any())
+	assert.True(t, filter.matches(ctx, pod("train-pod", "train")))
+	assert.False(t, filter.matches(ctx, pod("eval-pod", "eval")))
+	// pods created before node IDs were annotated are named after their node
+	assert.True(t, filter.matches(ctx, pod("train", "")))
+	assert.False(t, filter.matches(ctx, pod("later-pod", "later")))
+
+	t.Run("Refresh", func(t *testing.T) {
+		refreshes := 0
+		filter := newTemplatePodFilter("train", nodes)
+		filter.refresh = func(ctx context.Context) (wfv1.Nodes, error) {
+			refreshes++
+			latest := nodes.DeepCopy()
+			latest["later"] = wfv1.NodeStatus{ID: "later", Type: wfv1.NodeTypePod, TemplateName: "train"}
+			return latest, nil
+		}
+		assert.True(t, filter.matches(ctx, pod("train-pod", "train")))
+		assert.Equal(t, 0, refreshes)
+		assert.True(t, filter.matches(ctx, pod("later-pod", "later")))
+		assert.Equal(t, 1, refreshes)
+	})
+	t.Run("NoNodes", func(t *testing.T) {
+		assert.False(t, newTemplatePodFilter("main", wfv1.Nodes{"main": {ID: "main", Type: wfv1.NodeTypeSteps, TemplateName: "main"}}).any())
+	})
+}
@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	servercache "github.com/argoproj/argo-workflows/v3/server/cache"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+const (
+	// maintenanceCacheTTL is how long the maintenance mode is cached for, so other replicas of the server see it
+	// change within this long
+	maintenanceCacheTTL = 10 * time.Second
+	maintenanceCacheKey = "maintenance"
+)
+
+// Maintenance is whether the server is paused for maintenance, rejecting new workflows. It is stored as the
+// common.AnnotationKeyMaintenanceMessage annotation on the server's config map, so that every replica of the server is
+// paused together.
+type Maintenance struct {
+	// kubeClient is the server's own client, as users may not be able to read the config map
+	kubeClient kubernetes.Interface
+	namespace  string
+	configMap  string
+	cache      servercache.Interface
+}
+
+// NewMaintenance returns the maintenance mode stored on the config map. A nil *Maintenance is never paused.
+func NewMaintenance(kubeClient kubernetes.Interface, namespace, configMap string) *Maintenance {
+	return &Maintenance{
+		kubeClient: kubeClient,
+		namespace:  namespace,
+		configMap:  configMap,
+		cache:      servercache.NewLRUTtlCache(maintenanceCacheTTL, 1),
+	}
+}
+
+func (m *Maintenance) get(ctx context.Context) (*workflowpkg.MaintenanceMode, error) {
+	if m == nil {
+		return &workflowpkg.MaintenanceMode{}, nil
+	}
+	if mode, ok := m.cache.Get(maintenanceCacheKey); ok {
+		return mode.(*workflowpkg.MaintenanceMode), nil
+	}
+	cm, err := m.kubeClient.CoreV1().ConfigMaps(m.namespace).Get(ctx, m.configMap, metav1.GetOptions{})
+	if err != nil && !apierr.IsNotFound(err) {
+		return nil, err
+	}
+	mode := &workflowpkg.MaintenanceMode{}
+	if cm != nil {
+		mode.Message, mode.Paused = cm.Annotations[common.AnnotationKeyMaintenanceMessage]
+	}
+	m.cache.Add(maintenanceCacheKey, mode)
+	return mode, nil
+}
+
+// set pauses or unpauses the server by patching the config map with the user's client, so that only those who can
+// patch it can change the maintenance mode
+func (m *Maintenance) set(ctx context.Context, paused bool, message string) (*workflowpkg.MaintenanceMode, error) {
+	if m == nil {
+		return nil, status.Error(codes.Unimplemented, "maintenance mode is not supported by this server")
+	}
+	var value any // null removes the annotation
+	if paused {
+		value = message
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": map[string]any{"annotations": map[string]any{common.AnnotationKeyMaintenanceMessage: value}}})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	_, err = auth.GetKubeClient(ctx).CoreV1().ConfigMaps(m.namespace).Patch(ctx, m.configMap, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	mode := &workflowpkg.MaintenanceMode{Paused: paused}
+	if paused {
+		mode.Message = message
+	}
+	m.cache.Add(maintenanceCacheKey, mode)
+	return mode, nil
+}
+
+// Check returns an Unavailable error with the maintenance message if the server is paused. If the maintenance mode
+// cannot be read, new workflows are allowed, so that the config map being unreadable does not stop all submissions.
+func (m *Maintenance) Check(ctx context.Context) error {
+	mode, err := m.get(ctx)
+	if err != nil {
+		logging.RequireLoggerFromContext(ctx).WithError(err).Warn(ctx, "Unable to get the maintenance mode, allowing the workflow")
+		return nil
+	}
+	if !mode.Paused {
+		return nil
+	}
+	if mode.Message == "" {
+		return status.Error(codes.Unavailable, "the server is paused for maintenance, new workflows cannot be submitted")
+	}
+	return status.Errorf(codes.Unavailable, "the server is paused for maintenance, new workflows cannot be submitted: %s", mode.Message)
+}
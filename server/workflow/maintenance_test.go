@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestMaintenance(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	t.Run("Nil", func(t *testing.T) {
+		var m *Maintenance
+		require.NoError(t, m.Check(ctx))
+		_, err := m.set(ctx, true, "")
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+	t.Run("NoConfigMap", func(t *testing.T) {
+		m := NewMaintenance(fake.NewSimpleClientset(), "argo", "workflow-controller-configmap")
+		mode, err := m.get(ctx)
+		require.NoError(t, err)
+		assert.False(t, mode.Paused)
+		require.NoError(t, m.Check(ctx))
+	})
+	t.Run("Unreadable", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset()
+		kubeClient.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+		// new workflows are allowed rather than all submissions being stopped
+		require.NoError(t, NewMaintenance(kubeClient, "argo", "workflow-controller-configmap").Check(ctx))
+	})
+	t.Run("Paused", func(t *testing.T) {
+		m := NewMaintenance(fake.NewSimpleClientset(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name:        "workflow-controller-configmap",
+			Namespace:   "argo",
+			Annotations: map[string]string{common.AnnotationKeyMaintenanceMessage: "upgrading the cluster"},
+		}}), "argo", "workflow-controller-configmap")
+		err := m.Check(ctx)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Contains(t, err.Error(), "upgrading the cluster")
+	})
+	t.Run("Set", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller-configmap", Namespace: "argo"}})
+		ctx := context.WithValue(ctx, auth.KubeKey, kubeClient)
+		m := NewMaintenance(kubeClient, "argo", "workflow-controller-configmap")
+		mode, err := m.set(ctx, true, "")
+		require.NoError(t, err)
+		assert.True(t, mode.Paused)
+		cm, err := kubeClient.CoreV1().ConfigMaps("argo").Get(ctx, "workflow-controller-configmap", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Contains(t, cm.Annotations, common.AnnotationKeyMaintenanceMessage)
+		assert.Equal(t, codes.Unavailable, status.Code(m.Check(ctx)))
+		// another replica sees it too
+		assert.Equal(t, codes.Unavailable, status.Code(NewMaintenance(kubeClient, "argo", "workflow-controller-configmap").Check(ctx)))
+
+		mode, err = m.set(ctx, false, "")
+		require.NoError(t, err)
+		assert.False(t, mode.Paused)
+		cm, err = kubeClient.CoreV1().ConfigMaps("argo").Get(ctx, "workflow-controller-configmap", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, cm.Annotations, common.AnnotationKeyMaintenanceMessage)
+		require.NoError(t, m.Check(ctx))
+	})
+}
@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// workflowManifest returns the workflow as YAML. Typed clients do not set the apiVersion and kind, so they are set, as
+// a manifest needs them. The status is removed entirely, rather than left empty, if omitStatus is true.
+func workflowManifest(wf *wfv1.Workflow, omitManagedFields, omitStatus bool) (string, error) {
+	wf = wf.DeepCopy()
+	wf.APIVersion = wfv1.SchemeGroupVersion.String()
+	wf.Kind = workflow.WorkflowKind
+	if omitManagedFields {
+		wf.ManagedFields = nil
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(wf)
+	if err != nil {
+		return "", err
+	}
+	if omitStatus {
+		delete(obj, "status")
+	}
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,55 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestWorkflowManifest(t *testing.T) {
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "my-wf",
+			Namespace:     "my-ns",
+			Labels:        map[string]string{"my-label": "my-value"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "workflow-controller", Operation: metav1.ManagedFieldsOperationUpdate}},
+		},
+		Spec:   wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Suspend: &wfv1.SuspendTemplate{}}}},
+		Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, Nodes: wfv1.Nodes{"my-wf": {ID: "my-wf", Phase: wfv1.NodeRunning}}},
+	}
+	fromManifest := func(t *testing.T, manifest string) (*wfv1.Workflow, map[string]any) {
+		t.Helper()
+		parsed := &wfv1.Workflow{}
+		require.NoError(t, yaml.UnmarshalStrict([]byte(manifest), parsed))
+		var raw map[string]any
+		require.NoError(t, yaml.Unmarshal([]byte(manifest), &raw))
+		return parsed, raw
+	}
+	t.Run("Full", func(t *testing.T) {
+		manifest, err := workflowManifest(wf, false, false)
+		require.NoError(t, err)
+		parsed, _ := fromManifest(t, manifest)
+		expected := wf.DeepCopy()
+		expected.APIVersion = "argoproj.io/v1alpha1"
+		expected.Kind = "Workflow"
+		assert.Equal(t, expected, parsed)
+		// the workflow itself is not changed
+		assert.Empty(t, wf.Kind)
+	})
+	t.Run("OmitManagedFieldsAndStatus", func(t *testing.T) {
+		manifest, err := workflowManifest(wf, true, true)
+		require.NoError(t, err)
+		parsed, raw := fromManifest(t, manifest)
+		assert.NotContains(t, raw, "status")
+		assert.NotContains(t, raw["metadata"], "managedFields")
+		assert.Equal(t, "Workflow", parsed.Kind)
+		assert.Equal(t, wf.Spec, parsed.Spec)
+		assert.Equal(t, wf.Labels, parsed.Labels)
+		assert.NotEmpty(t, wf.ManagedFields)
+	})
+}
@@ -0,0 +1,22 @@
+package workflow
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// copyAnnotations are annotations that only hold a copy of the workflow, which can be as large as the workflow itself
+var copyAnnotations = []string{corev1.LastAppliedConfigAnnotation}
+
+// minimalWorkflow removes the metadata of the workflow that is of no use to display it: its managed fields and the
+// annotations that only hold a copy of it
+func minimalWorkflow(wf *wfv1.Workflow) {
+	wf.ManagedFields = nil
+	for _, key := range copyAnnotations {
+		delete(wf.Annotations, key)
+	}
+	if len(wf.Annotations) == 0 {
+		wf.Annotations = nil
+	}
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+func TestMinimalWorkflow(t *testing.T) {
+	t.Run("Bloated", func(t *testing.T) {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: `{"kind":"Workflow"}`, "my-annotation": "my-value"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply}},
+		}}
+		minimalWorkflow(wf)
+		assert.Nil(t, wf.ManagedFields)
+		assert.Equal(t, map[string]string{"my-annotation": "my-value"}, wf.Annotations)
+	})
+	t.Run("OnlyBloat", func(t *testing.T) {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{corev1.LastAppliedConfigAnnotation: "{}"}}}
+		minimalWorkflow(wf)
+		assert.Nil(t, wf.Annotations)
+	})
+}
+
+func TestGetWorkflowMinimal(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+	wf, err := wfClient.Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Annotations = map[string]string{corev1.LastAppliedConfigAnnotation: `{"kind":"Workflow"}`}
+	wf.ManagedFields = []metav1.ManagedFieldsEntry{{Manager: "workflow-controller", Operation: metav1.ManagedFieldsOperationUpdate}}
+	_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	t.Run("Minimal", func(t *testing.T) {
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows", Minimal: true})
+		require.NoError(t, err)
+		assert.Empty(t, wf.ManagedFields)
+		assert.NotContains(t, wf.Annotations, corev1.LastAppliedConfigAnnotation)
+		assert.NotEmpty(t, wf.Status.Nodes)
+	})
+	t.Run("Full", func(t *testing.T) {
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Len(t, wf.ManagedFields, 1)
+		assert.Contains(t, wf.Annotations, corev1.LastAppliedConfigAnnotation)
+	})
+}
@@ -0,0 +1,39 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// dateTokenRegex matches the date tokens of a name template, e.g. {{date:2006-01-02}}
+var dateTokenRegex = regexp.MustCompile(`{{\s*date:([^{}]*)}}`)
+
+// resolveNameTemplate replaces the date tokens of a name template with the time in UTC, so that periodic runs get
+// readable names such as report-2024-01-31, and checks the result is a valid workflow name.
+func resolveNameTemplate(nameTemplate string, now time.Time) (string, error) {
+	now = now.UTC()
+	var invalid error
+	name := dateTokenRegex.ReplaceAllStringFunc(nameTemplate, func(token string) string {
+		layout := strings.TrimSpace(dateTokenRegex.FindStringSubmatch(token)[1])
+		if layout == "" {
+			invalid = status.Errorf(codes.InvalidArgument, "name template %q has a date token without a layout", nameTemplate)
+		}
+		return now.Format(layout)
+	})
+	if invalid != nil {
+		return "", invalid
+	}
+	if strings.Contains(name, "{{") {
+		return "", status.Errorf(codes.InvalidArgument, "name template %q has an unknown token, only date tokens such as {{date:2006-01-02}} are supported", nameTemplate)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", status.Error(codes.InvalidArgument, fmt.Sprintf("name template %q resolved to the invalid name %q: %s", nameTemplate, name, strings.Join(errs, "; ")))
+	}
+	return name, nil
+}
@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResolveNameTemplate(t *testing.T) {
+	now := time.Date(2024, 1, 31, 23, 30, 0, 0, time.FixedZone("UTC-1", -60*60))
+	for nameTemplate, name := range map[string]string{
+		"report":                                 "report",
+		"report-{{date:2006-01-02}}":             "report-2024-02-01",
+		"report-{{ date:20060102 }}-{{date:15}}": "report-20240201-00",
+		"{{date:2006}}.{{date:01}}.report":       "2024.02.report",
+	} {
+		t.Run(nameTemplate, func(t *testing.T) {
+			resolved, err := resolveNameTemplate(nameTemplate, now)
+			require.NoError(t, err)
+			assert.Equal(t, name, resolved)
+		})
+	}
+	t.Run("NoLayout", func(t *testing.T) {
+		_, err := resolveNameTemplate("report-{{date:}}", now)
+		require.EqualError(t, err, `rpc error: code = InvalidArgument desc = name template "report-{{date:}}" has a date token without a layout`)
+	})
+	t.Run("UnknownToken", func(t *testing.T) {
+		_, err := resolveNameTemplate("report-{{time:15}}", now)
+		require.EqualError(t, err, `rpc error: code = InvalidArgument desc = name template "report-{{time:15}}" has an unknown token, only date tokens such as {{date:2006-01-02}} are supported`)
+	})
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := resolveNameTemplate("report-{{date:Jan 2}}", now)
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), `resolved to the invalid name "report-Feb 1"`)
+	})
+}
@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"sort"
+
+	"k8s.io/apimachinery/pkg/watch"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// nodeDeltas returns the changes between two consecutive states of a workflow's nodes. Only nodes that were added,
+// removed, or whose phase or message changed are returned, sorted by node ID so that the response is stable.
+func nodeDeltas(prev, next wfv1.Nodes) []*workflowpkg.WorkflowNodeDelta {
+	var deltas []*workflowpkg.WorkflowNodeDelta
+	for id, node := range next {
+		old, ok := prev[id]
+		switch {
+		case !ok:
+			deltas = append(deltas, newNodeDelta(watch.Added, id, node))
+		case old.Phase != node.Phase || old.Message != node.Message:
+			deltas = append(deltas, newNodeDelta(watch.Modified, id, node))
+		}
+	}
+	for id, node := range prev {
+		if _, ok := next[id]; !ok {
+			deltas = append(deltas, newNodeDelta(watch.Deleted, id, node))
+		}
+	}
+	sort.Slice(deltas, func(i, j int) bool {
+		return deltas[i].NodeId < deltas[j].NodeId
+	})
+	return deltas
+}
+
+func newNodeDelta(eventType watch.EventType, id string, node wfv1.NodeStatus) *workflowpkg.WorkflowNodeDelta {
+	return &workflowpkg.WorkflowNodeDelta{
+		Type:    string(eventType),
+		NodeId:  id,
+		Phase:   string(node.Phase),
+		Message: node.Message,
+	}
+}
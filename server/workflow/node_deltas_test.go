@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestNodeDeltas(t *testing.T) {
+	t.Run("Initial", func(t *testing.T) {
+		deltas := nodeDeltas(nil, wfv1.Nodes{
+			"b": {ID: "b", Phase: wfv1.NodePending},
+			"a": {ID: "a", Phase: wfv1.NodeRunning},
+		})
+		assert.Equal(t, []*workflowpkg.WorkflowNodeDelta{
+			{Type: "ADDED", NodeId: "a", Phase: "Running"},
+			{Type: "ADDED", NodeId: "b", Phase: "Pending"},
+		}, deltas)
+	})
+	t.Run("OnlyChanged", func(t *testing.T) {
+		prev := wfv1.Nodes{
+			"a": {ID: "a", Phase: wfv1.NodeRunning},
+			"b": {ID: "b", Phase: wfv1.NodePending},
+			"c": {ID: "c", Phase: wfv1.NodeRunning, Message: "ContainerCreating"},
+			"d": {ID: "d", Phase: wfv1.NodeFailed},
+		}
+		next := wfv1.Nodes{
+			"a": {ID: "a", Phase: wfv1.NodeRunning, Children: []string{"e"}},
+			"b": {ID: "b", Phase: wfv1.NodeSucceeded},
+			"c": {ID: "c", Phase: wfv1.NodeRunning},
+			"e": {ID: "e", Phase: wfv1.NodePending},
+		}
+		assert.Equal(t, []*workflowpkg.WorkflowNodeDelta{
+			{Type: "MODIFIED", NodeId: "b", Phase: "Succeeded"},
+			{Type: "MODIFIED", NodeId: "c", Phase: "Running"},
+			{Type: "DELETED", NodeId: "d", Phase: "Failed"},
+			{Type: "ADDED", NodeId: "e", Phase: "Pending"},
+		}, nodeDeltas(prev, next))
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		nodes := wfv1.Nodes{"a": {ID: "a", Phase: wfv1.NodeRunning}}
+		assert.Empty(t, nodeDeltas(nodes, nodes))
+	})
+}
@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/util/rand"
+)
+
+// operationTracker keeps track of the in-flight bulk operations (e.g. DeleteWorkflows) of the server, so that they can be
+// canceled by ID. Operations are only known to the server instance that is running them, and IDs are unique within a
+// namespace.
+type operationTracker struct {
+	mutex      sync.Mutex
+	operations map[string]*operation
+}
+
+type operation struct {
+	// subject is the subject of the user that started the operation, empty if they were not authenticated by one
+	subject string
+	cancel  context.CancelFunc
+}
+
+func newOperationTracker() *operationTracker {
+	return &operationTracker{operations: make(map[string]*operation)}
+}
+
+func operationKey(namespace, id string) string {
+	return namespace + "/" + id
+}
+
+// claimsSubject returns the subject of the user that made the request, empty if they were not authenticated by one
+func claimsSubject(ctx context.Context) string {
+	if claims := auth.GetClaims(ctx); claims != nil {
+		return claims.Subject
+	}
+	return ""
+}
+
+// start registers an operation in the namespace, generating an ID if id is empty. The returned context is done once the
+// operation is canceled, and should be checked between each item processed. The returned function must be called once
+// the operation has finished.
+func (t *operationTracker) start(ctx context.Context, namespace, id string) (context.Context, string, func(), error) {
+	if id == "" {
+		var err error
+		id, err = rand.RandString(16)
+		if err != nil {
+			return nil, "", nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	key := operationKey(namespace, id)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if _, ok := t.operations[key]; ok {
+		return nil, "", nil, status.Errorf(codes.AlreadyExists, "operation %q is already in progress", id)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	t.operations[key] = &operation{subject: claimsSubject(ctx), cancel: cancel}
+	return ctx, id, func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		delete(t.operations, key)
+		cancel()
+	}, nil
+}
+
+// get returns the operation in progress in the namespace, or a NotFound error if there is no such operation.
+func (t *operationTracker) get(namespace, id string) (*operation, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	op, ok := t.operations[operationKey(namespace, id)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "operation %q not found", id)
+	}
+	return op, nil
+}
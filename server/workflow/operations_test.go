@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestOperationTracker(t *testing.T) {
+	tracker := newOperationTracker()
+	t.Run("GeneratedID", func(t *testing.T) {
+		ctx, id, finish, err := tracker.start(t.Context(), "my-ns", "")
+		require.NoError(t, err)
+		defer finish()
+		assert.Len(t, id, 16)
+		op, err := tracker.get("my-ns", id)
+		require.NoError(t, err)
+		op.cancel()
+		require.Error(t, ctx.Err())
+	})
+	t.Run("AlreadyInProgress", func(t *testing.T) {
+		_, _, finish, err := tracker.start(t.Context(), "my-ns", "my-op")
+		require.NoError(t, err)
+		_, _, _, err = tracker.start(t.Context(), "my-ns", "my-op")
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		finish()
+		_, _, finish, err = tracker.start(t.Context(), "my-ns", "my-op")
+		require.NoError(t, err)
+		finish()
+	})
+	t.Run("OtherNamespace", func(t *testing.T) {
+		_, _, finish, err := tracker.start(t.Context(), "my-ns", "my-op")
+		require.NoError(t, err)
+		defer finish()
+		_, err = tracker.get("other-ns", "my-op")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		// the same ID can be used in another namespace
+		_, _, otherFinish, err := tracker.start(t.Context(), "other-ns", "my-op")
+		require.NoError(t, err)
+		otherFinish()
+	})
+	t.Run("Subject", func(t *testing.T) {
+		ctx := context.WithValue(t.Context(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "alice"}})
+		_, _, finish, err := tracker.start(ctx, "my-ns", "my-op")
+		require.NoError(t, err)
+		defer finish()
+		op, err := tracker.get("my-ns", "my-op")
+		require.NoError(t, err)
+		assert.Equal(t, "alice", op.subject)
+	})
+	t.Run("Finished", func(t *testing.T) {
+		_, _, finish, err := tracker.start(t.Context(), "my-ns", "my-op")
+		require.NoError(t, err)
+		finish()
+		_, err = tracker.get("my-ns", "my-op")
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"sort"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/util"
+)
+
+// pendingApprovals returns the active suspend nodes, in the order they were suspended
+func pendingApprovals(nodes wfv1.Nodes) []*workflowpkg.PendingApproval {
+	var suspended []wfv1.NodeStatus
+	for _, node := range nodes {
+		if node.IsActiveSuspendNode() {
+			suspended = append(suspended, node)
+		}
+	}
+	sort.Slice(suspended, func(i, j int) bool {
+		if !suspended[i].StartedAt.Equal(&suspended[j].StartedAt) {
+			return suspended[i].StartedAt.Before(&suspended[j].StartedAt)
+		}
+		return suspended[i].ID < suspended[j].ID
+	})
+	approvals := make([]*workflowpkg.PendingApproval, 0, len(suspended))
+	for _, node := range suspended {
+		approval := &workflowpkg.PendingApproval{
+			Id:           node.ID,
+			Name:         node.Name,
+			DisplayName:  node.DisplayName,
+			TemplateName: node.TemplateName,
+			Message:      node.Message,
+		}
+		if node.Inputs != nil {
+			for _, param := range node.Inputs.Parameters {
+				approval.Inputs = append(approval.Inputs, &param)
+			}
+		}
+		if node.Outputs != nil {
+			for _, param := range node.Outputs.Parameters {
+				// only those still to be supplied, the same as the ones that can be set when resuming
+				if param.ValueFrom != nil && param.ValueFrom.Supplied != nil {
+					approval.Outputs = append(approval.Outputs, &param)
+				}
+			}
+		}
+		approvals = append(approvals, approval)
+	}
+	return approvals
+}
+
+// awaitingApproval returns whether the workflow has not completed, and is suspended or has an active suspend node. The
+// workflow must be hydrated, unless it is suspended as a whole.
+func awaitingApproval(wf *wfv1.Workflow) bool {
+	return !wf.Status.Fulfilled() && util.IsWorkflowSuspended(wf)
+}
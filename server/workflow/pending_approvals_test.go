@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestPendingApprovals(t *testing.T) {
+	started := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	supplied := wfv1.Parameter{
+		Name:        "approved",
+		Enum:        []wfv1.AnyString{"yes", "no"},
+		Description: wfv1.AnyStringPtr("whether to deploy"),
+		ValueFrom:   &wfv1.ValueFrom{Supplied: &wfv1.SuppliedValueFrom{}, Default: wfv1.AnyStringPtr("no")},
+	}
+	nodes := wfv1.Nodes{
+		"wf": {ID: "wf", Name: "wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning, StartedAt: started},
+		"approve": {
+			ID: "approve", Name: "wf[0].approve", DisplayName: "approve", TemplateName: "approve", Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeRunning,
+			StartedAt: metav1.NewTime(started.Add(time.Minute)),
+			Inputs:    &wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "version", Value: wfv1.AnyStringPtr("v1.2.3")}}},
+			Outputs:   &wfv1.Outputs{Parameters: []wfv1.Parameter{supplied, {Name: "fixed", Value: wfv1.AnyStringPtr("1")}}},
+		},
+		"earlier":  {ID: "earlier", Name: "wf[0].earlier", DisplayName: "earlier", Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeRunning, StartedAt: started},
+		"approved": {ID: "approved", Name: "wf[0].approved", DisplayName: "approved", Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeSucceeded, StartedAt: started},
+		"pod":      {ID: "pod", Name: "wf[0].pod", DisplayName: "pod", Type: wfv1.NodeTypePod, Phase: wfv1.NodeRunning, StartedAt: started},
+	}
+	t.Run("Suspended", func(t *testing.T) {
+		approvals := pendingApprovals(nodes)
+		require.Len(t, approvals, 2)
+		assert.Equal(t, &workflowpkg.PendingApproval{Id: "earlier", Name: "wf[0].earlier", DisplayName: "earlier"}, approvals[0])
+		assert.Equal(t, &workflowpkg.PendingApproval{
+			Id:           "approve",
+			Name:         "wf[0].approve",
+			DisplayName:  "approve",
+			TemplateName: "approve",
+			Inputs:       []*wfv1.Parameter{{Name: "version", Value: wfv1.AnyStringPtr("v1.2.3")}},
+			Outputs:      []*wfv1.Parameter{&supplied},
+		}, approvals[1])
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, pendingApprovals(wfv1.Nodes{"wf": {ID: "wf", Phase: wfv1.NodeSucceeded}}))
+	})
+}
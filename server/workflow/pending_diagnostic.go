@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// Machine readable codes explaining why a workflow is not making progress.
+const (
+	PendingCodeParallelismLimit = "ParallelismLimit"
+	PendingCodeSynchronization  = "SynchronizationLock"
+	PendingCodeUnschedulable    = "Unschedulable"
+	PendingCodeImagePull        = "ImagePull"
+	PendingCodeQuotaExceeded    = "QuotaExceeded"
+	PendingCodePodPending       = "PodPending"
+)
+
+type pendingDiagnostic struct {
+	code    string
+	message string
+}
+
+// diagnosePending inspects the workflow, its pods and related events to explain why the workflow, or one of its
+// nodes, is stuck pending. It returns nil if the workflow is not pending or no cause could be found.
+// Failures to list pods or events are logged and otherwise ignored, as the diagnostic is best-effort.
+func diagnosePending(ctx context.Context, kubeClient kubernetes.Interface, wf *wfv1.Workflow) *pendingDiagnostic {
+	if wf.Status.Phase != wfv1.WorkflowUnknown && wf.Status.Phase != wfv1.WorkflowPending && wf.Status.Phase != wfv1.WorkflowRunning {
+		return nil
+	}
+	log := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name})
+	if wf.Status.Phase == wfv1.WorkflowPending && strings.Contains(wf.Status.Message, "too many workflows are already running") {
+		return &pendingDiagnostic{code: PendingCodeParallelismLimit, message: wf.Status.Message}
+	}
+	if isWaitingForLock(wf.Status.Message) {
+		return &pendingDiagnostic{code: PendingCodeSynchronization, message: wf.Status.Message}
+	}
+	for _, node := range wf.Status.Nodes {
+		if node.Phase != wfv1.NodePending {
+			continue
+		}
+		switch {
+		case isWaitingForLock(node.Message):
+			return &pendingDiagnostic{code: PendingCodeSynchronization, message: fmt.Sprintf("node %s: %s", node.DisplayName, node.Message)}
+		case strings.Contains(node.Message, "exceeded quota"):
+			return &pendingDiagnostic{code: PendingCodeQuotaExceeded, message: fmt.Sprintf("node %s: %s", node.DisplayName, node.Message)}
+		}
+	}
+
+	pods, err := kubeClient.CoreV1().Pods(wf.Namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeyWorkflow + "=" + wf.Name})
+	if err != nil {
+		log.WithError(err).Warn(ctx, "unable to list pods to diagnose pending workflow")
+	} else if diagnostic := diagnosePendingPods(pods.Items); diagnostic != nil {
+		return diagnostic
+	}
+
+	events, err := kubeClient.CoreV1().Events(wf.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(wf.UID)).String(),
+	})
+	if err != nil {
+		log.WithError(err).Warn(ctx, "unable to list events to diagnose pending workflow")
+	} else {
+		for _, event := range events.Items {
+			if event.Type == corev1.EventTypeWarning && strings.Contains(event.Message, "exceeded quota") {
+				return &pendingDiagnostic{code: PendingCodeQuotaExceeded, message: event.Message}
+			}
+		}
+	}
+	return nil
+}
+
+func diagnosePendingPods(pods []corev1.Pod) *pendingDiagnostic {
+	var pending *corev1.Pod
+	for i, pod := range pods {
+		if pod.Status.Phase != corev1.PodPending {
+			continue
+		}
+		pending = &pods[i]
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+				return &pendingDiagnostic{code: PendingCodeUnschedulable, message: fmt.Sprintf("pod %s is unschedulable: %s", pod.Name, condition.Message)}
+			}
+		}
+		for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+			if status.State.Waiting == nil {
+				continue
+			}
+			switch status.State.Waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				return &pendingDiagnostic{code: PendingCodeImagePull, message: fmt.Sprintf("pod %s cannot pull image for container %s: %s", pod.Name, status.Name, status.State.Waiting.Message)}
+			}
+		}
+	}
+	if pending != nil {
+		return &pendingDiagnostic{code: PendingCodePodPending, message: fmt.Sprintf("pod %s is pending", pending.Name)}
+	}
+	return nil
+}
+
+func isWaitingForLock(message string) bool {
+	return strings.HasPrefix(message, "Waiting for ") && strings.Contains(message, " lock")
+}
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestDiagnosePending(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns", UID: "my-uid"},
+		Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning},
+	}
+	t.Run("Unschedulable", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-pod", Namespace: "my-ns", Labels: map[string]string{common.LabelKeyWorkflow: "my-wf"}},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient cpu.",
+				}},
+			},
+		})
+		diagnostic := diagnosePending(ctx, kubeClient, wf)
+		require.NotNil(t, diagnostic)
+		assert.Equal(t, PendingCodeUnschedulable, diagnostic.code)
+		assert.Equal(t, "pod my-pod is unschedulable: 0/3 nodes are available: 3 Insufficient cpu.", diagnostic.message)
+	})
+	t.Run("QuotaExceededNode", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Status.Nodes = wfv1.Nodes{"my-node": {
+			ID:          "my-node",
+			DisplayName: "my-node",
+			Phase:       wfv1.NodePending,
+			Message:     `pods "my-pod" is forbidden: exceeded quota: compute, requested: pods=1, used: pods=10, limited: pods=10`,
+		}}
+		diagnostic := diagnosePending(ctx, fake.NewSimpleClientset(), wf)
+		require.NotNil(t, diagnostic)
+		assert.Equal(t, PendingCodeQuotaExceeded, diagnostic.code)
+		assert.Contains(t, diagnostic.message, "node my-node: ")
+	})
+	t.Run("QuotaExceededEvent", func(t *testing.T) {
+		kubeClient := fake.NewSimpleClientset(&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "my-event", Namespace: "my-ns"},
+			InvolvedObject: corev1.ObjectReference{Name: "my-wf", Namespace: "my-ns", UID: "my-uid"},
+			Type:           corev1.EventTypeWarning,
+			Message:        "exceeded quota: compute",
+		})
+		diagnostic := diagnosePending(ctx, kubeClient, wf)
+		require.NotNil(t, diagnostic)
+		assert.Equal(t, PendingCodeQuotaExceeded, diagnostic.code)
+		assert.Equal(t, "exceeded quota: compute", diagnostic.message)
+	})
+	t.Run("ParallelismLimit", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Status.Phase = wfv1.WorkflowPending
+		wf.Status.Message = "Workflow processing has been postponed because too many workflows are already running"
+		diagnostic := diagnosePending(ctx, fake.NewSimpleClientset(), wf)
+		require.NotNil(t, diagnostic)
+		assert.Equal(t, PendingCodeParallelismLimit, diagnostic.code)
+	})
+	t.Run("NotPending", func(t *testing.T) {
+		wf := wf.DeepCopy()
+		wf.Status.Phase = wfv1.WorkflowSucceeded
+		assert.Nil(t, diagnosePending(ctx, fake.NewSimpleClientset(), wf))
+	})
+	t.Run("NoCause", func(t *testing.T) {
+		assert.Nil(t, diagnosePending(ctx, fake.NewSimpleClientset(), wf))
+	})
+}
@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// maxPodResourcesPods is the most pods whose resources are returned, so that a workflow with many pods cannot make
+// GetWorkflowPodResources list them all
+const maxPodResourcesPods = 500
+
+// podMetricsList is the subset of a metrics.k8s.io/v1beta1 PodMetricsList that is needed, so that the metrics API
+// can be read with the discovery client rather than needing its own
+type podMetricsList struct {
+	Items []struct {
+		metav1.ObjectMeta `json:"metadata"`
+		Containers        []struct {
+			Usage corev1.ResourceList `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+// getPodResources gets the resource requests and limits of the workflow's pods, and their usage from the metrics API
+// if metricsClient is not nil and the API is available.
+func getPodResources(ctx context.Context, kubeClient kubernetes.Interface, metricsClient rest.Interface, wf *wfv1.Workflow) (*workflowpkg.WorkflowPodResources, error) {
+	selector := common.LabelKeyWorkflow + "=" + wf.Name
+	pods, err := kubeClient.CoreV1().Pods(wf.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector, Limit: maxPodResourcesPods})
+	if err != nil {
+		return nil, err
+	}
+	usage := getPodUsage(ctx, metricsClient, wf.Namespace, selector)
+	resources := &workflowpkg.WorkflowPodResources{Nodes: map[string]*workflowpkg.PodResources{}, Truncated: pods.Continue != ""}
+	for _, pod := range pods.Items {
+		nodeID := pod.Annotations[common.AnnotationKeyNodeID]
+		if nodeID == "" {
+			continue
+		}
+		requests, limits := corev1.ResourceList{}, corev1.ResourceList{}
+		for _, c := range pod.Spec.Containers {
+			addResources(requests, c.Resources.Requests)
+			addResources(limits, c.Resources.Limits)
+		}
+		resources.Nodes[nodeID] = &workflowpkg.PodResources{
+			Pod:      pod.Name,
+			Requests: quantityStrings(requests),
+			Limits:   quantityStrings(limits),
+			Usage:    quantityStrings(usage[pod.Name]),
+		}
+	}
+	return resources, nil
+}
+
+// getPodUsage returns the usage of the pods by name, or nil if it cannot be read, e.g. as the metrics server is not
+// installed
+func getPodUsage(ctx context.Context, metricsClient rest.Interface, namespace, selector string) map[string]corev1.ResourceList {
+	if metricsClient == nil {
+		return nil
+	}
+	log := logging.RequireLoggerFromContext(ctx).WithField("namespace", namespace)
+	data, err := metricsClient.Get().AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", namespace, "pods").Param("labelSelector", selector).DoRaw(ctx)
+	if err != nil {
+		log.WithError(err).Debug(ctx, "Unable to get pod metrics, returning pod resources without usage")
+		return nil
+	}
+	var list podMetricsList
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.WithError(err).Warn(ctx, "Unable to decode pod metrics, returning pod resources without usage")
+		return nil
+	}
+	usage := map[string]corev1.ResourceList{}
+	for _, item := range list.Items {
+		total := corev1.ResourceList{}
+		for _, c := range item.Containers {
+			addResources(total, c.Usage)
+		}
+		usage[item.Name] = total
+	}
+	return usage
+}
+
+// quantityStrings formats the quantities of the resources, or returns nil if there are none
+func quantityStrings(resources corev1.ResourceList) map[string]string {
+	if len(resources) == 0 {
+		return nil
+	}
+	formatted := make(map[string]string, len(resources))
+	for name, quantity := range resources {
+		formatted[string(name)] = quantity.String()
+	}
+	return formatted
+}
+
+func addResources(total, resources corev1.ResourceList) {
+	for name, quantity := range resources {
+		sum, ok := total[name]
+		if !ok {
+			sum = resource.Quantity{}
+		}
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
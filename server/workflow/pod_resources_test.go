@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakerest "k8s.io/client-go/rest/fake"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func newResourcesPod(name, nodeID, workflowName string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "my-ns",
+			Labels:      map[string]string{common.LabelKeyWorkflow: workflowName},
+			Annotations: map[string]string{common.AnnotationKeyNodeID: nodeID},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "wait", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")}}},
+			{Name: "main", Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m"), corev1.ResourceMemory: resource.MustParse("64Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}},
+		}},
+	}
+}
+
+func TestGetPodResources(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}}
+	kubeClient := fake.NewSimpleClientset(newResourcesPod("my-wf-a", "my-wf-1", "my-wf"), newResourcesPod("my-wf-b", "my-wf-2", "my-wf"), newResourcesPod("other-wf-a", "other-wf-1", "other-wf"))
+	metricsClient := func(status int, body string) *fakerest.RESTClient {
+		return &fakerest.RESTClient{
+			Client: fakerest.CreateHTTPClient(func(request *http.Request) (*http.Response, error) {
+				assert.Equal(t, "/apis/metrics.k8s.io/v1beta1/namespaces/my-ns/pods", request.URL.Path)
+				assert.Equal(t, common.LabelKeyWorkflow+"=my-wf", request.URL.Query().Get("labelSelector"))
+				return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+			}),
+			NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		}
+	}
+	t.Run("Usage", func(t *testing.T) {
+		resources, err := getPodResources(ctx, kubeClient, metricsClient(http.StatusOK, `{"items":[{"metadata":{"name":"my-wf-a"},"containers":[{"name":"wait","usage":{"cpu":"1m"}},{"name":"main","usage":{"cpu":"49m","memory":"32Mi"}}]}]}`), wf)
+		require.NoError(t, err)
+		data, err := json.Marshal(resources)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"nodes":{
+			"my-wf-1":{"pod":"my-wf-a","requests":{"cpu":"110m","memory":"64Mi"},"limits":{"cpu":"1"},"usage":{"cpu":"50m","memory":"32Mi"}},
+			"my-wf-2":{"pod":"my-wf-b","requests":{"cpu":"110m","memory":"64Mi"},"limits":{"cpu":"1"}}
+		}}`, string(data))
+	})
+	t.Run("MetricsUnavailable", func(t *testing.T) {
+		resources, err := getPodResources(ctx, kubeClient, metricsClient(http.StatusNotFound, `{}`), wf)
+		require.NoError(t, err)
+		require.Len(t, resources.Nodes, 2)
+		assert.Nil(t, resources.Nodes["my-wf-1"].Usage)
+	})
+	t.Run("NoMetricsClient", func(t *testing.T) {
+		resources, err := getPodResources(ctx, kubeClient, nil, wf)
+		require.NoError(t, err)
+		assert.Len(t, resources.Nodes, 2)
+		assert.False(t, resources.Truncated)
+	})
+}
@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+// readyTimeout bounds how long the readiness probe waits for the database
+const readyTimeout = 5 * time.Second
+
+// Ready returns an error if the workflow archive or offloaded node status database, when enabled, cannot be reached.
+func (s *workflowServer) Ready(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	if s.wfArchive.IsEnabled() {
+		if err := s.wfArchive.Ping(ctx); err != nil {
+			return fmt.Errorf("workflow archive database is unreachable: %w", err)
+		}
+	}
+	if s.offloadNodeStatusRepo.IsEnabled() {
+		if err := s.offloadNodeStatusRepo.Ping(ctx); err != nil {
+			return fmt.Errorf("offloaded node status database is unreachable: %w", err)
+		}
+	}
+	return nil
+}
+
+// Readyz is an HTTP readiness probe, so that traffic can be routed away from a replica that cannot reach its database.
+// https://kubernetes.io/docs/tasks/configure-pod-container/configure-liveness-readiness-startup-probes/#define-readiness-probes
+func (s *workflowServer) Readyz(w http.ResponseWriter, r *http.Request) {
+	if err := s.Ready(r.Context()); err != nil {
+		logging.RequireLoggerFromContext(r.Context()).WithError(err).Warn(r.Context(), "readyz")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(err.Error()))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
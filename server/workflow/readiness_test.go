@@ -0,0 +1,63 @@
+package workflow
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestReady(t *testing.T) {
+	newServer := func(archiveErr, offloadErr error) (*workflowServer, *mocks.WorkflowArchive, *mocks.OffloadNodeStatusRepo) {
+		wfArchive := &mocks.WorkflowArchive{}
+		wfArchive.On("IsEnabled").Return(true)
+		wfArchive.On("Ping", mock.Anything).Return(archiveErr)
+		offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+		offloadNodeStatusRepo.On("IsEnabled").Return(true)
+		offloadNodeStatusRepo.On("Ping").Return(offloadErr)
+		return &workflowServer{wfArchive: wfArchive, offloadNodeStatusRepo: offloadNodeStatusRepo}, wfArchive, offloadNodeStatusRepo
+	}
+	readyz := func(s *workflowServer) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+		r = r.WithContext(logging.TestContext(t.Context()))
+		w := httptest.NewRecorder()
+		s.Readyz(w, r)
+		return w
+	}
+	t.Run("Healthy", func(t *testing.T) {
+		s, _, _ := newServer(nil, nil)
+		require.NoError(t, s.Ready(logging.TestContext(t.Context())))
+		w := readyz(s)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "ok", w.Body.String())
+	})
+	t.Run("ArchiveUnreachable", func(t *testing.T) {
+		s, _, offloadNodeStatusRepo := newServer(errors.New("connection refused"), nil)
+		require.EqualError(t, s.Ready(logging.TestContext(t.Context())), "workflow archive database is unreachable: connection refused")
+		offloadNodeStatusRepo.AssertNotCalled(t, "Ping")
+		w := readyz(s)
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.Equal(t, "workflow archive database is unreachable: connection refused", w.Body.String())
+	})
+	t.Run("OffloadUnreachable", func(t *testing.T) {
+		s, _, _ := newServer(nil, errors.New("connection refused"))
+		require.EqualError(t, s.Ready(logging.TestContext(t.Context())), "offloaded node status database is unreachable: connection refused")
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		wfArchive := &mocks.WorkflowArchive{}
+		wfArchive.On("IsEnabled").Return(false)
+		offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+		offloadNodeStatusRepo.On("IsEnabled").Return(false)
+		s := &workflowServer{wfArchive: wfArchive, offloadNodeStatusRepo: offloadNodeStatusRepo}
+		require.NoError(t, s.Ready(logging.TestContext(t.Context())))
+		wfArchive.AssertNotCalled(t, "Ping", mock.Anything)
+		offloadNodeStatusRepo.AssertNotCalled(t, "Ping")
+	})
+}
@@ -0,0 +1,299 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+)
+
+// nodesCacheTTL is how long the nodes listed by each user to check resource fit are cached for
+const nodesCacheTTL = 30 * time.Second
+
+// nodesCacheSize is how many users' nodes are cached
+const nodesCacheSize = 100
+
+// checkResourceFit checks that the pods of the templates the workflow can run, or of those of the workflow template it
+// references, request no more than some node they can be scheduled on can allocate, as they would otherwise never be
+// scheduled. The nodes are listed with the user's credentials, and cached for each user, and the check is skipped if
+// they cannot be, or there are none, e.g. as the cluster scales from zero. Unless the workflow is rejected, the user is
+// warned in a warning header.
+func (s *workflowServer) checkResourceFit(ctx context.Context, wf *wfv1.Workflow, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter) error {
+	if s.resourceFitCheck == config.ResourceFitCheckOff {
+		return nil
+	}
+	logger := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name})
+	spec := &wf.Spec
+	nodeSelector := wf.Spec.NodeSelector
+	tolerations := wf.Spec.Tolerations
+	if ref := wf.Spec.WorkflowTemplateRef; ref != nil {
+		var err error
+		if ref.ClusterScope {
+			var cwftmpl *wfv1.ClusterWorkflowTemplate
+			if cwftmpl, err = cwftmplGetter.Get(ctx, ref.Name); err == nil {
+				spec = &cwftmpl.Spec
+			}
+		} else {
+			var wftmpl *wfv1.WorkflowTemplate
+			if wftmpl, err = wftmplGetter.Get(ctx, ref.Name); err == nil {
+				spec = &wftmpl.Spec
+			}
+		}
+		if err != nil {
+			logger.WithError(err).Warn(ctx, "Unable to get the workflow template, not checking resource fit")
+			return nil
+		}
+		// the workflow's node selector and tolerations are merged with the workflow template's when it is run
+		nodeSelector = mergeNodeSelectors(spec.NodeSelector, wf.Spec.NodeSelector)
+		tolerations = append(slices.Clone(spec.Tolerations), wf.Spec.Tolerations...)
+	}
+	nodes, err := s.listNodes(ctx, wf.Namespace)
+	if err != nil {
+		logger.WithError(err).Warn(ctx, "Unable to list nodes, not checking resource fit")
+		return nil
+	}
+	if len(nodes) == 0 {
+		return nil
+	}
+	entrypoint := wf.Spec.Entrypoint
+	if entrypoint == "" {
+		entrypoint = spec.Entrypoint
+	}
+	onExit := wf.Spec.OnExit
+	if onExit == "" {
+		onExit = spec.OnExit
+	}
+	unfit := unfitTemplates(reachableTemplates(spec, entrypoint, onExit), nodeSelector, tolerations, nodes)
+	if len(unfit) == 0 {
+		return nil
+	}
+	message := strings.Join(unfit, "; ")
+	if s.resourceFitCheck == config.ResourceFitCheckReject {
+		return status.Errorf(codes.InvalidArgument, "pods of the workflow would never be scheduled: %s", message)
+	}
+	logger.WithField("unfit", message).Warn(ctx, "Pods of the workflow would never be scheduled")
+	grpcutil.SetWarningHeader(ctx, message)
+	return nil
+}
+
+// listNodes lists the nodes with the user's credentials for the namespace, or returns those listed recently with the
+// same credentials. Nodes listed for a user whose credentials cannot be told apart from others' are not cached.
+func (s *workflowServer) listNodes(ctx context.Context, namespace string) ([]corev1.Node, error) {
+	key, cacheable := nodesCacheKey(ctx, namespace)
+	if cacheable {
+		if nodes, ok := s.nodes.Get(key); ok {
+			return nodes.([]corev1.Node), nil
+		}
+	}
+	list, err := auth.GetKubeClient(ctx).CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		s.nodes.Add(key, list.Items)
+	}
+	return list.Items, nil
+}
+
+// nodesCacheKey returns the key of the nodes listed with the user's credentials for the namespace: the service account
+// the request was resolved to, which under SSO RBAC depends on the namespace, else the subject of the user. It returns
+// false if the user has neither.
+func nodesCacheKey(ctx context.Context, namespace string) (string, bool) {
+	claims := auth.GetClaims(ctx)
+	switch {
+	case claims == nil:
+		return "", false
+	case claims.ServiceAccountName != "":
+		return "serviceaccount:" + claims.ServiceAccountNamespace + "/" + claims.ServiceAccountName + ":" + namespace, true
+	case claims.Subject != "":
+		return "subject:" + claims.Subject + ":" + namespace, true
+	default:
+		return "", false
+	}
+}
+
+// reachableTemplates returns the templates of the spec that can be run from the entrypoint, the exit handler, and the
+// spec's hooks, in the order of the spec's templates, followed by the inline templates. Templates referenced from other
+// workflow templates are not followed.
+func reachableTemplates(spec *wfv1.WorkflowSpec, entrypoint, onExit string) []wfv1.Template {
+	byName := map[string]*wfv1.Template{}
+	for i := range spec.Templates {
+		byName[spec.Templates[i].Name] = &spec.Templates[i]
+	}
+	reachable := map[string]bool{}
+	var inline []wfv1.Template
+	var visit func(name string, tmpl *wfv1.Template, hooks wfv1.LifecycleHooks, onExit string)
+	visit = func(name string, tmpl *wfv1.Template, hooks wfv1.LifecycleHooks, onExit string) {
+		for _, hook := range hooks {
+			visit(hook.Template, nil, nil, "")
+		}
+		if onExit != "" {
+			visit(onExit, nil, nil, "")
+		}
+		switch {
+		case tmpl != nil:
+			inline = append(inline, *tmpl)
+		case name == "" || reachable[name]:
+			return
+		default:
+			reachable[name] = true
+			if tmpl = byName[name]; tmpl == nil {
+				return
+			}
+		}
+		for _, parallel := range tmpl.Steps {
+			for _, step := range parallel.Steps {
+				visit(step.Template, step.Inline, step.Hooks, step.OnExit)
+			}
+		}
+		if tmpl.DAG != nil {
+			for _, task := range tmpl.DAG.Tasks {
+				visit(task.Template, task.Inline, task.Hooks, task.OnExit)
+			}
+		}
+	}
+	visit(entrypoint, nil, spec.Hooks, onExit)
+	var templates []wfv1.Template
+	for _, tmpl := range spec.Templates {
+		if reachable[tmpl.Name] {
+			templates = append(templates, tmpl)
+		}
+	}
+	return append(templates, inline...)
+}
+
+func mergeNodeSelectors(selectors ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, selector := range selectors {
+		maps.Copy(merged, selector)
+	}
+	return merged
+}
+
+// unfitTemplates describes the templates whose pods request more than any schedulable node that matches their node
+// selector, and whose taints they tolerate, can allocate, in the order of the templates
+func unfitTemplates(templates []wfv1.Template, nodeSelector map[string]string, tolerations []corev1.Toleration, nodes []corev1.Node) []string {
+	var unfit []string
+	for _, tmpl := range templates {
+		requests := podRequests(&tmpl)
+		if len(requests) == 0 {
+			continue
+		}
+		selector := labels.SelectorFromSet(mergeNodeSelectors(nodeSelector, tmpl.NodeSelector))
+		podTolerations := append(slices.Clone(tolerations), tmpl.Tolerations...)
+		fits := false
+		for _, node := range nodes {
+			if !node.Spec.Unschedulable && selector.Matches(labels.Set(node.Labels)) && toleratesTaints(podTolerations, node.Spec.Taints) && fitsAllocatable(requests, node.Status.Allocatable) {
+				fits = true
+				break
+			}
+		}
+		if !fits {
+			unfit = append(unfit, fmt.Sprintf("template %q requests %s, more than any node can allocate", tmpl.Name, formatResources(requests)))
+		}
+	}
+	return unfit
+}
+
+// podRequests returns the resources the pod of a template requests, as the scheduler counts them: the sum of its
+// containers' requests, or the most any of its init containers requests, if that is more. Containers that set a limit
+// but no request request their limit. Templates that do not run a pod of their own, e.g. steps, request nothing.
+func podRequests(tmpl *wfv1.Template) corev1.ResourceList {
+	var containers []corev1.Container
+	switch {
+	case tmpl.Container != nil:
+		containers = append(containers, *tmpl.Container)
+	case tmpl.Script != nil:
+		containers = append(containers, tmpl.Script.Container)
+	case tmpl.ContainerSet != nil:
+		for _, c := range tmpl.ContainerSet.Containers {
+			containers = append(containers, c.Container)
+		}
+	default:
+		return nil
+	}
+	for _, sidecar := range tmpl.Sidecars {
+		containers = append(containers, sidecar.Container)
+	}
+	requests := corev1.ResourceList{}
+	for _, c := range containers {
+		for name, quantity := range containerRequests(c) {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, c := range tmpl.InitContainers {
+		for name, quantity := range containerRequests(c.Container) {
+			if quantity.Cmp(requests[name]) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+	return requests
+}
+
+func containerRequests(c corev1.Container) corev1.ResourceList {
+	requests := c.Resources.Requests.DeepCopy()
+	for name, limit := range c.Resources.Limits {
+		if _, ok := requests[name]; !ok {
+			if requests == nil {
+				requests = corev1.ResourceList{}
+			}
+			requests[name] = limit
+		}
+	}
+	return requests
+}
+
+// toleratesTaints returns whether the tolerations tolerate every taint that stops pods being scheduled on the node
+func toleratesTaints(tolerations []corev1.Toleration, taints []corev1.Taint) bool {
+	for _, taint := range taints {
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !slices.ContainsFunc(tolerations, func(toleration corev1.Toleration) bool { return toleration.ToleratesTaint(&taint) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func fitsAllocatable(requests, allocatable corev1.ResourceList) bool {
+	for name, quantity := range requests {
+		if quantity.IsZero() {
+			continue
+		}
+		available, ok := allocatable[name]
+		if !ok || quantity.Cmp(available) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// formatResources formats the resources sorted by name, e.g. "cpu=64, memory=1Ti"
+func formatResources(resources corev1.ResourceList) string {
+	var parts []string
+	for name, quantity := range resources {
+		parts = append(parts, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
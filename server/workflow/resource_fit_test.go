@@ -0,0 +1,214 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+	servercache "github.com/argoproj/argo-workflows/v3/server/cache"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
+)
+
+func resources(cpu, memory string) corev1.ResourceList {
+	return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+}
+
+func TestPodRequests(t *testing.T) {
+	t.Run("Steps", func(t *testing.T) {
+		assert.Nil(t, podRequests(&wfv1.Template{Steps: []wfv1.ParallelSteps{}}))
+	})
+	t.Run("Sidecars", func(t *testing.T) {
+		requests := podRequests(&wfv1.Template{
+			Container: &corev1.Container{Resources: corev1.ResourceRequirements{Requests: resources("1", "1Gi")}},
+			Sidecars:  []wfv1.UserContainer{{Container: corev1.Container{Resources: corev1.ResourceRequirements{Limits: resources("500m", "512Mi")}}}},
+		})
+		assert.Equal(t, "cpu=1500m, memory=1536Mi", formatResources(requests))
+	})
+	t.Run("InitContainers", func(t *testing.T) {
+		requests := podRequests(&wfv1.Template{
+			Script:         &wfv1.ScriptTemplate{Container: corev1.Container{Resources: corev1.ResourceRequirements{Requests: resources("1", "1Gi")}}},
+			InitContainers: []wfv1.UserContainer{{Container: corev1.Container{Resources: corev1.ResourceRequirements{Requests: resources("2", "512Mi")}}}},
+		})
+		assert.Equal(t, "cpu=2, memory=1Gi", formatResources(requests))
+	})
+	t.Run("ContainerSet", func(t *testing.T) {
+		requests := podRequests(&wfv1.Template{ContainerSet: &wfv1.ContainerSetTemplate{Containers: []wfv1.ContainerNode{
+			{Container: corev1.Container{Resources: corev1.ResourceRequirements{Requests: resources("1", "1Gi")}}},
+			{Container: corev1.Container{Resources: corev1.ResourceRequirements{Requests: resources("1", "1Gi")}}},
+		}}})
+		assert.Equal(t, "cpu=2, memory=2Gi", formatResources(requests))
+	})
+}
+
+func TestCreateWorkflowResourceFit(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	kubeClient := auth.GetKubeClient(ctx)
+	for _, node := range []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "small"}, Status: corev1.NodeStatus{Allocatable: resources("4", "16Gi")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "large", Labels: map[string]string{"size": "large"}}, Status: corev1.NodeStatus{Allocatable: resources("16", "64Gi")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cordoned"}, Spec: corev1.NodeSpec{Unschedulable: true}, Status: corev1.NodeStatus{Allocatable: resources("64", "256Gi")}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "tainted"}, Spec: corev1.NodeSpec{Taints: []corev1.Taint{{Key: "gpu", Effect: corev1.TaintEffectNoSchedule}}}, Status: corev1.NodeStatus{Allocatable: resources("64", "256Gi")}},
+	} {
+		_, err := kubeClient.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	// create returns the warning headers set by the server
+	create := func(t *testing.T, mode, cpu string, nodeSelector map[string]string) ([]string, error) {
+		t.Helper()
+		server.(*workflowServer).resourceFitCheck = mode
+		stream := &testTransportStream{}
+		_, err := server.CreateWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowCreateRequest{
+			Namespace: "workflows",
+			Workflow: &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "resource-fit-"},
+				Spec: wfv1.WorkflowSpec{
+					Entrypoint:   "main",
+					NodeSelector: nodeSelector,
+					Templates: []wfv1.Template{{
+						Name:      "main",
+						Container: &corev1.Container{Image: "busybox", Resources: corev1.ResourceRequirements{Requests: resources(cpu, "1Gi")}},
+					}},
+				},
+			},
+		})
+		return stream.header.Get(grpcutil.WarningHeader), err
+	}
+	t.Run("Fits", func(t *testing.T) {
+		warnings, err := create(t, config.ResourceFitCheckReject, "8", nil)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("DoesNotFitSelectedNodes", func(t *testing.T) {
+		_, err := create(t, config.ResourceFitCheckReject, "8", map[string]string{"size": "small"})
+		require.EqualError(t, err, `rpc error: code = InvalidArgument desc = pods of the workflow would never be scheduled: template "main" requests cpu=8, memory=1Gi, more than any node can allocate`)
+	})
+	t.Run("Reject", func(t *testing.T) {
+		_, err := create(t, config.ResourceFitCheckReject, "32", nil)
+		require.EqualError(t, err, `rpc error: code = InvalidArgument desc = pods of the workflow would never be scheduled: template "main" requests cpu=32, memory=1Gi, more than any node can allocate`)
+	})
+	t.Run("Warn", func(t *testing.T) {
+		warnings, err := create(t, config.ResourceFitCheckWarn, "32", nil)
+		require.NoError(t, err)
+		assert.Equal(t, []string{`template "main" requests cpu=32, memory=1Gi, more than any node can allocate`}, warnings)
+	})
+	t.Run("Off", func(t *testing.T) {
+		warnings, err := create(t, config.ResourceFitCheckOff, "32", nil)
+		require.NoError(t, err)
+		assert.Empty(t, warnings)
+	})
+	t.Run("NodesForbidden", func(t *testing.T) {
+		kubeClient.(*fake.Clientset).PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+		// the nodes listed by the other tests are cached
+		server.(*workflowServer).nodes = servercache.NewLRUTtlCache(nodesCacheTTL, nodesCacheSize)
+		// the user may not be allowed to list nodes, which does not stop them creating workflows
+		_, err := create(t, config.ResourceFitCheckReject, "32", nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestListNodesCached(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	kubeClient := auth.GetKubeClient(ctx)
+	_, err := kubeClient.CoreV1().Nodes().Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "small"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	nodes, err := server.(*workflowServer).listNodes(ctx, "workflows")
+	require.NoError(t, err)
+	assert.Len(t, nodes, 1)
+	_, err = kubeClient.CoreV1().Nodes().Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "large"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	nodes, err = server.(*workflowServer).listNodes(ctx, "workflows")
+	require.NoError(t, err)
+	assert.Len(t, nodes, 1)
+	// the nodes listed for one service account or namespace are not returned for another, nor for users that cannot be
+	// told apart
+	for name, ctx := range map[string]context.Context{
+		"Namespace":      ctx,
+		"ServiceAccount": context.WithValue(ctx, auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}, ServiceAccountName: "my-sa", ServiceAccountNamespace: "argo"}),
+		"NoClaims":       context.WithValue(ctx, auth.ClaimsKey, nil),
+	} {
+		t.Run(name, func(t *testing.T) {
+			namespace := "workflows"
+			if name == "Namespace" {
+				namespace = "other"
+			}
+			nodes, err := server.(*workflowServer).listNodes(ctx, namespace)
+			require.NoError(t, err)
+			assert.Len(t, nodes, 2)
+		})
+	}
+}
+
+func TestReachableTemplates(t *testing.T) {
+	container := &corev1.Container{Image: "busybox"}
+	spec := &wfv1.WorkflowSpec{
+		Hooks: wfv1.LifecycleHooks{"running": {Template: "hook"}},
+		Templates: []wfv1.Template{
+			{Name: "unused", Container: container},
+			{Name: "main", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{
+				{Name: "a", Template: "dag"},
+				{Name: "b", Inline: &wfv1.Template{Container: container}},
+				{Name: "c", Template: "main"},
+			}}}},
+			{Name: "dag", DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{{Name: "a", Template: "task", OnExit: "task-exit"}}}},
+			{Name: "task", Container: container},
+			{Name: "task-exit", Container: container},
+			{Name: "hook", Container: container},
+			{Name: "exit", Container: container},
+		},
+	}
+	var names []string
+	for _, tmpl := range reachableTemplates(spec, "main", "exit") {
+		names = append(names, tmpl.Name)
+	}
+	assert.Equal(t, []string{"main", "dag", "task", "task-exit", "hook", "exit", ""}, names)
+}
+
+func TestToleratesTaints(t *testing.T) {
+	taints := []corev1.Taint{
+		{Key: "gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+	}
+	assert.False(t, toleratesTaints(nil, taints))
+	assert.True(t, toleratesTaints([]corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpEqual, Value: "true"}}, taints))
+	assert.True(t, toleratesTaints([]corev1.Toleration{{Operator: corev1.TolerationOpExists}}, taints))
+	assert.False(t, toleratesTaints([]corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpEqual, Value: "false"}}, taints))
+}
+
+func TestSubmitWorkflowResourceFit(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	server.(*workflowServer).resourceFitCheck = config.ResourceFitCheckReject
+	_, err := auth.GetKubeClient(ctx).CoreV1().Nodes().Create(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "small"}, Status: corev1.NodeStatus{Allocatable: resources("4", "16Gi")}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	wftmplClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().WorkflowTemplates("workflows")
+	wftmpl, err := wftmplClient.Get(ctx, "workflow-template-whalesay-template", metav1.GetOptions{})
+	require.NoError(t, err)
+	wftmpl.Spec.Templates[0].Container.Resources.Requests = resources("8", "1Gi")
+	_, err = wftmplClient.Update(ctx, wftmpl, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	_, err = server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+		Namespace:     "workflows",
+		ResourceKind:  "workflowtemplate",
+		ResourceName:  "workflow-template-whalesay-template",
+		SubmitOptions: &wfv1.SubmitOpts{Parameters: []string{"message=hello"}},
+	})
+	require.EqualError(t, err, `rpc error: code = InvalidArgument desc = pods of the workflow would never be scheduled: template "whalesay-template" requests cpu=8, memory=1Gi, more than any node can allocate`)
+}
@@ -0,0 +1,18 @@
+package workflow
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// aggregateResourceUsage sums the resources duration of the workflow's pods. Only pod nodes are counted, as
+// those are the only nodes the controller records a resources duration for.
+func aggregateResourceUsage(nodes wfv1.Nodes) wfv1.ResourcesDuration {
+	usage := wfv1.ResourcesDuration{}
+	for _, node := range nodes {
+		if node.Type != wfv1.NodeTypePod {
+			continue
+		}
+		usage = usage.Add(node.ResourcesDuration)
+	}
+	return usage
+}
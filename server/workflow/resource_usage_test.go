@@ -0,0 +1,26 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestAggregateResourceUsage(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, aggregateResourceUsage(nil))
+	})
+	t.Run("MultipleNodes", func(t *testing.T) {
+		nodes := wfv1.Nodes{
+			// the DAG node's duration is not counted, only its pods
+			"dag": {Type: wfv1.NodeTypeDAG, ResourcesDuration: wfv1.ResourcesDuration{corev1.ResourceCPU: 100}},
+			"a":   {Type: wfv1.NodeTypePod, ResourcesDuration: wfv1.ResourcesDuration{corev1.ResourceCPU: 10, corev1.ResourceMemory: 20}},
+			"b":   {Type: wfv1.NodeTypePod, ResourcesDuration: wfv1.ResourcesDuration{corev1.ResourceCPU: 5, corev1.ResourceMemory: 7, "nvidia.com/gpu": 3}},
+			"c":   {Type: wfv1.NodeTypePod},
+		}
+		assert.Equal(t, wfv1.ResourcesDuration{corev1.ResourceCPU: 15, corev1.ResourceMemory: 27, "nvidia.com/gpu": 3}, aggregateResourceUsage(nodes))
+	})
+}
@@ -0,0 +1,22 @@
+package workflow
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// retriesExhausted returns whether the workflow failed, or errored, with a node that failed after it ran out of
+// retries, as opposed to one that failed on its first attempt, or was not retried as its retry policy or expression did
+// not allow it. The controller marks a retry node with common.RetriesExhaustedMessage once it has more children than its
+// retry limit.
+func retriesExhausted(wf *wfv1.Workflow) bool {
+	if wf.Status.Phase != wfv1.WorkflowFailed && wf.Status.Phase != wfv1.WorkflowError {
+		return false
+	}
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeRetry && node.FailedOrError() && node.Message == common.RetriesExhaustedMessage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// retryWorkflow returns a workflow with a retry node of the attempts, each of the phase
+func retryWorkflow(phase wfv1.WorkflowPhase, retryPhase wfv1.NodePhase, message string, attempts ...wfv1.NodePhase) *wfv1.Workflow {
+	nodes := wfv1.Nodes{"retry": {ID: "retry", Name: "wf", Type: wfv1.NodeTypeRetry, Phase: retryPhase, Message: message}}
+	for i, attempt := range attempts {
+		id := fmt.Sprintf("attempt-%d", i)
+		nodes[id] = wfv1.NodeStatus{ID: id, Name: fmt.Sprintf("wf(%d)", i), Type: wfv1.NodeTypePod, Phase: attempt, BoundaryID: "retry"}
+		retry := nodes["retry"]
+		retry.Children = append(retry.Children, id)
+		nodes["retry"] = retry
+	}
+	return &wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: phase, Nodes: nodes}}
+}
+
+func TestRetriesExhausted(t *testing.T) {
+	t.Run("Exhausted", func(t *testing.T) {
+		wf := retryWorkflow(wfv1.WorkflowFailed, wfv1.NodeFailed, common.RetriesExhaustedMessage, wfv1.NodeFailed, wfv1.NodeFailed, wfv1.NodeFailed)
+		assert.True(t, retriesExhausted(wf))
+	})
+	t.Run("Errored", func(t *testing.T) {
+		wf := retryWorkflow(wfv1.WorkflowError, wfv1.NodeError, common.RetriesExhaustedMessage, wfv1.NodeError, wfv1.NodeError)
+		assert.True(t, retriesExhausted(wf))
+	})
+	t.Run("FirstAttempt", func(t *testing.T) {
+		// the node failed on its first attempt, and was not retried as its retry policy did not allow it
+		wf := retryWorkflow(wfv1.WorkflowFailed, wfv1.NodeFailed, "Error (exit code 1)", wfv1.NodeFailed)
+		assert.False(t, retriesExhausted(wf))
+	})
+	t.Run("NoRetryStrategy", func(t *testing.T) {
+		wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowFailed,
+			Nodes: wfv1.Nodes{"pod": {ID: "pod", Name: "wf", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed, Message: "Error (exit code 1)"}},
+		}}
+		assert.False(t, retriesExhausted(wf))
+	})
+	t.Run("SucceededOnRetry", func(t *testing.T) {
+		wf := retryWorkflow(wfv1.WorkflowSucceeded, wfv1.NodeSucceeded, "", wfv1.NodeFailed, wfv1.NodeSucceeded)
+		assert.False(t, retriesExhausted(wf))
+	})
+	t.Run("Running", func(t *testing.T) {
+		// e.g. the failure is handled by continueOn, or an exit handler is still running
+		wf := retryWorkflow(wfv1.WorkflowRunning, wfv1.NodeFailed, common.RetriesExhaustedMessage, wfv1.NodeFailed, wfv1.NodeFailed)
+		assert.False(t, retriesExhausted(wf))
+	})
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+const (
+	// retryPodDeletedReason is the reason of the event recorded on a workflow for each pod a retry deletes
+	retryPodDeletedReason = "WorkflowRetryPodDeleted"
+	retryEventComponent   = "argo-server"
+)
+
+// retryActor returns the user retrying the workflow, or an empty string if the server does not know who they are
+func retryActor(ctx context.Context) string {
+	claims := auth.GetClaims(ctx)
+	if claims == nil {
+		return ""
+	}
+	if claims.Subject != "" {
+		return claims.Subject
+	}
+	return claims.Email
+}
+
+// recordRetryPodDeleted records an event on the workflow that the retry deleted the pod, as the pod is gone and so
+// cannot record it itself. The event is created with the user's credentials, like the deletion.
+func recordRetryPodDeleted(ctx context.Context, kubeClient kubernetes.Interface, wf *wfv1.Workflow, podName string) error {
+	message := fmt.Sprintf("Pod %s deleted by retry", podName)
+	if actor := retryActor(ctx); actor != "" {
+		message += " of " + actor
+	}
+	now := metav1.NewTime(time.Now())
+	_, err := kubeClient.CoreV1().Events(wf.Namespace).Create(ctx, &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			// the name is unique as in the events recorded by client-go
+			Name:      fmt.Sprintf("%s.%x", wf.Name, now.UnixNano()),
+			Namespace: wf.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: wfv1.SchemeGroupVersion.String(),
+			Kind:       wfv1.WorkflowSchemaGroupVersionKind.Kind,
+			Namespace:  wf.Namespace,
+			Name:       wf.Name,
+			UID:        wf.UID,
+		},
+		Related: &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  wf.Namespace,
+			Name:       podName,
+		},
+		Reason:              retryPodDeletedReason,
+		Message:             message,
+		Type:                corev1.EventTypeNormal,
+		Source:              corev1.EventSource{Component: retryEventComponent},
+		ReportingController: retryEventComponent,
+		FirstTimestamp:      now,
+		LastTimestamp:       now,
+		Count:               1,
+	}, metav1.CreateOptions{})
+	return err
+}
@@ -0,0 +1,82 @@
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+func TestRetryWorkflowPodDeletedEvents(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+	kubeClient := auth.GetKubeClient(ctx)
+	podClient := kubeClient.CoreV1().Pods("workflows")
+	eventClient := kubeClient.CoreV1().Events("workflows")
+	setUp := func(t *testing.T) *wfv1.Workflow {
+		t.Helper()
+		wf, err := wfClient.Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Phase = wfv1.WorkflowFailed
+		wf.Status.Nodes = wfv1.Nodes{"failed": {ID: "failed", Name: "failed", Type: wfv1.NodeTypePod, TemplateName: "whalesay", Phase: wfv1.NodeFailed}}
+		wf, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		_, err = podClient.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "failed", Labels: map[string]string{common.LabelKeyWorkflow: "failed"}}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			events, err := eventClient.List(ctx, metav1.ListOptions{})
+			require.NoError(t, err)
+			for _, event := range events.Items {
+				require.NoError(t, eventClient.Delete(ctx, event.Name, metav1.DeleteOptions{}))
+			}
+		})
+		return wf
+	}
+	listEvents := func(t *testing.T) []corev1.Event {
+		t.Helper()
+		events, err := eventClient.List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		return events.Items
+	}
+	t.Run("Deleted", func(t *testing.T) {
+		wf := setUp(t)
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		events := listEvents(t)
+		require.Len(t, events, 1)
+		event := events[0]
+		assert.Equal(t, corev1.ObjectReference{APIVersion: "argoproj.io/v1alpha1", Kind: "Workflow", Namespace: "workflows", Name: "failed", UID: wf.UID}, event.InvolvedObject)
+		assert.Equal(t, &corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "workflows", Name: "failed"}, event.Related)
+		assert.Equal(t, retryPodDeletedReason, event.Reason)
+		assert.Equal(t, corev1.EventTypeNormal, event.Type)
+		assert.Equal(t, "Pod failed deleted by retry of my-sub", event.Message)
+	})
+	t.Run("Kept", func(t *testing.T) {
+		setUp(t)
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", KeepPods: true})
+		require.NoError(t, err)
+		assert.Empty(t, listEvents(t))
+		require.NoError(t, podClient.Delete(ctx, "failed", metav1.DeleteOptions{}))
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		setUp(t)
+		kubeClient.(*fake.Clientset).PrependReactor("create", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+		// the user may not be allowed to create events, which does not stop them retrying
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Empty(t, listEvents(t))
+	})
+}
@@ -0,0 +1,21 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// retryKey identifies identical retries of a workflow, which can share one retry. The workflow is identified by its UID,
+// so that a retry of a workflow that has been deleted and recreated with the same name is not shared. The user is part
+// of the key, so that one user's retry is never done with another user's credentials. Retries of users without a
+// subject, e.g. in the server auth mode, cannot be told apart, so they have no key and are never shared.
+func retryKey(ctx context.Context, wf *wfv1.Workflow, req *workflowpkg.WorkflowRetryRequest) (string, bool) {
+	subject := claimsSubject(ctx)
+	if subject == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s/%q/%s", wf.UID, subject, req.String()), true
+}
@@ -0,0 +1,37 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestRetryKey(t *testing.T) {
+	ctx := context.WithValue(t.Context(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "my-sub"}})
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", UID: "my-uid"}}
+	req := &workflowpkg.WorkflowRetryRequest{Name: "my-wf", Namespace: "my-ns"}
+	key, ok := retryKey(ctx, wf, req)
+	assert.True(t, ok)
+	otherKey := func(ctx context.Context, wf *wfv1.Workflow, req *workflowpkg.WorkflowRetryRequest) string {
+		key, _ := retryKey(ctx, wf, req)
+		return key
+	}
+
+	assert.Equal(t, key, otherKey(ctx, wf.DeepCopy(), &workflowpkg.WorkflowRetryRequest{Name: "my-wf", Namespace: "my-ns"}))
+	assert.NotEqual(t, key, otherKey(ctx, &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", UID: "other-uid"}}, req), "recreated workflow")
+	assert.NotEqual(t, key, otherKey(ctx, wf, &workflowpkg.WorkflowRetryRequest{Name: "my-wf", Namespace: "my-ns", RestartSuccessful: true, NodeFieldSelector: "id=1"}), "other options")
+	otherUser := context.WithValue(t.Context(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: "other-sub"}})
+	assert.NotEqual(t, key, otherKey(otherUser, wf, req), "other user")
+	_, ok = retryKey(t.Context(), wf, req)
+	assert.False(t, ok, "no user")
+	_, ok = retryKey(context.WithValue(t.Context(), auth.ClaimsKey, &types.Claims{}), wf, req)
+	assert.False(t, ok, "no subject")
+}
@@ -2,16 +2,27 @@ package store
 
 import (
 	"context"
+	"maps"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	persist "github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 )
 
 type WorkflowLister interface {
-	ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error)
+	ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore, orderBy string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error)
 	CountWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore string, listOptions metav1.ListOptions) (int64, error)
+	// ListNamespaces returns the distinct namespaces of the workflows, sorted
+	ListNamespaces(ctx context.Context, listOptions metav1.ListOptions) ([]string, error)
+	// GroupWorkflowsByTemplate returns the number of workflows started from each template, and the most recently started
+	// of them, ordered by it, see persist.TemplateGroupColumn
+	GroupWorkflowsByTemplate(ctx context.Context, namespace string, listOptions metav1.ListOptions) ([]persist.TemplateGroup, error)
 }
 
 type kubeLister struct {
@@ -24,7 +35,7 @@ func NewKubeLister(wfClient versioned.Interface) WorkflowLister {
 	return &kubeLister{wfClient: wfClient}
 }
 
-func (k *kubeLister) ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error) {
+func (k *kubeLister) ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore, orderBy string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error) {
 	wfList, err := k.wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, listOptions)
 	if err != nil {
 		return nil, err
@@ -39,3 +50,82 @@ func (k *kubeLister) CountWorkflows(ctx context.Context, namespace, nameFilter,
 	}
 	return int64(len(wfList.Items)), nil
 }
+
+func (k *kubeLister) ListNamespaces(ctx context.Context, listOptions metav1.ListOptions) ([]string, error) {
+	wfList, err := k.wfClient.ArgoprojV1alpha1().Workflows("").List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]bool)
+	for _, wf := range wfList.Items {
+		namespaces[wf.Namespace] = true
+	}
+	return slices.Sorted(maps.Keys(namespaces)), nil
+}
+
+func (k *kubeLister) GroupWorkflowsByTemplate(ctx context.Context, namespace string, listOptions metav1.ListOptions) ([]persist.TemplateGroup, error) {
+	wfList, err := k.wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	groups := map[string]*persist.TemplateGroup{}
+	for _, wf := range wfList.Items {
+		template := templateGroup(&wf)
+		group, ok := groups[template]
+		if !ok {
+			group = &persist.TemplateGroup{Template: template}
+			groups[template] = group
+		}
+		group.Count++
+		// of the workflows that started at the same time, that of the first UID is the latest, as in the database
+		latest := group.Latest.Status.StartedAt
+		if !ok || wf.Status.StartedAt.After(latest.Time) || (wf.Status.StartedAt.Equal(&latest) && wf.UID < group.Latest.UID) {
+			group.Latest = latestOfTemplateGroup(&wf)
+		}
+	}
+	res := []persist.TemplateGroup{}
+	for _, group := range groups {
+		res = append(res, *group)
+	}
+	SortTemplateGroups(res)
+	return res, nil
+}
+
+// templateGroup returns the template the workflow was started from, or its entrypoint if it was not started from a
+// template, as persist.TemplateGroupColumn does
+func templateGroup(wf *wfv1.Workflow) string {
+	if name := wf.Labels[common.LabelKeyWorkflowTemplate]; name != "" {
+		return workflow.WorkflowTemplateKind + "/" + name
+	}
+	if name := wf.Labels[common.LabelKeyClusterWorkflowTemplate]; name != "" {
+		return workflow.ClusterWorkflowTemplateKind + "/" + name
+	}
+	if ref := wf.Spec.WorkflowTemplateRef; ref != nil && ref.Name != "" {
+		if ref.ClusterScope {
+			return workflow.ClusterWorkflowTemplateKind + "/" + ref.Name
+		}
+		return workflow.WorkflowTemplateKind + "/" + ref.Name
+	}
+	if wf.Spec.Entrypoint != "" {
+		return "entrypoint/" + wf.Spec.Entrypoint
+	}
+	return ""
+}
+
+// latestOfTemplateGroup returns the metadata and phase of the most recently started workflow of a template group
+func latestOfTemplateGroup(wf *wfv1.Workflow) wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: wf.Name, Namespace: wf.Namespace, UID: wf.UID},
+		Status:     wfv1.WorkflowStatus{Phase: wf.Status.Phase, StartedAt: wf.Status.StartedAt},
+	}
+}
+
+// SortTemplateGroups orders the template groups by their most recently started workflow, then by template
+func SortTemplateGroups(groups []persist.TemplateGroup) {
+	slices.SortFunc(groups, func(a, b persist.TemplateGroup) int {
+		if c := b.Latest.Status.StartedAt.Compare(a.Latest.Status.StartedAt.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Template, b.Template)
+	})
+}
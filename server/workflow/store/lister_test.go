@@ -0,0 +1,63 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	persist "github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/util/instanceid"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestGroupWorkflowsByTemplate(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	now := time.Now().Truncate(time.Second)
+	newWf := func(uid string, age time.Duration, labels map[string]string, spec wfv1.WorkflowSpec) *wfv1.Workflow {
+		labels["workflows.argoproj.io/controller-instanceid"] = "my-instanceid"
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "wf-" + uid, Namespace: "my-ns", UID: types.UID(uid), Labels: labels},
+			Spec:       spec,
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, StartedAt: metav1.NewTime(now.Add(-age))},
+		}
+	}
+	wfs := []*wfv1.Workflow{
+		newWf("a", 2*time.Hour, map[string]string{"workflows.argoproj.io/workflow-template": "my-tmpl"}, wfv1.WorkflowSpec{}),
+		newWf("b", time.Hour, map[string]string{}, wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-tmpl"}}),
+		newWf("c", 3*time.Hour, map[string]string{}, wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "my-tmpl", ClusterScope: true}}),
+		newWf("e", time.Minute, map[string]string{"team": "a"}, wfv1.WorkflowSpec{Entrypoint: "main"}),
+		newWf("d", time.Minute, map[string]string{}, wfv1.WorkflowSpec{Entrypoint: "main"}),
+	}
+	store, err := NewSQLiteStore(instanceid.NewService("my-instanceid"))
+	require.NoError(t, err)
+	for _, wf := range wfs {
+		require.NoError(t, store.Add(wf))
+	}
+	summary := func(groups []persist.TemplateGroup) []string {
+		var res []string
+		for _, group := range groups {
+			res = append(res, fmt.Sprintf("%s %s %d", group.Template, group.Latest.Name, group.Count))
+		}
+		return res
+	}
+	// the database and the Kubernetes API are grouped alike
+	for name, lister := range map[string]WorkflowLister{"SQLite": store, "Kube": NewKubeLister(fake.NewSimpleClientset(wfs[0], wfs[1], wfs[2], wfs[3], wfs[4]))} {
+		t.Run(name, func(t *testing.T) {
+			groups, err := lister.GroupWorkflowsByTemplate(ctx, "my-ns", metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"entrypoint/main wf-d 2", "WorkflowTemplate/my-tmpl wf-b 2", "ClusterWorkflowTemplate/my-tmpl wf-c 1"}, summary(groups))
+			assert.Equal(t, wfv1.WorkflowRunning, groups[0].Latest.Status.Phase)
+
+			groups, err = lister.GroupWorkflowsByTemplate(ctx, "my-ns", metav1.ListOptions{LabelSelector: "team=a"})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"entrypoint/main wf-e 1"}, summary(groups))
+		})
+	}
+}
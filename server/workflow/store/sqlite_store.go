@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -89,11 +90,15 @@ func NewSQLiteStore(instanceService instanceid.Service) (*SQLiteStore, error) {
 	return &SQLiteStore{conn: conn, instanceService: instanceService}, nil
 }
 
-func (s *SQLiteStore) ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error) {
+func (s *SQLiteStore) ListWorkflows(ctx context.Context, namespace, nameFilter, createdAfter, finishedBefore, orderBy string, listOptions metav1.ListOptions) (*wfv1.WorkflowList, error) {
 	options, err := sutils.BuildListOptions(listOptions, namespace, "", nameFilter, createdAfter, finishedBefore)
 	if err != nil {
 		return nil, err
 	}
+	options.OrderBy, err = sutils.ParseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
 	query := `select workflow from argo_workflows
 where instanceid = ?
 `
@@ -163,6 +168,83 @@ where instanceid = ?
 	return total, nil
 }
 
+func (s *SQLiteStore) ListNamespaces(ctx context.Context, listOptions metav1.ListOptions) ([]string, error) {
+	options, err := sutils.BuildListOptions(listOptions, "", "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	query := `select distinct namespace from argo_workflows
+where instanceid = ?
+`
+	args := []any{s.instanceService.InstanceID()}
+
+	options.Limit = 0
+	options.Offset = 0
+	query, args, err = persist.BuildWorkflowSelector(query, args, workflowTableName, workflowLabelsTableName, sqldb.SQLite, options, true)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := []string{}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	err = sqlitex.Execute(s.conn, query, &sqlitex.ExecOptions{
+		Args: args,
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			namespaces = append(namespaces, stmt.ColumnText(0))
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(namespaces)
+	return namespaces, nil
+}
+
+func (s *SQLiteStore) GroupWorkflowsByTemplate(ctx context.Context, namespace string, listOptions metav1.ListOptions) ([]persist.TemplateGroup, error) {
+	options, err := sutils.BuildListOptions(listOptions, namespace, "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	query := `with workflows as (select uid, startedat, workflow, ` + persist.TemplateGroupColumn(sqldb.SQLite) + ` as templategroup from argo_workflows
+where instanceid = ?
+`
+	args := []any{s.instanceService.InstanceID()}
+
+	options.Limit = 0
+	options.Offset = 0
+	query, args, err = persist.BuildWorkflowSelector(query, args, workflowTableName, workflowLabelsTableName, sqldb.SQLite, options, true)
+	if err != nil {
+		return nil, err
+	}
+	query += ")\n" + persist.TemplateGroupsQuery("w.templategroup, g.total, w.workflow")
+
+	groups := []persist.TemplateGroup{}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	err = sqlitex.Execute(s.conn, query, &sqlitex.ExecOptions{
+		Args: args,
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			template := stmt.ColumnText(0)
+			// of the workflows of a group that started at the same time, only the first is the latest
+			if len(groups) > 0 && groups[len(groups)-1].Template == template {
+				return nil
+			}
+			wf := wfv1.Workflow{}
+			if err := json.Unmarshal([]byte(stmt.ColumnText(2)), &wf); err != nil {
+				return err
+			}
+			groups = append(groups, persist.TemplateGroup{Template: template, Count: stmt.ColumnInt64(1), Latest: latestOfTemplateGroup(&wf)})
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
 func (s *SQLiteStore) Add(obj interface{}) error {
 	wf, ok := obj.(*wfv1.Workflow)
 	if !ok {
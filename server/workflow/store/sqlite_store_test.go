@@ -133,102 +133,140 @@ func TestStoreOperation(t *testing.T) {
 	})
 	t.Run("TestListWorkflows", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
-		wfList, err := store.ListWorkflows(ctx, "argo", "", "", "", metav1.ListOptions{Limit: 5})
+		wfList, err := store.ListWorkflows(ctx, "argo", "", "", "", "", metav1.ListOptions{Limit: 5})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 5)
 	})
 	t.Run("TestListWorkflows name", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
-		wfList, err := store.ListWorkflows(ctx, "argo", "Exact", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
+		wfList, err := store.ListWorkflows(ctx, "argo", "Exact", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
 		require.NoError(t, err)
 		assert.Empty(t, wfList.Items)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "Exact", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "Exact", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 1)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 1)
 	})
 	t.Run("TestListWorkflows namePrefix", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
-		wfList, err := store.ListWorkflows(ctx, "argo", "Prefix", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
+		wfList, err := store.ListWorkflows(ctx, "argo", "Prefix", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
 		require.NoError(t, err)
 		assert.Empty(t, wfList.Items)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "Prefix", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "Prefix", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 5)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "Prefix", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "Prefix", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 1)
 	})
 	t.Run("TestListWorkflows namePattern", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
-		wfList, err := store.ListWorkflows(ctx, "argo", "Contains", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=non-existing-pattern"})
+		wfList, err := store.ListWorkflows(ctx, "argo", "Contains", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=non-existing-pattern"})
 		require.NoError(t, err)
 		assert.Empty(t, wfList.Items)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "Contains", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "Contains", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=flow"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 5)
 
-		wfList, err = store.ListWorkflows(ctx, "argo", "Contains", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
+		wfList, err = store.ListWorkflows(ctx, "argo", "Contains", "", "", "", metav1.ListOptions{Limit: 5, FieldSelector: "metadata.name=workflow-1"})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 1)
 	})
 	t.Run("TestListWorkflows finishedBefore", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
 		// Finished before today
-		wfList, err := store.ListWorkflows(ctx, "argo", "", "", time.Now().Format(time.RFC3339), metav1.ListOptions{})
+		wfList, err := store.ListWorkflows(ctx, "argo", "", "", time.Now().Format(time.RFC3339), "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 9)
 
 		// Finished before 1 day ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-24*time.Hour).Format(time.RFC3339), metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-24*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 8)
 
 		// Finished before 5 days ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-5*24*time.Hour).Format(time.RFC3339), metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-5*24*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 4)
 
 		// Finished before 10 days ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-24*10*time.Hour).Format(time.RFC3339), metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", "", time.Now().Add(-24*10*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Empty(t, wfList.Items)
 	})
 	t.Run("TestListWorkflows createdAfter", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
 		// Created after today
-		wfList, err := store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Format(time.RFC3339), "", metav1.ListOptions{})
+		wfList, err := store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Format(time.RFC3339), "", "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Empty(t, wfList.Items)
 
 		// Created after 1 day ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-24*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-24*time.Hour).Format(time.RFC3339), "", "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 1)
 
 		// Created after 3 days ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-3*24*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-3*24*time.Hour).Format(time.RFC3339), "", "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 3)
 
 		// Created after 10 days ago
-		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-10*24*time.Hour).Format(time.RFC3339), "", metav1.ListOptions{})
+		wfList, err = store.ListWorkflows(ctx, "argo", "", time.Now().UTC().Add(-10*24*time.Hour).Format(time.RFC3339), "", "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Len(t, wfList.Items, 9)
 	})
+	t.Run("TestListWorkflows orderBy", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		for orderBy, expected := range map[string][]string{
+			"startedAt":       {"workflow-1", "workflow-9"},
+			"startedAt asc":   {"workflow-9", "workflow-1"},
+			"finishedAt desc": {"workflow-1", "workflow-9"},
+			"finishedAt asc":  {"workflow-9", "workflow-1"},
+			"name":            {"workflow-1", "workflow-9"},
+			"name desc":       {"workflow-9", "workflow-1"},
+		} {
+			wfList, err := store.ListWorkflows(ctx, "argo", "", "", "", orderBy, metav1.ListOptions{})
+			require.NoError(t, err, orderBy)
+			require.Len(t, wfList.Items, 9, orderBy)
+			assert.Equal(t, expected[0], wfList.Items[0].Name, orderBy)
+			assert.Equal(t, expected[1], wfList.Items[8].Name, orderBy)
+		}
+		_, err := store.ListWorkflows(ctx, "argo", "", "", "", "phase", metav1.ListOptions{})
+		require.Error(t, err)
+	})
 	t.Run("TestCountWorkflows", func(t *testing.T) {
 		ctx := logging.TestContext(t.Context())
 		num, err := store.CountWorkflows(ctx, "argo", "", "", "", metav1.ListOptions{})
 		require.NoError(t, err)
 		assert.Equal(t, int64(9), num)
 	})
+	t.Run("TestListNamespaces", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		other := generateWorkflow(100)
+		other.Namespace = "other"
+		require.NoError(t, store.Add(other))
+		otherInstance := generateWorkflow(101)
+		otherInstance.Namespace = "other-instance"
+		otherInstance.Labels["workflows.argoproj.io/controller-instanceid"] = "other-instanceid"
+		otherStore := SQLiteStore{conn: conn, instanceService: instanceid.NewService("other-instanceid")}
+		require.NoError(t, otherStore.Add(otherInstance))
+
+		namespaces, err := store.ListNamespaces(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"argo", "other"}, namespaces)
+
+		namespaces, err = store.ListNamespaces(ctx, metav1.ListOptions{LabelSelector: "test-label=label-100"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other"}, namespaces)
+	})
 }
 
 func generateWorkflow(uid int) *wfv1.Workflow {
@@ -243,5 +281,8 @@ func generateWorkflow(uid int) *wfv1.Workflow {
 			"workflows.argoproj.io/controller-instanceid": "my-instanceid",
 			"test-label": fmt.Sprintf("label-%d", uid),
 		},
-	}, Status: wfv1.WorkflowStatus{FinishedAt: metav1.NewTime(time.Now().Add(-24 * time.Duration(uid) * time.Hour))}}
+	}, Status: wfv1.WorkflowStatus{
+		StartedAt:  metav1.NewTime(time.Now().Add(-24*time.Duration(uid)*time.Hour - time.Hour)),
+		FinishedAt: metav1.NewTime(time.Now().Add(-24 * time.Duration(uid) * time.Hour)),
+	}}
 }
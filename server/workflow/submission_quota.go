@@ -0,0 +1,64 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+)
+
+// submissionQuota counts the workflows each user has created or submitted today, so that one user cannot starve a
+// shared namespace. The counts are kept in memory, so each replica of the server enforces the quota separately.
+type submissionQuota struct {
+	quota *config.SubmissionQuota
+	now   func() time.Time
+	mutex sync.Mutex
+	// the start of the day the counts are for
+	day    time.Time
+	counts map[string]int
+}
+
+func newSubmissionQuota(quota *config.SubmissionQuota) *submissionQuota {
+	return &submissionQuota{quota: quota, now: time.Now, counts: map[string]int{}}
+}
+
+// take counts a submission by the authenticated user, returning a ResourceExhausted error with the time the quota
+// resets if they have used it up. The returned function gives the submission back, e.g. if the workflow could not be
+// created. Requests without an authenticated subject are not limited.
+func (q *submissionQuota) take(ctx context.Context) (func(), error) {
+	claims := auth.GetClaims(ctx)
+	if claims == nil || claims.Subject == "" {
+		return func() {}, nil
+	}
+	subject := claims.Subject
+	daily := q.quota.GetDaily(subject)
+	if daily <= 0 {
+		return func() {}, nil
+	}
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	day := q.now().UTC().Truncate(24 * time.Hour)
+	if !day.Equal(q.day) {
+		q.day = day
+		q.counts = map[string]int{}
+	}
+	if q.counts[subject] >= daily {
+		return nil, status.Errorf(codes.ResourceExhausted, "user %s has submitted their daily quota of %d workflows, which resets at %s", subject, daily, day.Add(24*time.Hour).Format(time.RFC3339))
+	}
+	q.counts[subject]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mutex.Lock()
+			defer q.mutex.Unlock()
+			if q.day.Equal(day) && q.counts[subject] > 0 {
+				q.counts[subject]--
+			}
+		})
+	}, nil
+}
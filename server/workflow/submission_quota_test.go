@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/config"
+	"github.com/argoproj/argo-workflows/v3/server/auth"
+	"github.com/argoproj/argo-workflows/v3/server/auth/types"
+)
+
+func TestSubmissionQuota(t *testing.T) {
+	asUser := func(subject string) context.Context {
+		return context.WithValue(t.Context(), auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: subject}})
+	}
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	quota := newSubmissionQuota(&config.SubmissionQuota{Daily: 2, Users: map[string]int{"ci": 0}})
+	quota.now = func() time.Time { return now }
+
+	t.Run("Exceeded", func(t *testing.T) {
+		for range 2 {
+			_, err := quota.take(asUser("alice"))
+			require.NoError(t, err)
+		}
+		_, err := quota.take(asUser("alice"))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "resets at 2024-01-02T00:00:00Z")
+		// other users have their own quota
+		_, err = quota.take(asUser("bob"))
+		require.NoError(t, err)
+	})
+	t.Run("GiveBack", func(t *testing.T) {
+		_, err := quota.take(asUser("carol"))
+		require.NoError(t, err)
+		giveBack, err := quota.take(asUser("carol"))
+		require.NoError(t, err)
+		giveBack()
+		giveBack()
+		_, err = quota.take(asUser("carol"))
+		require.NoError(t, err)
+		_, err = quota.take(asUser("carol"))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+	t.Run("Unlimited", func(t *testing.T) {
+		for range 5 {
+			_, err := quota.take(asUser("ci"))
+			require.NoError(t, err)
+			_, err = quota.take(t.Context())
+			require.NoError(t, err)
+		}
+	})
+	t.Run("Reset", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		for range 2 {
+			_, err := quota.take(asUser("alice"))
+			require.NoError(t, err)
+		}
+		_, err := quota.take(asUser("alice"))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		quota := newSubmissionQuota(nil)
+		for range 5 {
+			_, err := quota.take(asUser("alice"))
+			require.NoError(t, err)
+		}
+	})
+}
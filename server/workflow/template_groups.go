@@ -0,0 +1,34 @@
+package workflow
+
+import (
+	"slices"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
+)
+
+// mergeTemplateGroups merges the template groups of the live and archived workflows, adding up their counts and
+// keeping the most recently started workflow of each, ordered by it
+func mergeTemplateGroups(live, archived []sqldb.TemplateGroup) *workflowpkg.WorkflowTemplateGroupList {
+	var groups []sqldb.TemplateGroup
+	index := map[string]int{}
+	for _, group := range append(slices.Clone(live), archived...) {
+		i, ok := index[group.Template]
+		if !ok {
+			index[group.Template] = len(groups)
+			groups = append(groups, group)
+			continue
+		}
+		groups[i].Count += group.Count
+		if group.Latest.Status.StartedAt.After(groups[i].Latest.Status.StartedAt.Time) {
+			groups[i].Latest = group.Latest
+		}
+	}
+	store.SortTemplateGroups(groups)
+	list := &workflowpkg.WorkflowTemplateGroupList{Items: []*workflowpkg.WorkflowTemplateGroup{}}
+	for _, group := range groups {
+		list.Items = append(list.Items, &workflowpkg.WorkflowTemplateGroup{Template: group.Template, Count: group.Count, Latest: group.Latest.DeepCopy()})
+	}
+	return list
+}
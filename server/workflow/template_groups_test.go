@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func TestMergeTemplateGroups(t *testing.T) {
+	now := time.Now()
+	newGroup := func(template string, count int64, latest string, age time.Duration) sqldb.TemplateGroup {
+		return sqldb.TemplateGroup{
+			Template: template,
+			Count:    count,
+			Latest:   wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: latest}, Status: wfv1.WorkflowStatus{StartedAt: metav1.NewTime(now.Add(-age))}},
+		}
+	}
+	live := []sqldb.TemplateGroup{newGroup("entrypoint/a", 2, "a-live", time.Hour), newGroup("entrypoint/b", 1, "b-live", 3*time.Hour)}
+	archived := []sqldb.TemplateGroup{newGroup("entrypoint/b", 3, "b-archived", 2*time.Hour), newGroup("entrypoint/a", 1, "a-archived", 2*time.Hour)}
+	list := mergeTemplateGroups(live, archived)
+	if assert.Len(t, list.Items, 2) {
+		assert.Equal(t, "entrypoint/a", list.Items[0].Template)
+		assert.Equal(t, int64(3), list.Items[0].Count)
+		assert.Equal(t, "a-live", list.Items[0].Latest.Name)
+		assert.Equal(t, "entrypoint/b", list.Items[1].Template)
+		assert.Equal(t, int64(4), list.Items[1].Count)
+		assert.Equal(t, "b-archived", list.Items[1].Latest.Name)
+	}
+	assert.Empty(t, mergeTemplateGroups(nil, nil).Items)
+}
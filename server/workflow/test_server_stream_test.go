@@ -37,3 +37,27 @@ func (t testServerStream) SendMsg(interface{}) error {
 func (t testServerStream) RecvMsg(interface{}) error {
 	panic("implement me")
 }
+
+// testTransportStream records the headers set by a unary call
+type testTransportStream struct {
+	header metadata.MD
+}
+
+var _ grpc.ServerTransportStream = &testTransportStream{}
+
+func (t *testTransportStream) Method() string {
+	return ""
+}
+
+func (t *testTransportStream) SetHeader(md metadata.MD) error {
+	t.header = metadata.Join(t.header, md)
+	return nil
+}
+
+func (t *testTransportStream) SendHeader(md metadata.MD) error {
+	return nil
+}
+
+func (t *testTransportStream) SetTrailer(md metadata.MD) error {
+	return nil
+}
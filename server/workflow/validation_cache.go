@@ -0,0 +1,163 @@
+package workflow
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"golang.org/x/exp/maps"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	servercache "github.com/argoproj/argo-workflows/v3/server/cache"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+// validationCacheTTL is how long a successful validation is remembered for
+const validationCacheTTL = 10 * time.Minute
+
+type validateFunc func(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow, wfDefaults *wfv1.Workflow, opts validate.ValidateOpts) error
+
+// validationCache remembers the workflows that were recently validated successfully, keyed by a hash of everything
+// their validation depends on but the templates they reference, with the resource versions of the templates that were
+// read to validate them. A workflow is not validated again while it is remembered and those templates are unchanged.
+// Workflows that fail validation are not remembered. A nil *validationCache validates every workflow.
+type validationCache struct {
+	cache servercache.Interface
+	// validateWorkflow is validate.ValidateWorkflow, but for tests
+	validateWorkflow validateFunc
+}
+
+func newValidationCache(size int) *validationCache {
+	if size <= 0 {
+		return nil
+	}
+	return &validationCache{
+		cache:            servercache.NewLRUTtlCache(validationCacheTTL, size),
+		validateWorkflow: validate.ValidateWorkflow,
+	}
+}
+
+// validationKey is what the validation of a workflow depends on, other than the templates it references. Only the keys
+// of the workflow's labels and annotations are validated, so values that differ for each request, such as the creator,
+// do not prevent a workflow being remembered.
+type validationKey struct {
+	Namespace      string                `json:"namespace"`
+	Name           string                `json:"name"`
+	LabelKeys      []string              `json:"labelKeys"`
+	AnnotationKeys []string              `json:"annotationKeys"`
+	Spec           wfv1.WorkflowSpec     `json:"spec"`
+	Defaults       *wfv1.Workflow        `json:"defaults"`
+	Opts           validate.ValidateOpts `json:"opts"`
+}
+
+func (k validationKey) hash() (string, error) {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// validatedTemplate is a template read to validate a workflow, and the resource version it had
+type validatedTemplate struct {
+	clusterScope    bool
+	name            string
+	resourceVersion string
+}
+
+// validate validates the workflow, unless an identical workflow was recently validated successfully with the same
+// defaults and options, and the templates read to validate it have not changed since
+func (c *validationCache) validate(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow, wfDefaults *wfv1.Workflow, opts validate.ValidateOpts) error {
+	if c == nil {
+		return validate.ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, wf, wfDefaults, opts)
+	}
+	labelKeys := maps.Keys(wf.Labels)
+	slices.Sort(labelKeys)
+	annotationKeys := maps.Keys(wf.Annotations)
+	slices.Sort(annotationKeys)
+	key, err := validationKey{
+		Namespace:      wf.Namespace,
+		Name:           wf.Name,
+		LabelKeys:      labelKeys,
+		AnnotationKeys: annotationKeys,
+		Spec:           wf.Spec,
+		Defaults:       wfDefaults,
+		Opts:           opts,
+	}.hash()
+	if err != nil {
+		return c.validateWorkflow(ctx, wftmplGetter, cwftmplGetter, wf, wfDefaults, opts)
+	}
+	if templates, ok := c.cache.Get(key); ok && templatesUnchanged(ctx, wftmplGetter, cwftmplGetter, templates.([]validatedTemplate)) {
+		return nil
+	}
+	recorder := &templateRecorder{wftmplGetter: wftmplGetter, cwftmplGetter: cwftmplGetter}
+	if err := c.validateWorkflow(ctx, recorder, clusterTemplateRecorder{recorder}, wf, wfDefaults, opts); err != nil {
+		return err
+	}
+	c.cache.Add(key, recorder.templates)
+	return nil
+}
+
+// templatesUnchanged returns whether each of the templates still has the resource version it was validated with
+func templatesUnchanged(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, templates []validatedTemplate) bool {
+	for _, template := range templates {
+		var resourceVersion string
+		if template.clusterScope {
+			cwftmpl, err := cwftmplGetter.Get(ctx, template.name)
+			if err != nil {
+				return false
+			}
+			resourceVersion = cwftmpl.ResourceVersion
+		} else {
+			wftmpl, err := wftmplGetter.Get(ctx, template.name)
+			if err != nil {
+				return false
+			}
+			resourceVersion = wftmpl.ResourceVersion
+		}
+		if resourceVersion != template.resourceVersion {
+			return false
+		}
+	}
+	return true
+}
+
+// templateRecorder gets workflow templates, recording those it got, and through clusterTemplateRecorder, cluster
+// workflow templates
+type templateRecorder struct {
+	wftmplGetter  templateresolution.WorkflowTemplateNamespacedGetter
+	cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter
+	templates     []validatedTemplate
+}
+
+func (r *templateRecorder) Get(ctx context.Context, name string) (*wfv1.WorkflowTemplate, error) {
+	wftmpl, err := r.wftmplGetter.Get(ctx, name)
+	if err == nil {
+		r.record(validatedTemplate{name: name, resourceVersion: wftmpl.ResourceVersion})
+	}
+	return wftmpl, err
+}
+
+// record records the template, once however many times it is got
+func (r *templateRecorder) record(template validatedTemplate) {
+	if !slices.Contains(r.templates, template) {
+		r.templates = append(r.templates, template)
+	}
+}
+
+type clusterTemplateRecorder struct {
+	*templateRecorder
+}
+
+func (r clusterTemplateRecorder) Get(ctx context.Context, name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	cwftmpl, err := r.cwftmplGetter.Get(ctx, name)
+	if err == nil {
+		r.record(validatedTemplate{clusterScope: true, name: name, resourceVersion: cwftmpl.ResourceVersion})
+	}
+	return cwftmpl, err
+}
@@ -0,0 +1,120 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/templateresolution"
+	"github.com/argoproj/argo-workflows/v3/workflow/validate"
+)
+
+type fakeTemplateGetter map[string]*wfv1.WorkflowTemplate
+
+func (g fakeTemplateGetter) Get(_ context.Context, name string) (*wfv1.WorkflowTemplate, error) {
+	if wftmpl, ok := g[name]; ok {
+		return wftmpl, nil
+	}
+	return nil, fmt.Errorf("workflow template %q not found", name)
+}
+
+type fakeClusterTemplateGetter map[string]*wfv1.ClusterWorkflowTemplate
+
+func (g fakeClusterTemplateGetter) Get(_ context.Context, name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	if cwftmpl, ok := g[name]; ok {
+		return cwftmpl, nil
+	}
+	return nil, fmt.Errorf("cluster workflow template %q not found", name)
+}
+
+func TestValidationCache(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wftmpls := fakeTemplateGetter{"my-wftmpl": {
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wftmpl", ResourceVersion: "1"},
+		Spec:       wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main", Container: &corev1.Container{Image: "argoproj/argosay:v2"}}}},
+	}}
+	cwftmpls := fakeClusterTemplateGetter{"my-cwftmpl": {
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cwftmpl", ResourceVersion: "1"},
+		Spec:       wfv1.WorkflowSpec{Templates: []wfv1.Template{{Name: "main", Container: &corev1.Container{Image: "argoproj/argosay:v2"}}}},
+	}}
+	newWorkflow := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: "my-wf-", Namespace: "workflows", Labels: map[string]string{"workflows.argoproj.io/creator": "alice"}},
+			Spec: wfv1.WorkflowSpec{
+				Entrypoint: "main",
+				Templates: []wfv1.Template{{Name: "main", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{
+					{Name: "namespaced", TemplateRef: &wfv1.TemplateRef{Name: "my-wftmpl", Template: "main"}},
+					{Name: "cluster", TemplateRef: &wfv1.TemplateRef{Name: "my-cwftmpl", Template: "main", ClusterScope: true}},
+				}}}}},
+			},
+		}
+	}
+	newCache := func(t *testing.T) (*validationCache, *int) {
+		c := newValidationCache(10)
+		require.NotNil(t, c)
+		validations := 0
+		c.validateWorkflow = func(ctx context.Context, wftmplGetter templateresolution.WorkflowTemplateNamespacedGetter, cwftmplGetter templateresolution.ClusterWorkflowTemplateGetter, wf *wfv1.Workflow, wfDefaults *wfv1.Workflow, opts validate.ValidateOpts) error {
+			validations++
+			return validate.ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, wf, wfDefaults, opts)
+		}
+		return c, &validations
+	}
+
+	t.Run("Hit", func(t *testing.T) {
+		c, validations := newCache(t)
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{}))
+		wf := newWorkflow()
+		// only the keys of labels are validated
+		wf.Labels["workflows.argoproj.io/creator"] = "bob"
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, wf, nil, validate.ValidateOpts{}))
+		assert.Equal(t, 1, *validations, "an identical workflow is not validated again")
+	})
+	t.Run("Miss", func(t *testing.T) {
+		c, validations := newCache(t)
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{}))
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{Lint: true}))
+		wf := newWorkflow()
+		wf.Spec.Arguments.Parameters = []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("hello")}}
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, wf, nil, validate.ValidateOpts{}))
+		assert.Equal(t, 3, *validations, "the options and spec are part of the key")
+	})
+	t.Run("TemplateChanged", func(t *testing.T) {
+		c, validations := newCache(t)
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{}))
+		changed := fakeTemplateGetter{"my-wftmpl": wftmpls["my-wftmpl"].DeepCopy()}
+		changed["my-wftmpl"].ResourceVersion = "2"
+		changed["my-wftmpl"].Spec.Templates[0].Name = "renamed"
+		err := c.validate(ctx, changed, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{})
+		require.Error(t, err, "the workflow is validated against the changed template")
+		assert.Equal(t, 2, *validations)
+	})
+	t.Run("ClusterTemplateChanged", func(t *testing.T) {
+		c, validations := newCache(t)
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{}))
+		changed := fakeClusterTemplateGetter{"my-cwftmpl": cwftmpls["my-cwftmpl"].DeepCopy()}
+		changed["my-cwftmpl"].ResourceVersion = "2"
+		require.NoError(t, c.validate(ctx, wftmpls, changed, newWorkflow(), nil, validate.ValidateOpts{}))
+		require.NoError(t, c.validate(ctx, wftmpls, changed, newWorkflow(), nil, validate.ValidateOpts{}))
+		assert.Equal(t, 2, *validations, "the workflow is remembered with the changed template")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		c, validations := newCache(t)
+		wf := newWorkflow()
+		wf.Spec.Entrypoint = "missing"
+		require.Error(t, c.validate(ctx, wftmpls, cwftmpls, wf, nil, validate.ValidateOpts{}))
+		require.Error(t, c.validate(ctx, wftmpls, cwftmpls, wf, nil, validate.ValidateOpts{}))
+		assert.Equal(t, 2, *validations, "invalid workflows are not remembered")
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		var c *validationCache
+		assert.Nil(t, newValidationCache(0))
+		require.NoError(t, c.validate(ctx, wftmpls, cwftmpls, newWorkflow(), nil, validate.ValidateOpts{}))
+	})
+}
@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/server/metrics"
+)
+
+// watchLimiter caps the number of concurrent watch streams (WatchWorkflows, WatchWorkflowNodes and WatchEvents) served by the server,
+// as each one holds a goroutine and an upstream watch.
+type watchLimiter struct {
+	// the maximum number of concurrent watches, zero or less means unlimited
+	limit   int
+	metrics *metrics.Metrics
+	mutex   sync.Mutex
+	active  int
+}
+
+func newWatchLimiter(limit int, metrics *metrics.Metrics) *watchLimiter {
+	return &watchLimiter{limit: limit, metrics: metrics}
+}
+
+// acquire reserves a watch for the named operation, returning a ResourceExhausted error if the limit has been reached.
+// The returned function must be called once the watch has finished.
+func (l *watchLimiter) acquire(ctx context.Context, operation string) (func(), error) {
+	l.mutex.Lock()
+	if l.limit > 0 && l.active >= l.limit {
+		l.mutex.Unlock()
+		return nil, status.Errorf(codes.ResourceExhausted, "too many concurrent watches, the limit is %d", l.limit)
+	}
+	l.active++
+	l.mutex.Unlock()
+	l.metrics.WatchStarted(ctx, operation)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mutex.Lock()
+			l.active--
+			l.mutex.Unlock()
+			l.metrics.WatchFinished(ctx, operation)
+		})
+	}, nil
+}
@@ -0,0 +1,61 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/argoproj/argo-workflows/v3/server/metrics"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+func TestWatchLimiter(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	t.Run("Unlimited", func(t *testing.T) {
+		limiter := newWatchLimiter(0, nil)
+		for range 10 {
+			_, err := limiter.acquire(ctx, "WatchWorkflows")
+			require.NoError(t, err)
+		}
+	})
+	t.Run("Limited", func(t *testing.T) {
+		m, te, err := metrics.CreateDefaultTestMetrics(ctx)
+		require.NoError(t, err)
+		limiter := newWatchLimiter(2, m)
+		activeWatches := func(operation string) int64 {
+			attribs := attribute.NewSet(attribute.String(telemetry.AttribServerOperation, operation))
+			val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentActiveWatches.Name(), &attribs)
+			require.NoError(t, err)
+			return val
+		}
+
+		releaseWorkflows, err := limiter.acquire(ctx, "WatchWorkflows")
+		require.NoError(t, err)
+		releaseEvents, err := limiter.acquire(ctx, "WatchEvents")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), activeWatches("WatchWorkflows"))
+		assert.Equal(t, int64(1), activeWatches("WatchEvents"))
+
+		_, err = limiter.acquire(ctx, "WatchWorkflows")
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, int64(1), activeWatches("WatchWorkflows"))
+
+		releaseWorkflows()
+		// releasing twice must not free a second watch
+		releaseWorkflows()
+		assert.Equal(t, int64(0), activeWatches("WatchWorkflows"))
+		_, err = limiter.acquire(ctx, "WatchWorkflows")
+		require.NoError(t, err)
+		_, err = limiter.acquire(ctx, "WatchWorkflows")
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		releaseEvents()
+		assert.Equal(t, int64(0), activeWatches("WatchEvents"))
+	})
+}
@@ -0,0 +1,187 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/parser"
+	"github.com/expr-lang/expr/vm"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+	exprenv "github.com/argoproj/argo-workflows/v3/util/expr/env"
+)
+
+// workflowFilter is a predicate over workflows, written as an expression, e.g.
+// `workflow.phase == "Failed" && workflow.duration > 300`. It is compiled once, and then evaluated for each workflow.
+type workflowFilter struct {
+	program  *vm.Program
+	pushdown archivePushdown
+}
+
+func newWorkflowFilter(expression string) (*workflowFilter, error) {
+	program, err := expr.Compile(expression, expr.Env(workflowFilterEnv(&wfv1.Workflow{})), expr.AsBool())
+	if err != nil {
+		return nil, err
+	}
+	// the compiled program is optimized, so the pushdown is found from the expression as written
+	tree, err := parser.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	pushdown := archivePushdown{}
+	pushdown.complete = pushdown.add(tree.Node)
+	return &workflowFilter{program, pushdown}, nil
+}
+
+func (f *workflowFilter) matches(wf *wfv1.Workflow) (bool, error) {
+	result, err := expr.Run(f.program, workflowFilterEnv(wf))
+	if err != nil {
+		return false, err
+	}
+	matched, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("filter must evaluate to a bool, not a %T", result)
+	}
+	return matched, nil
+}
+
+// workflowFilterEnv is the environment a filter is evaluated in. The duration is in seconds, and for a running workflow
+// is the time since it started. The retries are the number of times any node has been retried.
+func workflowFilterEnv(wf *wfv1.Workflow) map[string]interface{} {
+	duration := wf.Status.GetDuration()
+	if wf.Status.FinishedAt.IsZero() && !wf.Status.StartedAt.IsZero() {
+		duration = time.Since(wf.Status.StartedAt.Time)
+	}
+	retries := 0
+	for _, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeRetry && len(node.Children) > 1 {
+			retries += len(node.Children) - 1
+		}
+	}
+	return exprenv.GetFuncMap(map[string]interface{}{
+		"workflow.name":        wf.Name,
+		"workflow.namespace":   wf.Namespace,
+		"workflow.phase":       string(wf.Status.Phase),
+		"workflow.labels":      wf.Labels,
+		"workflow.annotations": wf.Annotations,
+		"workflow.duration":    duration.Seconds(),
+		"workflow.retries":     retries,
+	})
+}
+
+// archivePushdown is the part of a filter that can be translated to SQL for the archive: comparisons of the phase to string
+// literals using == or in, and of a label using ==, != or in, joined with &&. A workflow that does not match it cannot match the filter,
+// so need not be fetched. Anything else, such as comparisons within || or !, is only evaluated in memory.
+type archivePushdown struct {
+	phases            []string
+	labelRequirements labels.Requirements
+	// whether the whole filter was pushed down, so the archive matches exactly the workflows that match the filter
+	complete bool
+}
+
+// add pushes down what it can of the node, returning whether it pushed down all of it
+func (p *archivePushdown) add(node ast.Node) bool {
+	binary, ok := node.(*ast.BinaryNode)
+	if !ok {
+		return false
+	}
+	if binary.Operator == "&&" || binary.Operator == "and" {
+		left := p.add(binary.Left)
+		right := p.add(binary.Right)
+		return left && right
+	}
+	operator, ok := map[string]selection.Operator{"==": selection.Equals, "!=": selection.NotEquals, "in": selection.In}[binary.Operator]
+	if !ok {
+		return false
+	}
+	values, ok := stringLiterals(binary.Right, operator == selection.In)
+	if !ok {
+		return false
+	}
+	if isWorkflowField(binary.Left, "phase") {
+		// only the first phase comparison is pushed down, any others are evaluated in memory
+		if operator != selection.NotEquals && p.phases == nil {
+			p.phases = values
+			return true
+		}
+		return false
+	}
+	if member, ok := binary.Left.(*ast.MemberNode); ok && isWorkflowField(member.Node, "labels") {
+		key, ok := member.Property.(*ast.StringNode)
+		if !ok {
+			return false
+		}
+		// an invalid label key or value cannot be in the database, but the comparison is still evaluated in memory
+		r, err := labels.NewRequirement(key.Value, operator, values)
+		if err != nil {
+			return false
+		}
+		p.labelRequirements = append(p.labelRequirements, *r)
+		return true
+	}
+	return false
+}
+
+// apply narrows the archive list options to the workflows that could match the filter
+func (p archivePushdown) apply(options sutils.ListOptions) sutils.ListOptions {
+	if p.phases != nil {
+		options.Phases = p.phases
+	}
+	options.LabelRequirements = append(slices.Clone(options.LabelRequirements), p.labelRequirements...)
+	return options
+}
+
+// labelSelector narrows the label selector of live workflows to those that could match the filter's labels
+func (p archivePushdown) labelSelector(selector string) string {
+	for _, r := range p.labelRequirements {
+		if selector != "" {
+			selector += ","
+		}
+		selector += r.String()
+	}
+	return selector
+}
+
+// isWorkflowField returns whether the node is `workflow.<field>`
+func isWorkflowField(node ast.Node, field string) bool {
+	member, ok := node.(*ast.MemberNode)
+	if !ok {
+		return false
+	}
+	identifier, ok := member.Node.(*ast.IdentifierNode)
+	if !ok || identifier.Value != "workflow" {
+		return false
+	}
+	property, ok := member.Property.(*ast.StringNode)
+	return ok && property.Value == field
+}
+
+// stringLiterals returns the value of a string literal, or of each element of an array of string literals
+func stringLiterals(node ast.Node, array bool) ([]string, bool) {
+	if !array {
+		str, ok := node.(*ast.StringNode)
+		if !ok {
+			return nil, false
+		}
+		return []string{str.Value}, true
+	}
+	arr, ok := node.(*ast.ArrayNode)
+	if !ok || len(arr.Nodes) == 0 {
+		return nil, false
+	}
+	values := make([]string, len(arr.Nodes))
+	for i, n := range arr.Nodes {
+		str, ok := n.(*ast.StringNode)
+		if !ok {
+			return nil, false
+		}
+		values[i] = str.Value
+	}
+	return values, true
+}
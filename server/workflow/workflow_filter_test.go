@@ -0,0 +1,91 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
+)
+
+func TestWorkflowFilter(t *testing.T) {
+	started := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	failed := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed", Labels: map[string]string{"team": "a"}},
+		Status: wfv1.WorkflowStatus{
+			Phase:      wfv1.WorkflowFailed,
+			StartedAt:  started,
+			FinishedAt: metav1.NewTime(started.Add(6 * time.Minute)),
+			Nodes: wfv1.Nodes{
+				"retry": {Type: wfv1.NodeTypeRetry, Children: []string{"a", "b", "c", "d"}},
+				"a":     {Type: wfv1.NodeTypePod},
+			},
+		},
+	}
+	running := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "running"},
+		Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, StartedAt: started},
+	}
+	for _, tt := range []struct {
+		filter  string
+		failed  bool
+		running bool
+	}{
+		{`workflow.phase == "Failed" && workflow.retries > 2`, true, false},
+		{`workflow.phase == "Failed" && workflow.retries > 3`, false, false},
+		{`workflow.duration > 300`, true, true},
+		{`workflow.duration > 300 && workflow.phase != "Failed"`, false, true},
+		{`workflow.labels["team"] == "a" || workflow.name == "running"`, true, true},
+	} {
+		t.Run(tt.filter, func(t *testing.T) {
+			filter, err := newWorkflowFilter(tt.filter)
+			require.NoError(t, err)
+			matched, err := filter.matches(failed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.failed, matched)
+			matched, err = filter.matches(running)
+			require.NoError(t, err)
+			assert.Equal(t, tt.running, matched)
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := newWorkflowFilter(`workflow.phase ==`)
+		require.Error(t, err)
+	})
+}
+
+func TestArchivePushdown(t *testing.T) {
+	pushdown := func(expression string) archivePushdown {
+		filter, err := newWorkflowFilter(expression)
+		require.NoError(t, err)
+		return filter.pushdown
+	}
+	t.Run("PushedDown", func(t *testing.T) {
+		p := pushdown(`workflow.phase in ["Failed", "Error"] && workflow.labels["team"] == "a" && workflow.labels["tier"] != "b"`)
+		assert.Equal(t, []string{"Failed", "Error"}, p.phases)
+		assert.Equal(t, `team=a, tier!=b`, p.labelRequirements.String())
+		options := p.apply(sutils.ListOptions{Namespace: "my-ns"})
+		assert.Equal(t, "my-ns", options.Namespace)
+		assert.Equal(t, []string{"Failed", "Error"}, options.Phases)
+		assert.Len(t, options.LabelRequirements, 2)
+		assert.True(t, p.complete)
+		assert.Equal(t, "a=b,team=a,tier!=b", p.labelSelector("a=b"))
+	})
+	t.Run("Partial", func(t *testing.T) {
+		p := pushdown(`workflow.phase == "Failed" and workflow.duration > 300`)
+		assert.Equal(t, []string{"Failed"}, p.phases)
+		assert.Empty(t, p.labelRequirements)
+		assert.False(t, p.complete)
+	})
+	t.Run("InMemoryOnly", func(t *testing.T) {
+		p := pushdown(`workflow.phase == "Failed" || workflow.labels["team"] == "a"`)
+		assert.Nil(t, p.phases)
+		assert.Empty(t, p.labelRequirements)
+		assert.Equal(t, sutils.ListOptions{}, p.apply(sutils.ListOptions{}))
+		assert.False(t, p.complete)
+	})
+}
@@ -1,14 +1,21 @@
 package workflow
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -16,28 +23,37 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/errors"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
+	servercache "github.com/argoproj/argo-workflows/v3/server/cache"
+	"github.com/argoproj/argo-workflows/v3/server/metrics"
 	servertypes "github.com/argoproj/argo-workflows/v3/server/types"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
 	argoutil "github.com/argoproj/argo-workflows/v3/util"
 	"github.com/argoproj/argo-workflows/v3/util/fields"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/logs"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 	"github.com/argoproj/argo-workflows/v3/workflow/validate"
 )
@@ -46,6 +62,14 @@ const (
 	latestAlias                  = "@latest"
 	reSyncDuration               = 20 * time.Minute
 	workflowTemplateResyncPeriod = 20 * time.Minute
+	defaultSubmitWaitTimeout     = 30 * time.Second
+	defaultStopWaitTimeout       = 60 * time.Second
+	maxSubmitReasonLength        = 256
+	maxCorrelationIDLength       = 256
+	maxProvenanceURLLength       = 1024
+	// namespacesCacheTTL is how long the namespaces that contain workflows are cached for, as listing them is expensive
+	namespacesCacheTTL = 30 * time.Second
+	namespacesCacheKey = "namespaces"
 )
 
 type workflowServer struct {
@@ -58,21 +82,105 @@ type workflowServer struct {
 	wftmplStore           servertypes.WorkflowTemplateStore
 	cwftmplStore          servertypes.ClusterWorkflowTemplateStore
 	wfDefaults            *wfv1.Workflow
+	metrics               *metrics.Metrics
+	watches               *watchLimiter
+	namespaces            servercache.Interface
+	generateNameRetries   int
+	operations            *operationTracker
+	// retries single-flights concurrent identical RetryWorkflow requests, keyed by retryKey
+	retries singleflight.Group
+	// retryJoined is called once a RetryWorkflow request has started or joined a retry, but for tests
+	retryJoined func()
+	// hydrateMaxNodes and hydrateRefuseOverMaxNodes limit the size of workflows hydrated by hydrateWithinLimit
+	hydrateMaxNodes           int
+	hydrateRefuseOverMaxNodes bool
+	// ignoreInstanceIDMismatch is whether workflows without an instance ID are operated on, rather than rejected
+	ignoreInstanceIDMismatch bool
+	// validationCache remembers workflows that were recently validated, nil if every workflow is validated
+	validationCache *validationCache
+	// resourceFitCheck is whether workflows with pods that fit no node are warned about or rejected, see checkResourceFit
+	resourceFitCheck string
+	// nodes are the nodes each user listed recently, to check resource fit
+	nodes servercache.Interface
+	// namespaceDeletePropagation is the propagation policy workflows of each namespace are deleted with by default
+	namespaceDeletePropagation map[string]metav1.DeletionPropagation
+	// maxRequestSize is the maximum size of a created or linted workflow, zero means unlimited
+	maxRequestSize int
+	// submissionQuota limits the number of workflows each user can create or submit per day
+	submissionQuota *submissionQuota
+	// managedNamespace is the only namespace the server serves workflows from, empty for all
+	managedNamespace string
+	// allowedNamespaces are the only namespaces listed, as requests for others are denied by the server, nil for all
+	allowedNamespaces auth.AllowedNamespaces
+	// maintenance is whether new workflows are rejected, nil if they never are
+	maintenance *Maintenance
+	// now is the time name templates are resolved with
+	now func() time.Time
 }
 
 var _ workflowpkg.WorkflowServiceServer = &workflowServer{}
 
+// WorkflowServerOptions are the options of a WorkflowServer, most of which are configured in the workflow controller
+// configmap. The zero value disables every limit and check.
+type WorkflowServerOptions struct {
+	// MaxConcurrentWatches is the maximum number of concurrent watches, zero means unlimited
+	MaxConcurrentWatches int
+	Metrics              *metrics.Metrics
+	// NamespaceDeletePropagation is the propagation policy workflows of each namespace are deleted with by default
+	NamespaceDeletePropagation map[string]metav1.DeletionPropagation
+	// MaxRequestSize is the maximum size of a created or linted workflow, zero means unlimited
+	MaxRequestSize  int
+	SubmissionQuota *config.SubmissionQuota
+	// AllowedNamespaces are the only namespaces served, empty for all
+	AllowedNamespaces []string
+	// Maintenance is whether new workflows are rejected, nil if they never are
+	Maintenance *Maintenance
+	// GenerateNameRetries is how many times to retry creating a workflow with a generated name that already exists
+	GenerateNameRetries int
+	// ValidationCacheSize is how many validated workflows are remembered, zero to validate every workflow
+	ValidationCacheSize int
+	// HydrateMaxNodes and HydrateRefuseOverMaxNodes limit the size of workflows hydrated, see hydrateWithinLimit
+	HydrateMaxNodes           int
+	HydrateRefuseOverMaxNodes bool
+	// InstanceIDMismatch is config.InstanceIDMismatchReject (default) or config.InstanceIDMismatchIgnore
+	InstanceIDMismatch string
+	// ResourceFitCheck is config.ResourceFitCheckOff (default), config.ResourceFitCheckWarn or config.ResourceFitCheckReject
+	ResourceFitCheck string
+}
+
 // NewWorkflowServer returns a new WorkflowServer
-func NewWorkflowServer(ctx context.Context, instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, wfClientSet versioned.Interface, wfLister store.WorkflowLister, wfStore store.WorkflowStore, wftmplStore servertypes.WorkflowTemplateStore, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults *wfv1.Workflow, namespace *string) *workflowServer {
+func NewWorkflowServer(ctx context.Context, instanceIDService instanceid.Service, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfArchive sqldb.WorkflowArchive, wfClientSet versioned.Interface, wfLister store.WorkflowLister, wfStore store.WorkflowStore, wftmplStore servertypes.WorkflowTemplateStore, cwftmplStore servertypes.ClusterWorkflowTemplateStore, wfDefaults *wfv1.Workflow, namespace *string, opts WorkflowServerOptions) *workflowServer {
+	resourceFitCheck := opts.ResourceFitCheck
+	if resourceFitCheck == "" {
+		resourceFitCheck = config.ResourceFitCheckOff
+	}
 	ws := &workflowServer{
-		instanceIDService:     instanceIDService,
-		offloadNodeStatusRepo: offloadNodeStatusRepo,
-		hydrator:              hydrator.New(offloadNodeStatusRepo),
-		wfArchive:             wfArchive,
-		wfLister:              wfLister,
-		wftmplStore:           wftmplStore,
-		cwftmplStore:          cwftmplStore,
-		wfDefaults:            wfDefaults,
+		instanceIDService:          instanceIDService,
+		managedNamespace:           managedNamespace(namespace),
+		offloadNodeStatusRepo:      offloadNodeStatusRepo,
+		hydrator:                   hydrator.New(offloadNodeStatusRepo),
+		wfArchive:                  wfArchive,
+		wfLister:                   wfLister,
+		wftmplStore:                wftmplStore,
+		cwftmplStore:               cwftmplStore,
+		wfDefaults:                 wfDefaults,
+		metrics:                    opts.Metrics,
+		watches:                    newWatchLimiter(opts.MaxConcurrentWatches, opts.Metrics),
+		namespaces:                 servercache.NewLRUTtlCache(namespacesCacheTTL, 1),
+		generateNameRetries:        opts.GenerateNameRetries,
+		validationCache:            newValidationCache(opts.ValidationCacheSize),
+		operations:                 newOperationTracker(),
+		hydrateMaxNodes:            opts.HydrateMaxNodes,
+		hydrateRefuseOverMaxNodes:  opts.HydrateRefuseOverMaxNodes,
+		ignoreInstanceIDMismatch:   opts.InstanceIDMismatch == config.InstanceIDMismatchIgnore,
+		resourceFitCheck:           resourceFitCheck,
+		nodes:                      servercache.NewLRUTtlCache(nodesCacheTTL, nodesCacheSize),
+		namespaceDeletePropagation: opts.NamespaceDeletePropagation,
+		maxRequestSize:             opts.MaxRequestSize,
+		submissionQuota:            newSubmissionQuota(opts.SubmissionQuota),
+		allowedNamespaces:          auth.NewAllowedNamespaces(opts.AllowedNamespaces),
+		maintenance:                opts.Maintenance,
+		now:                        time.Now,
 	}
 	if wfStore != nil && namespace != nil {
 		lw := &cache.ListWatch{
@@ -89,6 +197,14 @@ func NewWorkflowServer(ctx context.Context, instanceIDService instanceid.Service
 	return ws
 }
 
+// managedNamespace returns the namespace the server watches, empty if it watches every namespace
+func managedNamespace(namespace *string) string {
+	if namespace == nil {
+		return ""
+	}
+	return *namespace
+}
+
 func (s *workflowServer) Run(stopCh <-chan struct{}) {
 	if s.wfReflector != nil {
 		s.wfReflector.Run(stopCh)
@@ -101,21 +217,39 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 	if req.Workflow == nil {
 		return nil, sutils.ToStatusError(fmt.Errorf("workflow body not specified"), codes.InvalidArgument)
 	}
+	if err := s.validateRequestSize(req.Workflow); err != nil {
+		return nil, err
+	}
 
 	if req.Workflow.Namespace == "" {
 		req.Workflow.Namespace = req.Namespace
 	}
+	if err := s.maintenance.Check(ctx); err != nil {
+		return nil, err
+	}
 
 	s.instanceIDService.Label(req.Workflow)
 	creator.LabelCreator(ctx, req.Workflow)
+	annotateSubmitReason(req.Workflow, req.Reason)
+	ctx, err := annotateCorrelationID(ctx, req.Workflow, req.CorrelationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProvenance(req.Workflow, req.Provenance); err != nil {
+		return nil, err
+	}
 
 	wftmplGetter := s.wftmplStore.Getter(ctx, req.Workflow.Namespace)
 	cwftmplGetter := s.cwftmplStore.Getter(ctx)
 
-	err := validate.ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{})
+	err = s.validationCache.validate(ctx, wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	err = s.checkResourceFit(ctx, req.Workflow, wftmplGetter, cwftmplGetter)
+	if err != nil {
+		return nil, err
+	}
 
 	// if we are doing a normal dryRun, just return the workflow un-altered
 	if req.CreateOptions != nil && len(req.CreateOptions.DryRun) > 0 {
@@ -129,9 +263,37 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return workflow, nil
 	}
 
-	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, req.Workflow, metav1.CreateOptions{})
 	logger := logging.RequireLoggerFromContext(ctx)
+	if req.Uid != "" {
+		existing, err := s.getWorkflowByClientUID(ctx, wfClient, req.Namespace, req.Uid)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			logger.WithFields(logging.Fields{"namespace": existing.Namespace, "workflow": existing.Name, "uid": req.Uid}).Info(ctx, "Workflow with UID already exists, returning it")
+			return existing, nil
+		}
+		if req.Workflow.Labels == nil {
+			req.Workflow.Labels = map[string]string{}
+		}
+		req.Workflow.Labels[common.LabelKeyClientUID] = req.Uid
+	}
+
+	giveBack, err := s.submissionQuota.take(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var wf *wfv1.Workflow
+	for attempt := 0; ; attempt++ {
+		wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, req.Workflow, metav1.CreateOptions{})
+		// the API server generates a different name on each attempt, so a collision is unlikely to happen again
+		if !apierr.IsAlreadyExists(err) || req.Workflow.GenerateName == "" || req.Workflow.Name != "" || attempt >= s.generateNameRetries {
+			break
+		}
+		logger.WithField("attempt", attempt+1).WithError(err).Info(ctx, "Generated workflow name already exists, retrying create")
+	}
 	if err != nil {
+		giveBack()
 		if apierr.IsServerTimeout(err) && req.Workflow.GenerateName != "" && req.Workflow.Name != "" {
 			errWithHint := fmt.Errorf(`create request failed due to timeout, but it's possible that workflow "%s" already exists. Original error: %w`, req.Workflow.Name, err)
 			logger.WithError(err).Error(ctx, errWithHint.Error())
@@ -140,29 +302,65 @@ func (s *workflowServer) CreateWorkflow(ctx context.Context, req *workflowpkg.Wo
 		logger.WithError(err).Error(ctx, "Create request failed")
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	logger.WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name}).Info(ctx, "Created workflow")
 
 	return wf, nil
 }
 
+// getWorkflowByClientUID returns the workflow created with the client-provided UID, or nil if there is none.
+func (s *workflowServer) getWorkflowByClientUID(ctx context.Context, wfClient versioned.Interface, namespace, uid string) (*wfv1.Workflow, error) {
+	if errs := validation.IsValidLabelValue(uid); len(errs) > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid uid %q: %s", uid, strings.Join(errs, ", "))
+	}
+	listOptions := metav1.ListOptions{LabelSelector: common.LabelKeyClientUID + "=" + uid}
+	s.instanceIDService.With(&listOptions)
+	list, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+	return &list.Items[0], nil
+}
+
 func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.WorkflowGetRequest) (*wfv1.Workflow, error) {
+	if req.Dehydrated && req.FailedNodesOnly {
+		return nil, status.Error(codes.InvalidArgument, "dehydrated cannot be combined with failedNodesOnly, as it needs the node status")
+	}
 	wfGetOption := metav1.GetOptions{}
 	if req.GetOptions != nil {
 		wfGetOption = *req.GetOptions
 	}
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, wfGetOption)
+	wf, source, err := s.getWorkflowAndSource(ctx, wfClient, req.Namespace, req.Name, wfGetOption, req.LiveOnly)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	if req.Minimal {
+		minimalWorkflow(wf)
+	}
+	setHeader(ctx, workflowpkg.SourceHeader, source)
 	cleaner := fields.NewCleaner(req.Fields)
-	if !cleaner.WillExclude("status.nodes") {
-		if err := s.hydrator.Hydrate(ctx, wf); err != nil {
-			return nil, sutils.ToStatusError(err, codes.Internal)
+	if !req.Dehydrated && !cleaner.WillExclude("status.nodes") {
+		unavailable, err := s.hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		if err != nil {
+			if !req.AllowDegraded {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).WithError(err).Warn(ctx, "Unable to hydrate workflow, returning it without node status")
+			unavailable = err.Error()
 		}
+		if unavailable != "" {
+			setHeader(ctx, workflowpkg.NodeStatusUnavailableHeader, unavailable)
+		}
+	}
+	if req.FailedNodesOnly {
+		wf.Status.Nodes = failedNodes(wf.Status.Nodes)
 	}
 	newWf := &wfv1.Workflow{}
 	if ok, err := cleaner.Clean(wf, &newWf); err != nil {
@@ -174,15 +372,216 @@ func (s *workflowServer) GetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	return wf, nil
 }
 
+func (s *workflowServer) GetWorkflowGraph(ctx context.Context, req *workflowpkg.WorkflowGraphRequest) (*workflowpkg.WorkflowGraph, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if err := s.hydrate(ctx, "GetWorkflowGraph", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return workflowGraph(wf.Status.Nodes), nil
+}
+
+func (s *workflowServer) GetWorkflowManifest(ctx context.Context, req *workflowpkg.WorkflowManifestRequest) (*workflowpkg.WorkflowManifest, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if !req.OmitStatus {
+		if err := s.hydrate(ctx, "GetWorkflowManifest", wf); err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+	manifest, err := workflowManifest(wf, req.OmitManagedFields, req.OmitStatus)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowpkg.WorkflowManifest{Yaml: manifest}, nil
+}
+
+func (s *workflowServer) GetWorkflowOutputs(ctx context.Context, req *workflowpkg.WorkflowOutputsRequest) (*wfv1.Outputs, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	// the global outputs are never offloaded with the node status, so the workflow need not be hydrated
+	if wf.Status.Outputs == nil {
+		return &wfv1.Outputs{}, nil
+	}
+	return wf.Status.Outputs, nil
+}
+
+func (s *workflowServer) GetWorkflowAllowedVerbs(ctx context.Context, req *workflowpkg.WorkflowAllowedVerbsRequest) (*workflowpkg.WorkflowAllowedVerbs, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	verbs, err := allowedWorkflowVerbs(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowpkg.WorkflowAllowedVerbs{Verbs: verbs}, nil
+}
+
+func (s *workflowServer) GetWorkflowLineage(ctx context.Context, req *workflowpkg.WorkflowLineageRequest) (*workflowpkg.WorkflowLineage, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	lineage, err := getWorkflowLineage(ctx, wfClient, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return lineage, nil
+}
+
+func (s *workflowServer) GetWorkflowCallStacks(ctx context.Context, req *workflowpkg.WorkflowCallStacksRequest) (*workflowpkg.WorkflowCallStacks, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if err := s.hydrate(ctx, "GetWorkflowCallStacks", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	stacks := &workflowpkg.WorkflowCallStacks{Nodes: map[string]*workflowpkg.CallStack{}}
+	for id, templates := range callStacks(wf.Status.Nodes) {
+		stacks.Nodes[id] = &workflowpkg.CallStack{Templates: templates}
+	}
+	return stacks, nil
+}
+
+func (s *workflowServer) GetWorkflowPodResources(ctx context.Context, req *workflowpkg.WorkflowPodResourcesRequest) (*workflowpkg.WorkflowPodResources, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	kubeClient := auth.GetKubeClient(ctx)
+	resources, err := getPodResources(ctx, kubeClient, kubeClient.Discovery().RESTClient(), wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return resources, nil
+}
+
+func (s *workflowServer) GetWorkflowPendingApprovals(ctx context.Context, req *workflowpkg.WorkflowPendingApprovalsRequest) (*workflowpkg.WorkflowPendingApprovals, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if err := s.hydrate(ctx, "GetWorkflowPendingApprovals", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowpkg.WorkflowPendingApprovals{Items: pendingApprovals(wf.Status.Nodes)}, nil
+}
+
+func (s *workflowServer) GetWorkflowPendingDiagnostic(ctx context.Context, req *workflowpkg.WorkflowPendingDiagnosticRequest) (*workflowpkg.WorkflowPendingDiagnostic, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if err := s.hydrate(ctx, "GetWorkflowPendingDiagnostic", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	diagnostic := diagnosePending(ctx, auth.GetKubeClient(ctx), wf)
+	if diagnostic == nil {
+		return &workflowpkg.WorkflowPendingDiagnostic{}, nil
+	}
+	return &workflowpkg.WorkflowPendingDiagnostic{Code: diagnostic.code, Reason: diagnostic.message}, nil
+}
+
+func (s *workflowServer) GetWorkflowResourceUsage(ctx context.Context, req *workflowpkg.WorkflowResourceUsageRequest) (*workflowpkg.WorkflowResourceUsage, error) {
+	wfClient := auth.GetWfClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if err := s.hydrate(ctx, "GetWorkflowResourceUsage", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	usage := &workflowpkg.WorkflowResourceUsage{ResourcesDuration: map[string]int64{}}
+	for name, duration := range aggregateResourceUsage(wf.Status.Nodes) {
+		usage.ResourcesDuration[string(name)] = int64(duration)
+	}
+	return usage, nil
+}
+
 func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest) (*wfv1.WorkflowList, error) {
 	listOption := metav1.ListOptions{}
 	if req.ListOptions != nil {
 		listOption = *req.ListOptions
 	}
 	s.instanceIDService.With(&listOption)
+	if req.CreatedBy != "" {
+		if errs := validation.IsValidLabelValue(req.CreatedBy); len(errs) > 0 {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid createdBy %q: %s", req.CreatedBy, strings.Join(errs, "; ")))
+		}
+		if len(listOption.LabelSelector) > 0 {
+			listOption.LabelSelector += ","
+		}
+		listOption.LabelSelector += fmt.Sprintf("%s=%s", common.LabelKeyCreator, req.CreatedBy)
+	}
 
 	options, err := sutils.BuildListOptions(listOption, req.Namespace, "", req.NameFilter, req.CreatedAfter, req.FinishedBefore)
 
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := sutils.ParseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	options = options.WithOrderBy(orderBy)
+	options, err = options.WithDurations(req.MinDuration, req.MaxDuration)
 	if err != nil {
 		return nil, err
 	}
@@ -196,23 +595,102 @@ func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.Wor
 		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Permission denied, you are not allowed to list workflows in namespace \"%s\". Maybe you want to specify a namespace with query parameter `.namespace=%s`?", options.Namespace, options.Namespace))
 	}
 
+	var wfs wfv1.Workflows
+	var meta metav1.ListMeta
+	if req.Filter != "" || options.MinDuration > 0 || options.MaxDuration > 0 || req.SuspendedOnly || req.RetriesExhausted {
+		var filter *workflowFilter
+		if req.Filter != "" {
+			filter, err = newWorkflowFilter(req.Filter)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", req.Filter, err)
+			}
+		}
+		wfs, meta, err = s.listFilteredWorkflows(ctx, req, listOption, options, filter)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		wfs, meta, err = s.listWorkflows(ctx, req, listOption, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if s.wfReflector != nil {
+		meta.ResourceVersion = s.wfReflector.LastSyncResourceVersion()
+	}
+
+	cleaner := fields.NewCleaner(req.Fields)
+	logger := logging.RequireLoggerFromContext(ctx)
+	if s.offloadNodeStatusRepo.IsEnabled() && !cleaner.WillExclude("items.status.nodes") {
+		offloadedNodes, err := s.offloadNodeStatusRepo.List(ctx, req.Namespace)
+		if err != nil {
+			s.metrics.OffloadHydrationFailed(ctx, "ListWorkflows", metrics.HydrationFailed)
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		for i, wf := range wfs {
+			if wf.Status.IsOffloadNodeStatus() {
+				if s.offloadNodeStatusRepo.IsEnabled() {
+					nodes, ok := offloadedNodes[sqldb.UUIDVersion{UID: string(wf.UID), Version: wf.GetOffloadNodeStatusVersion()}]
+					if !ok {
+						logger.WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).Warn(ctx, "Offloaded node status not found")
+						s.metrics.OffloadHydrationFailed(ctx, "ListWorkflows", metrics.OffloadDataMissing)
+					}
+					wfs[i].Status.Nodes = nodes
+				} else {
+					logger.WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).Warn(ctx, sqldb.OffloadNodeStatusDisabled)
+				}
+			}
+		}
+	}
+
+	// we make no promises about the overall list sorting, we just sort each page
+	sortWorkflows(wfs, orderBy)
+
+	res := &wfv1.WorkflowList{ListMeta: meta, Items: wfs}
+	newRes := &wfv1.WorkflowList{}
+	if ok, err := cleaner.Clean(res, &newRes); err != nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("unable to CleanFields in request: %w", err), codes.Internal)
+	} else if ok {
+		return newRes, nil
+	}
+	return res, nil
+}
+
+func (s *workflowServer) ListWorkflowProgress(ctx context.Context, req *workflowpkg.WorkflowListRequest) (*workflowpkg.WorkflowProgressList, error) {
+	listReq := *req
+	// only the fields the progress needs, so that the node status is not loaded
+	listReq.Fields = "metadata,items.metadata.name,items.metadata.namespace,items.status.progress"
+	wfList, err := s.ListWorkflows(ctx, &listReq)
+	if err != nil {
+		return nil, err
+	}
+	list := &workflowpkg.WorkflowProgressList{Metadata: &wfList.ListMeta, Items: make([]*workflowpkg.WorkflowProgress, len(wfList.Items))}
+	for i, wf := range wfList.Items {
+		percent, valid := wf.Status.Progress.Percent()
+		list.Items[i] = &workflowpkg.WorkflowProgress{Name: wf.Name, Namespace: wf.Namespace, Progress: string(wf.Status.Progress), Percent: percent, Valid: valid}
+	}
+	return list, nil
+}
+
+// listWorkflows lists a page of the live workflows, followed by the archived workflows
+func (s *workflowServer) listWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest, listOption metav1.ListOptions, options sutils.ListOptions) (wfv1.Workflows, metav1.ListMeta, error) {
 	var wfs wfv1.Workflows
 	liveWfCount, err := s.wfLister.CountWorkflows(ctx, req.Namespace, req.NameFilter, req.CreatedAfter, req.FinishedBefore, listOption)
 	if err != nil {
-		return nil, sutils.ToStatusError(err, codes.Internal)
+		return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
 	}
 	archivedCount, err := s.wfArchive.CountWorkflows(ctx, options)
 	if err != nil {
-		return nil, sutils.ToStatusError(err, codes.Internal)
+		return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
 	}
 	totalCount := liveWfCount + archivedCount
 
 	// first fetch live workflows
 	liveWfList := &wfv1.WorkflowList{}
 	if liveWfCount > 0 && (options.Limit == 0 || options.Offset < int(liveWfCount)) {
-		liveWfList, err = s.wfLister.ListWorkflows(ctx, req.Namespace, req.NameFilter, req.CreatedAfter, req.FinishedBefore, listOption)
+		liveWfList, err = s.wfLister.ListWorkflows(ctx, req.Namespace, req.NameFilter, req.CreatedAfter, req.FinishedBefore, req.OrderBy, listOption)
 		if err != nil {
-			return nil, sutils.ToStatusError(err, codes.Internal)
+			return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
 		}
 		wfs = append(wfs, liveWfList.Items...)
 	}
@@ -228,58 +706,335 @@ func (s *workflowServer) ListWorkflows(ctx context.Context, req *workflowpkg.Wor
 		}
 		archivedWfList, err := s.wfArchive.ListWorkflows(ctx, options.WithLimit(archivedLimit).WithOffset(archivedOffset))
 		if err != nil {
-			return nil, sutils.ToStatusError(err, codes.Internal)
+			return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
 		}
 		wfs = append(wfs, archivedWfList...)
 	}
-	meta := metav1.ListMeta{ResourceVersion: liveWfList.ResourceVersion}
-	if s.wfReflector != nil {
-		meta.ResourceVersion = s.wfReflector.LastSyncResourceVersion()
+	return wfs, listMeta(liveWfList.ResourceVersion, options, totalCount, len(wfs)), nil
+}
+
+// filteredListBatchSize is the number of archived workflows fetched at a time when they are filtered in memory
+const filteredListBatchSize = 500
+
+// listFilteredWorkflows lists a page of the workflows that match the filter, which may be nil, and the options' durations,
+// and, if the request is for them only, that are awaiting approval, or that exhausted their retries. The label
+// comparisons of the filter are pushed down to both stores, and the phase comparisons and durations to the archive.
+// What cannot be pushed down is evaluated in memory, live workflows first, then archived workflows a batch at a time,
+// until the page, and one more workflow, have matched, so a page only evaluates, and hydrates, the workflows up to its
+// end. If the archive can match everything itself, it is counted, and only the page is fetched from it. Archived workflows have
+// completed, so are not fetched if only those awaiting approval are listed. Archived workflows are listed without their
+// nodes, so if only those that exhausted their retries are listed, the whole of each archived workflow that mentions
+// exhausted retries is fetched instead. The remaining item count is only known if the matches were not cut short.
+func (s *workflowServer) listFilteredWorkflows(ctx context.Context, req *workflowpkg.WorkflowListRequest, listOption metav1.ListOptions, options sutils.ListOptions, filter *workflowFilter) (wfv1.Workflows, metav1.ListMeta, error) {
+	page := &filteredPage{offset: options.Offset, limit: options.Limit}
+	now := time.Now()
+	liveListOption := listOption
+	if filter != nil {
+		liveListOption.LabelSelector = filter.pushdown.labelSelector(liveListOption.LabelSelector)
+	}
+	// live workflows are listed from the server's cache, in one go, as the lister may not page by offset
+	liveListOption.Limit = 0
+	liveListOption.Continue = ""
+	liveWfList, err := s.wfLister.ListWorkflows(ctx, req.Namespace, req.NameFilter, req.CreatedAfter, req.FinishedBefore, req.OrderBy, liveListOption)
+	if err != nil {
+		return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
+	}
+	resourceVersion := liveWfList.ResourceVersion
+	for i := 0; i < len(liveWfList.Items) && !page.full(); i++ {
+		wf := &liveWfList.Items[i]
+		if matchesDuration(wf, options.MinDuration, options.MaxDuration, now) && s.matchesListRequest(ctx, req, filter, wf) {
+			page.add(*wf)
+		}
+	}
+
+	known := !page.full()
+	archivedOptions := options
+	if filter != nil {
+		archivedOptions = filter.pushdown.apply(options)
+	}
+	// the archive matches the durations itself
+	switch {
+	case req.SuspendedOnly || page.full():
+	case !req.RetriesExhausted && (filter == nil || filter.pushdown.complete):
+		err = s.listArchivedPage(ctx, archivedOptions, page)
+	default:
+		err = scanWorkflows(func(offset, limit int) (wfv1.Workflows, error) {
+			if req.RetriesExhausted {
+				return s.wfArchive.ListWorkflowsContaining(ctx, archivedOptions.WithLimit(limit).WithOffset(offset), common.RetriesExhaustedMessage)
+			}
+			return s.wfArchive.ListWorkflows(ctx, archivedOptions.WithLimit(limit).WithOffset(offset))
+		}, func(wf *wfv1.Workflow) bool {
+			if s.matchesListRequest(ctx, req, filter, wf) {
+				page.add(*wf)
+			}
+			return !page.full()
+		})
+		known = !page.full()
+	}
+	if err != nil {
+		return nil, metav1.ListMeta{}, sutils.ToStatusError(err, codes.Internal)
+	}
+	if known {
+		return page.items, listMeta(resourceVersion, options, int64(page.matched), len(page.items)), nil
+	}
+	return page.items, metav1.ListMeta{ResourceVersion: resourceVersion, Continue: strconv.Itoa(options.Offset + len(page.items))}, nil
+}
+
+// listArchivedPage adds the archived workflows that are on the page to it, and counts those that are not, for an archive
+// that matches everything the workflows are listed by itself
+func (s *workflowServer) listArchivedPage(ctx context.Context, options sutils.ListOptions, page *filteredPage) error {
+	count, err := s.wfArchive.CountWorkflows(ctx, options)
+	if err != nil {
+		return err
+	}
+	skip := min(max(page.offset-page.matched, 0), int(count))
+	limit := int(count) - skip
+	if page.limit > 0 {
+		limit = min(limit, page.offset+page.limit-max(page.offset, page.matched))
+	}
+	total := page.matched + int(count)
+	if limit > 0 {
+		page.matched += skip
+		wfs, err := s.wfArchive.ListWorkflows(ctx, options.WithLimit(limit).WithOffset(skip))
+		if err != nil {
+			return err
+		}
+		for _, wf := range wfs {
+			page.add(wf)
+		}
+	}
+	page.matched = total
+	return nil
+}
+
+// matchesListRequest returns whether the workflow matches the filter, which may be nil, and, if the request is for them
+// only, is awaiting approval, or exhausted its retries. The filter is evaluated first, as the others may hydrate the
+// workflow.
+func (s *workflowServer) matchesListRequest(ctx context.Context, req *workflowpkg.WorkflowListRequest, filter *workflowFilter, wf *wfv1.Workflow) bool {
+	if filter != nil {
+		matched, err := filter.matches(wf)
+		if err != nil {
+			logging.RequireLoggerFromContext(ctx).WithError(err).WithField("workflow", wf.Name).Debug(ctx, "Failed to evaluate filter, skipping workflow")
+		}
+		if !matched {
+			return false
+		}
+	}
+	if req.SuspendedOnly && !s.awaitingApproval(ctx, wf) {
+		return false
+	}
+	return !req.RetriesExhausted || s.retriesExhausted(ctx, wf)
+}
+
+// filteredPage collects a page of the workflows that match, counting those that matched before it, and after it, up to
+// one more than the page, which is enough to know whether there are more
+type filteredPage struct {
+	offset, limit int
+	matched       int
+	items         wfv1.Workflows
+}
+
+func (p *filteredPage) add(wf wfv1.Workflow) {
+	if p.matched >= p.offset && (p.limit == 0 || len(p.items) < p.limit) {
+		p.items = append(p.items, wf)
+	}
+	p.matched++
+}
+
+// full returns whether the page, and one more workflow, have matched. A page without a limit is never full.
+func (p *filteredPage) full() bool {
+	return p.limit > 0 && p.matched > p.offset+p.limit
+}
+
+// scanWorkflows visits each workflow listed, a batch at a time, until visit returns false or there are no more
+func scanWorkflows(list func(offset, limit int) (wfv1.Workflows, error), visit func(wf *wfv1.Workflow) bool) error {
+	for offset := 0; ; offset += filteredListBatchSize {
+		wfs, err := list(offset, filteredListBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range wfs {
+			if !visit(&wfs[i]) {
+				return nil
+			}
+		}
+		if len(wfs) < filteredListBatchSize {
+			return nil
+		}
+	}
+}
+
+// awaitingApproval returns whether the live workflow is awaiting approval, hydrating a copy of it if its nodes are needed,
+// so that it is listed as it is stored
+func (s *workflowServer) awaitingApproval(ctx context.Context, wf *wfv1.Workflow) bool {
+	if wf.Status.Fulfilled() || awaitingApproval(wf) || s.hydrator.IsHydrated(wf) {
+		return awaitingApproval(wf)
+	}
+	hydrated := wf.DeepCopy()
+	if err := s.hydrate(ctx, "ListWorkflows", hydrated); err != nil {
+		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).WithError(err).Warn(ctx, "Unable to hydrate workflow, skipping it")
+		return false
+	}
+	return awaitingApproval(hydrated)
+}
+
+// retriesExhausted returns whether the workflow exhausted its retries, hydrating a copy of it if its nodes are needed,
+// so that it is listed as it is stored
+func (s *workflowServer) retriesExhausted(ctx context.Context, wf *wfv1.Workflow) bool {
+	if !wf.Status.Phase.Completed() || retriesExhausted(wf) || s.hydrator.IsHydrated(wf) {
+		return retriesExhausted(wf)
+	}
+	hydrated := wf.DeepCopy()
+	if err := s.hydrate(ctx, "ListWorkflows", hydrated); err != nil {
+		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).WithError(err).Warn(ctx, "Unable to hydrate workflow, skipping it")
+		return false
+	}
+	return retriesExhausted(hydrated)
+}
+
+// matchesDuration returns whether the workflow ran for at least minDuration and at most maxDuration, if they are not
+// zero. A running workflow matches minDuration once it has run that long, but never matches maxDuration, as it may yet
+// run for longer. A workflow that has not started matches neither.
+func matchesDuration(wf *wfv1.Workflow, minDuration, maxDuration time.Duration, now time.Time) bool {
+	if minDuration == 0 && maxDuration == 0 {
+		return true
+	}
+	if wf.Status.StartedAt.IsZero() {
+		return false
+	}
+	finishedAt := now
+	if !wf.Status.FinishedAt.IsZero() {
+		finishedAt = wf.Status.FinishedAt.Time
+	} else if maxDuration > 0 {
+		return false
+	}
+	duration := finishedAt.Sub(wf.Status.StartedAt.Time)
+	return duration >= minDuration && (maxDuration == 0 || duration <= maxDuration)
+}
+
+// listMeta returns the metadata of a page of workflows, of pageLen items, starting at options.Offset
+func listMeta(resourceVersion string, options sutils.ListOptions, totalCount int64, pageLen int) metav1.ListMeta {
+	meta := metav1.ListMeta{ResourceVersion: resourceVersion}
+	remainCount := totalCount - int64(options.Offset) - int64(pageLen)
+	if remainCount < 0 {
+		remainCount = 0
+	}
+	if remainCount > 0 {
+		meta.Continue = fmt.Sprintf("%v", options.Offset+pageLen)
+	}
+	if options.ShowRemainingItemCount {
+		meta.RemainingItemCount = &remainCount
+	}
+	return meta
+}
+
+func (s *workflowServer) ListWorkflowTemplateGroups(ctx context.Context, req *workflowpkg.WorkflowTemplateGroupsRequest) (*workflowpkg.WorkflowTemplateGroupList, error) {
+	listOption := metav1.ListOptions{}
+	if req.ListOptions != nil {
+		listOption = *req.ListOptions
+	}
+	s.instanceIDService.With(&listOption)
+	options, err := sutils.BuildListOptions(listOption, req.Namespace, "", "", "", "")
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, options.Namespace, "")
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	remainCount := totalCount - int64(options.Offset) - int64(len(wfs))
-	if remainCount < 0 {
-		remainCount = 0
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Permission denied, you are not allowed to list workflows in namespace \"%s\". Maybe you want to specify a namespace with query parameter `.namespace=%s`?", options.Namespace, options.Namespace))
 	}
-	if remainCount > 0 {
-		meta.Continue = fmt.Sprintf("%v", options.Offset+len(wfs))
+	live, err := s.wfLister.GroupWorkflowsByTemplate(ctx, req.Namespace, listOption)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	if options.ShowRemainingItemCount {
-		meta.RemainingItemCount = &remainCount
+	archived, err := s.wfArchive.GroupWorkflowsByTemplate(ctx, options)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	return mergeTemplateGroups(live, archived), nil
+}
 
-	cleaner := fields.NewCleaner(req.Fields)
-	logger := logging.RequireLoggerFromContext(ctx)
-	if s.offloadNodeStatusRepo.IsEnabled() && !cleaner.WillExclude("items.status.nodes") {
-		offloadedNodes, err := s.offloadNodeStatusRepo.List(ctx, req.Namespace)
-		if err != nil {
-			return nil, sutils.ToStatusError(err, codes.Internal)
-		}
-		for i, wf := range wfs {
-			if wf.Status.IsOffloadNodeStatus() {
-				if s.offloadNodeStatusRepo.IsEnabled() {
-					wfs[i].Status.Nodes = offloadedNodes[sqldb.UUIDVersion{UID: string(wf.UID), Version: wf.GetOffloadNodeStatusVersion()}]
-				} else {
-					logger.WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name}).Warn(ctx, sqldb.OffloadNodeStatusDisabled)
-				}
+func (s *workflowServer) ListWorkflowNamespaces(ctx context.Context, req *workflowpkg.ListWorkflowNamespacesRequest) (*workflowpkg.WorkflowNamespaceList, error) {
+	namespaces, err := s.workflowNamespaces(ctx)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	namespaces = s.allowedNamespaces.Filter(namespaces)
+	// a user that can list workflows in every namespace needs only one access review
+	allowedAll, err := s.canListWorkflows(ctx, "")
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	items := []string{}
+	for _, namespace := range namespaces {
+		allowed := allowedAll
+		if !allowed {
+			allowed, err = s.canListWorkflows(ctx, namespace)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
 			}
 		}
+		if allowed {
+			items = append(items, namespace)
+		}
 	}
+	return &workflowpkg.WorkflowNamespaceList{Items: items}, nil
+}
 
-	// we make no promises about the overall list sorting, we just sort each page
-	sort.Sort(wfs)
+// canListWorkflows returns whether the user can list the workflows of the namespace, or of every namespace if empty. A
+// user that cannot review their own access cannot list them.
+func (s *workflowServer) canListWorkflows(ctx context.Context, namespace string) (bool, error) {
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, namespace, "")
+	if apierr.IsForbidden(err) {
+		return false, nil
+	}
+	return allowed, err
+}
 
-	res := &wfv1.WorkflowList{ListMeta: meta, Items: wfs}
-	newRes := &wfv1.WorkflowList{}
-	if ok, err := cleaner.Clean(res, &newRes); err != nil {
-		return nil, sutils.ToStatusError(fmt.Errorf("unable to CleanFields in request: %w", err), codes.Internal)
-	} else if ok {
-		return newRes, nil
+// workflowNamespaces returns the distinct namespaces of the live and archived workflows of this instance, sorted, or
+// only the managed namespace if the server has one. They are cached for all users, so they must be filtered by
+// permission before being returned.
+func (s *workflowServer) workflowNamespaces(ctx context.Context) ([]string, error) {
+	if s.managedNamespace != "" {
+		return []string{s.managedNamespace}, nil
 	}
-	return res, nil
+	if namespaces, ok := s.namespaces.Get(namespacesCacheKey); ok {
+		return namespaces.([]string), nil
+	}
+	listOptions := metav1.ListOptions{}
+	s.instanceIDService.With(&listOptions)
+	live, err := s.wfLister.ListNamespaces(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := s.wfArchive.ListWorkflowNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	namespaces := append(live, archived...)
+	slices.Sort(namespaces)
+	namespaces = slices.Compact(namespaces)
+	s.namespaces.Add(namespacesCacheKey, namespaces)
+	return namespaces, nil
 }
 
 func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest, ws workflowpkg.WorkflowService_WatchWorkflowsServer) error {
 	ctx := ws.Context()
+	var filter *workflowFilter
+	if req.Filter != "" {
+		var err error
+		filter, err = newWorkflowFilter(req.Filter)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid filter %q: %v", req.Filter, err)
+		}
+	}
+	release, err := s.watches.acquire(ctx, "WatchWorkflows")
+	if err != nil {
+		return err
+	}
+	defer release()
 	wfClient := auth.GetWfClient(ctx)
 	opts := &metav1.ListOptions{}
 	if req.ListOptions != nil {
@@ -288,13 +1043,23 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 		if wfName != "" {
 			// If we are using an alias (such as `@latest`) we need to dereference it.
 			// s.getWorkflow does that for us
-			wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, wfName, metav1.GetOptions{})
+			wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, wfName, metav1.GetOptions{}, false)
 			if err != nil {
 				return sutils.ToStatusError(err, codes.Internal)
 			}
 			opts.FieldSelector = argoutil.GenerateFieldSelectorFromWorkflowName(wf.Name)
 		}
 	}
+	if req.CronWorkflowName != "" {
+		if errs := validation.IsValidLabelValue(req.CronWorkflowName); len(errs) > 0 {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid cronWorkflowName %q: %s", req.CronWorkflowName, strings.Join(errs, "; ")))
+		}
+		// the cron workflow labels, as well as owns, the workflows it starts
+		if len(opts.LabelSelector) > 0 {
+			opts.LabelSelector += ","
+		}
+		opts.LabelSelector += fmt.Sprintf("%s=%s", common.LabelKeyCronWorkflow, req.CronWorkflowName)
+	}
 	s.instanceIDService.With(opts)
 	wfIf := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace)
 	watch, err := wfIf.Watch(ctx, *opts)
@@ -341,17 +1106,37 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 				// object is probably metav1.Status, `FromObject` can deal with anything
 				return sutils.ToStatusError(apierr.FromObject(event.Object), codes.Internal)
 			}
-			if !cleaner.WillExclude("status.nodes") {
-				if err := s.hydrator.Hydrate(ctx, wf); err != nil {
+			// the filter may need the nodes, even if the client does not
+			var unavailable string
+			if (!req.MetadataOnly && !cleaner.WillExclude("status.nodes")) || filter != nil {
+				unavailable, err = s.hydrateWithinLimit(ctx, "WatchWorkflows", wf)
+				if err != nil {
 					return sutils.ToStatusError(err, codes.Internal)
 				}
 			}
+			if filter != nil {
+				matched, err := filter.matches(wf)
+				if err != nil {
+					logger.WithError(err).WithField("workflow", wf.Name).Debug(ctx, "Failed to evaluate filter, skipping workflow event")
+				}
+				if !matched {
+					continue
+				}
+			}
 			newWf, err := clean(wf)
 			if err != nil {
 				return sutils.ToStatusError(fmt.Errorf("unable to CleanFields in request: %w", err), codes.Internal)
 			}
+			if req.MetadataOnly {
+				newWf = metadataOnly(wf)
+			}
 			logger.WithFields(logging.Fields{"workflow": wf.Name, "type": event.Type, "phase": wf.Status.Phase}).Debug(ctx, "Sending workflow event")
-			err = ws.Send(&workflowpkg.WorkflowWatchEvent{Type: string(event.Type), Object: newWf})
+			watchEvent := &workflowpkg.WorkflowWatchEvent{Type: string(event.Type), Object: newWf}
+			if req.CloudEvents {
+				watchEvent = &workflowpkg.WorkflowWatchEvent{CloudEvent: newCloudEvent(event.Type, wf, newWf)}
+			}
+			watchEvent.NodeStatusUnavailable = unavailable
+			err = ws.Send(watchEvent)
 			if err != nil {
 				return sutils.ToStatusError(err, codes.Internal)
 			}
@@ -359,8 +1144,103 @@ func (s *workflowServer) WatchWorkflows(req *workflowpkg.WatchWorkflowsRequest,
 	}
 }
 
+// setHeader sets a header of the response to a unary call, logging rather than failing the call if it cannot be set,
+// e.g. when the server is called directly rather than over gRPC
+func setHeader(ctx context.Context, key, value string) {
+	if err := grpc.SetHeader(ctx, metadata.Pairs(key, value)); err != nil {
+		logging.RequireLoggerFromContext(ctx).WithError(err).WithField("header", key).Warn(ctx, "Failed to set header")
+	}
+}
+
+// metadataOnly returns a workflow with only the metadata and phase of the workflow, for clients that only index them.
+func metadataOnly(wf *wfv1.Workflow) *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            wf.Name,
+			Namespace:       wf.Namespace,
+			UID:             wf.UID,
+			ResourceVersion: wf.ResourceVersion,
+			Labels:          wf.Labels,
+		},
+		Status: wfv1.WorkflowStatus{Phase: wf.Status.Phase},
+	}
+}
+
+func (s *workflowServer) WatchWorkflowNodes(req *workflowpkg.WatchWorkflowNodesRequest, ws workflowpkg.WorkflowService_WatchWorkflowNodesServer) error {
+	ctx := ws.Context()
+	release, err := s.watches.acquire(ctx, "WatchWorkflowNodes")
+	if err != nil {
+		return err
+	}
+	defer release()
+	wfClient := auth.GetWfClient(ctx)
+	// resolves any alias (such as `@latest`), and returns NotFound rather than watching a workflow that does not exist
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, true)
+	if err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	if err := s.hydrate(ctx, "WatchWorkflowNodes", wf); err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	// watch from the version we got, so that no change is missed between sending the initial nodes and the first event
+	opts := &metav1.ListOptions{FieldSelector: argoutil.GenerateFieldSelectorFromWorkflowName(wf.Name), ResourceVersion: wf.ResourceVersion}
+	s.instanceIDService.With(opts)
+	watcher, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Watch(ctx, *opts)
+	if err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	defer watcher.Stop()
+	logger := logging.RequireLoggerFromContext(ctx)
+
+	// see WatchWorkflows
+	err = ws.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	nodes := wf.Status.Nodes
+	for _, delta := range nodeDeltas(nil, nodes) {
+		if err := ws.Send(delta); err != nil {
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				return sutils.ToStatusError(io.EOF, codes.ResourceExhausted)
+			}
+			wf, ok := event.Object.(*wfv1.Workflow)
+			if !ok {
+				return sutils.ToStatusError(apierr.FromObject(event.Object), codes.Internal)
+			}
+			if event.Type == watch.Deleted {
+				return nil
+			}
+			if err := s.hydrate(ctx, "WatchWorkflowNodes", wf); err != nil {
+				return sutils.ToStatusError(err, codes.Internal)
+			}
+			deltas := nodeDeltas(nodes, wf.Status.Nodes)
+			logger.WithFields(logging.Fields{"workflow": wf.Name, "type": event.Type, "deltas": len(deltas)}).Debug(ctx, "Sending node deltas")
+			for _, delta := range deltas {
+				if err := ws.Send(delta); err != nil {
+					return sutils.ToStatusError(err, codes.Internal)
+				}
+			}
+			nodes = wf.Status.Nodes
+		}
+	}
+}
+
 func (s *workflowServer) WatchEvents(req *workflowpkg.WatchEventsRequest, ws workflowpkg.WorkflowService_WatchEventsServer) error {
 	ctx := ws.Context()
+	release, err := s.watches.acquire(ctx, "WatchEvents")
+	if err != nil {
+		return err
+	}
+	defer release()
 	kubeClient := auth.GetKubeClient(ctx)
 	opts := &metav1.ListOptions{}
 	if req.ListOptions != nil {
@@ -368,6 +1248,17 @@ func (s *workflowServer) WatchEvents(req *workflowpkg.WatchEventsRequest, ws wor
 	}
 	s.instanceIDService.With(opts)
 	eventInterface := kubeClient.CoreV1().Events(req.Namespace)
+	var recent []corev1.Event
+	if req.SendRecent > 0 {
+		list, err := eventInterface.List(ctx, *opts)
+		if err != nil {
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+		recent = recentEvents(list.Items, int(req.SendRecent))
+		// watch from the list, so that no event is missed or sent twice
+		opts = opts.DeepCopy()
+		opts.ResourceVersion = list.ResourceVersion
+	}
 	watch, err := eventInterface.Watch(ctx, *opts)
 	if err != nil {
 		return sutils.ToStatusError(err, codes.Internal)
@@ -384,6 +1275,13 @@ func (s *workflowServer) WatchEvents(req *workflowpkg.WatchEventsRequest, ws wor
 		return sutils.ToStatusError(err, codes.Internal)
 	}
 
+	logger.WithField("recent", len(recent)).Debug(ctx, "Sending recent events")
+	for i := range recent {
+		if err := ws.Send(&recent[i]); err != nil {
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -407,27 +1305,154 @@ func (s *workflowServer) WatchEvents(req *workflowpkg.WatchEventsRequest, ws wor
 	}
 }
 
+// recentEvents returns the n most recent of the events, oldest first.
+func recentEvents(events []corev1.Event, n int) []corev1.Event {
+	sort.SliceStable(events, func(i, j int) bool {
+		return eventTimestamp(events[i]).Before(eventTimestamp(events[j]))
+	})
+	if len(events) > n {
+		events = events[len(events)-n:]
+	}
+	return events
+}
+
+// eventTimestamp returns when the event last occurred, falling back to when it was first recorded.
+func eventTimestamp(e corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
 func (s *workflowServer) DeleteWorkflow(ctx context.Context, req *workflowpkg.WorkflowDeleteRequest) (*workflowpkg.WorkflowDeleteResponse, error) {
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
-	if req.Force {
-		_, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, []byte("{\"metadata\":{\"finalizers\":null}}"), metav1.PatchOptions{})
+	err = deleteWorkflow(ctx, wfClient, wf, req.Force, s.deletePropagation(wf.Namespace, req.DeleteOptions))
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowpkg.WorkflowDeleteResponse{}, nil
+}
+
+// DeleteWorkflows deletes the workflows matching the selector. Each workflow is checked and deleted on its own, so
+// one that cannot be deleted is reported in its result rather than failing the request.
+func (s *workflowServer) DeleteWorkflows(ctx context.Context, req *workflowpkg.WorkflowsDeleteRequest) (*workflowpkg.WorkflowsDeleteResponse, error) {
+	listOptions := metav1.ListOptions{}
+	if req.ListOptions != nil {
+		listOptions = *req.ListOptions
+	}
+	// an empty selector would match every workflow in the namespace, which is more likely to be a mistake than intended
+	if listOptions.LabelSelector == "" && listOptions.FieldSelector == "" {
+		return nil, status.Error(codes.InvalidArgument, "a label or field selector is required")
+	}
+	s.instanceIDService.With(&listOptions)
+	opCtx, operationID, finish, err := s.operations.start(ctx, req.Namespace, req.OperationId)
+	if err != nil {
+		return nil, err
+	}
+	defer finish()
+	wfClient := auth.GetWfClient(ctx)
+	wfList, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).List(ctx, listOptions)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	res := &workflowpkg.WorkflowsDeleteResponse{OperationId: operationID, Items: make([]*workflowpkg.WorkflowDeleteResult, 0, len(wfList.Items))}
+	for _, wf := range wfList.Items {
+		// each workflow is deleted using the request's context, so that canceling never leaves a deletion half done
+		if opCtx.Err() != nil {
+			logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"operationId": operationID, "processed": len(res.Items)}).Info(ctx, "Delete workflows operation canceled")
+			res.Canceled = true
+			break
+		}
+		result := &workflowpkg.WorkflowDeleteResult{Name: wf.Name, Namespace: wf.Namespace}
+		res.Items = append(res.Items, result)
+		allowed, err := auth.CanINamed(ctx, "delete", workflow.WorkflowPlural, wf.Namespace, wf.Name)
+		if err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		if !allowed {
+			result.Error = status.Errorf(codes.PermissionDenied, "cannot delete workflow %s", wf.Name).Error()
+			continue
+		}
+		if req.DryRun {
+			continue
+		}
+		if err := deleteWorkflow(ctx, wfClient, &wf, req.Force, s.deletePropagation(wf.Namespace, nil)); err != nil {
+			result.Error = err.Error()
+			continue
+		}
+		result.Deleted = true
+	}
+	return res, nil
+}
+
+// CancelOperation cancels an operation the user started, or one another user started if the user can delete workflows
+// in its namespace.
+func (s *workflowServer) CancelOperation(ctx context.Context, req *workflowpkg.CancelOperationRequest) (*workflowpkg.CancelOperationResponse, error) {
+	op, notFound := s.operations.get(req.Namespace, req.OperationId)
+	// users other than the one that started the operation are checked before whether it exists is returned, so that
+	// they cannot find out which operations are running in namespaces they cannot access
+	if subject := claimsSubject(ctx); op == nil || subject == "" || subject != op.subject {
+		allowed, err := auth.CanI(ctx, "delete", workflow.WorkflowPlural, req.Namespace, "")
 		if err != nil {
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
+		if !allowed {
+			return nil, status.Errorf(codes.PermissionDenied, "you did not start operation %q, and you cannot delete workflows in namespace %s", req.OperationId, req.Namespace)
+		}
+	}
+	if notFound != nil {
+		return nil, notFound
 	}
-	err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(wf.Namespace).Delete(ctx, wf.Name, metav1.DeleteOptions{PropagationPolicy: argoutil.GetDeletePropagation()})
+	op.cancel()
+	return &workflowpkg.CancelOperationResponse{}, nil
+}
+
+func (s *workflowServer) GetMaintenanceMode(ctx context.Context, _ *workflowpkg.GetMaintenanceModeRequest) (*workflowpkg.MaintenanceMode, error) {
+	mode, err := s.maintenance.get(ctx)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	return &workflowpkg.WorkflowDeleteResponse{}, nil
+	return mode, nil
+}
+
+func (s *workflowServer) SetMaintenanceMode(ctx context.Context, req *workflowpkg.SetMaintenanceModeRequest) (*workflowpkg.MaintenanceMode, error) {
+	return s.maintenance.set(ctx, req.Paused, req.Message)
+}
+
+// deletePropagation returns the propagation policy to delete a workflow of the namespace with: the one of the request's
+// delete options if set, or else the one configured for the namespace, or else the global default.
+func (s *workflowServer) deletePropagation(namespace string, deleteOptions *metav1.DeleteOptions) *metav1.DeletionPropagation {
+	if deleteOptions != nil && deleteOptions.PropagationPolicy != nil {
+		return deleteOptions.PropagationPolicy
+	}
+	if propagationPolicy, ok := s.namespaceDeletePropagation[namespace]; ok {
+		return &propagationPolicy
+	}
+	return argoutil.GetDeletePropagation()
+}
+
+// deleteWorkflow deletes the workflow with the propagation policy, first removing its finalizers if forced.
+func deleteWorkflow(ctx context.Context, wfClient versioned.Interface, wf *wfv1.Workflow, force bool, propagationPolicy *metav1.DeletionPropagation) error {
+	if force {
+		_, err := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, []byte("{\"metadata\":{\"finalizers\":null}}"), metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	return wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Delete(ctx, wf.Name, metav1.DeleteOptions{PropagationPolicy: propagationPolicy})
 }
 
 func errorFromChannel(errCh <-chan error) error {
@@ -439,39 +1464,109 @@ func errorFromChannel(errCh <-chan error) error {
 	return nil
 }
 
-func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.WorkflowRetryRequest) (*wfv1.Workflow, error) {
-	logger := logging.RequireLoggerFromContext(ctx)
+func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.WorkflowRetryRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "RetryWorkflow", req.Namespace, err) }()
 	wfClient := auth.GetWfClient(ctx)
-	kubeClient := auth.GetKubeClient(ctx)
 
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	err = s.hydrator.Hydrate(ctx, wf)
+	err = sutils.ValidateRetryOptions(ctx, req.RestartSuccessful, req.NodeFieldSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// the workflow is checked before it is retried, so that a workflow that cannot be retried is a failed precondition,
+	// rather than a bad request
+	err = util.ValidateRetryable(wf, util.RetryOpts{RestartSuccessful: req.RestartSuccessful, NodeFieldSelector: req.NodeFieldSelector})
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	// concurrent identical retries, e.g. from a double click, share one retry rather than failing with a conflict, unless
+	// the user is unknown, as one user's retry must never be done with another user's credentials
+	key, ok := retryKey(ctx, wf, req)
+	if !ok {
+		return s.retryWorkflow(ctx, wf, req)
+	}
+	retried := s.retries.DoChan(key, func() (interface{}, error) {
+		// the retry is completed even if the caller that started it goes away, as the others are waiting for it
+		return s.retryWorkflow(context.WithoutCancel(ctx), wf, req)
+	})
+	if s.retryJoined != nil {
+		s.retryJoined()
+	}
+	result := <-retried
+	if result.Err != nil {
+		return nil, result.Err
+	}
+	wf = result.Val.(*wfv1.Workflow)
+	if result.Shared {
+		wf = wf.DeepCopy()
+	}
+	return wf, nil
+}
+
+// retryWorkflow retries the workflow, which must be retryable
+func (s *workflowServer) retryWorkflow(ctx context.Context, wf *wfv1.Workflow, req *workflowpkg.WorkflowRetryRequest) (*wfv1.Workflow, error) {
+	logger := logging.RequireLoggerFromContext(ctx)
+	wfClient := auth.GetWfClient(ctx)
+	kubeClient := auth.GetKubeClient(ctx)
+
+	err := s.hydrator.Hydrate(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	wf, podsToDelete, err := util.FormulateRetryWorkflow(ctx, wf, req.RestartSuccessful, req.NodeFieldSelector, req.Parameters)
+	wf, podsToDelete, err := util.FormulateRetryWorkflow(ctx, wf, util.RetryOpts{
+		RestartSuccessful:     req.RestartSuccessful,
+		NodeFieldSelector:     req.NodeFieldSelector,
+		Parameters:            req.Parameters,
+		ParametersFrom:        parametersFrom(req.ParametersFrom),
+		ClearOutputParameters: req.ClearOutputParameters,
+	})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
+	err = util.OverrideRetryLimits(wf, req.RetryLimits)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+
+	entrypointPodsToDelete, err := util.OverrideRetryEntrypoint(wf, req.Entrypoint)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	podsToDelete = append(podsToDelete, entrypointPodsToDelete...)
+
 	errCh := make(chan error, len(podsToDelete))
 	var wg sync.WaitGroup
 	wg.Add(len(podsToDelete))
 	for _, podName := range podsToDelete {
-		logger.WithFields(logging.Fields{"podDeleted": podName}).Info(ctx, "Deleting pod")
 		go func(podName string) {
 			defer wg.Done()
-			err := kubeClient.CoreV1().Pods(wf.Namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+			var err error
+			if req.KeepPods {
+				logger.WithFields(logging.Fields{"podKept": podName}).Info(ctx, "Keeping pod")
+				err = keepPod(ctx, kubeClient, wf.Namespace, podName, wf.Name)
+			} else {
+				logger.WithFields(logging.Fields{"podDeleted": podName}).Info(ctx, "Deleting pod")
+				err = kubeClient.CoreV1().Pods(wf.Namespace).Delete(ctx, podName, metav1.DeleteOptions{})
+				if err == nil {
+					// for audit only, so the retry goes on without it
+					if eventErr := recordRetryPodDeleted(ctx, kubeClient, wf, podName); eventErr != nil {
+						logger.WithFields(logging.Fields{"podDeleted": podName}).WithError(eventErr).Warn(ctx, "Failed to record the deletion of the pod")
+					}
+				}
+			}
 			if err != nil && !apierr.IsNotFound(err) {
 				errCh <- err
 				return
@@ -498,22 +1593,46 @@ func (s *workflowServer) RetryWorkflow(ctx context.Context, req *workflowpkg.Wor
 	return wf, nil
 }
 
-func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowResubmitRequest) (*wfv1.Workflow, error) {
+func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowResubmitRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "ResubmitWorkflow", req.Namespace, err) }()
+	if err := s.maintenance.Check(ctx); err != nil {
+		return nil, err
+	}
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 
-	newWF, err := util.FormulateResubmitWorkflow(ctx, wf, req.Memoized, req.Parameters)
+	if req.FailedOnly {
+		if req.Memoized {
+			return nil, status.Error(codes.InvalidArgument, "cannot resubmit only the failed nodes of a workflow in memoized mode")
+		}
+		if err := s.hydrate(ctx, "ResubmitWorkflow", wf); err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+
+	newWF, err := util.FormulateResubmitWorkflow(ctx, wf, util.ResubmitOpts{
+		Memoized:       req.Memoized,
+		Parameters:     req.Parameters,
+		ParametersFrom: parametersFrom(req.ParametersFrom),
+		ServiceAccount: req.ServiceAccount,
+	})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	if req.FailedOnly {
+		if err := util.PruneResubmitToFailedNodes(newWF, wf); err != nil {
+			return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+		}
+	}
+	newWF.OwnerReferences = existingOwnerReferences(ctx, req.Namespace, newWF.OwnerReferences)
 	creator.LabelCreator(ctx, newWF)
 
 	created, err := util.SubmitWorkflow(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wfClient, req.Namespace, newWF, s.wfDefaults, &wfv1.SubmitOpts{})
@@ -523,14 +1642,80 @@ func (s *workflowServer) ResubmitWorkflow(ctx context.Context, req *workflowpkg.
 	return created, nil
 }
 
-func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.WorkflowResumeRequest) (*wfv1.Workflow, error) {
+// existingOwnerReferences removes references to owners that no longer exist, as the garbage collector would
+// otherwise delete a resubmitted workflow as soon as it is created. Owners that cannot be checked are kept.
+func existingOwnerReferences(ctx context.Context, namespace string, refs []metav1.OwnerReference) []metav1.OwnerReference {
+	if len(refs) == 0 {
+		return refs
+	}
+	logger := logging.RequireLoggerFromContext(ctx)
+	var existing []metav1.OwnerReference
+	for _, ref := range refs {
+		exists, err := ownerExists(ctx, namespace, ref)
+		if err != nil {
+			logger.WithFields(logging.Fields{"kind": ref.Kind, "name": ref.Name}).WithError(err).Warn(ctx, "Failed to check owner exists, keeping owner reference")
+			exists = true
+		}
+		if !exists {
+			logger.WithFields(logging.Fields{"kind": ref.Kind, "name": ref.Name}).Info(ctx, "Owner no longer exists, removing owner reference")
+			continue
+		}
+		existing = append(existing, ref)
+	}
+	return existing
+}
+
+func ownerExists(ctx context.Context, namespace string, ref metav1.OwnerReference) (bool, error) {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	resources, err := auth.GetKubeClient(ctx).Discovery().ServerResourcesForGroupVersion(ref.APIVersion)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range resources.APIResources {
+		// skip sub-resources, e.g. "pods/status"
+		if r.Kind != ref.Kind || strings.Contains(r.Name, "/") {
+			continue
+		}
+		resource := auth.GetDynamicClient(ctx).Resource(gv.WithResource(r.Name))
+		var ri dynamic.ResourceInterface = resource
+		if r.Namespaced {
+			ri = resource.Namespace(namespace)
+		}
+		owner, err := ri.Get(ctx, ref.Name, metav1.GetOptions{})
+		if apierr.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return owner.GetUID() == ref.UID, nil
+	}
+	return false, fmt.Errorf("unknown owner kind %s in %s", ref.Kind, ref.APIVersion)
+}
+
+// parametersFrom dereferences the parameters of a request, which are overridden from config map keys
+func parametersFrom(params []*wfv1.Parameter) []wfv1.Parameter {
+	var values []wfv1.Parameter
+	for _, param := range params {
+		if param != nil {
+			values = append(values, *param)
+		}
+	}
+	return values
+}
+
+func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.WorkflowResumeRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "ResumeWorkflow", req.Namespace, err) }()
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -551,15 +1736,16 @@ func (s *workflowServer) ResumeWorkflow(ctx context.Context, req *workflowpkg.Wo
 	return wf, nil
 }
 
-func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.WorkflowSuspendRequest) (*wfv1.Workflow, error) {
+func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.WorkflowSuspendRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "SuspendWorkflow", req.Namespace, err) }()
 	wfClient := auth.GetWfClient(ctx)
 
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -577,15 +1763,16 @@ func (s *workflowServer) SuspendWorkflow(ctx context.Context, req *workflowpkg.W
 	return wf, nil
 }
 
-func (s *workflowServer) TerminateWorkflow(ctx context.Context, req *workflowpkg.WorkflowTerminateRequest) (*wfv1.Workflow, error) {
+func (s *workflowServer) TerminateWorkflow(ctx context.Context, req *workflowpkg.WorkflowTerminateRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "TerminateWorkflow", req.Namespace, err) }()
 	wfClient := auth.GetWfClient(ctx)
 
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -599,16 +1786,23 @@ func (s *workflowServer) TerminateWorkflow(ctx context.Context, req *workflowpkg
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	if req.Wait {
+		wf, err = waitForWorkflowCompleted(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wf, stopWaitTimeout(req.WaitTimeoutSeconds))
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
 	return wf, nil
 }
 
-func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.WorkflowStopRequest) (*wfv1.Workflow, error) {
+func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.WorkflowStopRequest) (_ *wfv1.Workflow, err error) {
+	defer func() { s.metrics.WorkflowOperation(ctx, "StopWorkflow", req.Namespace, err) }()
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -621,16 +1815,29 @@ func (s *workflowServer) StopWorkflow(ctx context.Context, req *workflowpkg.Work
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	if req.Wait {
+		wf, err = waitForWorkflowCompleted(ctx, wfClient.ArgoprojV1alpha1().Workflows(req.Namespace), wf, stopWaitTimeout(req.WaitTimeoutSeconds))
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
 	return wf, nil
 }
 
+func stopWaitTimeout(seconds int64) time.Duration {
+	if seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultStopWaitTimeout
+}
+
 func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.WorkflowSetRequest) (*wfv1.Workflow, error) {
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
@@ -669,43 +1876,267 @@ func (s *workflowServer) SetWorkflow(ctx context.Context, req *workflowpkg.Workf
 	return wf, nil
 }
 
+func (s *workflowServer) ArchiveWorkflow(ctx context.Context, req *workflowpkg.WorkflowArchiveRequest) (*wfv1.Workflow, error) {
+	if !s.wfArchive.IsEnabled() {
+		return nil, status.Error(codes.FailedPrecondition, "workflow archive is not enabled")
+	}
+	wfClient := auth.GetWfClient(ctx)
+	// only the live workflow can be archived, so do not fall back to the archive
+	wf, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, req.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	if !wf.Status.Fulfilled() {
+		return nil, status.Errorf(codes.FailedPrecondition, "workflow %s has not completed, only completed workflows can be archived", wf.Name)
+	}
+	// the live workflow is either labelled as archived, or deleted, so reading it is not enough to archive it. The
+	// workflow is checked by name, so that RBAC rules restricted to it are honoured.
+	verb := "update"
+	if req.DeleteLive {
+		verb = "delete"
+	}
+	allowed, err := auth.CanINamed(ctx, verb, workflow.WorkflowPlural, wf.Namespace, wf.Name)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if !allowed {
+		return nil, status.Errorf(codes.PermissionDenied, "cannot %s workflow %s", verb, wf.Name)
+	}
+	if err := s.hydrate(ctx, "ArchiveWorkflow", wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name, "uid": wf.UID}).Info(ctx, "archiving workflow")
+	if err := s.wfArchive.ArchiveWorkflow(ctx, wf); err != nil {
+		return nil, sutils.ToStatusError(fmt.Errorf("failed to archive workflow: %w", err), codes.Internal)
+	}
+	if req.DeleteLive {
+		err = wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Delete(ctx, wf.Name, metav1.DeleteOptions{PropagationPolicy: s.deletePropagation(wf.Namespace, nil)})
+	} else {
+		// mark the workflow as archived, as the controller does, so that it is not archived again
+		_, err = wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Patch(ctx, wf.Name, types.MergePatchType, []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:"Archived"}}}`, common.LabelKeyWorkflowArchivingStatus)), metav1.PatchOptions{})
+	}
+	// the workflow has been archived, so it is not a problem if it has since been deleted
+	if err != nil && !apierr.IsNotFound(err) {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if wf.Labels == nil {
+		wf.Labels = map[string]string{}
+	}
+	wf.Labels[common.LabelKeyWorkflowArchivingStatus] = "Archived"
+	return wf, nil
+}
+
 func (s *workflowServer) LintWorkflow(ctx context.Context, req *workflowpkg.WorkflowLintRequest) (*wfv1.Workflow, error) {
 	if req.Workflow == nil {
 		return nil, fmt.Errorf("unable to get a workflow")
 	}
+	if err := s.validateRequestSize(req.Workflow); err != nil {
+		return nil, err
+	}
 	wftmplGetter := s.wftmplStore.Getter(ctx, req.Workflow.Namespace)
 	cwftmplGetter := s.cwftmplStore.Getter(ctx)
 	s.instanceIDService.Label(req.Workflow)
 	creator.LabelCreator(ctx, req.Workflow)
 
-	err := validate.ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{Lint: true})
+	err := s.validationCache.validate(ctx, wftmplGetter, cwftmplGetter, req.Workflow, s.wfDefaults, validate.ValidateOpts{Lint: true})
 	if err != nil {
 		return nil, err
 	}
 
+	if req.ResolveConfigMaps {
+		namespace := req.Workflow.Namespace
+		if namespace == "" {
+			namespace = req.Namespace
+		}
+		grpcutil.SetWarningHeader(ctx, configMapParameterWarnings(ctx, auth.GetKubeClient(ctx), namespace, req.Workflow)...)
+	}
+
 	return req.Workflow, nil
 }
 
+func (s *workflowServer) EstimateWorkflowCost(ctx context.Context, req *workflowpkg.WorkflowCostEstimateRequest) (*workflowpkg.WorkflowCostEstimate, error) {
+	if req.Workflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "unable to get a workflow")
+	}
+	templates := req.Workflow.Spec.Templates
+	if ref := req.Workflow.Spec.WorkflowTemplateRef; ref != nil {
+		var spec wfv1.WorkflowSpec
+		if ref.ClusterScope {
+			cwftmpl, err := s.cwftmplStore.Getter(ctx).Get(ctx, ref.Name)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+			}
+			spec = cwftmpl.Spec
+		} else {
+			wftmpl, err := s.wftmplStore.Getter(ctx, req.Namespace).Get(ctx, ref.Name)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+			}
+			spec = wftmpl.Spec
+		}
+		templates = append(templates, spec.Templates...)
+	}
+	// the estimate reveals how long the archived workflows ran for, so needs the same permission as listing them
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, req.Namespace, "")
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, "permission denied")
+	}
+	options, err := costEstimateHistoryOptions(req.Namespace, req.Workflow)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	var history []*wfv1.Workflow
+	if options != nil {
+		archived, err := s.wfArchive.ListWorkflows(ctx, *options)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		// the listed workflows do not have their nodes, so each must be got
+		for _, summary := range archived {
+			wf, err := s.wfArchive.GetWorkflow(ctx, string(summary.UID), summary.Namespace, summary.Name)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			if wf == nil {
+				continue
+			}
+			if err := s.hydrator.Hydrate(ctx, wf); err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			history = append(history, wf)
+		}
+	}
+	return workflowCostEstimate(templates, history), nil
+}
+
+func (s *workflowServer) DiffWorkflows(ctx context.Context, req *workflowpkg.WorkflowDiffRequest) (*workflowpkg.WorkflowDiff, error) {
+	if (req.Name == "" && req.Uid == "") || (req.OtherName == "" && req.OtherUid == "") {
+		return nil, status.Error(codes.InvalidArgument, "the name or UID of both workflows must be given")
+	}
+	from, err := s.getWorkflowToDiff(ctx, req.Namespace, req.Name, req.Uid)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getWorkflowToDiff(ctx, req.Namespace, req.OtherName, req.OtherUid)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status {
+		for _, wf := range []*wfv1.Workflow{from, to} {
+			unavailable, err := s.hydrateWithinLimit(ctx, "DiffWorkflows", wf)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			// without its nodes, every node of the workflow would be reported as removed or added
+			if unavailable != "" {
+				return nil, status.Errorf(codes.FailedPrecondition, "the status of workflow %s cannot be compared: %s", wf.Name, unavailable)
+			}
+		}
+	}
+	differences, err := diffWorkflows(from, to, req.Status)
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	return &workflowpkg.WorkflowDiff{Differences: differences}, nil
+}
+
+// getWorkflowToDiff gets the workflow by name like getWorkflow, or by UID, either the live workflow of the name if it
+// has the UID, or else the archived one
+func (s *workflowServer) getWorkflowToDiff(ctx context.Context, namespace, name, uid string) (*wfv1.Workflow, error) {
+	wfClient := auth.GetWfClient(ctx)
+	var wf *wfv1.Workflow
+	if uid == "" {
+		var err error
+		wf, err = s.getWorkflow(ctx, wfClient, namespace, name, metav1.GetOptions{}, false)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	} else {
+		if name != "" {
+			live, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err == nil && string(live.UID) == uid {
+				wf = live
+			}
+		}
+		if wf == nil {
+			allowed, err := auth.CanI(ctx, "get", workflow.WorkflowPlural, namespace, name)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			if !allowed {
+				return nil, status.Error(codes.PermissionDenied, "permission denied")
+			}
+			wf, err = s.wfArchive.GetWorkflow(ctx, uid, namespace, name)
+			if err != nil {
+				return nil, sutils.ToStatusError(err, codes.Internal)
+			}
+			// the archive gets workflows by UID in any namespace
+			if wf == nil || wf.Namespace != namespace || (name != "" && wf.Name != name) {
+				return nil, status.Errorf(codes.NotFound, "workflow with UID %s not found", uid)
+			}
+		}
+	}
+	if err := s.validateWorkflow(ctx, wf); err != nil {
+		return nil, err
+	}
+	return wf, nil
+}
+
 func (s *workflowServer) PodLogs(req *workflowpkg.WorkflowLogRequest, ws workflowpkg.WorkflowService_PodLogsServer) error {
 	ctx := ws.Context()
 	wfClient := auth.GetWfClient(ctx)
 	kubeClient := auth.GetKubeClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
 	if err != nil {
 		return sutils.ToStatusError(err, codes.Internal)
 	}
-	err = s.validateWorkflow(wf)
+	err = s.validateWorkflow(ctx, wf)
 	if err != nil {
 		return sutils.ToStatusError(err, codes.InvalidArgument)
 	}
 	req.Name = wf.Name
+	if err := logs.ValidateLevel(req.MinLevel); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var podFilter func(pod *corev1.Pod) bool
+	if req.TemplateName != "" {
+		if err := s.hydrate(ctx, "PodLogs", wf); err != nil {
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+		filter := newTemplatePodFilter(req.TemplateName, wf.Status.Nodes)
+		if req.LogOptions != nil && req.LogOptions.Follow {
+			filter.refresh = func(ctx context.Context) (wfv1.Nodes, error) {
+				latest, err := wfClient.ArgoprojV1alpha1().Workflows(wf.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
+				if err != nil {
+					return nil, err
+				}
+				if err := s.hydrate(ctx, "PodLogs", latest); err != nil {
+					return nil, err
+				}
+				return latest.Status.Nodes, nil
+			}
+		} else if !filter.any() {
+			return status.Errorf(codes.NotFound, "workflow %s has no pods of template %s", wf.Name, req.TemplateName)
+		}
+		podFilter = func(pod *corev1.Pod) bool {
+			return filter.matches(ctx, pod)
+		}
+	}
 
 	err = ws.SendHeader(metadata.MD{})
 	if err != nil {
 		return sutils.ToStatusError(err, codes.Internal)
 	}
 
-	err = logs.WorkflowLogs(ctx, wfClient, kubeClient, req, ws)
+	err = logs.WorkflowLogs(ctx, wfClient, kubeClient, req, podFilter, ws)
 	return sutils.ToStatusError(err, codes.Internal)
 }
 
@@ -713,35 +2144,229 @@ func (s *workflowServer) WorkflowLogs(req *workflowpkg.WorkflowLogRequest, ws wo
 	return sutils.ToStatusError(s.PodLogs(req, ws), codes.Internal)
 }
 
-func (s *workflowServer) getWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string, name string, options metav1.GetOptions) (*wfv1.Workflow, error) {
+// logArchiveChunkSize is the maximum size of each chunk of a log archive sent to the client
+const logArchiveChunkSize = 64 * 1024
+
+func (s *workflowServer) GetWorkflowLogArchive(req *workflowpkg.WorkflowLogArchiveRequest, ws workflowpkg.WorkflowService_GetWorkflowLogArchiveServer) error {
+	ctx := ws.Context()
+	wfClient := auth.GetWfClient(ctx)
+	kubeClient := auth.GetKubeClient(ctx)
+	wf, err := s.getWorkflow(ctx, wfClient, req.Namespace, req.Name, metav1.GetOptions{}, false)
+	if err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	err = s.validateWorkflow(ctx, wf)
+	if err != nil {
+		return sutils.ToStatusError(err, codes.InvalidArgument)
+	}
+	w := bufio.NewWriterSize(logArchiveChunkWriter{ws}, logArchiveChunkSize)
+	err = logs.WorkflowLogArchive(ctx, kubeClient, wf.Namespace, wf.Name, req.NodeId, w)
+	if err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	return sutils.ToStatusError(w.Flush(), codes.Internal)
+}
+
+// logArchiveChunkWriter sends each write as a chunk of the log archive
+type logArchiveChunkWriter struct {
+	ws workflowpkg.WorkflowService_GetWorkflowLogArchiveServer
+}
+
+func (w logArchiveChunkWriter) Write(p []byte) (int, error) {
+	// the buffer is re-used by the caller once we return, but the chunk may not have been sent yet
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.ws.Send(&workflowpkg.LogArchiveChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// getWorkflow gets the live workflow, falling back to the archived workflow if it cannot be got, unless liveOnly is set
+func (s *workflowServer) getWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string, name string, options metav1.GetOptions, liveOnly bool) (*wfv1.Workflow, error) {
+	wf, _, err := s.getWorkflowAndSource(ctx, wfClient, namespace, name, options, liveOnly)
+	return wf, err
+}
+
+// getWorkflowAndSource gets the workflow like getWorkflow, also returning where it was read from, either
+// workflowpkg.SourceLive or workflowpkg.SourceArchived
+func (s *workflowServer) getWorkflowAndSource(ctx context.Context, wfClient versioned.Interface, namespace string, name string, options metav1.GetOptions, liveOnly bool) (*wfv1.Workflow, string, error) {
 	logger := logging.RequireLoggerFromContext(ctx)
 	if name == latestAlias {
 		latest, err := getLatestWorkflow(ctx, wfClient, namespace)
 		if err != nil {
-			return nil, sutils.ToStatusError(err, codes.Internal)
+			return nil, "", sutils.ToStatusError(err, codes.Internal)
 		}
 		logger.WithFields(logging.Fields{"alias": latestAlias, "workflow": latest.Name}).Debug(ctx, "Resolved alias to workflow")
-		return latest, nil
+		return latest, workflowpkg.SourceLive, nil
 	}
 
 	wf, origErr := wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, options)
+	if liveOnly {
+		if origErr != nil {
+			return nil, "", sutils.ToStatusError(origErr, codes.Internal)
+		}
+		return wf, workflowpkg.SourceLive, nil
+	}
 	// fallback to retrieve from archived workflows
 	if wf == nil || origErr != nil {
 		allowed, err := auth.CanI(ctx, "get", workflow.WorkflowPlural, namespace, name)
 		if err != nil {
-			return nil, getWorkflowOrigErr(ctx, origErr, err)
+			return nil, "", getWorkflowOrigErr(ctx, origErr, err)
 		}
 		if !allowed {
 			err = status.Error(codes.PermissionDenied, "permission denied")
-			return nil, getWorkflowOrigErr(ctx, origErr, err)
+			return nil, "", getWorkflowOrigErr(ctx, origErr, err)
 		}
 
 		wf, err = s.wfArchive.GetWorkflow(ctx, "", namespace, name)
 		if wf == nil || err != nil {
-			return nil, getWorkflowOrigErr(ctx, origErr, err)
+			return nil, "", getWorkflowOrigErr(ctx, origErr, err)
 		}
+		return wf, workflowpkg.SourceArchived, nil
 	}
-	return wf, nil
+	return wf, workflowpkg.SourceLive, nil
+}
+
+// hydrate hydrates the offloaded node status of the workflow, recording any failure against the operation
+func (s *workflowServer) hydrate(ctx context.Context, operation string, wf *wfv1.Workflow) error {
+	err := s.hydrator.Hydrate(ctx, wf)
+	if err != nil {
+		cause := metrics.HydrationFailed
+		if sqldb.IsOffloadedNodesNotFound(err) {
+			cause = metrics.OffloadDataMissing
+		}
+		s.metrics.OffloadHydrationFailed(ctx, operation, cause)
+	}
+	return err
+}
+
+// hydrateWithinLimit hydrates the workflow as hydrate does, unless it has more nodes than the limit, when a warning is
+// logged. The nodes are counted before the workflow is hydrated. If configured to, a workflow over the limit is left
+// dehydrated and why is returned, so that its nodes are not held in memory and sent to the client in full. Workflows
+// that were already hydrated are only warned about.
+func (s *workflowServer) hydrateWithinLimit(ctx context.Context, operation string, wf *wfv1.Workflow) (string, error) {
+	if s.hydrateMaxNodes <= 0 {
+		return "", s.hydrate(ctx, operation, wf)
+	}
+	nodes, err := s.nodeCount(ctx, wf)
+	if err != nil {
+		return "", err
+	}
+	if nodes > s.hydrateMaxNodes {
+		refuse := s.hydrateRefuseOverMaxNodes && !s.hydrator.IsHydrated(wf)
+		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name, "operation": operation, "nodes": nodes, "maxNodes": s.hydrateMaxNodes, "refused": refuse}).Warn(ctx, "Workflow has more nodes than the hydration limit")
+		if refuse {
+			return fmt.Sprintf("workflow has %d nodes, more than the limit of %d", nodes, s.hydrateMaxNodes), nil
+		}
+	}
+	return "", s.hydrate(ctx, operation, wf)
+}
+
+// nodeCount returns the number of nodes of the workflow, without hydrating it
+func (s *workflowServer) nodeCount(ctx context.Context, wf *wfv1.Workflow) (int, error) {
+	switch {
+	case len(wf.Status.Nodes) == 0 && wf.Status.CompressedNodes != "":
+		return packer.CompressedNodeCount(wf.Status.CompressedNodes)
+	case wf.Status.IsOffloadNodeStatus():
+		return s.offloadNodeStatusRepo.Count(ctx, string(wf.UID), wf.GetOffloadNodeStatusVersion())
+	}
+	return len(wf.Status.Nodes), nil
+}
+
+// annotateSubmitReason records why the workflow was submitted. Control characters are removed and whitespace
+// collapsed so the reason displays on a single line, and it is truncated to maxSubmitReasonLength characters.
+func annotateSubmitReason(wf *wfv1.Workflow, reason string) {
+	reason = strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, reason)), " ")
+	if reason == "" {
+		return
+	}
+	if runes := []rune(reason); len(runes) > maxSubmitReasonLength {
+		reason = string(runes[:maxSubmitReasonLength])
+	}
+	if wf.Annotations == nil {
+		wf.Annotations = map[string]string{}
+	}
+	wf.Annotations[common.AnnotationKeySubmitReason] = reason
+}
+
+// annotateCorrelationID records the caller's correlation ID on the workflow. It is also added to the logger of the
+// returned context, so that everything logged about the workflow by the request can be correlated.
+func annotateCorrelationID(ctx context.Context, wf *wfv1.Workflow, correlationID string) (context.Context, error) {
+	if correlationID == "" {
+		return ctx, nil
+	}
+	if len(correlationID) > maxCorrelationIDLength || strings.IndexFunc(correlationID, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return ctx, status.Errorf(codes.InvalidArgument, "correlationID must be at most %d characters, without whitespace", maxCorrelationIDLength)
+	}
+	if wf.Annotations == nil {
+		wf.Annotations = map[string]string{}
+	}
+	wf.Annotations[common.AnnotationKeyCorrelationID] = correlationID
+	ctx, _ = logging.RequireLoggerFromContext(ctx).WithField("correlationID", correlationID).InContext(ctx)
+	return ctx, nil
+}
+
+// applyProvenance records where the workflow was created or submitted from. The git commit is a label, so that
+// workflows can be selected by it, and the rest are annotations.
+func applyProvenance(wf *wfv1.Workflow, provenance *workflowpkg.SubmitProvenance) error {
+	if provenance == nil {
+		return nil
+	}
+	if errs := validation.IsValidLabelValue(provenance.GitCommit); len(errs) > 0 {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("invalid provenance gitCommit %q: %s", provenance.GitCommit, strings.Join(errs, "; ")))
+	}
+	if len(provenance.GitRepository) > maxProvenanceURLLength {
+		return status.Errorf(codes.InvalidArgument, "provenance gitRepository must be at most %d characters", maxProvenanceURLLength)
+	}
+	if len(provenance.CiRunURL) > maxProvenanceURLLength {
+		return status.Errorf(codes.InvalidArgument, "provenance ciRunURL must be at most %d characters", maxProvenanceURLLength)
+	}
+	if provenance.GitCommit != "" {
+		if wf.Labels == nil {
+			wf.Labels = map[string]string{}
+		}
+		wf.Labels[common.LabelKeyGitCommit] = provenance.GitCommit
+	}
+	for key, value := range map[string]string{common.AnnotationKeyGitRepository: provenance.GitRepository, common.AnnotationKeyCIRunURL: provenance.CiRunURL} {
+		if value == "" {
+			continue
+		}
+		if wf.Annotations == nil {
+			wf.Annotations = map[string]string{}
+		}
+		wf.Annotations[key] = value
+	}
+	return nil
+}
+
+// sortWorkflows sorts the workflows by the requested order, or by the default workflow order if none was requested
+func sortWorkflows(wfs wfv1.Workflows, orderBy sutils.OrderBy) {
+	if orderBy.Field == "" {
+		sort.Sort(wfs)
+		return
+	}
+	compare := func(a, b *wfv1.Workflow) int {
+		switch orderBy.Field {
+		case sutils.OrderByFinishedAt:
+			return a.Status.FinishedAt.Compare(b.Status.FinishedAt.Time)
+		case sutils.OrderByName:
+			return strings.Compare(a.Name, b.Name)
+		default:
+			return a.Status.StartedAt.Compare(b.Status.StartedAt.Time)
+		}
+	}
+	sort.SliceStable(wfs, func(i, j int) bool {
+		if orderBy.Ascending {
+			return compare(&wfs[i], &wfs[j]) < 0
+		}
+		return compare(&wfs[i], &wfs[j]) > 0
+	})
 }
 
 // getWorkflowOrigErr only returns the original error to preserve the original status code
@@ -752,8 +2377,27 @@ func getWorkflowOrigErr(ctx context.Context, origErr error, err error) error {
 	return sutils.ToStatusError(origErr, codes.Internal)
 }
 
-func (s *workflowServer) validateWorkflow(wf *wfv1.Workflow) error {
-	return sutils.ToStatusError(s.instanceIDService.Validate(wf), codes.InvalidArgument)
+// validateWorkflow returns an error if the workflow is not managed by the server's instance, unless the workflow has no
+// instance ID and the server is configured to ignore the mismatch, in which case a warning is logged instead. Workflows
+// of another instance are always rejected, as they are managed by another controller.
+func (s *workflowServer) validateWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
+	err := s.instanceIDService.Validate(wf)
+	if _, labelled := wf.Labels[common.LabelKeyControllerInstanceID]; err != nil && !labelled && s.ignoreInstanceIDMismatch {
+		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "name": wf.Name, "instanceID": wf.Labels[common.LabelKeyControllerInstanceID]}).WithError(err).Warn(ctx, "Ignoring workflow instance ID mismatch")
+		return nil
+	}
+	return sutils.ToStatusError(err, codes.InvalidArgument)
+}
+
+// validateRequestSize rejects workflows larger than the configured maximum request size.
+func (s *workflowServer) validateRequestSize(wf *wfv1.Workflow) error {
+	if s.maxRequestSize <= 0 {
+		return nil
+	}
+	if size := wf.Size(); size > s.maxRequestSize {
+		return sutils.ToStatusError(fmt.Errorf("workflow is %d bytes, which exceeds the maximum request size of %d bytes", size, s.maxRequestSize), codes.InvalidArgument)
+	}
+	return nil
 }
 
 func getLatestWorkflow(ctx context.Context, wfClient versioned.Interface, namespace string) (*wfv1.Workflow, error) {
@@ -773,40 +2417,127 @@ func getLatestWorkflow(ctx context.Context, wfClient versioned.Interface, namesp
 	return &latest, nil
 }
 
+// checkTemplateVersion returns a NotFound error if the template is not at the pinned generation. Kubernetes only keeps
+// the current generation, so any other generation no longer (or does not yet) exist. A version of zero is not pinned.
+func checkTemplateVersion(kind string, tmpl metav1.Object, version int64) error {
+	if version == 0 || tmpl.GetGeneration() == version {
+		return nil
+	}
+	return status.Errorf(codes.NotFound, "version %d of %s %q does not exist, the current version is %d", version, kind, tmpl.GetName(), tmpl.GetGeneration())
+}
+
+// storeTemplateSpec stores the spec of the pinned template in the workflow, as the controller does when it first
+// reconciles a workflow from a template, so that the workflow runs the pinned version even if the template is changed
+// before then. The controller only reads the template again to check it is unchanged if templateReferencing is Secure.
+func (s *workflowServer) storeTemplateSpec(wf *wfv1.Workflow, pinned wfv1.WorkflowSpecHolder) error {
+	if pinned == nil {
+		return nil
+	}
+	wfDefaults := &wfv1.WorkflowSpec{}
+	if s.wfDefaults != nil {
+		wfDefaults = &s.wfDefaults.Spec
+	}
+	merged, err := util.JoinWorkflowSpec(&wf.Spec, pinned.GetWorkflowSpec(), wfDefaults)
+	if err != nil {
+		return err
+	}
+	wf.Status.StoredWorkflowSpec = &merged.Spec
+	return nil
+}
+
 func (s *workflowServer) SubmitWorkflow(ctx context.Context, req *workflowpkg.WorkflowSubmitRequest) (*wfv1.Workflow, error) {
+	if err := s.maintenance.Check(ctx); err != nil {
+		return nil, err
+	}
 	wfClient := auth.GetWfClient(ctx)
+	wftmplGetter := s.wftmplStore.Getter(ctx, req.Namespace)
+	cwftmplGetter := s.cwftmplStore.Getter(ctx)
 	var wf *wfv1.Workflow
+	// pinned is the template at the pinned version, nil if the version is not pinned
+	var pinned wfv1.WorkflowSpecHolder
 	switch req.ResourceKind {
 	case workflow.CronWorkflowKind, workflow.CronWorkflowSingular, workflow.CronWorkflowPlural, workflow.CronWorkflowShortName:
+		if req.TemplateVersion != 0 {
+			return nil, status.Error(codes.InvalidArgument, "a template version can only be given when submitting from a workflow template or cluster workflow template")
+		}
 		cronWf, err := wfClient.ArgoprojV1alpha1().CronWorkflows(req.Namespace).Get(ctx, req.ResourceName, metav1.GetOptions{})
 		if err != nil {
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
 		wf = common.ConvertCronWorkflowToWorkflow(cronWf)
 	case workflow.WorkflowTemplateKind, workflow.WorkflowTemplateSingular, workflow.WorkflowTemplatePlural, workflow.WorkflowTemplateShortName:
+		wftmpl, err := wftmplGetter.Get(ctx, req.ResourceName)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+		}
+		if err := checkTemplateVersion(workflow.WorkflowTemplateKind, wftmpl, req.TemplateVersion); err != nil {
+			return nil, err
+		}
+		if req.TemplateVersion != 0 {
+			pinned = wftmpl
+		}
 		wf = common.NewWorkflowFromWorkflowTemplate(req.ResourceName, false)
+		common.SetWorkflowTemplateArguments(wf, wftmpl)
 	case workflow.ClusterWorkflowTemplateKind, workflow.ClusterWorkflowTemplateSingular, workflow.ClusterWorkflowTemplatePlural, workflow.ClusterWorkflowTemplateShortName:
+		cwftmpl, err := cwftmplGetter.Get(ctx, req.ResourceName)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.InvalidArgument)
+		}
+		if err := checkTemplateVersion(workflow.ClusterWorkflowTemplateKind, cwftmpl, req.TemplateVersion); err != nil {
+			return nil, err
+		}
+		if req.TemplateVersion != 0 {
+			pinned = cwftmpl
+		}
 		wf = common.NewWorkflowFromWorkflowTemplate(req.ResourceName, true)
+		common.SetWorkflowTemplateArguments(wf, cwftmpl)
 	default:
 		err := errors.Errorf(errors.CodeBadRequest, "Resource kind '%s' is not supported for submitting", req.ResourceKind)
 		err = sutils.ToStatusError(err, codes.InvalidArgument)
 		return nil, err
 	}
 
+	if req.SubmitOptions != nil && req.SubmitOptions.StartAt != nil && !req.SubmitOptions.StartAt.After(s.now()) {
+		return nil, status.Errorf(codes.InvalidArgument, "startAt %s is not in the future, to start the workflow now leave it unset", req.SubmitOptions.StartAt.UTC().Format(time.RFC3339))
+	}
+
 	s.instanceIDService.Label(wf)
 	creator.LabelCreator(ctx, wf)
+	// the parameters passed in the submit options replace the template's defaults
 	err := util.ApplySubmitOpts(wf, req.SubmitOptions)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
+	if req.NameTemplate != "" {
+		if req.SubmitOptions != nil && (req.SubmitOptions.Name != "" || req.SubmitOptions.GenerateName != "") {
+			return nil, status.Error(codes.InvalidArgument, "a name template cannot be given with the name or generateName submit options")
+		}
+		wf.Name, err = resolveNameTemplate(req.NameTemplate, s.now())
+		if err != nil {
+			return nil, err
+		}
+		wf.GenerateName = ""
+	}
+	annotateSubmitReason(wf, req.Reason)
+	ctx, err = annotateCorrelationID(ctx, wf, req.CorrelationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyProvenance(wf, req.Provenance); err != nil {
+		return nil, err
+	}
+	if err := s.storeTemplateSpec(wf, pinned); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
 
-	wftmplGetter := s.wftmplStore.Getter(ctx, req.Namespace)
-	cwftmplGetter := s.cwftmplStore.Getter(ctx)
-
-	err = validate.ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, wf, s.wfDefaults, validate.ValidateOpts{Submit: true})
+	err = s.validationCache.validate(ctx, wftmplGetter, cwftmplGetter, wf, s.wfDefaults, validate.ValidateOpts{Submit: true})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	err = s.checkResourceFit(ctx, wf, wftmplGetter, cwftmplGetter)
+	if err != nil {
+		return nil, err
+	}
 
 	// if we are doing a normal dryRun, just return the workflow un-altered
 	if req.SubmitOptions != nil && req.SubmitOptions.DryRun {
@@ -824,9 +2555,79 @@ func (s *workflowServer) SubmitWorkflow(ctx context.Context, req *workflowpkg.Wo
 		return workflow, nil
 	}
 
-	wf, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+	giveBack, err := s.submissionQuota.take(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wfIf := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace)
+	wf, err = wfIf.Create(ctx, wf, metav1.CreateOptions{})
 	if err != nil {
+		giveBack()
 		return nil, sutils.ToStatusError(err, codes.InvalidArgument)
 	}
+	logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"namespace": wf.Namespace, "workflow": wf.Name}).Info(ctx, "Submitted workflow")
+	if req.WaitForRunning {
+		timeout := defaultSubmitWaitTimeout
+		if req.WaitTimeoutSeconds > 0 {
+			timeout = time.Duration(req.WaitTimeoutSeconds) * time.Second
+		}
+		wf, err = waitForWorkflowStarted(ctx, wfIf, wf, timeout)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+	return wf, nil
+}
+
+// waitForWorkflowStarted blocks until the workflow has left the Pending phase, returning the latest state seen.
+// If the timeout expires first, the current state is returned rather than an error.
+func waitForWorkflowStarted(ctx context.Context, wfIf v1alpha1.WorkflowInterface, wf *wfv1.Workflow, timeout time.Duration) (*wfv1.Workflow, error) {
+	return waitForWorkflow(ctx, wfIf, wf, timeout, func(wf *wfv1.Workflow) bool {
+		return wf.Status.Phase != wfv1.WorkflowUnknown && wf.Status.Phase != wfv1.WorkflowPending
+	})
+}
+
+// waitForWorkflowCompleted blocks until the workflow has completed, returning the latest state seen.
+// If the timeout expires first, the current state is returned rather than an error.
+func waitForWorkflowCompleted(ctx context.Context, wfIf v1alpha1.WorkflowInterface, wf *wfv1.Workflow, timeout time.Duration) (*wfv1.Workflow, error) {
+	return waitForWorkflow(ctx, wfIf, wf, timeout, func(wf *wfv1.Workflow) bool {
+		return wf.Status.Fulfilled()
+	})
+}
+
+// waitForWorkflow watches the workflow until done returns true for it, or the timeout expires, returning the latest state seen
+func waitForWorkflow(ctx context.Context, wfIf v1alpha1.WorkflowInterface, wf *wfv1.Workflow, timeout time.Duration, done func(wf *wfv1.Workflow) bool) (*wfv1.Workflow, error) {
+	if done(wf) {
+		return wf, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	watcher, err := wfIf.Watch(ctx, metav1.ListOptions{
+		FieldSelector:   "metadata.name=" + wf.Name,
+		ResourceVersion: wf.ResourceVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer watcher.Stop()
+	for !done(wf) {
+		select {
+		case <-ctx.Done():
+			return wf, nil
+		case event, open := <-watcher.ResultChan():
+			if !open {
+				return wf, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				return nil, apierr.FromObject(event.Object)
+			case watch.Deleted:
+				return nil, apierr.NewNotFound(wfv1.Resource(workflow.WorkflowPlural), wf.Name)
+			}
+			if updated, ok := event.Object.(*wfv1.Workflow); ok && updated.Name == wf.Name {
+				wf = updated
+			}
+		}
+	}
 	return wf, nil
 }
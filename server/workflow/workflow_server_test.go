@@ -1,40 +1,75 @@
 package workflow
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-jose/go-jose/v3/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/upper/db/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
 	ktesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
 	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	v1alpha "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/server/auth/types"
 	"github.com/argoproj/argo-workflows/v3/server/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v3/server/metrics"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 	"github.com/argoproj/argo-workflows/v3/server/workflow/store"
 	"github.com/argoproj/argo-workflows/v3/server/workflowtemplate"
 	"github.com/argoproj/argo-workflows/v3/util"
+	grpcutil "github.com/argoproj/argo-workflows/v3/util/grpc"
 	"github.com/argoproj/argo-workflows/v3/util/instanceid"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/logs"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
+	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 )
 
 const unlabelled = `{
@@ -615,6 +650,15 @@ func getWorkflowServer(t *testing.T) (workflowpkg.WorkflowServiceServer, context
 	if err != nil {
 		panic(err)
 	}
+	archivedRepo.On("ListWorkflows", mock.Anything, sutils.ListOptions{Namespace: "workflows", NamePrefix: "cost-", Phases: []string{"Succeeded"}, Limit: costEstimateHistoryLimit}).Return(v1alpha1.Workflows{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cost-1", Namespace: "workflows", UID: "cost-uid-1"}},
+	}, nil)
+	archivedRepo.On("GetWorkflow", mock.Anything, "cost-uid-1", "workflows", "cost-1").Return(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "cost-1", Namespace: "workflows", UID: "cost-uid-1"},
+		Status: v1alpha1.WorkflowStatus{Nodes: v1alpha1.Nodes{
+			"cost-1": {Type: v1alpha1.NodeTypePod, TemplateName: "main", StartedAt: metav1.Unix(0, 0), FinishedAt: metav1.Unix(100, 0)},
+		}},
+	}, nil)
 	archivedRepo.On("CountWorkflows", mock.Anything, sutils.ListOptions{Namespace: "workflows", LabelRequirements: r}).Return(int64(2), nil)
 	archivedRepo.On("ListWorkflows", mock.Anything, sutils.ListOptions{Namespace: "workflows", Limit: -2, LabelRequirements: r}).Return(v1alpha1.Workflows{wfObj2, failedWfObj}, nil)
 	archivedRepo.On("CountWorkflows", mock.Anything, sutils.ListOptions{Namespace: "test", LabelRequirements: r}).Return(int64(1), nil)
@@ -649,10 +693,45 @@ func getWorkflowServer(t *testing.T) (workflowpkg.WorkflowServiceServer, context
 	namespaceAll := metav1.NamespaceAll
 	wftmplStore := workflowtemplate.NewWorkflowTemplateClientStore()
 	cwftmplStore := clusterworkflowtemplate.NewClusterWorkflowTemplateClientStore()
-	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, archivedRepo, wfClientset, wfStore, wfStore, wftmplStore, cwftmplStore, nil, &namespaceAll)
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, archivedRepo, wfClientset, wfStore, wfStore, wftmplStore, cwftmplStore, nil, &namespaceAll, WorkflowServerOptions{})
+	return server, ctx
+}
+
+// getWorkflowServerWith returns a server of the workflows, which are both live and in its store, and the archive, with
+// node status offloading disabled, and a context in which every access review is allowed.
+func getWorkflowServerWith(t *testing.T, wfs []*v1alpha1.Workflow, archive *mocks.WorkflowArchive) (*workflowServer, context.Context) {
+	t.Helper()
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+	wfClientset := v1alpha.NewSimpleClientset()
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	wfStore, err := store.NewSQLiteStore(instanceIDSvc)
+	require.NoError(t, err)
+	for _, wf := range wfs {
+		require.NoError(t, wfClientset.Tracker().Add(wf.DeepCopy()))
+		require.NoError(t, wfStore.Add(wf))
+	}
+	ctx := logging.TestContext(t.Context())
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, archive, wfClientset, wfStore, nil, nil, nil, nil, nil, WorkflowServerOptions{})
 	return server, ctx
 }
 
+// workflowNames returns the names of the listed workflows
+func workflowNames(list *v1alpha1.WorkflowList) []string {
+	var names []string
+	for _, wf := range list.Items {
+		names = append(names, wf.Name)
+	}
+	return names
+}
+
 // generateNameReactor implements the logic required for the GenerateName field to work when using
 // the fake client. Add it with client.PrependReactor to your fake client.
 func generateNameReactor(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
@@ -683,6 +762,270 @@ func TestCreateWorkflow(t *testing.T) {
 	assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyCreatorEmail])
 }
 
+func TestCreateWorkflowGenerateNameCollision(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	server.(*workflowServer).generateNameRetries = config.DefaultCreateWorkflowGenerateNameRetries
+	// the first `collisions` creates fail, as if the generated name already existed
+	collide := func(t *testing.T, collisions int) *int {
+		t.Helper()
+		attempts := 0
+		wfClientset := auth.GetWfClient(ctx).(*v1alpha.Clientset)
+		wfClientset.PrependReactor("create", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+			attempts++
+			if attempts <= collisions {
+				return true, nil, apierr.NewAlreadyExists(schema.GroupResource{Group: workflow.Group, Resource: workflow.WorkflowPlural}, fmt.Sprintf("hello-world-%d", attempts))
+			}
+			return false, nil, nil
+		})
+		t.Cleanup(func() { wfClientset.ReactionChain = wfClientset.ReactionChain[1:] })
+		return &attempts
+	}
+	create := func(mutate func(wf *v1alpha1.Workflow)) (*v1alpha1.Workflow, error) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		if mutate != nil {
+			mutate(req.Workflow)
+		}
+		return server.CreateWorkflow(ctx, &req)
+	}
+	t.Run("Retried", func(t *testing.T) {
+		attempts := collide(t, 2)
+		wf, err := create(nil)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(wf.Name, "hello-world-"))
+		assert.Equal(t, 3, *attempts)
+	})
+	t.Run("RetriesExhausted", func(t *testing.T) {
+		attempts := collide(t, 10)
+		_, err := create(nil)
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		// the first attempt, and then the default of 3 retries
+		assert.Equal(t, 4, *attempts)
+	})
+	t.Run("Name", func(t *testing.T) {
+		attempts := collide(t, 1)
+		_, err := create(func(wf *v1alpha1.Workflow) {
+			wf.GenerateName = ""
+			wf.Name = "hello-world"
+		})
+		assert.Equal(t, codes.AlreadyExists, status.Code(err))
+		// the name is not generated, so retrying would collide again
+		assert.Equal(t, 1, *attempts)
+	})
+}
+
+func TestCreateWorkflowUID(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	create := func(uid string) (*v1alpha1.Workflow, error) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Uid = uid
+		return server.CreateWorkflow(ctx, &req)
+	}
+	t.Run("First", func(t *testing.T) {
+		wf, err := create("reconcile-1")
+		require.NoError(t, err)
+		assert.Equal(t, "reconcile-1", wf.Labels[common.LabelKeyClientUID])
+	})
+	t.Run("Duplicate", func(t *testing.T) {
+		first, err := create("reconcile-2")
+		require.NoError(t, err)
+		second, err := create("reconcile-2")
+		require.NoError(t, err)
+		assert.Equal(t, first.Name, second.Name)
+		list, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows(first.Namespace).List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeyClientUID + "=reconcile-2"})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := create("not a label value")
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateWorkflowMaxRequestSize(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	server.(*workflowServer).maxRequestSize = 4096
+	request := func(annotation int) *v1alpha1.Workflow {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Workflow.Annotations = map[string]string{"large": strings.Repeat("x", annotation)}
+		return req.Workflow
+	}
+	t.Run("UnderLimit", func(t *testing.T) {
+		_, err := server.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{Namespace: "workflows", Workflow: request(1024)})
+		require.NoError(t, err)
+	})
+	t.Run("OverLimit", func(t *testing.T) {
+		_, err := server.CreateWorkflow(ctx, &workflowpkg.WorkflowCreateRequest{Namespace: "workflows", Workflow: request(8192)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "exceeds the maximum request size of 4096 bytes")
+	})
+	t.Run("Lint", func(t *testing.T) {
+		_, err := server.LintWorkflow(ctx, &workflowpkg.WorkflowLintRequest{Namespace: "workflows", Workflow: request(8192)})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestCreateWorkflowSubmissionQuota(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	server.(*workflowServer).submissionQuota = newSubmissionQuota(&config.SubmissionQuota{Daily: 1})
+	create := func() error {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		_, err := server.CreateWorkflow(ctx, &req)
+		return err
+	}
+	require.NoError(t, create())
+	assert.Equal(t, codes.ResourceExhausted, status.Code(create()))
+}
+
+func TestWorkflowSubmitReason(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("CreateWorkflow", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Reason = "nightly\nbackfill \t for  2024-01-01"
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assert.Equal(t, "nightly backfill for 2024-01-01", wf.Annotations[common.AnnotationKeySubmitReason])
+	})
+	t.Run("SubmitWorkflow", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "workflowtemplate",
+			ResourceName:  "workflow-template-whalesay-template",
+			SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}},
+			Reason:        strings.Repeat("é", maxSubmitReasonLength+10),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("é", maxSubmitReasonLength), wf.Annotations[common.AnnotationKeySubmitReason])
+	})
+	t.Run("NoReason", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Reason = " \n "
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assert.NotContains(t, wf.Annotations, common.AnnotationKeySubmitReason)
+	})
+}
+
+func TestWorkflowProvenance(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	provenance := &workflowpkg.SubmitProvenance{
+		GitCommit:     "4bf92f3577b34da6a3ce929d0e0e4736a1b2c3d4",
+		GitRepository: "https://github.com/my-org/my-repo",
+		CiRunURL:      "https://ci.my-org.io/runs/123",
+	}
+	assertProvenance := func(t *testing.T, wf *v1alpha1.Workflow) {
+		t.Helper()
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736a1b2c3d4", wf.Labels[common.LabelKeyGitCommit])
+		assert.Equal(t, "https://github.com/my-org/my-repo", wf.Annotations[common.AnnotationKeyGitRepository])
+		assert.Equal(t, "https://ci.my-org.io/runs/123", wf.Annotations[common.AnnotationKeyCIRunURL])
+	}
+	t.Run("CreateWorkflow", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Provenance = provenance
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assertProvenance(t, wf)
+	})
+	t.Run("SubmitWorkflow", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "workflowtemplate",
+			ResourceName:  "workflow-template-whalesay-template",
+			SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}},
+			Provenance:    provenance,
+		})
+		require.NoError(t, err)
+		assertProvenance(t, wf)
+	})
+	t.Run("Partial", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.Provenance = &workflowpkg.SubmitProvenance{GitRepository: "https://github.com/my-org/my-repo"}
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assert.NotContains(t, wf.Labels, common.LabelKeyGitCommit)
+		assert.NotContains(t, wf.Annotations, common.AnnotationKeyCIRunURL)
+		assert.Equal(t, "https://github.com/my-org/my-repo", wf.Annotations[common.AnnotationKeyGitRepository])
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for name, provenance := range map[string]*workflowpkg.SubmitProvenance{
+			"GitCommit":     {GitCommit: "not a commit"},
+			"GitRepository": {GitRepository: strings.Repeat("a", 1025)},
+			"CIRunURL":      {CiRunURL: strings.Repeat("a", 1025)},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var req workflowpkg.WorkflowCreateRequest
+				v1alpha1.MustUnmarshal(workflow1, &req)
+				req.Provenance = provenance
+				_, err := server.CreateWorkflow(ctx, &req)
+				assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			})
+		}
+	})
+}
+
+func TestWorkflowCorrelationID(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	hook := logging.NewTestHook()
+	ctx = logging.WithLogger(ctx, logging.NewTestLogger(logging.Info, logging.Text, hook))
+	// lastLog returns the fields of the last message logged
+	lastLog := func(t *testing.T, msg string) logging.Fields {
+		t.Helper()
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, msg, entry.Msg)
+		return entry.Fields
+	}
+	t.Run("CreateWorkflow", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		req.CorrelationID = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", wf.Annotations[common.AnnotationKeyCorrelationID])
+		fields := lastLog(t, "Created workflow")
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", fields["correlationID"])
+		assert.Equal(t, wf.Name, fields["workflow"])
+	})
+	t.Run("SubmitWorkflow", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "workflowtemplate",
+			ResourceName:  "workflow-template-whalesay-template",
+			SubmitOptions: &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}},
+			CorrelationID: "my-trigger-123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "my-trigger-123", wf.Annotations[common.AnnotationKeyCorrelationID])
+		fields := lastLog(t, "Submitted workflow")
+		assert.Equal(t, "my-trigger-123", fields["correlationID"])
+		assert.Equal(t, wf.Name, fields["workflow"])
+	})
+	t.Run("NoCorrelationID", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		wf, err := server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+		assert.NotContains(t, wf.Annotations, common.AnnotationKeyCorrelationID)
+		assert.NotContains(t, lastLog(t, "Created workflow"), "correlationID")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, correlationID := range []string{"my trigger", strings.Repeat("a", maxCorrelationIDLength+1)} {
+			var req workflowpkg.WorkflowCreateRequest
+			v1alpha1.MustUnmarshal(workflow1, &req)
+			req.CorrelationID = correlationID
+			_, err := server.CreateWorkflow(ctx, &req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
 type testWatchWorkflowServer struct {
 	testServerStream
 }
@@ -705,6 +1048,374 @@ func TestWatchWorkflows(t *testing.T) {
 	cancel()
 }
 
+func TestWatchWorkflowsMaxConcurrentWatches(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	limiter := newWatchLimiter(2, nil)
+	server.(*workflowServer).watches = limiter
+	activeWatches := func() int {
+		limiter.mutex.Lock()
+		defer limiter.mutex.Unlock()
+		return limiter.active
+	}
+	watch := func(ctx context.Context) <-chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{}, &testWatchWorkflowServer{testServerStream{ctx}})
+		}()
+		return errCh
+	}
+
+	ctx1, cancel1 := context.WithCancel(ctx)
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(ctx)
+	defer cancel2()
+	errCh1 := watch(ctx1)
+	watch(ctx2)
+	require.Eventually(t, func() bool { return activeWatches() == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	err := <-watch(ctx)
+	require.Error(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	cancel1()
+	require.NoError(t, <-errCh1)
+	require.Eventually(t, func() bool { return activeWatches() == 1 }, 5*time.Second, 10*time.Millisecond)
+	ctx3, cancel3 := context.WithCancel(ctx)
+	defer cancel3()
+	watch(ctx3)
+	require.Eventually(t, func() bool { return activeWatches() == 2 }, 5*time.Second, 10*time.Millisecond)
+}
+
+type testFilteredWatchWorkflowServer struct {
+	testServerStream
+	events chan *workflowpkg.WorkflowWatchEvent
+}
+
+func (t testFilteredWatchWorkflowServer) Send(event *workflowpkg.WorkflowWatchEvent) error {
+	t.events <- event
+	return nil
+}
+
+func TestWatchWorkflowsFilter(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset()
+	watcher := watch.NewFake()
+	wfClientset.PrependWatchReactor("workflows", ktesting.DefaultWatchReactor(watcher, nil))
+	ctx = context.WithValue(ctx, auth.WfKey, wfClientset)
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{Filter: `workflow.phase ==`}, &testWatchWorkflowServer{testServerStream{ctx}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Compound", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream := &testFilteredWatchWorkflowServer{testServerStream{ctx}, make(chan *workflowpkg.WorkflowWatchEvent, 4)}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{Namespace: "workflows", Filter: `workflow.phase == "Failed" && workflow.retries > 2`}, stream)
+		}()
+		newWorkflow := func(name string, phase v1alpha1.WorkflowPhase, attempts int) *v1alpha1.Workflow {
+			retry := v1alpha1.NodeStatus{ID: name, Type: v1alpha1.NodeTypeRetry}
+			for i := range attempts {
+				retry.Children = append(retry.Children, fmt.Sprintf("%s-%d", name, i))
+			}
+			return &v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows"},
+				Status:     v1alpha1.WorkflowStatus{Phase: phase, Nodes: v1alpha1.Nodes{name: retry}},
+			}
+		}
+		watcher.Add(newWorkflow("failed-retried", v1alpha1.WorkflowFailed, 4))
+		watcher.Add(newWorkflow("failed", v1alpha1.WorkflowFailed, 1))
+		watcher.Add(newWorkflow("succeeded-retried", v1alpha1.WorkflowSucceeded, 4))
+		watcher.Modify(newWorkflow("failed", v1alpha1.WorkflowFailed, 5))
+		cancel()
+		require.NoError(t, <-errCh)
+		close(stream.events)
+		var received []string
+		for event := range stream.events {
+			received = append(received, event.Type+" "+event.Object.Name)
+		}
+		assert.Equal(t, []string{"ADDED failed-retried", "MODIFIED failed"}, received)
+	})
+}
+
+func TestWatchWorkflowsCronWorkflowName(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset()
+	watcher := watch.NewFake()
+	// the fake clientset does not select the watched workflows by their labels, as the API does
+	wfClientset.PrependWatchReactor("workflows", func(action ktesting.Action) (bool, watch.Interface, error) {
+		selector := action.(ktesting.WatchAction).GetWatchRestrictions().Labels
+		return true, watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+			return event, selector.Matches(labels.Set(event.Object.(*v1alpha1.Workflow).Labels))
+		}), nil
+	})
+	ctx = context.WithValue(ctx, auth.WfKey, wfClientset)
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Invalid", func(t *testing.T) {
+		err := server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{CronWorkflowName: "not a name"}, &testWatchWorkflowServer{testServerStream{ctx}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Owned", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream := &testFilteredWatchWorkflowServer{testServerStream{ctx}, make(chan *workflowpkg.WorkflowWatchEvent, 4)}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{Namespace: "workflows", CronWorkflowName: "my-cron"}, stream)
+		}()
+		newWorkflow := func(name, cronWorkflowName string) *v1alpha1.Workflow {
+			wf := &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows"}}
+			if cronWorkflowName != "" {
+				wf.Labels = map[string]string{common.LabelKeyCronWorkflow: cronWorkflowName}
+			}
+			return wf
+		}
+		watcher.Add(newWorkflow("my-cron-1", "my-cron"))
+		watcher.Add(newWorkflow("other-cron-1", "other-cron"))
+		watcher.Add(newWorkflow("not-cron", ""))
+		watcher.Modify(newWorkflow("my-cron-1", "my-cron"))
+		var received []string
+		for range 2 {
+			select {
+			case event := <-stream.events:
+				received = append(received, event.Type+" "+event.Object.Name)
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for events")
+			}
+		}
+		cancel()
+		require.NoError(t, <-errCh)
+		assert.Equal(t, []string{"ADDED my-cron-1", "MODIFIED my-cron-1"}, received)
+		assert.Empty(t, stream.events)
+	})
+}
+
+func TestWatchWorkflowsCloudEvents(t *testing.T) {
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+
+	// watchEvent returns the first event sent for a modified workflow
+	watchEvent := func(t *testing.T, req *workflowpkg.WatchWorkflowsRequest) *workflowpkg.WorkflowWatchEvent {
+		wfClientset := v1alpha.NewSimpleClientset()
+		watcher := watch.NewFake()
+		wfClientset.PrependWatchReactor("workflows", ktesting.DefaultWatchReactor(watcher, nil))
+		ctx, cancel := context.WithCancel(context.WithValue(logging.TestContext(t.Context()), auth.WfKey, wfClientset))
+		defer cancel()
+		server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+		stream := &testFilteredWatchWorkflowServer{testServerStream{ctx}, make(chan *workflowpkg.WorkflowWatchEvent, 1)}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchWorkflows(req, stream)
+		}()
+		watcher.Modify(&v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "workflows", UID: "my-uid", ResourceVersion: "2"},
+			Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning},
+		})
+		event := <-stream.events
+		cancel()
+		require.NoError(t, <-errCh)
+		return event
+	}
+	t.Run("Raw", func(t *testing.T) {
+		event := watchEvent(t, &workflowpkg.WatchWorkflowsRequest{Namespace: "workflows"})
+		assert.Equal(t, "MODIFIED", event.Type)
+		assert.Equal(t, "my-wf", event.Object.Name)
+		assert.Nil(t, event.CloudEvent)
+	})
+	t.Run("CloudEvents", func(t *testing.T) {
+		event := watchEvent(t, &workflowpkg.WatchWorkflowsRequest{Namespace: "workflows", CloudEvents: true, Fields: "result.object.status.phase"})
+		assert.Empty(t, event.Type)
+		assert.Nil(t, event.Object)
+		require.NotNil(t, event.CloudEvent)
+		assert.Equal(t, "io.argoproj.workflow.modified", event.CloudEvent.Type)
+		assert.Equal(t, "/api/v1/workflows/workflows", event.CloudEvent.Source)
+		assert.Equal(t, "my-wf", event.CloudEvent.Subject)
+		assert.Equal(t, "my-uid/2", event.CloudEvent.Id)
+		// the fields apply to the data
+		assert.Equal(t, &v1alpha1.Workflow{Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning}}, event.CloudEvent.Data)
+	})
+}
+
+func TestWatchWorkflowsMetadataOnly(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset()
+	watcher := watch.NewFake()
+	wfClientset.PrependWatchReactor("workflows", ktesting.DefaultWatchReactor(watcher, nil))
+	ctx, cancel := context.WithCancel(context.WithValue(ctx, auth.WfKey, wfClientset))
+	defer cancel()
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+	stream := &testFilteredWatchWorkflowServer{testServerStream{ctx}, make(chan *workflowpkg.WorkflowWatchEvent, 1)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.WatchWorkflows(&workflowpkg.WatchWorkflowsRequest{Namespace: "workflows", MetadataOnly: true}, stream)
+	}()
+	watcher.Modify(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-wf",
+			Namespace:       "workflows",
+			UID:             "my-uid",
+			ResourceVersion: "2",
+			Labels:          map[string]string{"foo": "bar"},
+			Annotations:     map[string]string{"baz": "qux"},
+		},
+		Spec: v1alpha1.WorkflowSpec{Entrypoint: "main"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowRunning,
+			Nodes: v1alpha1.Nodes{"my-wf": {ID: "my-wf", Name: "my-wf"}},
+		},
+	})
+	event := <-stream.events
+	cancel()
+	require.NoError(t, <-errCh)
+	assert.Equal(t, "MODIFIED", event.Type)
+	assert.Equal(t, &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "my-wf",
+			Namespace:       "workflows",
+			UID:             "my-uid",
+			ResourceVersion: "2",
+			Labels:          map[string]string{"foo": "bar"},
+		},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning},
+	}, event.Object)
+}
+
+type testWatchEventsServer struct {
+	testServerStream
+	events chan *corev1.Event
+}
+
+func (t testWatchEventsServer) Send(event *corev1.Event) error {
+	t.events <- event
+	return nil
+}
+
+func TestWatchEventsSendRecent(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	newEvent := func(name string, minutesAgo int) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "workflows"},
+			InvolvedObject: corev1.ObjectReference{Kind: workflow.WorkflowKind, Name: "my-wf"},
+			LastTimestamp:  metav1.NewTime(time.Now().Add(-time.Duration(minutesAgo) * time.Minute)),
+		}
+	}
+	kubeClientSet := fake.NewSimpleClientset(newEvent("oldest", 30), newEvent("newest", 10), newEvent("older", 20))
+	ctx = context.WithValue(ctx, auth.KubeKey, kubeClientSet)
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, v1alpha.NewSimpleClientset(), nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	watchEvents := func(t *testing.T, sendRecent int32) []string {
+		watcher := watch.NewFake()
+		kubeClientSet.PrependWatchReactor("events", ktesting.DefaultWatchReactor(watcher, nil))
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream := &testWatchEventsServer{testServerStream{ctx}, make(chan *corev1.Event, 4)}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- server.WatchEvents(&workflowpkg.WatchEventsRequest{Namespace: "workflows", SendRecent: sendRecent}, stream)
+		}()
+		// the fake watcher is unbuffered, so this blocks until the recent events have been sent
+		watcher.Add(newEvent("live", 0))
+		cancel()
+		require.NoError(t, <-errCh)
+		close(stream.events)
+		var received []string
+		for event := range stream.events {
+			received = append(received, event.Name)
+		}
+		return received
+	}
+	t.Run("LiveOnly", func(t *testing.T) {
+		assert.Equal(t, []string{"live"}, watchEvents(t, 0))
+	})
+	t.Run("SendRecent", func(t *testing.T) {
+		assert.Equal(t, []string{"older", "newest", "live"}, watchEvents(t, 2))
+	})
+	t.Run("FewerThanRecent", func(t *testing.T) {
+		assert.Equal(t, []string{"oldest", "older", "newest", "live"}, watchEvents(t, 10))
+	})
+}
+
+type testWatchWorkflowNodesServer struct {
+	testServerStream
+	deltas chan *workflowpkg.WorkflowNodeDelta
+}
+
+func (t testWatchWorkflowNodesServer) Send(delta *workflowpkg.WorkflowNodeDelta) error {
+	t.deltas <- delta
+	return nil
+}
+
+func TestWatchWorkflowNodes(t *testing.T) {
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(wf1, &wf)
+	wf.Status.Nodes = v1alpha1.Nodes{
+		"a": {ID: "a", Phase: v1alpha1.NodeRunning},
+		"b": {ID: "b", Phase: v1alpha1.NodePending},
+	}
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{&wf}, &mocks.WorkflowArchive{})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream := &testWatchWorkflowNodesServer{testServerStream{ctx}, make(chan *workflowpkg.WorkflowNodeDelta)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.WatchWorkflowNodes(&workflowpkg.WatchWorkflowNodesRequest{Name: "hello-world-9tql2", Namespace: "workflows"}, stream)
+	}()
+	receive := func() *workflowpkg.WorkflowNodeDelta {
+		select {
+		case delta := <-stream.deltas:
+			return delta
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for a node delta")
+			return nil
+		}
+	}
+
+	// the existing nodes are sent as added
+	assert.Equal(t, &workflowpkg.WorkflowNodeDelta{Type: "ADDED", NodeId: "a", Phase: "Running"}, receive())
+	assert.Equal(t, &workflowpkg.WorkflowNodeDelta{Type: "ADDED", NodeId: "b", Phase: "Pending"}, receive())
+
+	// only the changed node is sent
+	wf.Status.Nodes = v1alpha1.Nodes{
+		"a": {ID: "a", Phase: v1alpha1.NodeRunning},
+		"b": {ID: "b", Phase: v1alpha1.NodeFailed, Message: "Error (exit code 1)"},
+	}
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+	_, err := wfClient.Update(ctx, &wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, &workflowpkg.WorkflowNodeDelta{Type: "MODIFIED", NodeId: "b", Phase: "Failed", Message: "Error (exit code 1)"}, receive())
+
+	// the stream ends when the workflow is deleted
+	err = wfClient.Delete(ctx, "hello-world-9tql2", metav1.DeleteOptions{})
+	require.NoError(t, err)
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case delta := <-stream.deltas:
+		require.Failf(t, "unexpected node delta", "%v", delta)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for the watch to end")
+	}
+}
+
+func TestWatchWorkflowNodesNotFound(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	err := server.WatchWorkflowNodes(&workflowpkg.WatchWorkflowNodesRequest{Name: "not-found", Namespace: "workflows"}, &testWatchWorkflowNodesServer{testServerStream{ctx}, nil})
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func TestWatchLatestWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer(t)
 	wf := &v1alpha1.Workflow{
@@ -748,10 +1459,10 @@ func TestGetWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer(t)
 	s := server.(*workflowServer)
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{}, false)
 	require.NoError(t, err)
 	assert.NotNil(t, wf)
-	wf, err = s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{})
+	wf, err = s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{}, false)
 	require.NoError(t, err)
 	assert.NotNil(t, wf)
 }
@@ -760,9 +1471,45 @@ func TestValidateWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer(t)
 	s := server.(*workflowServer)
 	wfClient := auth.GetWfClient(ctx)
-	wf, err := s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{})
+	wf, err := s.getWorkflow(ctx, wfClient, "test", "hello-world-9tql2-test", metav1.GetOptions{}, false)
 	require.NoError(t, err)
-	require.NoError(t, s.validateWorkflow(wf))
+	require.NoError(t, s.validateWorkflow(ctx, wf))
+}
+
+func TestValidateWorkflowInstanceIDMismatch(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Labels: map[string]string{common.LabelKeyControllerInstanceID: "other-instanceid"}}}
+	t.Run("Reject", func(t *testing.T) {
+		s := &workflowServer{instanceIDService: instanceid.NewService("my-instanceid")}
+		err := s.validateWorkflow(ctx, wf)
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Ignore", func(t *testing.T) {
+		s := &workflowServer{instanceIDService: instanceid.NewService("my-instanceid"), ignoreInstanceIDMismatch: true}
+		require.NoError(t, s.validateWorkflow(ctx, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled"}}))
+		// workflows of another instance are still rejected
+		err := s.validateWorkflow(ctx, wf)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		err = s.validateWorkflow(ctx, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "empty", Labels: map[string]string{common.LabelKeyControllerInstanceID: ""}}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("Options", func(t *testing.T) {
+		for value, ignore := range map[string]bool{"": false, config.InstanceIDMismatchReject: false, config.InstanceIDMismatchIgnore: true} {
+			server := NewWorkflowServer(ctx, instanceid.NewService("my-instanceid"), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, v1alpha.NewSimpleClientset(), nil, nil, nil, nil, nil, nil, WorkflowServerOptions{InstanceIDMismatch: value})
+			assert.Equal(t, ignore, server.ignoreInstanceIDMismatch, value)
+		}
+	})
+	t.Run("GetWorkflow", func(t *testing.T) {
+		wfClientset := v1alpha.NewSimpleClientset(&v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}})
+		offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+		offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+		server := NewWorkflowServer(ctx, instanceid.NewService("my-instanceid"), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{InstanceIDMismatch: config.InstanceIDMismatchIgnore})
+		ctx := context.WithValue(ctx, auth.WfKey, wfClientset)
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Namespace: "my-ns", Name: "my-wf"})
+		require.NoError(t, err)
+		assert.Equal(t, "my-wf", wf.Name)
+	})
 }
 
 func TestListWorkflow(t *testing.T) {
@@ -777,50 +1524,610 @@ func TestListWorkflow(t *testing.T) {
 	assert.Len(t, wfl.Items, 2)
 }
 
-func TestDeleteWorkflow(t *testing.T) {
-	server, ctx := getWorkflowServer(t)
-	t.Run("Labelled", func(t *testing.T) {
-		delRsp, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "hello-world-b6h5m", Namespace: "workflows"})
-		require.NoError(t, err)
-		assert.NotNil(t, delRsp)
+func TestListWorkflowCreatedBy(t *testing.T) {
+	newWf := func(namespace, name, creator string) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			UID:       k8stypes.UID(name),
+			Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid", common.LabelKeyCreator: creator},
+		}}
+	}
+	hasCreator := mock.MatchedBy(func(options sutils.ListOptions) bool {
+		for _, r := range options.LabelRequirements {
+			if r.Key() == common.LabelKeyCreator {
+				return true
+			}
+		}
+		return false
 	})
-	t.Run("Unlabelled", func(t *testing.T) {
-		_, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "unlabelled", Namespace: "workflows"})
-		require.Error(t, err)
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("CountWorkflows", mock.Anything, hasCreator).Return(int64(0), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, hasCreator).Return(v1alpha1.Workflows{}, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+		newWf("workflows", "alice-1", "alice"),
+		newWf("workflows", "alice-2", "alice"),
+		newWf("workflows", "bob-1", "bob"),
+		newWf("other", "alice-3", "alice"),
+	}, archivedRepo)
+
+	t.Run("Namespace", func(t *testing.T) {
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", CreatedBy: "alice"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"alice-1", "alice-2"}, workflowNames(wfl))
 	})
-	t.Run("Latest", func(t *testing.T) {
-		_, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "@latest", Namespace: "workflows"})
+	t.Run("AllNamespaces", func(t *testing.T) {
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{CreatedBy: "alice"})
 		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"alice-1", "alice-2", "alice-3"}, workflowNames(wfl))
 	})
-}
-
-func TestRetryWorkflow(t *testing.T) {
-	server, ctx := getWorkflowServer(t)
-	t.Run("Labelled", func(t *testing.T) {
-		retried, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+	t.Run("NameFilter", func(t *testing.T) {
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{
+			Namespace:   "workflows",
+			CreatedBy:   "alice",
+			NameFilter:  "Prefix",
+			ListOptions: &metav1.ListOptions{FieldSelector: "metadata.name=alice-2"},
+		})
 		require.NoError(t, err)
-		assert.NotNil(t, retried)
+		assert.Equal(t, []string{"alice-2"}, workflowNames(wfl))
 	})
-	t.Run("Unlabelled", func(t *testing.T) {
-		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "unlabelled", Namespace: "workflows"})
-		require.Error(t, err)
+	t.Run("OtherUser", func(t *testing.T) {
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", CreatedBy: "bob"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob-1"}, workflowNames(wfl))
 	})
-	t.Run("Latest", func(t *testing.T) {
-		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "latest", Namespace: "workflows"})
-		require.Error(t, err)
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", CreatedBy: "not a label"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 }
 
-func TestSuspendResumeWorkflow(t *testing.T) {
-	server, ctx := getWorkflowServer(t)
-	wf, err := server.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
-	require.NoError(t, err)
-	assert.NotNil(t, wf)
-	assert.True(t, *wf.Spec.Suspend)
-	assert.Contains(t, wf.Labels, common.LabelKeyActor)
-	assert.Equal(t, string(creator.ActionSuspend), wf.Labels[common.LabelKeyAction])
-	assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyActorEmail])
-	wf, err = server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: wf.Name, Namespace: wf.Namespace})
+func TestListWorkflowProgress(t *testing.T) {
+	newWf := func(name string, progress v1alpha1.Progress) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "workflows",
+				UID:       k8stypes.UID(name),
+				Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"},
+			},
+			Status: v1alpha1.WorkflowStatus{Progress: progress},
+		}
+	}
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("CountWorkflows", mock.Anything, mock.Anything).Return(int64(1), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, mock.Anything).Return(v1alpha1.Workflows{*newWf("archived", "3/3")}, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{newWf("half", "1/2"), newWf("no-tasks", "0/0"), newWf("no-progress", "")}, archivedRepo)
+
+	list, err := server.ListWorkflowProgress(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows"})
+	require.NoError(t, err)
+	percents := map[string]int64{}
+	invalid := []string{}
+	for _, item := range list.Items {
+		if item.Valid {
+			percents[item.Name] = item.Percent
+		} else {
+			invalid = append(invalid, item.Name)
+		}
+	}
+	assert.Equal(t, map[string]int64{"half": 50, "no-tasks": 0, "archived": 100}, percents)
+	assert.Equal(t, []string{"no-progress"}, invalid)
+}
+
+func TestListWorkflowTemplateGroups(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	newWf := func(name, template string, age time.Duration) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "workflows",
+				UID:       k8stypes.UID(name),
+				Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid", common.LabelKeyWorkflowTemplate: template},
+			},
+			Status: v1alpha1.WorkflowStatus{StartedAt: metav1.NewTime(now.Add(-age))},
+		}
+	}
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("GroupWorkflowsByTemplate", mock.Anything, mock.Anything).Return([]sqldb.TemplateGroup{
+		{Template: "WorkflowTemplate/test", Count: 1, Latest: *newWf("test-1", "test", time.Hour)},
+		{Template: "WorkflowTemplate/build", Count: 1, Latest: *newWf("build-1", "build", 2*time.Hour)},
+	}, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{newWf("build-2", "build", time.Minute), newWf("build-3", "build", time.Second), newWf("deploy-1", "deploy", 3*time.Hour)}, archivedRepo)
+
+	list, err := server.ListWorkflowTemplateGroups(ctx, &workflowpkg.WorkflowTemplateGroupsRequest{Namespace: "workflows"})
+	require.NoError(t, err)
+	var groups []string
+	for _, group := range list.Items {
+		groups = append(groups, fmt.Sprintf("%s %s %d", group.Template, group.Latest.Name, group.Count))
+	}
+	assert.Equal(t, []string{"WorkflowTemplate/build build-3 3", "WorkflowTemplate/test test-1 1", "WorkflowTemplate/deploy deploy-1 1"}, groups)
+}
+
+func TestListWorkflowOrderBy(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", OrderBy: "phase"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSortWorkflows(t *testing.T) {
+	now := time.Now()
+	newWf := func(name string, age time.Duration) v1alpha1.Workflow {
+		return v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: v1alpha1.WorkflowStatus{
+				StartedAt:  metav1.NewTime(now.Add(-age - time.Hour)),
+				FinishedAt: metav1.NewTime(now.Add(-age)),
+			},
+		}
+	}
+	names := func(wfs v1alpha1.Workflows) []string {
+		var names []string
+		for _, wf := range wfs {
+			names = append(names, wf.Name)
+		}
+		return names
+	}
+	for orderBy, expected := range map[sutils.OrderBy][]string{
+		{Field: sutils.OrderByStartedAt}:                   {"b", "c", "a"},
+		{Field: sutils.OrderByStartedAt, Ascending: true}:  {"a", "c", "b"},
+		{Field: sutils.OrderByFinishedAt}:                  {"b", "c", "a"},
+		{Field: sutils.OrderByFinishedAt, Ascending: true}: {"a", "c", "b"},
+		{Field: sutils.OrderByName, Ascending: true}:       {"a", "b", "c"},
+		{Field: sutils.OrderByName}:                        {"c", "b", "a"},
+	} {
+		wfs := v1alpha1.Workflows{newWf("a", 3*time.Hour), newWf("b", time.Hour), newWf("c", 2*time.Hour)}
+		sortWorkflows(wfs, orderBy)
+		assert.Equal(t, expected, names(wfs), orderBy)
+	}
+}
+
+func TestDeleteWorkflow(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("Labelled", func(t *testing.T) {
+		delRsp, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "hello-world-b6h5m", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.NotNil(t, delRsp)
+	})
+	t.Run("Unlabelled", func(t *testing.T) {
+		_, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "unlabelled", Namespace: "workflows"})
+		require.Error(t, err)
+	})
+	t.Run("Latest", func(t *testing.T) {
+		_, err := server.DeleteWorkflow(ctx, &workflowpkg.WorkflowDeleteRequest{Name: "@latest", Namespace: "workflows"})
+		require.NoError(t, err)
+	})
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	kubeClient := auth.GetKubeClient(ctx)
+	_, err := kubeClient.CoreV1().ConfigMaps("argo").Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller-configmap"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	server.(*workflowServer).maintenance = NewMaintenance(kubeClient, "argo", "workflow-controller-configmap")
+	mode, err := server.SetMaintenanceMode(ctx, &workflowpkg.SetMaintenanceModeRequest{Paused: true, Message: "upgrading the cluster"})
+	require.NoError(t, err)
+	assert.True(t, mode.Paused)
+	mode, err = server.GetMaintenanceMode(ctx, &workflowpkg.GetMaintenanceModeRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "upgrading the cluster", mode.Message)
+	t.Run("Create", func(t *testing.T) {
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		_, err := server.CreateWorkflow(ctx, &req)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Contains(t, err.Error(), "upgrading the cluster")
+	})
+	t.Run("Submit", func(t *testing.T) {
+		_, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:    "workflows",
+			ResourceKind: "workflowtemplate",
+			ResourceName: "workflow-template-whalesay-template",
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("Resubmit", func(t *testing.T) {
+		_, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+	t.Run("Read", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.NoError(t, err)
+		wfl, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.NotEmpty(t, wfl.Items)
+	})
+	t.Run("Unpaused", func(t *testing.T) {
+		_, err := server.SetMaintenanceMode(ctx, &workflowpkg.SetMaintenanceModeRequest{})
+		require.NoError(t, err)
+		var req workflowpkg.WorkflowCreateRequest
+		v1alpha1.MustUnmarshal(workflow1, &req)
+		_, err = server.CreateWorkflow(ctx, &req)
+		require.NoError(t, err)
+	})
+}
+
+func TestDeleteWorkflowPropagation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, v1alpha.NewSimpleClientset(), nil, nil, nil, nil, nil, nil, WorkflowServerOptions{
+		NamespaceDeletePropagation: map[string]metav1.DeletionPropagation{"debug": metav1.DeletePropagationOrphan},
+	})
+	foreground := metav1.DeletePropagationForeground
+	t.Run("Namespace", func(t *testing.T) {
+		assert.Equal(t, metav1.DeletePropagationOrphan, *server.deletePropagation("debug", nil))
+		assert.Equal(t, metav1.DeletePropagationOrphan, *server.deletePropagation("debug", &metav1.DeleteOptions{}))
+	})
+	t.Run("Default", func(t *testing.T) {
+		assert.Equal(t, metav1.DeletePropagationBackground, *server.deletePropagation("workflows", nil))
+	})
+	t.Run("Request", func(t *testing.T) {
+		assert.Equal(t, metav1.DeletePropagationForeground, *server.deletePropagation("debug", &metav1.DeleteOptions{PropagationPolicy: &foreground}))
+		assert.Equal(t, metav1.DeletePropagationForeground, *server.deletePropagation("workflows", &metav1.DeleteOptions{PropagationPolicy: &foreground}))
+	})
+}
+
+func TestDeleteWorkflows(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	newWorkflow := func(name, instanceID string) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "workflows",
+			Labels:    map[string]string{"team": "my-team", common.LabelKeyControllerInstanceID: instanceID},
+		}}
+	}
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Name != "forbidden"},
+		}, nil
+	})
+	wfClientset := v1alpha.NewSimpleClientset(
+		newWorkflow("allowed-1", "my-instanceid"),
+		newWorkflow("allowed-2", "my-instanceid"),
+		newWorkflow("forbidden", "my-instanceid"),
+		newWorkflow("other-instance", "other-instanceid"),
+	)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	server := NewWorkflowServer(ctx, instanceIDSvc, &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+	remaining := func() []string {
+		list, err := wfClientset.ArgoprojV1alpha1().Workflows("workflows").List(ctx, metav1.ListOptions{})
+		require.NoError(t, err)
+		var names []string
+		for _, wf := range list.Items {
+			names = append(names, wf.Name)
+		}
+		return names
+	}
+	listOptions := &metav1.ListOptions{LabelSelector: "team=my-team"}
+
+	t.Run("NoSelector", func(t *testing.T) {
+		_, err := server.DeleteWorkflows(ctx, &workflowpkg.WorkflowsDeleteRequest{Namespace: "workflows"})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		rsp, err := server.DeleteWorkflows(ctx, &workflowpkg.WorkflowsDeleteRequest{Namespace: "workflows", ListOptions: listOptions, DryRun: true})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*workflowpkg.WorkflowDeleteResult{
+			{Name: "allowed-1", Namespace: "workflows"},
+			{Name: "allowed-2", Namespace: "workflows"},
+			{Name: "forbidden", Namespace: "workflows", Error: "rpc error: code = PermissionDenied desc = cannot delete workflow forbidden"},
+		}, rsp.Items)
+		assert.ElementsMatch(t, []string{"allowed-1", "allowed-2", "forbidden", "other-instance"}, remaining())
+	})
+	t.Run("Delete", func(t *testing.T) {
+		rsp, err := server.DeleteWorkflows(ctx, &workflowpkg.WorkflowsDeleteRequest{Namespace: "workflows", ListOptions: listOptions})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []*workflowpkg.WorkflowDeleteResult{
+			{Name: "allowed-1", Namespace: "workflows", Deleted: true},
+			{Name: "allowed-2", Namespace: "workflows", Deleted: true},
+			{Name: "forbidden", Namespace: "workflows", Error: "rpc error: code = PermissionDenied desc = cannot delete workflow forbidden"},
+		}, rsp.Items)
+		assert.ElementsMatch(t, []string{"forbidden", "other-instance"}, remaining())
+	})
+}
+
+func TestDeleteWorkflowsCancel(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var objects []runtime.Object
+	for i := range 4 {
+		objects = append(objects, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("wf-%d", i),
+			Namespace: "workflows",
+			Labels:    map[string]string{"team": "my-team", common.LabelKeyControllerInstanceID: "my-instanceid"},
+		}})
+	}
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+	})
+	wfClientset := v1alpha.NewSimpleClientset(objects...)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	server := NewWorkflowServer(ctx, instanceid.NewService("my-instanceid"), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+	// cancel the operation as soon as the first workflow has been deleted
+	wfClientset.PrependReactor("delete", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+		_, err := server.CancelOperation(ctx, &workflowpkg.CancelOperationRequest{Namespace: "workflows", OperationId: "my-operation"})
+		require.NoError(t, err)
+		return false, nil, nil
+	})
+
+	rsp, err := server.DeleteWorkflows(ctx, &workflowpkg.WorkflowsDeleteRequest{Namespace: "workflows", ListOptions: &metav1.ListOptions{LabelSelector: "team=my-team"}, OperationId: "my-operation"})
+	require.NoError(t, err)
+	assert.Equal(t, "my-operation", rsp.OperationId)
+	assert.True(t, rsp.Canceled)
+	require.Len(t, rsp.Items, 1)
+	assert.True(t, rsp.Items[0].Deleted)
+	list, err := wfClientset.ArgoprojV1alpha1().Workflows("workflows").List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 3)
+
+	_, err = server.CancelOperation(ctx, &workflowpkg.CancelOperationRequest{Namespace: "workflows", OperationId: "my-operation"})
+	assert.Equal(t, codes.NotFound, status.Code(err), "the operation has finished")
+}
+
+func TestCancelOperation(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	canDelete := false
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		allowed := canDelete && review.Spec.ResourceAttributes.Verb == "delete" && review.Spec.ResourceAttributes.Namespace == "workflows"
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed}}, nil
+	})
+	ctx = context.WithValue(ctx, auth.KubeKey, kubeClientSet)
+	as := func(subject string) context.Context {
+		return context.WithValue(ctx, auth.ClaimsKey, &types.Claims{Claims: jwt.Claims{Subject: subject}})
+	}
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, v1alpha.NewSimpleClientset(), nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+	start := func(t *testing.T) context.Context {
+		t.Helper()
+		opCtx, _, finish, err := server.operations.start(as("alice"), "workflows", "my-operation")
+		require.NoError(t, err)
+		t.Cleanup(finish)
+		return opCtx
+	}
+	cancel := func(ctx context.Context) error {
+		_, err := server.CancelOperation(ctx, &workflowpkg.CancelOperationRequest{Namespace: "workflows", OperationId: "my-operation"})
+		return err
+	}
+
+	t.Run("Starter", func(t *testing.T) {
+		opCtx := start(t)
+		require.NoError(t, cancel(as("alice")))
+		require.Error(t, opCtx.Err())
+	})
+	t.Run("OtherUser", func(t *testing.T) {
+		opCtx := start(t)
+		assert.Equal(t, codes.PermissionDenied, status.Code(cancel(as("bob"))))
+		assert.Equal(t, codes.PermissionDenied, status.Code(cancel(ctx)), "a user without a subject is another user")
+		require.NoError(t, opCtx.Err())
+	})
+	t.Run("OtherUserCanDelete", func(t *testing.T) {
+		canDelete = true
+		defer func() { canDelete = false }()
+		opCtx := start(t)
+		require.NoError(t, cancel(as("bob")))
+		require.Error(t, opCtx.Err())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		// whether the operation exists is not returned to users that cannot delete workflows
+		assert.Equal(t, codes.PermissionDenied, status.Code(cancel(as("alice"))))
+		canDelete = true
+		defer func() { canDelete = false }()
+		assert.Equal(t, codes.NotFound, status.Code(cancel(as("alice"))))
+	})
+}
+
+func TestRetryWorkflow(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("Labelled", func(t *testing.T) {
+		retried, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.NotNil(t, retried)
+	})
+	t.Run("Unlabelled", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "unlabelled", Namespace: "workflows"})
+		require.Error(t, err)
+	})
+	t.Run("Latest", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "latest", Namespace: "workflows"})
+		require.Error(t, err)
+	})
+	t.Run("Running", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.EqualError(t, err, "rpc error: code = FailedPrecondition desc = workflow hello-world-9tql2-run cannot be retried in phase Running, only failed or errored workflows can be retried")
+	})
+	t.Run("Succeeded", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.EqualError(t, err, "rpc error: code = FailedPrecondition desc = workflow hello-world-9tql2 has succeeded, to retry it set the options restartSuccessful and nodeFieldSelector")
+	})
+	t.Run("Failed", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		wf, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		require.Equal(t, v1alpha1.WorkflowFailed, wf.Status.Phase)
+		retried, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.WorkflowRunning, retried.Status.Phase)
+	})
+}
+
+func TestRetryWorkflowOptions(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("RestartSuccessfulOnSucceededWithoutSelector", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "hello-world-9tql2", Namespace: "workflows", RestartSuccessful: true})
+		require.Error(t, err)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("InvalidSelector", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", RestartSuccessful: true, NodeFieldSelector: "templateName"})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), `invalid nodeFieldSelector "templateName"`)
+	})
+	t.Run("InvalidSelectorWithoutRestartSuccessful", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", NodeFieldSelector: "templateName"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("SelectorWithoutRestartSuccessful", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", NodeFieldSelector: "templateName=whalesay"})
+		require.NoError(t, err)
+	})
+	t.Run("RestartSuccessfulWithoutSelector", func(t *testing.T) {
+		// deprecated, but still accepted, retrying only the failed nodes
+		server, ctx := getWorkflowServer(t)
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", RestartSuccessful: true})
+		require.NoError(t, err)
+	})
+}
+
+func TestRetryWorkflowRetryLimits(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("NoRetryStrategy", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", RetryLimits: []string{"whalesay=3"}})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = template whalesay has no retryStrategy")
+	})
+	t.Run("Raised", func(t *testing.T) {
+		wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+		wf, err := wfClient.Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Spec.Templates[0].RetryStrategy = &v1alpha1.RetryStrategy{Limit: ptr.To(intstr.FromInt(1))}
+		_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		retried, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", RetryLimits: []string{"whalesay=3"}})
+		require.NoError(t, err)
+		assert.Equal(t, intstr.FromInt(3), *retried.Spec.Templates[0].RetryStrategy.Limit)
+	})
+}
+
+func TestRetryWorkflowConcurrent(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClientset := auth.GetWfClient(ctx).(*v1alpha.Clientset)
+	wf, err := wfClientset.ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "failed", metav1.GetOptions{})
+	require.NoError(t, err)
+	wf.Status.Nodes = v1alpha1.Nodes{"failed": {ID: "failed", Name: "failed", Type: v1alpha1.NodeTypePod, TemplateName: "whalesay", Phase: v1alpha1.NodeFailed}}
+	_, err = wfClientset.ArgoprojV1alpha1().Workflows("workflows").Update(ctx, wf, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	var updates atomic.Int32
+	wfClientset.PrependReactor("update", "workflows", func(action ktesting.Action) (bool, runtime.Object, error) {
+		updates.Add(1)
+		return false, nil, nil
+	})
+	// the first retry is held while deleting the pods of the workflow, rather than while updating it, as the fake
+	// workflow client would block the other retries from reading the workflow
+	var deletes atomic.Int32
+	deleting := make(chan struct{})
+	release := make(chan struct{})
+	auth.GetKubeClient(ctx).(*fake.Clientset).PrependReactor("delete", "pods", func(action ktesting.Action) (bool, runtime.Object, error) {
+		if deletes.Add(1) == 1 {
+			close(deleting)
+			<-release
+		}
+		return false, nil, nil
+	})
+
+	const retries = 3
+	joined := make(chan struct{}, retries)
+	server.(*workflowServer).retryJoined = func() { joined <- struct{}{} }
+	results := make(chan *v1alpha1.Workflow, retries)
+	errs := make(chan error, retries)
+	for range retries {
+		go func() {
+			wf, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+			results <- wf
+			errs <- err
+		}()
+	}
+	// wait until the first retry is deleting the pods, and the others have joined it
+	select {
+	case <-deleting:
+	case err := <-errs:
+		t.Fatalf("retry finished without deleting the pods: %v", err)
+	}
+	for range retries {
+		<-joined
+	}
+	close(release)
+
+	for range retries {
+		require.NoError(t, <-errs)
+		wf := <-results
+		require.NotNil(t, wf)
+		assert.Equal(t, v1alpha1.WorkflowRunning, wf.Status.Phase)
+	}
+	assert.Equal(t, int32(1), updates.Load())
+}
+
+func TestRetryWorkflowEntrypoint(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", Entrypoint: "missing"})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = entrypoint template missing not found")
+	})
+	t.Run("Changed", func(t *testing.T) {
+		wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+		wf, err := wfClient.Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Spec.Templates = append(wf.Spec.Templates, v1alpha1.Template{Name: "cowsay", Container: &corev1.Container{Image: "docker/whalesay"}})
+		wf.Status.Nodes = v1alpha1.Nodes{"failed": {ID: "failed", Name: "failed", Type: v1alpha1.NodeTypePod, TemplateName: "whalesay", Phase: v1alpha1.NodeFailed}}
+		_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		retried, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", Entrypoint: "cowsay"})
+		require.NoError(t, err)
+		assert.Equal(t, "cowsay", retried.Spec.Entrypoint)
+		assert.Empty(t, retried.Status.Nodes)
+	})
+}
+
+func TestRetryWorkflowKeepPods(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+	podClient := auth.GetKubeClient(ctx).CoreV1().Pods("workflows")
+	setUp := func(t *testing.T) {
+		t.Helper()
+		wf, err := wfClient.Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Phase = v1alpha1.WorkflowFailed
+		wf.Status.Nodes = v1alpha1.Nodes{"failed": {ID: "failed", Name: "failed", Type: v1alpha1.NodeTypePod, TemplateName: "whalesay", Phase: v1alpha1.NodeFailed}}
+		_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+		_, err = podClient.Create(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:       "failed",
+			Labels:     map[string]string{common.LabelKeyWorkflow: "failed"},
+			Finalizers: []string{common.FinalizerPodStatus},
+		}}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	t.Run("Kept", func(t *testing.T) {
+		setUp(t)
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows", KeepPods: true})
+		require.NoError(t, err)
+		pod, err := podClient.Get(ctx, "failed", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{common.LabelKeyRetriedWorkflow: "failed"}, pod.Labels)
+		assert.Empty(t, pod.Finalizers)
+		require.NoError(t, podClient.Delete(ctx, "failed", metav1.DeleteOptions{}))
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		setUp(t)
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		_, err = podClient.Get(ctx, "failed", metav1.GetOptions{})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+}
+
+func TestSuspendResumeWorkflow(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wf, err := server.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+	require.NoError(t, err)
+	assert.NotNil(t, wf)
+	assert.True(t, *wf.Spec.Suspend)
+	assert.Contains(t, wf.Labels, common.LabelKeyActor)
+	assert.Equal(t, string(creator.ActionSuspend), wf.Labels[common.LabelKeyAction])
+	assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyActorEmail])
+	wf, err = server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: wf.Name, Namespace: wf.Namespace})
 	require.NoError(t, err)
 	assert.NotNil(t, wf)
 	assert.Contains(t, wf.Labels, common.LabelKeyActor)
@@ -888,6 +2195,49 @@ func TestStopWorkflow(t *testing.T) {
 	assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyActorEmail])
 }
 
+func TestStopWorkflowWait(t *testing.T) {
+	// the controller completes the workflow once it has been stopped
+	completeOnWatch := func(wfClient *v1alpha.Clientset, phase v1alpha1.WorkflowPhase) {
+		watcher := watch.NewFake()
+		wfClient.PrependWatchReactor("workflows", func(action ktesting.Action) (bool, watch.Interface, error) {
+			name, _ := action.(ktesting.WatchAction).GetWatchRestrictions().Fields.RequiresExactMatch("metadata.name")
+			go watcher.Modify(&v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows"},
+				Status:     v1alpha1.WorkflowStatus{Phase: phase},
+			})
+			return true, watcher, nil
+		})
+	}
+	t.Run("TerminateCompleted", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		completeOnWatch(ctx.Value(auth.WfKey).(*v1alpha.Clientset), v1alpha1.WorkflowFailed)
+		wf, err := server.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Wait: true})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.WorkflowFailed, wf.Status.Phase)
+	})
+	t.Run("StopCompleted", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		completeOnWatch(ctx.Value(auth.WfKey).(*v1alpha.Clientset), v1alpha1.WorkflowSucceeded)
+		wf, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Wait: true})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.WorkflowSucceeded, wf.Status.Phase)
+	})
+	t.Run("TerminateTimeout", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		wf, err := server.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Wait: true, WaitTimeoutSeconds: 1})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ShutdownStrategyTerminate, wf.Spec.Shutdown)
+		assert.Equal(t, v1alpha1.WorkflowRunning, wf.Status.Phase)
+	})
+	t.Run("StopTimeout", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		wf, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows", Wait: true, WaitTimeoutSeconds: 1})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.ShutdownStrategyStop, wf.Spec.Shutdown)
+		assert.Equal(t, v1alpha1.WorkflowRunning, wf.Status.Phase)
+	})
+}
+
 func TestResubmitWorkflow(t *testing.T) {
 	server, ctx := getWorkflowServer(t)
 	t.Run("Labelled", func(t *testing.T) {
@@ -906,6 +2256,72 @@ func TestResubmitWorkflow(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, wf)
 	})
+	t.Run("ServiceAccount", func(t *testing.T) {
+		wf, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-b6h5m", Namespace: "workflows", ServiceAccount: "other-sa"})
+		require.NoError(t, err)
+		assert.Equal(t, "other-sa", wf.Spec.ServiceAccountName)
+	})
+	t.Run("InvalidServiceAccount", func(t *testing.T) {
+		_, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-b6h5m", Namespace: "workflows", ServiceAccount: "Not_Valid"})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("OwnerReferences", func(t *testing.T) {
+		kubeClient := ctx.Value(auth.KubeKey).(*fake.Clientset)
+		kubeClient.Resources = []*metav1.APIResourceList{{
+			GroupVersion: "argoproj.io/v1alpha1",
+			APIResources: []metav1.APIResource{{Name: "cronworkflows", Namespaced: true, Kind: "CronWorkflow"}},
+		}}
+		dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "argoproj.io/v1alpha1",
+			"kind":       "CronWorkflow",
+			"metadata":   map[string]interface{}{"name": "my-cron", "namespace": "workflows", "uid": "my-cron-uid"},
+		}})
+		ctx := context.WithValue(ctx, auth.DynamicKey, dynamic.Interface(dynamicClient))
+		var owned v1alpha1.Workflow
+		v1alpha1.MustUnmarshal(failedWf, &owned)
+		owned.Name = "owned"
+		owned.OwnerReferences = []metav1.OwnerReference{
+			{APIVersion: "argoproj.io/v1alpha1", Kind: "CronWorkflow", Name: "my-cron", UID: "my-cron-uid"},
+			{APIVersion: "argoproj.io/v1alpha1", Kind: "CronWorkflow", Name: "deleted-cron", UID: "deleted-cron-uid"},
+		}
+		wfClient := ctx.Value(auth.WfKey).(versioned.Interface)
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Create(ctx, &owned, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		wf, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "owned", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, owned.OwnerReferences[:1], wf.OwnerReferences)
+	})
+	t.Run("FailedOnly", func(t *testing.T) {
+		var partial v1alpha1.Workflow
+		v1alpha1.MustUnmarshal(failedWf, &partial)
+		partial.Name = "partially-failed"
+		partial.Spec.Templates = append(partial.Spec.Templates, v1alpha1.Template{Name: "unused"})
+		partial.Status.Nodes = v1alpha1.Nodes{
+			"partially-failed": {ID: "partially-failed", Name: "partially-failed", DisplayName: "partially-failed", Type: v1alpha1.NodeTypePod, TemplateName: "whalesay", Phase: v1alpha1.NodeFailed},
+		}
+		wfClient := ctx.Value(auth.WfKey).(versioned.Interface)
+		_, err := wfClient.ArgoprojV1alpha1().Workflows("workflows").Create(ctx, &partial, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		wf, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "partially-failed", Namespace: "workflows", FailedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, "resubmit-failed", wf.Spec.Entrypoint)
+		require.Len(t, wf.Spec.Templates, 2)
+		assert.Equal(t, "whalesay", wf.Spec.Templates[0].Name)
+		assert.Equal(t, "whalesay", wf.Spec.Templates[1].DAG.Tasks[0].Template)
+	})
+	t.Run("FailedOnlyNothingFailed", func(t *testing.T) {
+		_, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2", Namespace: "workflows", FailedOnly: true})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("FailedOnlyMemoized", func(t *testing.T) {
+		_, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "failed", Namespace: "workflows", FailedOnly: true, Memoized: true})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 }
 
 func TestLintWorkflow(t *testing.T) {
@@ -917,6 +2333,18 @@ func TestLintWorkflow(t *testing.T) {
 	assert.NotNil(t, linted)
 	assert.Contains(t, linted.Labels, common.LabelKeyControllerInstanceID)
 	assert.Contains(t, linted.Labels, common.LabelKeyCreator)
+
+	t.Run("ResolveConfigMaps", func(t *testing.T) {
+		wf := &v1alpha1.Workflow{}
+		v1alpha1.MustUnmarshal(unlabelled, &wf)
+		wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, v1alpha1.Parameter{Name: "my-param", ValueFrom: &v1alpha1.ValueFrom{
+			ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "my-cm"}, Key: "my-key"},
+		}})
+		stream := &testTransportStream{}
+		_, err := server.LintWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowLintRequest{Namespace: "workflows", Workflow: wf, ResolveConfigMaps: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"workflow parameter my-param: config map my-cm not found"}, stream.header.Get(grpcutil.WarningHeader))
+	})
 }
 
 type testPodLogsServer struct {
@@ -941,24 +2369,201 @@ func TestPodLogs(t *testing.T) {
 	cancel()
 }
 
-func TestSubmitWorkflowFromResource(t *testing.T) {
-	server, ctx := getWorkflowServer(t)
-	t.Run("SubmitFromWorkflowTemplate fails if missing parameters", func(t *testing.T) {
-		_, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
-			Namespace:    "workflows",
-			ResourceKind: "workflowtemplate",
-			ResourceName: "workflow-template-whalesay-template",
-		})
-		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = spec.arguments.message.value or spec.arguments.message.valueFrom is required")
-	})
-	t.Run("SubmitFromWorkflowTemplate", func(t *testing.T) {
-		opts := v1alpha1.SubmitOpts{
-			Parameters: []string{
-				"message=hello",
-			},
-		}
-		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
-			Namespace:     "workflows",
+type testWorkflowLogsServer struct {
+	testServerStream
+	mu      sync.Mutex
+	entries []*workflowpkg.LogEntry
+}
+
+func (t *testWorkflowLogsServer) Send(entry *workflowpkg.LogEntry) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.entries = append(t.entries, entry)
+	return nil
+}
+
+func TestWorkflowLogsTemplateName(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "workflows"},
+		Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowSucceeded, Nodes: v1alpha1.Nodes{
+			"my-wf":         {ID: "my-wf", Name: "my-wf", Type: v1alpha1.NodeTypeSteps, TemplateName: "main"},
+			"my-wf-train-1": {ID: "my-wf-train-1", Name: "my-wf[0].train", Type: v1alpha1.NodeTypePod, TemplateName: "train"},
+			"my-wf-train-2": {ID: "my-wf-train-2", Name: "my-wf[1].train-again", Type: v1alpha1.NodeTypePod, TemplateRef: &v1alpha1.TemplateRef{Name: "my-wftmpl", Template: "train"}},
+			"my-wf-eval":    {ID: "my-wf-eval", Name: "my-wf[2].eval", Type: v1alpha1.NodeTypePod, TemplateName: "eval"},
+		}},
+	}
+	kubeClientSet := fake.NewSimpleClientset()
+	for _, nodeID := range []string{"my-wf-train-1", "my-wf-train-2", "my-wf-eval"} {
+		_, err := kubeClientSet.CoreV1().Pods("workflows").Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        nodeID + "-pod",
+				Namespace:   "workflows",
+				UID:         k8stypes.UID(nodeID + "-uid"),
+				Labels:      map[string]string{common.LabelKeyWorkflow: "my-wf"},
+				Annotations: map[string]string{common.AnnotationKeyNodeID: nodeID},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	wfClientset := v1alpha.NewSimpleClientset(wf)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Template", func(t *testing.T) {
+		ws := &testWorkflowLogsServer{testServerStream: testServerStream{ctx}}
+		err := server.WorkflowLogs(&workflowpkg.WorkflowLogRequest{Name: "my-wf", Namespace: "workflows", TemplateName: "train", LogOptions: &corev1.PodLogOptions{}}, ws)
+		require.NoError(t, err)
+		var podNames []string
+		for _, entry := range ws.entries {
+			podNames = append(podNames, entry.PodName)
+		}
+		assert.ElementsMatch(t, []string{"my-wf-train-1-pod", "my-wf-train-2-pod"}, podNames)
+	})
+	t.Run("TemplateNotFound", func(t *testing.T) {
+		ws := &testWorkflowLogsServer{testServerStream: testServerStream{ctx}}
+		err := server.WorkflowLogs(&workflowpkg.WorkflowLogRequest{Name: "my-wf", Namespace: "workflows", TemplateName: "main", LogOptions: &corev1.PodLogOptions{}}, ws)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestWorkflowLogsMinLevel(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "workflows"},
+		Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowSucceeded},
+	}
+	kubeClientSet := fake.NewSimpleClientset(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf-pod", Namespace: "workflows", UID: "my-wf-pod-uid", Labels: map[string]string{common.LabelKeyWorkflow: "my-wf"}},
+		Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+	})
+	wfClientset := v1alpha.NewSimpleClientset(wf)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), &mocks.OffloadNodeStatusRepo{}, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("PlainText", func(t *testing.T) {
+		ws := &testWorkflowLogsServer{testServerStream: testServerStream{ctx}}
+		err := server.WorkflowLogs(&workflowpkg.WorkflowLogRequest{Name: "my-wf", Namespace: "workflows", MinLevel: "error", LogOptions: &corev1.PodLogOptions{}}, ws)
+		require.NoError(t, err)
+		// the fake pod logs are not JSON, so are not filtered
+		require.Len(t, ws.entries, 1)
+		assert.Equal(t, "fake logs", ws.entries[0].Content)
+	})
+	t.Run("UnknownLevel", func(t *testing.T) {
+		ws := &testWorkflowLogsServer{testServerStream: testServerStream{ctx}}
+		err := server.WorkflowLogs(&workflowpkg.WorkflowLogRequest{Name: "my-wf", Namespace: "workflows", MinLevel: "loud", LogOptions: &corev1.PodLogOptions{}}, ws)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+type testLogArchiveServer struct {
+	testServerStream
+	data bytes.Buffer
+}
+
+func (t *testLogArchiveServer) Send(chunk *workflowpkg.LogArchiveChunk) error {
+	t.data.Write(chunk.Data)
+	return nil
+}
+
+// readLogArchive returns the contents of each file in the tar.gz
+func readLogArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestGetWorkflowLogArchive(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	kubeClient := auth.GetKubeClient(ctx)
+	for _, node := range []string{"a", "b"} {
+		_, err := kubeClient.CoreV1().Pods("workflows").Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "hello-world-9tql2-" + node,
+				Namespace:   "workflows",
+				Labels:      map[string]string{common.LabelKeyWorkflow: "hello-world-9tql2"},
+				Annotations: map[string]string{common.AnnotationKeyNodeID: "node-" + node, common.AnnotationKeyNodeName: "hello-world-9tql2." + node},
+			},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: common.InitContainerName}},
+				Containers:     []corev1.Container{{Name: common.WaitContainerName}, {Name: common.MainContainerName}},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+	t.Run("Workflow", func(t *testing.T) {
+		ws := &testLogArchiveServer{testServerStream: testServerStream{ctx}}
+		err := server.GetWorkflowLogArchive(&workflowpkg.WorkflowLogArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows"}, ws)
+		require.NoError(t, err)
+		files := readLogArchive(t, ws.data.Bytes())
+		assert.Len(t, files, 7)
+		for _, node := range []string{"a", "b"} {
+			for _, container := range []string{common.InitContainerName, common.WaitContainerName, common.MainContainerName} {
+				// the fake client returns the same logs for every container
+				assert.Equal(t, "fake logs", files["node-"+node+"/"+container+".log"])
+			}
+		}
+		var manifest []logs.ArchiveManifestEntry
+		require.NoError(t, json.Unmarshal([]byte(files[logs.ArchiveManifestFile]), &manifest))
+		require.Len(t, manifest, 6)
+		assert.Equal(t, logs.ArchiveManifestEntry{
+			File:      "node-a/init.log",
+			NodeID:    "node-a",
+			NodeName:  "hello-world-9tql2.a",
+			PodName:   "hello-world-9tql2-a",
+			Container: common.InitContainerName,
+		}, manifest[0])
+		assert.Equal(t, "hello-world-9tql2-b", manifest[5].PodName)
+		assert.Equal(t, common.MainContainerName, manifest[5].Container)
+	})
+	t.Run("Node", func(t *testing.T) {
+		ws := &testLogArchiveServer{testServerStream: testServerStream{ctx}}
+		err := server.GetWorkflowLogArchive(&workflowpkg.WorkflowLogArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows", NodeId: "node-b"}, ws)
+		require.NoError(t, err)
+		files := readLogArchive(t, ws.data.Bytes())
+		assert.Len(t, files, 4)
+		assert.Contains(t, files, "node-b/main.log")
+		assert.NotContains(t, files, "node-a/main.log")
+	})
+	t.Run("NodeNotFound", func(t *testing.T) {
+		ws := &testLogArchiveServer{testServerStream: testServerStream{ctx}}
+		err := server.GetWorkflowLogArchive(&workflowpkg.WorkflowLogArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows", NodeId: "node-c"}, ws)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestSubmitWorkflowFromResource(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("SubmitFromWorkflowTemplate fails if missing parameters", func(t *testing.T) {
+		_, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:    "workflows",
+			ResourceKind: "workflowtemplate",
+			ResourceName: "workflow-template-whalesay-template",
+		})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = spec.arguments.message.value or spec.arguments.message.valueFrom is required")
+	})
+	t.Run("SubmitFromWorkflowTemplate", func(t *testing.T) {
+		opts := v1alpha1.SubmitOpts{
+			Parameters: []string{
+				"message=hello",
+			},
+		}
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
 			ResourceKind:  "workflowtemplate",
 			ResourceName:  "workflow-template-whalesay-template",
 			SubmitOptions: &opts,
@@ -993,4 +2598,1148 @@ func TestSubmitWorkflowFromResource(t *testing.T) {
 		assert.Contains(t, wf.Labels, common.LabelKeyCreator)
 		assert.Equal(t, userEmailLabel, wf.Labels[common.LabelKeyCreatorEmail])
 	})
+	t.Run("SubmitFromClusterWorkflowTemplate materializes default parameters", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "ClusterWorkflowTemplate",
+			ResourceName:  "cluster-workflow-template-whalesay-template",
+			SubmitOptions: &v1alpha1.SubmitOpts{DryRun: true},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.Parameter{{Name: "message", Value: v1alpha1.AnyStringPtr("hello world")}}, wf.Spec.Arguments.Parameters)
+	})
+	t.Run("SubmitFromClusterWorkflowTemplate overrides default parameters", func(t *testing.T) {
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "ClusterWorkflowTemplate",
+			ResourceName:  "cluster-workflow-template-whalesay-template",
+			SubmitOptions: &v1alpha1.SubmitOpts{DryRun: true, Parameters: []string{"message=hello"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []v1alpha1.Parameter{{Name: "message", Value: v1alpha1.AnyStringPtr("hello")}}, wf.Spec.Arguments.Parameters)
+	})
+	t.Run("SubmitFromWorkflowTemplate not found", func(t *testing.T) {
+		_, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:    "workflows",
+			ResourceKind: "workflowtemplate",
+			ResourceName: "not-found",
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestSubmitWorkflowTemplateVersion(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wfClient := auth.GetWfClient(ctx)
+	// the fake clientset does not bump the generation, so simulate the template having been edited once
+	wftmpl, err := wfClient.ArgoprojV1alpha1().WorkflowTemplates("workflows").Get(ctx, "workflow-template-whalesay-template", metav1.GetOptions{})
+	require.NoError(t, err)
+	wftmpl.Generation = 2
+	_, err = wfClient.ArgoprojV1alpha1().WorkflowTemplates("workflows").Update(ctx, wftmpl, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	submit := func(kind, name string, version int64) (*v1alpha1.Workflow, error) {
+		return server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:       "workflows",
+			ResourceKind:    kind,
+			ResourceName:    name,
+			SubmitOptions:   &v1alpha1.SubmitOpts{Parameters: []string{"message=hello"}},
+			TemplateVersion: version,
+		})
+	}
+
+	t.Run("Current", func(t *testing.T) {
+		wf, err := submit("workflowtemplate", "workflow-template-whalesay-template", 2)
+		require.NoError(t, err)
+		assert.Equal(t, "workflow-template-whalesay-template", wf.Spec.WorkflowTemplateRef.Name)
+		// the pinned template's spec is stored, so that later edits of the template do not change the workflow
+		require.NotNil(t, wf.Status.StoredWorkflowSpec)
+		assert.Equal(t, wftmpl.Spec.Templates, wf.Status.StoredWorkflowSpec.Templates)
+		assert.Equal(t, "hello", wf.Status.StoredWorkflowSpec.Arguments.GetParameterByName("message").Value.String())
+	})
+	t.Run("NotPinned", func(t *testing.T) {
+		wf, err := submit("workflowtemplate", "workflow-template-whalesay-template", 0)
+		require.NoError(t, err)
+		assert.Nil(t, wf.Status.StoredWorkflowSpec)
+	})
+	t.Run("Older", func(t *testing.T) {
+		_, err := submit("workflowtemplate", "workflow-template-whalesay-template", 1)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Contains(t, status.Convert(err).Message(), "the current version is 2")
+	})
+	t.Run("ClusterWorkflowTemplate", func(t *testing.T) {
+		_, err := submit("clusterworkflowtemplate", "cluster-workflow-template-whalesay-template", 1)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("CronWorkflow", func(t *testing.T) {
+		_, err := submit("cronworkflow", "hello-world", 1)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubmitWorkflowNameTemplate(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	server.(*workflowServer).now = func() time.Time { return time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC) }
+	submit := func(nameTemplate string, opts *v1alpha1.SubmitOpts) (*v1alpha1.Workflow, error) {
+		return server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "cronworkflow",
+			ResourceName:  "hello-world",
+			SubmitOptions: opts,
+			NameTemplate:  nameTemplate,
+		})
+	}
+	t.Run("Resolved", func(t *testing.T) {
+		wf, err := submit("report-{{date:2006-01-02}}", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "report-2024-01-31", wf.Name)
+		assert.Empty(t, wf.GenerateName)
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_, err := submit("report-{{date:2006-01-02}}", nil)
+		require.Error(t, err)
+	})
+	t.Run("WithName", func(t *testing.T) {
+		_, err := submit("report-{{date:2006-01-02}}", &v1alpha1.SubmitOpts{Name: "my-report"})
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = a name template cannot be given with the name or generateName submit options")
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := submit("Report-{{date:2006-01-02}}", nil)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestSubmitWorkflowStartSuspended(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+		Namespace:    "workflows",
+		ResourceKind: "cronworkflow",
+		ResourceName: "hello-world",
+		SubmitOptions: &v1alpha1.SubmitOpts{
+			Name:           "hello-world-suspended",
+			StartSuspended: true,
+		},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, wf.Spec.Suspend)
+	assert.True(t, *wf.Spec.Suspend)
+
+	wf, err = server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: wf.Name, Namespace: wf.Namespace})
+	require.NoError(t, err)
+	assert.Nil(t, wf.Spec.Suspend)
+}
+
+func TestSubmitWorkflowStartAt(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	now := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	server.(*workflowServer).now = func() time.Time { return now }
+	submit := func(startAt time.Time) (*v1alpha1.Workflow, error) {
+		return server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:     "workflows",
+			ResourceKind:  "cronworkflow",
+			ResourceName:  "hello-world",
+			SubmitOptions: &v1alpha1.SubmitOpts{GenerateName: "hello-world-", StartAt: &metav1.Time{Time: startAt}},
+		})
+	}
+	t.Run("Future", func(t *testing.T) {
+		wf, err := submit(now.Add(time.Hour))
+		require.NoError(t, err)
+		require.NotNil(t, wf.Spec.Suspend)
+		assert.True(t, *wf.Spec.Suspend)
+		assert.Equal(t, "2030-01-02T04:04:05Z", wf.Annotations[common.AnnotationKeyStartAt])
+	})
+	t.Run("Past", func(t *testing.T) {
+		_, err := submit(now)
+		require.EqualError(t, err, "rpc error: code = InvalidArgument desc = startAt 2030-01-02T03:04:05Z is not in the future, to start the workflow now leave it unset")
+	})
+}
+
+func TestSubmitWorkflowWaitForRunning(t *testing.T) {
+	t.Run("Running", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		wfClient := ctx.Value(auth.WfKey).(*v1alpha.Clientset)
+		watcher := watch.NewFake()
+		wfClient.PrependWatchReactor("workflows", func(action ktesting.Action) (bool, watch.Interface, error) {
+			name, _ := action.(ktesting.WatchAction).GetWatchRestrictions().Fields.RequiresExactMatch("metadata.name")
+			go watcher.Modify(&v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows"},
+				Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowRunning},
+			})
+			return true, watcher, nil
+		})
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:      "workflows",
+			ResourceKind:   "cronworkflow",
+			ResourceName:   "hello-world",
+			WaitForRunning: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.WorkflowRunning, wf.Status.Phase)
+	})
+	t.Run("TimeoutInPending", func(t *testing.T) {
+		server, ctx := getWorkflowServer(t)
+		wf, err := server.SubmitWorkflow(ctx, &workflowpkg.WorkflowSubmitRequest{
+			Namespace:          "workflows",
+			ResourceKind:       "cronworkflow",
+			ResourceName:       "hello-world",
+			WaitForRunning:     true,
+			WaitTimeoutSeconds: 1,
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, wf.Name)
+		assert.Equal(t, v1alpha1.WorkflowUnknown, wf.Status.Phase)
+	})
+}
+
+func TestOffloadHydrationFailureMetrics(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	m, te, err := metrics.CreateDefaultTestMetrics(ctx)
+	require.NoError(t, err)
+
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(failedWf, &wf)
+	wf.UID = "offloaded-uid"
+	wf.Status.OffloadNodeStatusVersion = "fnv:123"
+
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
+	offloadNodeStatusRepo.On("Get", "offloaded-uid", "fnv:123").Return(nil, db.ErrNoMoreRows)
+	offloadNodeStatusRepo.On("List", mock.Anything).Return(map[sqldb.UUIDVersion]v1alpha1.Nodes{}, nil)
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("CountWorkflows", mock.Anything, mock.Anything).Return(int64(0), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, mock.Anything).Return(v1alpha1.Workflows{}, nil)
+
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+	wfClientset := v1alpha.NewSimpleClientset(&wf)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	wfStore, err := store.NewSQLiteStore(instanceIDSvc)
+	require.NoError(t, err)
+	require.NoError(t, wfStore.Add(&wf))
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, archivedRepo, wfClientset, wfStore, nil, nil, nil, nil, nil, WorkflowServerOptions{Metrics: m})
+
+	t.Run("GetWorkflow", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows"})
+		require.Error(t, err)
+		attribs := attribute.NewSet(
+			attribute.String(telemetry.AttribServerOperation, "GetWorkflow"),
+			attribute.String(telemetry.AttribHydrationFailureCause, metrics.OffloadDataMissing),
+		)
+		val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentOffloadHydrationFailures.Name(), &attribs)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), val)
+	})
+	t.Run("ListWorkflows", func(t *testing.T) {
+		_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows"})
+		require.NoError(t, err)
+		attribs := attribute.NewSet(
+			attribute.String(telemetry.AttribServerOperation, "ListWorkflows"),
+			attribute.String(telemetry.AttribHydrationFailureCause, metrics.OffloadDataMissing),
+		)
+		val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentOffloadHydrationFailures.Name(), &attribs)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), val)
+	})
+}
+
+func TestWorkflowOperationMetrics(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	m, te, err := metrics.CreateDefaultTestMetrics(ctx)
+	require.NoError(t, err)
+	server.(*workflowServer).metrics = m
+	assertCount := func(t *testing.T, operation, outcome string) {
+		t.Helper()
+		attribs := attribute.NewSet(
+			attribute.String(telemetry.AttribServerOperation, operation),
+			attribute.String(telemetry.AttribWorkflowNamespace, "workflows"),
+			attribute.String(telemetry.AttribOperationOutcome, outcome),
+		)
+		val, err := te.GetInt64CounterValue(ctx, telemetry.InstrumentWorkflowOperations.Name(), &attribs)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), val)
+	}
+	t.Run("SuspendWorkflow", func(t *testing.T) {
+		_, err := server.SuspendWorkflow(ctx, &workflowpkg.WorkflowSuspendRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "SuspendWorkflow", "OK")
+	})
+	t.Run("ResumeWorkflow", func(t *testing.T) {
+		_, err := server.ResumeWorkflow(ctx, &workflowpkg.WorkflowResumeRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "ResumeWorkflow", "OK")
+	})
+	t.Run("StopWorkflow", func(t *testing.T) {
+		_, err := server.StopWorkflow(ctx, &workflowpkg.WorkflowStopRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "StopWorkflow", "OK")
+	})
+	t.Run("TerminateWorkflow", func(t *testing.T) {
+		_, err := server.TerminateWorkflow(ctx, &workflowpkg.WorkflowTerminateRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "TerminateWorkflow", "OK")
+	})
+	t.Run("RetryWorkflow", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "RetryWorkflow", "OK")
+	})
+	t.Run("ResubmitWorkflow", func(t *testing.T) {
+		_, err := server.ResubmitWorkflow(ctx, &workflowpkg.WorkflowResubmitRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assertCount(t, "ResubmitWorkflow", "OK")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.RetryWorkflow(ctx, &workflowpkg.WorkflowRetryRequest{Name: "hello-world-9tql2-not", Namespace: "workflows"})
+		require.Error(t, err)
+		assertCount(t, "RetryWorkflow", "NotFound")
+	})
+}
+
+func TestGetWorkflowAllowDegraded(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(failedWf, &wf)
+	wf.UID = "offloaded-uid"
+	wf.Status.OffloadNodeStatusVersion = "fnv:123"
+
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
+	offloadNodeStatusRepo.On("Get", "offloaded-uid", "fnv:123").Return(nil, errors.New("database is down"))
+	wfClientset := v1alpha.NewSimpleClientset(&wf)
+	ctx = context.WithValue(ctx, auth.WfKey, wfClientset)
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	wfStore, err := store.NewSQLiteStore(instanceIDSvc)
+	require.NoError(t, err)
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, wfStore, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Disabled", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows"})
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+	t.Run("Enabled", func(t *testing.T) {
+		stream := &testTransportStream{}
+		wf, err := server.GetWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows", AllowDegraded: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"database is down"}, stream.header.Get(workflowpkg.NodeStatusUnavailableHeader))
+		assert.Empty(t, wf.Status.Nodes)
+		assert.Equal(t, "fnv:123", wf.Status.OffloadNodeStatusVersion)
+	})
+}
+
+func TestGetWorkflowDehydrated(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(failedWf, &wf)
+	wf.UID = "offloaded-uid"
+	wf.Status.Nodes = nil
+	wf.Status.OffloadNodeStatusVersion = "fnv:123"
+
+	// no expectations, so that any attempt to hydrate the workflow fails the test
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	wfClientset := v1alpha.NewSimpleClientset(&wf)
+	ctx = context.WithValue(ctx, auth.WfKey, wfClientset)
+	instanceIDSvc := instanceid.NewService("my-instanceid")
+	wfStore, err := store.NewSQLiteStore(instanceIDSvc)
+	require.NoError(t, err)
+	server := NewWorkflowServer(ctx, instanceIDSvc, offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, wfStore, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	stream := &testTransportStream{}
+	got, err := server.GetWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows", Dehydrated: true})
+	require.NoError(t, err)
+	assert.Empty(t, got.Status.Nodes)
+	assert.Equal(t, "fnv:123", got.Status.OffloadNodeStatusVersion)
+	assert.Empty(t, stream.header.Get(workflowpkg.NodeStatusUnavailableHeader))
+	offloadNodeStatusRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+
+	t.Run("NeedsNodeStatus", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows", Dehydrated: true, FailedNodesOnly: true})
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetWorkflowLineage(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	resubmitted := func(name, previous string) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows", Labels: map[string]string{common.LabelKeyPreviousWorkflowName: previous}}}
+	}
+	wf := resubmitted("my-cron-2", "my-cron-1")
+	wf.Labels[common.LabelKeyCronWorkflow] = "my-cron"
+	wf.OwnerReferences = []metav1.OwnerReference{{APIVersion: "argoproj.io/v1alpha1", Kind: "CronWorkflow", Name: "my-cron", UID: "my-cron-uid"}}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset(wf, resubmitted("my-cron-2-b", "my-cron-2"), resubmitted("my-cron-2-a", "my-cron-2"), resubmitted("other", "my-cron-1"))
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, fake.NewSimpleClientset())
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Lineage", func(t *testing.T) {
+		lineage, err := server.GetWorkflowLineage(ctx, &workflowpkg.WorkflowLineageRequest{Name: "my-cron-2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, &workflowpkg.WorkflowLineage{Previous: "my-cron-1", CronWorkflow: "my-cron", Children: []string{"my-cron-2-a", "my-cron-2-b"}}, lineage)
+	})
+	t.Run("NoChildren", func(t *testing.T) {
+		lineage, err := server.GetWorkflowLineage(ctx, &workflowpkg.WorkflowLineageRequest{Name: "my-cron-2-a", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, &workflowpkg.WorkflowLineage{Previous: "my-cron-2"}, lineage)
+	})
+}
+
+func TestGetWorkflowPendingApprovals(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "workflows"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowRunning,
+			Nodes: v1alpha1.Nodes{
+				"my-wf": {ID: "my-wf", Name: "my-wf", DisplayName: "my-wf", Type: v1alpha1.NodeTypeSteps, Phase: v1alpha1.NodeRunning, Children: []string{"my-wf-1"}},
+				"my-wf-1": {
+					ID: "my-wf-1", Name: "my-wf[0].approve", DisplayName: "approve", TemplateName: "approve", Type: v1alpha1.NodeTypeSuspend, Phase: v1alpha1.NodeRunning,
+					Inputs:  &v1alpha1.Inputs{Parameters: []v1alpha1.Parameter{{Name: "version", Value: v1alpha1.AnyStringPtr("v1")}}},
+					Outputs: &v1alpha1.Outputs{Parameters: []v1alpha1.Parameter{{Name: "approved", ValueFrom: &v1alpha1.ValueFrom{Supplied: &v1alpha1.SuppliedValueFrom{}}}}},
+				},
+			},
+		},
+	}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset(wf)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, fake.NewSimpleClientset())
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	approvals, err := server.GetWorkflowPendingApprovals(ctx, &workflowpkg.WorkflowPendingApprovalsRequest{Name: "my-wf", Namespace: "workflows"})
+	require.NoError(t, err)
+	assert.Equal(t, []*workflowpkg.PendingApproval{{
+		Id:           "my-wf-1",
+		Name:         "my-wf[0].approve",
+		DisplayName:  "approve",
+		TemplateName: "approve",
+		Inputs:       []*v1alpha1.Parameter{{Name: "version", Value: v1alpha1.AnyStringPtr("v1")}},
+		Outputs:      []*v1alpha1.Parameter{{Name: "approved", ValueFrom: &v1alpha1.ValueFrom{Supplied: &v1alpha1.SuppliedValueFrom{}}}},
+	}}, approvals.Items)
+}
+
+func TestGetWorkflowPodResources(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowRunning,
+			Nodes: v1alpha1.Nodes{"my-wf-1": {ID: "my-wf-1", Name: "my-wf", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeRunning}},
+		},
+	}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	wfClientset := v1alpha.NewSimpleClientset(wf)
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, fake.NewSimpleClientset(newResourcesPod("my-wf-a", "my-wf-1", "my-wf")))
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, &mocks.WorkflowArchive{}, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	resources, err := server.GetWorkflowPodResources(ctx, &workflowpkg.WorkflowPodResourcesRequest{Name: "my-wf", Namespace: "my-ns"})
+	require.NoError(t, err)
+	assert.Equal(t, &workflowpkg.WorkflowPodResources{Nodes: map[string]*workflowpkg.PodResources{
+		"my-wf-1": {Pod: "my-wf-a", Requests: map[string]string{"cpu": "110m", "memory": "64Mi"}, Limits: map[string]string{"cpu": "1"}},
+	}}, resources)
+}
+
+func TestGetWorkflowLiveOnly(t *testing.T) {
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(wf1, &wf)
+	var archived v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(failedWf, &archived)
+
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("GetWorkflow", mock.Anything, "", "workflows", "failed").Return(&archived, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{&wf}, archivedRepo)
+
+	t.Run("Live", func(t *testing.T) {
+		stream := &testTransportStream{}
+		wf, err := server.GetWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows", LiveOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, "hello-world-9tql2", wf.Name)
+		assert.Equal(t, []string{workflowpkg.SourceLive}, stream.header.Get(workflowpkg.SourceHeader))
+		archivedRepo.AssertNotCalled(t, "GetWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows", LiveOnly: true})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		archivedRepo.AssertNotCalled(t, "GetWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		stream := &testTransportStream{}
+		wf, err := server.GetWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowGetRequest{Name: "failed", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, "failed", wf.Name)
+		assert.Equal(t, []string{workflowpkg.SourceArchived}, stream.header.Get(workflowpkg.SourceHeader))
+		archivedRepo.AssertCalled(t, "GetWorkflow", mock.Anything, "", "workflows", "failed")
+	})
+	t.Run("LiveWithFallback", func(t *testing.T) {
+		stream := &testTransportStream{}
+		_, err := server.GetWorkflow(grpc.NewContextWithServerTransportStream(ctx, stream), &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{workflowpkg.SourceLive}, stream.header.Get(workflowpkg.SourceHeader))
+	})
+}
+
+func TestListWorkflowNamespaces(t *testing.T) {
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("ListWorkflowNamespaces", mock.Anything).Return([]string{"b", "c"}, nil)
+	var wfs []*v1alpha1.Workflow
+	for i, namespace := range []string{"b", "a", "b"} {
+		wfs = append(wfs, &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{
+			UID:       k8stypes.UID(fmt.Sprintf("uid-%d", i)),
+			Name:      fmt.Sprintf("workflow-%d", i),
+			Namespace: namespace,
+			Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"},
+		}})
+	}
+	server, ctx := getWorkflowServerWith(t, wfs, archivedRepo)
+	// the user can list workflows in every namespace, in every namespace but c, or cannot review their access
+	var allowAll, forbidden bool
+	auth.GetKubeClient(ctx).(*fake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		if forbidden {
+			return true, nil, apierr.NewForbidden(authorizationv1.Resource("selfsubjectaccessreviews"), "", errors.New("forbidden"))
+		}
+		namespace := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes.Namespace
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowAll || (namespace != "" && namespace != "c")},
+		}, nil
+	})
+	list := func(t *testing.T) []string {
+		t.Helper()
+		namespaces, err := server.ListWorkflowNamespaces(ctx, &workflowpkg.ListWorkflowNamespacesRequest{})
+		require.NoError(t, err)
+		return namespaces.Items
+	}
+
+	t.Run("SomeNamespaces", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "b"}, list(t))
+		// the second call is served from the cache
+		assert.Equal(t, []string{"a", "b"}, list(t))
+		archivedRepo.AssertNumberOfCalls(t, "ListWorkflowNamespaces", 1)
+	})
+	t.Run("AllNamespaces", func(t *testing.T) {
+		allowAll = true
+		defer func() { allowAll = false }()
+		assert.Equal(t, []string{"a", "b", "c"}, list(t))
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		forbidden = true
+		defer func() { forbidden = false }()
+		assert.Empty(t, list(t))
+	})
+	t.Run("ManagedNamespace", func(t *testing.T) {
+		server.managedNamespace = "b"
+		defer func() { server.managedNamespace = "" }()
+		server.namespaces.Add(namespacesCacheKey, []string{"a", "b", "c"})
+		assert.Equal(t, []string{"b"}, list(t))
+	})
+}
+
+func TestListWorkflowsFilter(t *testing.T) {
+	newWorkflow := func(name string, phase v1alpha1.WorkflowPhase, team string) v1alpha1.Workflow {
+		return v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       k8stypes.UID(name),
+				Name:      name,
+				Namespace: "workflows",
+				Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid", "team": team},
+			},
+			Status: v1alpha1.WorkflowStatus{Phase: phase},
+		}
+	}
+	archivedRepo := &mocks.WorkflowArchive{}
+	// only the workflows matching the pushed down phase are returned by the archive
+	pushedDown := mock.MatchedBy(func(options sutils.ListOptions) bool {
+		return slices.Equal(options.Phases, []string{"Failed"}) && options.LabelRequirements.String() == "workflows.argoproj.io/controller-instanceid=my-instanceid, team=a"
+	})
+	archivedRepo.On("CountWorkflows", mock.Anything, pushedDown).Return(int64(1), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, pushedDown).Return(v1alpha1.Workflows{newWorkflow("archived-failed-a", v1alpha1.WorkflowFailed, "a")}, nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, mock.MatchedBy(func(options sutils.ListOptions) bool {
+		return options.Phases == nil && options.LabelRequirements.String() == "workflows.argoproj.io/controller-instanceid=my-instanceid"
+	})).Return(v1alpha1.Workflows{
+		newWorkflow("archived-failed-a", v1alpha1.WorkflowFailed, "a"),
+		newWorkflow("archived-failed-b", v1alpha1.WorkflowFailed, "b"),
+		newWorkflow("archived-succeeded-b", v1alpha1.WorkflowSucceeded, "b"),
+	}, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+		ptr.To(newWorkflow("failed-a", v1alpha1.WorkflowFailed, "a")),
+		ptr.To(newWorkflow("failed-b", v1alpha1.WorkflowFailed, "b")),
+		ptr.To(newWorkflow("succeeded-a", v1alpha1.WorkflowSucceeded, "a")),
+	}, archivedRepo)
+
+	t.Run("PushedDown", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.phase == "Failed" && workflow.labels["team"] == "a"`})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"failed-a", "archived-failed-a"}, workflowNames(list))
+	})
+	t.Run("PushedDownPaginated", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.phase == "Failed" && workflow.labels["team"] == "a"`, ListOptions: &metav1.ListOptions{Limit: 1, Continue: "1"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"archived-failed-a"}, workflowNames(list))
+		assert.Empty(t, list.Continue)
+		archivedRepo.AssertCalled(t, "ListWorkflows", mock.Anything, mock.MatchedBy(func(options sutils.ListOptions) bool {
+			return options.Limit == 1 && options.Offset == 0 && slices.Equal(options.Phases, []string{"Failed"})
+		}))
+	})
+	t.Run("InMemory", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.phase == "Failed" || workflow.labels["team"] == "a"`})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"failed-a", "failed-b", "succeeded-a", "archived-failed-a", "archived-failed-b"}, workflowNames(list))
+	})
+	t.Run("Paginated", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.labels["team"] == "b" || workflow.phase == "Succeeded"`, ListOptions: &metav1.ListOptions{Limit: 2}})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+		assert.Equal(t, "2", list.Continue)
+		list, err = server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.labels["team"] == "b" || workflow.phase == "Succeeded"`, ListOptions: &metav1.ListOptions{Limit: 2, Continue: "2"}})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 2)
+		assert.Empty(t, list.Continue)
+	})
+	t.Run("PageOfLiveWorkflows", func(t *testing.T) {
+		archivedRepo := &mocks.WorkflowArchive{}
+		server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+			ptr.To(newWorkflow("failed-a", v1alpha1.WorkflowFailed, "a")),
+			ptr.To(newWorkflow("failed-b", v1alpha1.WorkflowFailed, "b")),
+		}, archivedRepo)
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.phase == "Failed" || workflow.labels["team"] == "a"`, ListOptions: &metav1.ListOptions{Limit: 1}})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+		assert.Equal(t, "1", list.Continue)
+		// the page, and one more, matched live workflows, so the archive is not listed
+		archivedRepo.AssertNotCalled(t, "ListWorkflows", mock.Anything, mock.Anything)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", Filter: `workflow.phase ==`})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListWorkflowsDuration(t *testing.T) {
+	now := time.Now()
+	newWorkflow := func(name string, ran time.Duration, running bool) v1alpha1.Workflow {
+		wf := v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       k8stypes.UID(name),
+				Name:      name,
+				Namespace: "workflows",
+				Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"},
+			},
+			Status: v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowSucceeded, StartedAt: metav1.NewTime(now.Add(-ran)), FinishedAt: metav1.NewTime(now)},
+		}
+		if running {
+			wf.Status.Phase = v1alpha1.WorkflowRunning
+			wf.Status.FinishedAt = metav1.Time{}
+		}
+		return wf
+	}
+	archivedRepo := &mocks.WorkflowArchive{}
+	// the archive matches the durations in its query
+	minDuration := mock.MatchedBy(func(options sutils.ListOptions) bool {
+		return options.MinDuration == 10*time.Minute && options.MaxDuration == 0
+	})
+	maxDuration := mock.MatchedBy(func(options sutils.ListOptions) bool {
+		return options.MinDuration == 0 && options.MaxDuration == 10*time.Minute
+	})
+	archivedRepo.On("CountWorkflows", mock.Anything, minDuration).Return(int64(1), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, minDuration).Return(v1alpha1.Workflows{newWorkflow("archived-slow", time.Hour, false)}, nil)
+	archivedRepo.On("CountWorkflows", mock.Anything, maxDuration).Return(int64(1), nil)
+	archivedRepo.On("ListWorkflows", mock.Anything, maxDuration).Return(v1alpha1.Workflows{newWorkflow("archived-fast", time.Minute, false)}, nil)
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+		ptr.To(newWorkflow("slow", time.Hour, false)),
+		ptr.To(newWorkflow("fast", time.Minute, false)),
+		ptr.To(newWorkflow("running-slow", time.Hour, true)),
+		ptr.To(newWorkflow("running-fast", time.Minute, true)),
+	}, archivedRepo)
+
+	t.Run("MinDuration", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", MinDuration: "10m"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"slow", "running-slow", "archived-slow"}, workflowNames(list))
+	})
+	t.Run("MaxDuration", func(t *testing.T) {
+		list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", MaxDuration: "10m"})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"fast", "archived-fast"}, workflowNames(list))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", MinDuration: "1h", MaxDuration: "10m"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestListWorkflowsSuspendedOnly(t *testing.T) {
+	newWorkflow := func(name string, phase v1alpha1.WorkflowPhase, suspend bool, nodePhase v1alpha1.NodePhase) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				UID:       k8stypes.UID(name),
+				Name:      name,
+				Namespace: "workflows",
+				Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"},
+			},
+			Spec: v1alpha1.WorkflowSpec{Suspend: ptr.To(suspend)},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: phase,
+				Nodes: v1alpha1.Nodes{name: {ID: name, Name: name, Type: v1alpha1.NodeTypeSuspend, Phase: nodePhase}},
+			},
+		}
+	}
+	// archived workflows have completed, so the archive is not queried
+	archivedRepo := &mocks.WorkflowArchive{}
+	compressed := newWorkflow("approval-compressed", v1alpha1.WorkflowRunning, false, v1alpha1.NodeRunning)
+	require.NoError(t, packer.CompressWorkflow(logging.TestContext(t.Context()), compressed))
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+		newWorkflow("suspended", v1alpha1.WorkflowRunning, true, v1alpha1.NodeSucceeded),
+		newWorkflow("approval", v1alpha1.WorkflowRunning, false, v1alpha1.NodeRunning),
+		compressed,
+		newWorkflow("approved", v1alpha1.WorkflowRunning, false, v1alpha1.NodeSucceeded),
+		newWorkflow("completed", v1alpha1.WorkflowFailed, true, v1alpha1.NodeFailed),
+	}, archivedRepo)
+
+	list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", SuspendedOnly: true})
+	require.NoError(t, err)
+	var names []string
+	for _, wf := range list.Items {
+		names = append(names, wf.Name)
+		if wf.Name == "approval-compressed" {
+			// listed as it is stored
+			assert.NotEmpty(t, wf.Status.CompressedNodes)
+		}
+	}
+	assert.ElementsMatch(t, []string{"suspended", "approval", "approval-compressed"}, names)
+	archivedRepo.AssertNotCalled(t, "ListWorkflows", mock.Anything, mock.Anything)
+}
+
+func TestListWorkflowsRetriesExhausted(t *testing.T) {
+	newWorkflow := func(name string, phase v1alpha1.WorkflowPhase, message string, attempts int) *v1alpha1.Workflow {
+		wf := retryWorkflow(phase, v1alpha1.NodeFailed, message, slices.Repeat([]v1alpha1.NodePhase{v1alpha1.NodeFailed}, attempts)...)
+		wf.ObjectMeta = metav1.ObjectMeta{
+			UID:       k8stypes.UID(name),
+			Name:      name,
+			Namespace: "workflows",
+			Labels:    map[string]string{common.LabelKeyControllerInstanceID: "my-instanceid"},
+		}
+		return wf
+	}
+	archivedRepo := &mocks.WorkflowArchive{}
+	archivedRepo.On("ListWorkflowsContaining", mock.Anything, mock.Anything, common.RetriesExhaustedMessage).Return(v1alpha1.Workflows{
+		*newWorkflow("archived-exhausted", v1alpha1.WorkflowFailed, common.RetriesExhaustedMessage, 3),
+		// the message of another node may mention exhausted retries
+		*newWorkflow("archived-first-attempt", v1alpha1.WorkflowFailed, "Error (exit code 1)", 1),
+	}, nil)
+	compressed := newWorkflow("exhausted-compressed", v1alpha1.WorkflowFailed, common.RetriesExhaustedMessage, 2)
+	require.NoError(t, packer.CompressWorkflow(logging.TestContext(t.Context()), compressed))
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{
+		newWorkflow("exhausted", v1alpha1.WorkflowFailed, common.RetriesExhaustedMessage, 3),
+		compressed,
+		newWorkflow("first-attempt", v1alpha1.WorkflowFailed, "Error (exit code 1)", 1),
+		newWorkflow("retrying", v1alpha1.WorkflowRunning, "", 1),
+	}, archivedRepo)
+
+	list, err := server.ListWorkflows(ctx, &workflowpkg.WorkflowListRequest{Namespace: "workflows", RetriesExhausted: true})
+	require.NoError(t, err)
+	var names []string
+	for _, wf := range list.Items {
+		names = append(names, wf.Name)
+		if wf.Name == "exhausted-compressed" {
+			// listed as it is stored
+			assert.NotEmpty(t, wf.Status.CompressedNodes)
+		}
+	}
+	assert.ElementsMatch(t, []string{"exhausted", "exhausted-compressed", "archived-exhausted"}, names)
+	archivedRepo.AssertNotCalled(t, "ListWorkflows", mock.Anything, mock.Anything)
+}
+
+func TestGetWorkflowManifest(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	manifest, err := server.GetWorkflowManifest(ctx, &workflowpkg.WorkflowManifestRequest{Name: "hello-world-9tql2", Namespace: "workflows", OmitStatus: true})
+	require.NoError(t, err)
+	wf := &v1alpha1.Workflow{}
+	require.NoError(t, yaml.Unmarshal([]byte(manifest.Yaml), wf))
+	assert.Equal(t, "Workflow", wf.Kind)
+	assert.Equal(t, "hello-world-9tql2", wf.Name)
+	assert.NotContains(t, manifest.Yaml, "status:")
+}
+
+func TestGetWorkflowOutputs(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("Outputs", func(t *testing.T) {
+		wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+		wf, err := wfClient.Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Phase = v1alpha1.WorkflowSucceeded
+		wf.Status.Outputs = &v1alpha1.Outputs{
+			Parameters: []v1alpha1.Parameter{{Name: "result", Value: v1alpha1.AnyStringPtr("42"), GlobalName: "result"}},
+			Artifacts:  v1alpha1.Artifacts{{Name: "report", GlobalName: "report", ArtifactLocation: v1alpha1.ArtifactLocation{S3: &v1alpha1.S3Artifact{Key: "hello-world-9tql2/report.tgz"}}}},
+		}
+		_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		outputs, err := server.GetWorkflowOutputs(ctx, &workflowpkg.WorkflowOutputsRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		require.Len(t, outputs.Parameters, 1)
+		assert.Equal(t, "42", outputs.Parameters[0].Value.String())
+		require.Len(t, outputs.Artifacts, 1)
+		assert.Equal(t, "hello-world-9tql2/report.tgz", outputs.Artifacts[0].S3.Key)
+	})
+	t.Run("NoOutputs", func(t *testing.T) {
+		outputs, err := server.GetWorkflowOutputs(ctx, &workflowpkg.WorkflowOutputsRequest{Name: "hello-world-b6h5m", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, &v1alpha1.Outputs{}, outputs)
+	})
+}
+
+func TestGetWorkflowPendingDiagnostic(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("Pending", func(t *testing.T) {
+		wfClient := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows")
+		wf, err := wfClient.Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+		require.NoError(t, err)
+		wf.Status.Phase = v1alpha1.WorkflowPending
+		wf.Status.Message = "Workflow processing has been postponed because too many workflows are already running"
+		_, err = wfClient.Update(ctx, wf, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		diagnostic, err := server.GetWorkflowPendingDiagnostic(ctx, &workflowpkg.WorkflowPendingDiagnosticRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, PendingCodeParallelismLimit, diagnostic.Code)
+		assert.Equal(t, wf.Status.Message, diagnostic.Reason)
+	})
+	t.Run("NotPending", func(t *testing.T) {
+		diagnostic, err := server.GetWorkflowPendingDiagnostic(ctx, &workflowpkg.WorkflowPendingDiagnosticRequest{Name: "hello-world-b6h5m", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Empty(t, diagnostic.Code)
+	})
+}
+
+func TestGetWorkflowGraph(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("Found", func(t *testing.T) {
+		graph, err := server.GetWorkflowGraph(ctx, &workflowpkg.WorkflowGraphRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, []*workflowpkg.WorkflowGraphVertex{{
+			Id:           "hello-world-9tql2",
+			Name:         "hello-world-9tql2",
+			DisplayName:  "hello-world-9tql2",
+			Type:         "Pod",
+			Phase:        "Succeeded",
+			TemplateName: "whalesay",
+		}}, graph.Vertices)
+		assert.Empty(t, graph.Edges)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.GetWorkflowGraph(ctx, &workflowpkg.WorkflowGraphRequest{Name: "not-found", Namespace: "test"})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestArchiveWorkflow(t *testing.T) {
+	var completedWf, runningWf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(wf1, &completedWf)
+	v1alpha1.MustUnmarshal(wf5, &runningWf)
+
+	newServer := func(t *testing.T, archiveEnabled bool) (*workflowServer, *mocks.WorkflowArchive, context.Context) {
+		t.Helper()
+		archivedRepo := &mocks.WorkflowArchive{}
+		archivedRepo.On("IsEnabled").Return(archiveEnabled)
+		archivedRepo.On("ArchiveWorkflow", mock.Anything, mock.Anything).Return(nil)
+		server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{&completedWf, &runningWf}, archivedRepo)
+		return server, archivedRepo, ctx
+	}
+
+	t.Run("ArchiveDisabled", func(t *testing.T) {
+		server, _, ctx := newServer(t, false)
+		_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+	t.Run("NotCompleted", func(t *testing.T) {
+		server, archivedRepo, ctx := newServer(t, true)
+		_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2-run", Namespace: "workflows"})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		archivedRepo.AssertNotCalled(t, "ArchiveWorkflow", mock.Anything, mock.Anything)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		server, _, ctx := newServer(t, true)
+		_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "not-found", Namespace: "workflows"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("KeepLive", func(t *testing.T) {
+		server, archivedRepo, ctx := newServer(t, true)
+		wf, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Equal(t, "Archived", wf.Labels[common.LabelKeyWorkflowArchivingStatus])
+		archivedRepo.AssertCalled(t, "ArchiveWorkflow", mock.Anything, mock.MatchedBy(func(wf *v1alpha1.Workflow) bool {
+			return wf.Name == "hello-world-9tql2" && len(wf.Status.Nodes) == 1
+		}))
+		live, err := auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "Archived", live.Labels[common.LabelKeyWorkflowArchivingStatus])
+	})
+	t.Run("DeleteLive", func(t *testing.T) {
+		server, archivedRepo, ctx := newServer(t, true)
+		_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows", DeleteLive: true})
+		require.NoError(t, err)
+		archivedRepo.AssertCalled(t, "ArchiveWorkflow", mock.Anything, mock.Anything)
+		_, err = auth.GetWfClient(ctx).ArgoprojV1alpha1().Workflows("workflows").Get(ctx, "hello-world-9tql2", metav1.GetOptions{})
+		assert.True(t, apierr.IsNotFound(err))
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		for _, tt := range []struct {
+			name       string
+			deleteLive bool
+			allowed    string
+		}{
+			{name: "ReadOnly", allowed: "get"},
+			{name: "KeepLive", allowed: "delete"},
+			{name: "DeleteLive", deleteLive: true, allowed: "update"},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				server, archivedRepo, ctx := newServer(t, true)
+				auth.GetKubeClient(ctx).(*fake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+					review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+					return true, &authorizationv1.SelfSubjectAccessReview{
+						Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Verb == tt.allowed},
+					}, nil
+				})
+				_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows", DeleteLive: tt.deleteLive})
+				assert.Equal(t, codes.PermissionDenied, status.Code(err))
+				archivedRepo.AssertNotCalled(t, "ArchiveWorkflow", mock.Anything, mock.Anything)
+			})
+		}
+	})
+	t.Run("OnlyThisWorkflow", func(t *testing.T) {
+		server, archivedRepo, ctx := newServer(t, true)
+		auth.GetKubeClient(ctx).(*fake.Clientset).PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+			review := action.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			return true, &authorizationv1.SelfSubjectAccessReview{
+				Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Name == "hello-world-9tql2"},
+			}, nil
+		})
+		_, err := server.ArchiveWorkflow(ctx, &workflowpkg.WorkflowArchiveRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		archivedRepo.AssertCalled(t, "ArchiveWorkflow", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetWorkflowResourceUsage(t *testing.T) {
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(wf1, &wf)
+	wf.Status.Nodes = v1alpha1.Nodes{
+		"steps": {ID: "steps", Type: v1alpha1.NodeTypeSteps, ResourcesDuration: v1alpha1.ResourcesDuration{corev1.ResourceCPU: 3, corev1.ResourceMemory: 6}},
+		"a":     {ID: "a", Type: v1alpha1.NodeTypePod, ResourcesDuration: v1alpha1.ResourcesDuration{corev1.ResourceCPU: 1, corev1.ResourceMemory: 2}},
+		"b":     {ID: "b", Type: v1alpha1.NodeTypePod, ResourcesDuration: v1alpha1.ResourcesDuration{corev1.ResourceCPU: 2, corev1.ResourceMemory: 4}},
+	}
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{&wf}, &mocks.WorkflowArchive{})
+
+	usage, err := server.GetWorkflowResourceUsage(ctx, &workflowpkg.WorkflowResourceUsageRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"cpu": 3, "memory": 6}, usage.ResourcesDuration)
+}
+
+func TestGetWorkflowFailedNodesOnly(t *testing.T) {
+	var wf v1alpha1.Workflow
+	v1alpha1.MustUnmarshal(wf1, &wf)
+	wf.Status.Nodes = v1alpha1.Nodes{
+		"steps":  {ID: "steps", Type: v1alpha1.NodeTypeSteps, Phase: v1alpha1.NodeFailed, Children: []string{"group"}},
+		"group":  {ID: "group", Type: v1alpha1.NodeTypeStepGroup, Phase: v1alpha1.NodeFailed, Children: []string{"a", "b", "c"}},
+		"a":      {ID: "a", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeSucceeded},
+		"b":      {ID: "b", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeFailed},
+		"c":      {ID: "c", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeError},
+		"onExit": {ID: "onExit", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeSucceeded},
+	}
+	server, ctx := getWorkflowServerWith(t, []*v1alpha1.Workflow{&wf}, &mocks.WorkflowArchive{})
+
+	t.Run("NotRequested", func(t *testing.T) {
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+		require.NoError(t, err)
+		assert.Len(t, wf.Status.Nodes, 6)
+	})
+	t.Run("Requested", func(t *testing.T) {
+		wf, err := server.GetWorkflow(ctx, &workflowpkg.WorkflowGetRequest{Name: "hello-world-9tql2", Namespace: "workflows", FailedNodesOnly: true})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"steps", "group", "b", "c"}, nodeIDs(wf.Status.Nodes))
+		assert.Equal(t, []string{"b", "c"}, wf.Status.Nodes["group"].Children)
+	})
+}
+
+func TestGetWorkflowAllowedVerbs(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	verbs, err := server.GetWorkflowAllowedVerbs(ctx, &workflowpkg.WorkflowAllowedVerbsRequest{Name: "hello-world-9tql2", Namespace: "workflows"})
+	require.NoError(t, err)
+	// the user is allowed everything
+	assert.Equal(t, []string{"delete", "get", "resubmit", "resume", "retry", "set", "stop", "suspend", "terminate"}, verbs.Verbs)
+}
+
+func TestEstimateWorkflowCost(t *testing.T) {
+	server, ctx := getWorkflowServer(t)
+	t.Run("HistoricalRuns", func(t *testing.T) {
+		estimate, err := server.EstimateWorkflowCost(ctx, &workflowpkg.WorkflowCostEstimateRequest{
+			Namespace: "workflows",
+			Workflow: &v1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{GenerateName: "cost-"},
+				Spec: v1alpha1.WorkflowSpec{
+					Entrypoint: "main",
+					Templates: []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("1"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					}}}}},
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), estimate.Workflows)
+		require.Len(t, estimate.Templates, 1)
+		assert.Equal(t, int64(1), estimate.Templates[0].Runs)
+		assert.Equal(t, int64(100), estimate.Templates[0].AverageDurationSeconds)
+		assert.Equal(t, map[string]int64{"cpu": 100, "memory": 256}, estimate.ResourcesDuration)
+	})
+	t.Run("NoWorkflow", func(t *testing.T) {
+		_, err := server.EstimateWorkflowCost(ctx, &workflowpkg.WorkflowCostEstimateRequest{Namespace: "workflows"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("WorkflowTemplateNotFound", func(t *testing.T) {
+		_, err := server.EstimateWorkflowCost(ctx, &workflowpkg.WorkflowCostEstimateRequest{
+			Namespace: "workflows",
+			Workflow:  &v1alpha1.Workflow{Spec: v1alpha1.WorkflowSpec{WorkflowTemplateRef: &v1alpha1.WorkflowTemplateRef{Name: "not-found"}}},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestHydrateWithinLimit(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	nodes := v1alpha1.Nodes{"a": {ID: "a"}, "b": {ID: "b"}, "c": {ID: "c"}}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("Get", "my-uid", "my-version").Return(nodes, nil)
+	offloadNodeStatusRepo.On("Count", "my-uid", "my-version").Return(len(nodes), nil)
+	newServer := func(maxNodes int, refuse bool) *workflowServer {
+		return &workflowServer{offloadNodeStatusRepo: offloadNodeStatusRepo, hydrator: hydrator.New(offloadNodeStatusRepo), hydrateMaxNodes: maxNodes, hydrateRefuseOverMaxNodes: refuse}
+	}
+	offloaded := func() *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", UID: "my-uid"},
+			Status:     v1alpha1.WorkflowStatus{OffloadNodeStatusVersion: "my-version"},
+		}
+	}
+	t.Run("NoLimit", func(t *testing.T) {
+		wf := offloaded()
+		unavailable, err := newServer(0, true).hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		require.NoError(t, err)
+		assert.Len(t, wf.Status.Nodes, 3)
+		assert.Empty(t, unavailable)
+	})
+	t.Run("AtLimit", func(t *testing.T) {
+		wf := offloaded()
+		unavailable, err := newServer(3, true).hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		require.NoError(t, err)
+		assert.Len(t, wf.Status.Nodes, 3)
+		assert.Empty(t, wf.Status.OffloadNodeStatusVersion)
+		assert.Empty(t, unavailable)
+	})
+	t.Run("OverLimitWarns", func(t *testing.T) {
+		wf := offloaded()
+		unavailable, err := newServer(2, false).hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		require.NoError(t, err)
+		assert.Len(t, wf.Status.Nodes, 3)
+		assert.Empty(t, unavailable)
+	})
+	t.Run("OverLimitRefuses", func(t *testing.T) {
+		offloadNodeStatusRepo.Calls = nil
+		wf := offloaded()
+		unavailable, err := newServer(2, true).hydrateWithinLimit(ctx, "WatchWorkflows", wf)
+		require.NoError(t, err)
+		assert.Empty(t, wf.Status.Nodes)
+		assert.Equal(t, "my-version", wf.Status.OffloadNodeStatusVersion)
+		assert.Equal(t, "workflow has 3 nodes, more than the limit of 2", unavailable)
+		offloadNodeStatusRepo.AssertNotCalled(t, "Get", "my-uid", "my-version")
+	})
+	t.Run("CompressedOverLimitRefuses", func(t *testing.T) {
+		wf := &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}, Status: v1alpha1.WorkflowStatus{Nodes: nodes}}
+		require.NoError(t, packer.CompressWorkflow(ctx, wf))
+		compressedNodes := wf.Status.CompressedNodes
+		unavailable, err := newServer(2, true).hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		require.NoError(t, err)
+		assert.Empty(t, wf.Status.Nodes)
+		assert.Equal(t, compressedNodes, wf.Status.CompressedNodes)
+		assert.Equal(t, "workflow has 3 nodes, more than the limit of 2", unavailable)
+	})
+	t.Run("OverLimitAlreadyHydrated", func(t *testing.T) {
+		wf := &v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}, Status: v1alpha1.WorkflowStatus{Nodes: nodes}}
+		unavailable, err := newServer(2, true).hydrateWithinLimit(ctx, "GetWorkflow", wf)
+		require.NoError(t, err)
+		assert.Len(t, wf.Status.Nodes, 3)
+		assert.Empty(t, unavailable)
+	})
+}
+
+func TestDiffWorkflows(t *testing.T) {
+	newWorkflow := func(name, uid, message, image string) *v1alpha1.Workflow {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "workflows", UID: k8stypes.UID(uid)},
+			Spec: v1alpha1.WorkflowSpec{
+				Entrypoint: "main",
+				Arguments:  v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "message", Value: v1alpha1.AnyStringPtr(message)}}},
+				Templates:  []v1alpha1.Template{{Name: "main", Container: &corev1.Container{Image: image}}},
+			},
+		}
+	}
+	ctx := logging.TestContext(t.Context())
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(false)
+	big := newWorkflow("big-wf", "big-uid", "hello", "argoproj/argosay:v2")
+	big.Status.Nodes = v1alpha1.Nodes{"big-wf": {ID: "big-wf", Name: "big-wf"}, "big-wf-1": {ID: "big-wf-1", Name: "big-wf[0]"}}
+	// only workflows with compressed or offloaded nodes are refused hydration
+	require.NoError(t, packer.CompressWorkflow(ctx, big))
+	wfClientset := v1alpha.NewSimpleClientset(newWorkflow("my-wf", "my-uid", "hello", "argoproj/argosay:v2"), newWorkflow("my-wf-resubmitted", "resubmitted-uid", "bye", "argoproj/argosay:v2"), big)
+	kubeClientSet := fake.NewSimpleClientset()
+	kubeClientSet.PrependReactor("create", "selfsubjectaccessreviews", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+	})
+	ctx = context.WithValue(context.WithValue(ctx, auth.WfKey, wfClientset), auth.KubeKey, kubeClientSet)
+	wfArchive := &mocks.WorkflowArchive{}
+	wfArchive.On("GetWorkflow", mock.Anything, "archived-uid", "workflows", "").Return(newWorkflow("my-wf", "archived-uid", "hello", "argoproj/argosay:v1"), nil)
+	other := newWorkflow("other-wf", "other-uid", "hello", "argoproj/argosay:v2")
+	other.Namespace = "other"
+	wfArchive.On("GetWorkflow", mock.Anything, "other-uid", "workflows", "").Return(other, nil)
+	server := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, wfArchive, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{})
+
+	t.Run("Resubmitted", func(t *testing.T) {
+		diff, err := server.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Name: "my-wf", OtherName: "my-wf-resubmitted"})
+		require.NoError(t, err)
+		require.Len(t, diff.Differences, 1)
+		assert.Equal(t, &workflowpkg.WorkflowDifference{Path: "spec.arguments.parameters[name=message].value", From: `"hello"`, To: `"bye"`}, diff.Differences[0])
+	})
+	t.Run("Same", func(t *testing.T) {
+		diff, err := server.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Name: "my-wf", OtherName: "my-wf", Status: true})
+		require.NoError(t, err)
+		assert.Empty(t, diff.Differences)
+	})
+	t.Run("UID", func(t *testing.T) {
+		diff, err := server.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Uid: "archived-uid", OtherName: "my-wf", OtherUid: "my-uid"})
+		require.NoError(t, err)
+		require.Len(t, diff.Differences, 1)
+		assert.Equal(t, &workflowpkg.WorkflowDifference{Path: "spec.templates[name=main].container.image", From: `"argoproj/argosay:v1"`, To: `"argoproj/argosay:v2"`}, diff.Differences[0])
+	})
+	t.Run("UIDOfOtherNamespace", func(t *testing.T) {
+		_, err := server.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Uid: "other-uid", OtherName: "my-wf"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+	t.Run("NoOther", func(t *testing.T) {
+		_, err := server.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Name: "my-wf"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("OverMaxNodes", func(t *testing.T) {
+		limited := NewWorkflowServer(ctx, instanceid.NewService(""), offloadNodeStatusRepo, wfArchive, wfClientset, nil, nil, nil, nil, nil, nil, WorkflowServerOptions{HydrateMaxNodes: 1, HydrateRefuseOverMaxNodes: true})
+		_, err := limited.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Name: "my-wf", OtherName: "big-wf", Status: true})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		// the specs of the workflows can still be compared
+		_, err = limited.DiffWorkflows(ctx, &workflowpkg.WorkflowDiffRequest{Namespace: "workflows", Name: "my-wf", OtherName: "big-wf"})
+		require.NoError(t, err)
+	})
 }
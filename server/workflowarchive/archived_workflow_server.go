@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"sort"
 
 	"google.golang.org/grpc/codes"
@@ -13,32 +14,65 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
 	"github.com/argoproj/argo-workflows/v3/workflow/creator"
 	"github.com/argoproj/argo-workflows/v3/workflow/hydrator"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 	"github.com/argoproj/argo-workflows/v3/workflow/util"
 
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 )
 
-const disableValueListRetrievalKeyPattern = "DISABLE_VALUE_LIST_RETRIEVAL_KEY_PATTERN"
+const (
+	disableValueListRetrievalKeyPattern = "DISABLE_VALUE_LIST_RETRIEVAL_KEY_PATTERN"
+	// defaultStreamPageSize is how many archived workflows StreamArchivedWorkflows reads at a time by default, and at
+	// most, so that a request cannot read the whole archive into memory at once
+	defaultStreamPageSize = 500
+	// defaultFindByArtifactLimit and maxFindByArtifactLimit bound how many workflows FindWorkflowsByArtifact returns
+	defaultFindByArtifactLimit = 100
+	maxFindByArtifactLimit     = 500
+)
+
+// Maintenance returns an error if the server is paused for maintenance, so that new workflows cannot be submitted,
+// see workflow.Maintenance
+type Maintenance interface {
+	Check(ctx context.Context) error
+}
 
 type archivedWorkflowServer struct {
 	wfArchive             sqldb.WorkflowArchive
 	offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo
 	hydrator              hydrator.Interface
 	wfDefaults            *wfv1.Workflow
+	retention             *config.ArchiveRetention
+	// maintenance is checked before archived workflows are resubmitted or retried, nil to never be paused
+	maintenance Maintenance
 }
 
 // NewWorkflowArchiveServer returns a new archivedWorkflowServer
-func NewWorkflowArchiveServer(wfArchive sqldb.WorkflowArchive, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfDefaults *wfv1.Workflow) workflowarchivepkg.ArchivedWorkflowServiceServer {
-	return &archivedWorkflowServer{wfArchive, offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo), wfDefaults}
+func NewWorkflowArchiveServer(wfArchive sqldb.WorkflowArchive, offloadNodeStatusRepo sqldb.OffloadNodeStatusRepo, wfDefaults *wfv1.Workflow, persistence *config.PersistConfig, maintenance Maintenance) workflowarchivepkg.ArchivedWorkflowServiceServer {
+	var retention *config.ArchiveRetention
+	if persistence != nil {
+		retention = persistence.ArchiveRetention
+	}
+	return &archivedWorkflowServer{wfArchive, offloadNodeStatusRepo, hydrator.New(offloadNodeStatusRepo), wfDefaults, retention, maintenance}
+}
+
+// checkMaintenance returns an error if the server is paused for maintenance
+func (w *archivedWorkflowServer) checkMaintenance(ctx context.Context) error {
+	if w.maintenance == nil {
+		return nil
+	}
+	return w.maintenance.Check(ctx)
 }
 
 func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req *workflowarchivepkg.ListArchivedWorkflowsRequest) (*wfv1.WorkflowList, error) {
@@ -104,12 +138,113 @@ func (w *archivedWorkflowServer) ListArchivedWorkflows(ctx context.Context, req
 	return &wfv1.WorkflowList{ListMeta: meta, Items: items}, nil
 }
 
+// StreamArchivedWorkflows sends the archived workflows that match the request one at a time, reading them from the
+// archive a page at a time rather than all at once. They are read oldest first, and each page is read after the last
+// workflow of the one before, so that workflows archived or deleted while streaming do not move others between pages.
+func (w *archivedWorkflowServer) StreamArchivedWorkflows(req *workflowarchivepkg.StreamArchivedWorkflowsRequest, stream workflowarchivepkg.ArchivedWorkflowService_StreamArchivedWorkflowsServer) error {
+	ctx := stream.Context()
+	listOptions := metav1.ListOptions{}
+	if req.ListOptions != nil {
+		listOptions = *req.ListOptions
+	}
+	options, err := sutils.BuildListOptions(listOptions, req.Namespace, req.NamePrefix, req.NameFilter, "", "")
+	if err != nil {
+		return err
+	}
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, options.Namespace, "")
+	if err != nil {
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+	if !allowed {
+		return status.Error(codes.PermissionDenied, fmt.Sprintf("Permission denied, you are not allowed to list workflows in namespace \"%s\". Maybe you want to specify a namespace with query parameter `.namespace=%s`?", options.Namespace, options.Namespace))
+	}
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 || pageSize > defaultStreamPageSize {
+		pageSize = defaultStreamPageSize
+	}
+	// a limit of zero sends every archived workflow that matches
+	remaining := options.Limit
+	options.OrderBy = sutils.OrderBy{Field: sutils.OrderByStartedAt, Ascending: true}
+	for {
+		limit := pageSize
+		if remaining > 0 && remaining < limit {
+			limit = remaining
+		}
+		items, err := w.wfArchive.ListWorkflows(ctx, options.WithLimit(limit))
+		if err != nil {
+			return sutils.ToStatusError(err, codes.Internal)
+		}
+		for i := range items {
+			if err := stream.Send(&items[i]); err != nil {
+				return err
+			}
+		}
+		if len(items) > 0 {
+			// the offset of the request only applies to the first page
+			last := items[len(items)-1]
+			options = options.WithOffset(0).WithAfter(&sutils.Cursor{StartedAt: last.Status.StartedAt.Time, UID: string(last.UID)})
+		}
+		if options.Limit > 0 {
+			remaining -= len(items)
+			if remaining <= 0 {
+				return nil
+			}
+		}
+		if len(items) < limit {
+			return nil
+		}
+	}
+}
+
+// FindWorkflowsByArtifact reads the archived workflows whose JSON contains the artifact reference a page at a time, and
+// returns those with an artifact at the reference, which the text match alone may not be, e.g. a parameter with the same
+// value. Each page is read after the last workflow of the one before, most recently started first.
+func (w *archivedWorkflowServer) FindWorkflowsByArtifact(ctx context.Context, req *workflowarchivepkg.FindWorkflowsByArtifactRequest) (*workflowarchivepkg.FindWorkflowsByArtifactResponse, error) {
+	if req.Artifact == "" {
+		return nil, status.Error(codes.InvalidArgument, "artifact is required")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultFindByArtifactLimit
+	}
+	if limit > maxFindByArtifactLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit %d is greater than the maximum of %d", limit, maxFindByArtifactLimit)
+	}
+	allowed, err := auth.CanI(ctx, "list", workflow.WorkflowPlural, req.Namespace, "")
+	if err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if !allowed {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("Permission denied, you are not allowed to list workflows in namespace \"%s\". Maybe you want to specify a namespace with query parameter `.namespace=%s`?", req.Namespace, req.Namespace))
+	}
+	options := sutils.ListOptions{Namespace: req.Namespace, Limit: limit}
+	items := make([]*workflowarchivepkg.ArtifactWorkflow, 0)
+	for len(items) < limit {
+		page, err := w.wfArchive.ListWorkflowsContaining(ctx, options, req.Artifact)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		for i := range page {
+			if roles := artifactRoles(&page[i], req.Artifact); len(items) < limit && len(roles) > 0 {
+				items = append(items, &workflowarchivepkg.ArtifactWorkflow{Workflow: &page[i], Roles: roles})
+			}
+		}
+		if len(page) < limit {
+			break
+		}
+		last := page[len(page)-1]
+		options = options.WithAfter(&sutils.Cursor{StartedAt: last.Status.StartedAt.Time, UID: string(last.UID)})
+	}
+	return &workflowarchivepkg.FindWorkflowsByArtifactResponse{Items: items}, nil
+}
+
 func (w *archivedWorkflowServer) GetArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.GetArchivedWorkflowRequest) (*wfv1.Workflow, error) {
 	wf, err := w.wfArchive.GetWorkflow(ctx, req.Uid, req.Namespace, req.Name)
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
-	if wf == nil {
+	// a workflow found by its UID may be in another namespace than the request's, which is the one that was authorized
+	if wf == nil || (req.Namespace != "" && wf.Namespace != req.Namespace) {
 		// no need to call ToStatusError since it is already a status
 		return nil, status.Error(codes.NotFound, "not found")
 	}
@@ -120,11 +255,23 @@ func (w *archivedWorkflowServer) GetArchivedWorkflow(ctx context.Context, req *w
 	if !allowed {
 		return nil, status.Error(codes.PermissionDenied, "permission denied")
 	}
+	// workflows archived with their node status offloaded need it to be loaded, like a live workflow
+	if err := w.hydrator.Hydrate(ctx, wf); err != nil {
+		return nil, sutils.ToStatusError(err, codes.Internal)
+	}
+	if req.Compact {
+		packer.CompactWorkflow(wf)
+	}
+	if req.Compressed {
+		if err := packer.CompressWorkflow(ctx, wf); err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
 	return wf, nil
 }
 
 func (w *archivedWorkflowServer) DeleteArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.DeleteArchivedWorkflowRequest) (*workflowarchivepkg.ArchivedWorkflowDeletedResponse, error) {
-	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid})
+	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid, Namespace: req.Namespace})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -143,6 +290,42 @@ func (w *archivedWorkflowServer) DeleteArchivedWorkflow(ctx context.Context, req
 	return &workflowarchivepkg.ArchivedWorkflowDeletedResponse{}, nil
 }
 
+// PruneArchivedWorkflows deletes the archived workflows beyond the retention policy of the namespace. If no namespace
+// is given, every archived namespace the user can delete workflows in is pruned.
+func (w *archivedWorkflowServer) PruneArchivedWorkflows(ctx context.Context, req *workflowarchivepkg.PruneArchivedWorkflowsRequest) (*workflowarchivepkg.PruneArchivedWorkflowsResponse, error) {
+	namespaces := []string{req.Namespace}
+	if req.Namespace == "" {
+		var err error
+		namespaces, err = w.wfArchive.ListWorkflowNamespaces(ctx)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+	}
+	var deleted int64
+	for _, namespace := range namespaces {
+		allowed, err := auth.CanI(ctx, "delete", workflow.WorkflowPlural, namespace, "")
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		if !allowed {
+			if req.Namespace != "" {
+				return nil, status.Error(codes.PermissionDenied, "permission denied")
+			}
+			continue
+		}
+		policy := w.retention.ForNamespace(namespace)
+		if policy.IsZero() {
+			continue
+		}
+		n, err := w.wfArchive.PruneWorkflows(ctx, namespace, policy, req.DryRun)
+		if err != nil {
+			return nil, sutils.ToStatusError(err, codes.Internal)
+		}
+		deleted += n
+	}
+	return &workflowarchivepkg.PruneArchivedWorkflowsResponse{Deleted: deleted}, nil
+}
+
 func (w *archivedWorkflowServer) ListArchivedWorkflowLabelKeys(ctx context.Context, req *workflowarchivepkg.ListArchivedWorkflowLabelKeysRequest) (*wfv1.LabelKeys, error) {
 	labelkeys, err := w.wfArchive.ListWorkflowsLabelKeys(ctx)
 	if err != nil {
@@ -195,14 +378,17 @@ func (w *archivedWorkflowServer) ListArchivedWorkflowLabelValues(ctx context.Con
 }
 
 func (w *archivedWorkflowServer) ResubmitArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.ResubmitArchivedWorkflowRequest) (*wfv1.Workflow, error) {
+	if err := w.checkMaintenance(ctx); err != nil {
+		return nil, err
+	}
 	wfClient := auth.GetWfClient(ctx)
 
-	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid})
+	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid, Namespace: req.Namespace})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 
-	newWF, err := util.FormulateResubmitWorkflow(ctx, wf, req.Memoized, req.Parameters)
+	newWF, err := util.FormulateResubmitWorkflow(ctx, wf, util.ResubmitOpts{Memoized: req.Memoized, Parameters: req.Parameters})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
@@ -216,19 +402,34 @@ func (w *archivedWorkflowServer) ResubmitArchivedWorkflow(ctx context.Context, r
 }
 
 func (w *archivedWorkflowServer) RetryArchivedWorkflow(ctx context.Context, req *workflowarchivepkg.RetryArchivedWorkflowRequest) (*wfv1.Workflow, error) {
+	if err := w.checkMaintenance(ctx); err != nil {
+		return nil, err
+	}
 	wfClient := auth.GetWfClient(ctx)
 	kubeClient := auth.GetKubeClient(ctx)
 
-	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid})
+	if err := sutils.ValidateRetryOptions(ctx, req.RestartSuccessful, req.NodeFieldSelector); err != nil {
+		return nil, err
+	}
+
+	wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: req.Uid, Namespace: req.Namespace})
 	if err != nil {
 		return nil, sutils.ToStatusError(err, codes.Internal)
 	}
 	oriUID := wf.UID
 
-	_, err = wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Get(ctx, wf.Name, metav1.GetOptions{})
+	namespace := req.Namespace
+	if req.TargetNamespace != "" && req.TargetNamespace != wf.Namespace {
+		if err := validateTargetNamespace(ctx, kubeClient, req.TargetNamespace); err != nil {
+			return nil, err
+		}
+		namespace = req.TargetNamespace
+	}
+
+	_, err = wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, wf.Name, metav1.GetOptions{})
 	if apierr.IsNotFound(err) {
 
-		wf, podsToDelete, err := util.FormulateRetryWorkflow(ctx, wf, req.RestartSuccessful, req.NodeFieldSelector, req.Parameters)
+		wf, podsToDelete, err := util.FormulateRetryWorkflow(ctx, wf, util.RetryOpts{RestartSuccessful: req.RestartSuccessful, NodeFieldSelector: req.NodeFieldSelector, Parameters: req.Parameters})
 		if err != nil {
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
@@ -249,9 +450,12 @@ func (w *archivedWorkflowServer) RetryArchivedWorkflow(ctx context.Context, req
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
 
-		wf.ResourceVersion = ""
-		wf.UID = ""
-		result, err := wfClient.ArgoprojV1alpha1().Workflows(req.Namespace).Create(ctx, wf, metav1.CreateOptions{})
+		if req.TargetNamespace != "" && req.TargetNamespace != wf.Namespace {
+			retargetNamespace(wf, req.TargetNamespace)
+		}
+
+		resetArchivedMetadata(wf)
+		result, err := wfClient.ArgoprojV1alpha1().Workflows(namespace).Create(ctx, wf, metav1.CreateOptions{})
 		if err != nil {
 			return nil, sutils.ToStatusError(err, codes.Internal)
 		}
@@ -277,3 +481,68 @@ func (w *archivedWorkflowServer) RetryArchivedWorkflow(ctx context.Context, req
 
 	return nil, sutils.ToStatusError(err, codes.Internal)
 }
+
+// validateTargetNamespace returns an InvalidArgument error if the namespace to retry a workflow into does not exist
+func validateTargetNamespace(ctx context.Context, kubeClient kubernetes.Interface, namespace string) error {
+	_, err := kubeClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		return nil
+	case apierr.IsNotFound(err):
+		return status.Errorf(codes.InvalidArgument, "target namespace %s does not exist", namespace)
+	case apierr.IsForbidden(err):
+		// the user may be allowed to create workflows in the namespace without being allowed to get it, creating the
+		// workflow then fails if it does not exist
+		return nil
+	default:
+		return sutils.ToStatusError(err, codes.Internal)
+	}
+}
+
+// retargetNamespace moves a workflow into another namespace. Synchronization references explicitly naming the
+// original namespace are rewritten to the new one, and owner references are removed as they cannot cross namespaces.
+func retargetNamespace(wf *wfv1.Workflow, namespace string) {
+	original := wf.Namespace
+	wf.Namespace = namespace
+	wf.OwnerReferences = nil
+	rewrite := func(sync *wfv1.Synchronization) {
+		if sync == nil {
+			return
+		}
+		for _, semaphore := range append([]*wfv1.SemaphoreRef{sync.Semaphore}, sync.Semaphores...) {
+			if semaphore != nil && semaphore.Namespace == original {
+				semaphore.Namespace = namespace
+			}
+		}
+		for _, mutex := range append([]*wfv1.Mutex{sync.Mutex}, sync.Mutexes...) {
+			if mutex != nil && mutex.Namespace == original {
+				mutex.Namespace = namespace
+			}
+		}
+	}
+	rewriteSpec := func(spec *wfv1.WorkflowSpec) {
+		rewrite(spec.Synchronization)
+		for i := range spec.Templates {
+			rewrite(spec.Templates[i].Synchronization)
+		}
+	}
+	rewriteSpec(&wf.Spec)
+	if wf.Status.StoredWorkflowSpec != nil {
+		rewriteSpec(wf.Status.StoredWorkflowSpec)
+	}
+}
+
+// resetArchivedMetadata resets the metadata that belonged to the archived object, so that the retried workflow is
+// created as a new one. Its labels and annotations, which dashboards select and group workflows by, are kept, other
+// than those FormulateRetryWorkflow resets. The artifact GC finalizer is removed, as the controller adds it again if
+// the retried workflow needs it.
+func resetArchivedMetadata(wf *wfv1.Workflow) {
+	wf.ResourceVersion = ""
+	wf.UID = ""
+	wf.Generation = 0
+	wf.CreationTimestamp = metav1.Time{}
+	wf.DeletionTimestamp = nil
+	wf.DeletionGracePeriodSeconds = nil
+	wf.ManagedFields = nil
+	wf.Finalizers = slices.DeleteFunc(wf.Finalizers, func(s string) bool { return s == common.FinalizerArtifactGC })
+}
@@ -2,6 +2,9 @@ package workflowarchive
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,15 +13,20 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	kubefake "k8s.io/client-go/kubernetes/fake"
 	k8stesting "k8s.io/client-go/testing"
 
+	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
 	workflowarchivepkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflowarchive"
@@ -27,6 +35,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/server/auth"
 	sutils "github.com/argoproj/argo-workflows/v3/server/utils"
 	"github.com/argoproj/argo-workflows/v3/workflow/common"
+	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 )
 
 func Test_archivedWorkflowServer(t *testing.T) {
@@ -36,7 +45,7 @@ func Test_archivedWorkflowServer(t *testing.T) {
 	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
 	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
 	offloadNodeStatusRepo.On("List", mock.Anything).Return(map[sqldb.UUIDVersion]v1alpha1.Nodes{}, nil)
-	w := NewWorkflowArchiveServer(repo, offloadNodeStatusRepo, nil)
+	w := NewWorkflowArchiveServer(repo, offloadNodeStatusRepo, nil, nil, nil)
 	allowed := true
 	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
 		return true, &authorizationv1.SelfSubjectAccessReview{
@@ -212,6 +221,80 @@ func Test_archivedWorkflowServer(t *testing.T) {
 		wf, err = w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "", Name: "my-name", Namespace: "my-ns"})
 		require.NoError(t, err)
 		assert.NotNil(t, wf)
+
+		repo.On("GetWorkflow", mock.Anything, "my-uid", "other-ns", "").Return(&v1alpha1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-name", Namespace: "my-ns"}}, nil)
+		_, err = w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "my-uid", Namespace: "other-ns"})
+		assert.Equal(t, status.Error(codes.NotFound, "not found"), err, "the workflow is in another namespace than requested")
+	})
+	t.Run("GetArchivedWorkflowCompressed", func(t *testing.T) {
+		original := &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "compressed-wf", Namespace: "my-ns"},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: v1alpha1.WorkflowSucceeded,
+				Nodes: v1alpha1.Nodes{
+					"node-1": {ID: "node-1", Name: "node-1", Phase: v1alpha1.NodeSucceeded, Message: "done"},
+					"node-2": {ID: "node-2", Name: "node-2", Phase: v1alpha1.NodeSucceeded, Children: []string{"node-1"}},
+				},
+			},
+		}
+		repo.On("GetWorkflow", mock.Anything, "compressed-uid", "", "").Return(original.DeepCopy(), nil)
+		wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "compressed-uid", Compressed: true})
+		require.NoError(t, err)
+		assert.Empty(t, wf.Status.Nodes)
+		assert.NotEmpty(t, wf.Status.CompressedNodes)
+
+		require.NoError(t, packer.DecompressWorkflow(ctx, wf))
+		assert.Equal(t, original, wf)
+	})
+	t.Run("GetArchivedWorkflowCompact", func(t *testing.T) {
+		original := &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "compact-wf", Namespace: "my-ns"},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: v1alpha1.WorkflowSucceeded,
+				Nodes: v1alpha1.Nodes{
+					"compact-wf":   {ID: "compact-wf", Name: "compact-wf", DisplayName: "compact-wf", Phase: v1alpha1.NodeSucceeded, Children: []string{"compact-wf-1"}},
+					"compact-wf-1": {ID: "compact-wf-1", Name: "compact-wf.step", DisplayName: "step", Phase: v1alpha1.NodeSucceeded, BoundaryID: "compact-wf"},
+				},
+			},
+		}
+		repo.On("GetWorkflow", mock.Anything, "compact-uid", "", "").Return(original.DeepCopy(), nil)
+		wf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "compact-uid", Compact: true, Compressed: true})
+		require.NoError(t, err)
+		require.NoError(t, packer.DecompressWorkflow(ctx, wf))
+		assert.Equal(t, v1alpha1.NodeStatus{Name: ".step", Phase: v1alpha1.NodeSucceeded, BoundaryID: "compact-wf"}, wf.Status.Nodes["compact-wf-1"])
+
+		packer.ExpandWorkflow(wf)
+		assert.Equal(t, original, wf)
+	})
+	t.Run("GetArchivedWorkflowOffloaded", func(t *testing.T) {
+		nodes := v1alpha1.Nodes{
+			"node-1": {ID: "node-1", Name: "node-1", Phase: v1alpha1.NodeSucceeded},
+			"node-2": {ID: "node-2", Name: "node-2", Phase: v1alpha1.NodeFailed, Children: []string{"node-1"}},
+		}
+		inline := &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "inline-wf", Namespace: "my-ns", UID: "inline-uid"},
+			Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowFailed, Nodes: nodes},
+		}
+		offloaded := &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "offloaded-wf", Namespace: "my-ns", UID: "offloaded-uid"},
+			Status:     v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowFailed, OffloadNodeStatusVersion: "fnv:123"},
+		}
+		repo.On("GetWorkflow", mock.Anything, "inline-uid", "", "").Return(inline, nil)
+		repo.On("GetWorkflow", mock.Anything, "offloaded-uid", "", "").Return(offloaded, nil)
+		offloadNodeStatusRepo.On("Get", "offloaded-uid", "fnv:123").Return(nodes, nil)
+
+		inlineWf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "inline-uid"})
+		require.NoError(t, err)
+		offloadedWf, err := w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "offloaded-uid"})
+		require.NoError(t, err)
+		assert.Equal(t, inlineWf.Status, offloadedWf.Status)
+
+		// the nodes are hydrated before they are compressed
+		offloaded.Status = v1alpha1.WorkflowStatus{Phase: v1alpha1.WorkflowFailed, OffloadNodeStatusVersion: "fnv:123"}
+		offloadedWf, err = w.GetArchivedWorkflow(ctx, &workflowarchivepkg.GetArchivedWorkflowRequest{Uid: "offloaded-uid", Compressed: true})
+		require.NoError(t, err)
+		require.NoError(t, packer.DecompressWorkflow(ctx, offloadedWf))
+		assert.Equal(t, nodes, offloadedWf.Status.Nodes)
 	})
 	t.Run("DeleteArchivedWorkflow", func(t *testing.T) {
 		allowed = false
@@ -243,9 +326,461 @@ func Test_archivedWorkflowServer(t *testing.T) {
 		_, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid"})
 		assert.Equal(t, err, status.Error(codes.AlreadyExists, "Workflow already exists on cluster, use argo retry {name} instead"))
 	})
+	t.Run("RetryArchivedWorkflowInvalidSelector", func(t *testing.T) {
+		_, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid", RestartSuccessful: true, NodeFieldSelector: "templateName"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
 	t.Run("ResubmitArchivedWorkflow", func(t *testing.T) {
 		wf, err := w.ResubmitArchivedWorkflow(ctx, &workflowarchivepkg.ResubmitArchivedWorkflowRequest{Uid: "resubmit-uid", Memoized: false})
 		require.NoError(t, err)
 		assert.NotNil(t, wf)
 	})
 }
+
+func TestRetryArchivedWorkflowTargetNamespace(t *testing.T) {
+	repo := &mocks.WorkflowArchive{}
+	kubeClient := &kubefake.Clientset{}
+	wfClient := &argofake.Clientset{}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
+	w := NewWorkflowArchiveServer(repo, offloadNodeStatusRepo, nil, nil, nil)
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+	kubeClient.AddReactor("get", "namespaces", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		name := action.(k8stesting.GetAction).GetName()
+		if name != "other-ns" {
+			return true, nil, apierr.NewNotFound(apiv1.Resource("namespaces"), name)
+		}
+		return true, &apiv1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}, nil
+	})
+	wfClient.AddReactor("get", "workflows", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, nil, apierr.NewNotFound(v1alpha1.Resource("workflows"), action.(k8stesting.GetAction).GetName())
+	})
+	var created *v1alpha1.Workflow
+	wfClient.AddReactor("create", "workflows", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		created = action.(k8stesting.CreateAction).GetObject().(*v1alpha1.Workflow)
+		return true, created, nil
+	})
+	repo.On("GetWorkflow", mock.Anything, "failed-uid", mock.Anything, "").Return(func(context.Context, string, string, string) (*v1alpha1.Workflow, error) {
+		return &v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "failed-wf",
+				Namespace:       "my-ns",
+				UID:             "failed-uid",
+				Labels:          map[string]string{common.LabelKeyCompleted: "true"},
+				OwnerReferences: []metav1.OwnerReference{{Kind: "CronWorkflow", Name: "my-cron"}},
+			},
+			Spec: v1alpha1.WorkflowSpec{
+				Entrypoint: "main",
+				Synchronization: &v1alpha1.Synchronization{
+					Mutexes: []*v1alpha1.Mutex{{Name: "local", Namespace: "my-ns"}, {Name: "shared", Namespace: "shared-ns"}},
+				},
+				Templates: []v1alpha1.Template{{
+					Name:      "main",
+					Container: &apiv1.Container{},
+					Synchronization: &v1alpha1.Synchronization{
+						Semaphores: []*v1alpha1.SemaphoreRef{{Namespace: "my-ns", ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{Key: "key"}}},
+					},
+				}},
+			},
+			Status: v1alpha1.WorkflowStatus{
+				Phase: v1alpha1.WorkflowFailed,
+				Nodes: v1alpha1.Nodes{
+					"failed-wf": {ID: "failed-wf", Name: "failed-wf", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeFailed, TemplateName: "main"},
+				},
+			},
+		}, nil
+	})
+	ctx := context.WithValue(context.WithValue(logging.TestContext(t.Context()), auth.WfKey, wfClient), auth.KubeKey, kubeClient)
+
+	t.Run("TargetNamespace", func(t *testing.T) {
+		wf, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid", Namespace: "my-ns", TargetNamespace: "other-ns"})
+		require.NoError(t, err)
+		assert.Equal(t, "other-ns", wf.Namespace)
+		assert.Empty(t, wf.OwnerReferences)
+		assert.Equal(t, "other-ns", created.Spec.Synchronization.Mutexes[0].Namespace)
+		assert.Equal(t, "shared-ns", created.Spec.Synchronization.Mutexes[1].Namespace)
+		assert.Equal(t, "other-ns", created.Spec.Templates[0].Synchronization.Semaphores[0].Namespace)
+	})
+	t.Run("TargetNamespaceNotFound", func(t *testing.T) {
+		_, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid", Namespace: "my-ns", TargetNamespace: "missing-ns"})
+		assert.Equal(t, status.Error(codes.InvalidArgument, "target namespace missing-ns does not exist"), err)
+	})
+	t.Run("SameNamespace", func(t *testing.T) {
+		wf, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid", Namespace: "my-ns", TargetNamespace: "my-ns"})
+		require.NoError(t, err)
+		assert.Equal(t, "my-ns", wf.Namespace)
+		assert.Len(t, wf.OwnerReferences, 1)
+	})
+}
+
+func TestRetryArchivedWorkflowMetadata(t *testing.T) {
+	repo := &mocks.WorkflowArchive{}
+	kubeClient := &kubefake.Clientset{}
+	wfClient := &argofake.Clientset{}
+	offloadNodeStatusRepo := &mocks.OffloadNodeStatusRepo{}
+	offloadNodeStatusRepo.On("IsEnabled", mock.Anything).Return(true)
+	w := NewWorkflowArchiveServer(repo, offloadNodeStatusRepo, nil, nil, nil)
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: true},
+		}, nil
+	})
+	wfClient.AddReactor("get", "workflows", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		return true, nil, apierr.NewNotFound(v1alpha1.Resource("workflows"), action.(k8stesting.GetAction).GetName())
+	})
+	var created *v1alpha1.Workflow
+	wfClient.AddReactor("create", "workflows", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		created = action.(k8stesting.CreateAction).GetObject().(*v1alpha1.Workflow)
+		return true, created, nil
+	})
+	repo.On("GetWorkflow", mock.Anything, "failed-uid", mock.Anything, "").Return(&v1alpha1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "failed-wf",
+			Namespace:         "my-ns",
+			UID:               "failed-uid",
+			ResourceVersion:   "123",
+			Generation:        4,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+			Labels: map[string]string{
+				"team":                                 "my-team",
+				common.LabelKeyControllerInstanceID:    "my-instance",
+				common.LabelKeyCompleted:               "true",
+				common.LabelKeyPhase:                   string(v1alpha1.WorkflowFailed),
+				common.LabelKeyWorkflowArchivingStatus: "Persisted",
+			},
+			Annotations:   map[string]string{"dashboard": "my-dashboard"},
+			Finalizers:    []string{common.FinalizerArtifactGC, "my-finalizer"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "workflow-controller", Operation: metav1.ManagedFieldsOperationUpdate}},
+		},
+		Spec: v1alpha1.WorkflowSpec{Entrypoint: "main", Templates: []v1alpha1.Template{{Name: "main", Container: &apiv1.Container{}}}},
+		Status: v1alpha1.WorkflowStatus{
+			Phase: v1alpha1.WorkflowFailed,
+			Nodes: v1alpha1.Nodes{
+				"failed-wf": {ID: "failed-wf", Name: "failed-wf", Type: v1alpha1.NodeTypePod, Phase: v1alpha1.NodeFailed, TemplateName: "main"},
+			},
+		},
+	}, nil)
+	ctx := context.WithValue(context.WithValue(logging.TestContext(t.Context()), auth.WfKey, wfClient), auth.KubeKey, kubeClient)
+
+	_, err := w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "failed-uid", Namespace: "my-ns"})
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, map[string]string{
+		"team":                              "my-team",
+		common.LabelKeyControllerInstanceID: "my-instance",
+		common.LabelKeyPhase:                string(v1alpha1.NodeRunning),
+	}, created.Labels)
+	assert.Equal(t, map[string]string{"dashboard": "my-dashboard"}, created.Annotations)
+	assert.Equal(t, []string{"my-finalizer"}, created.Finalizers)
+	assert.Empty(t, created.UID)
+	assert.Empty(t, created.ResourceVersion)
+	assert.Zero(t, created.Generation)
+	assert.True(t, created.CreationTimestamp.IsZero())
+	assert.Empty(t, created.ManagedFields)
+}
+
+func TestPruneArchivedWorkflows(t *testing.T) {
+	repo := &mocks.WorkflowArchive{}
+	kubeClient := &kubefake.Clientset{}
+	countPolicy := config.ArchiveRetentionPolicy{MaxCount: 2}
+	agePolicy := config.ArchiveRetentionPolicy{MaxAge: config.TTL(time.Hour)}
+	w := NewWorkflowArchiveServer(repo, &mocks.OffloadNodeStatusRepo{}, nil, &config.PersistConfig{
+		ArchiveRetention: &config.ArchiveRetention{
+			Namespaces: map[string]config.ArchiveRetentionPolicy{"count-ns": countPolicy, "age-ns": agePolicy},
+		},
+	}, nil)
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Namespace != "forbidden-ns"},
+		}, nil
+	})
+	repo.On("PruneWorkflows", mock.Anything, "count-ns", countPolicy, false).Return(int64(3), nil)
+	repo.On("PruneWorkflows", mock.Anything, "age-ns", agePolicy, false).Return(int64(2), nil)
+	repo.On("PruneWorkflows", mock.Anything, "age-ns", agePolicy, true).Return(int64(2), nil)
+	repo.On("ListWorkflowNamespaces", mock.Anything).Return([]string{"age-ns", "count-ns", "forbidden-ns", "other-ns"}, nil)
+	ctx := context.WithValue(logging.TestContext(t.Context()), auth.KubeKey, kubeClient)
+
+	t.Run("CountBased", func(t *testing.T) {
+		rsp, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{Namespace: "count-ns"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), rsp.Deleted)
+		repo.AssertCalled(t, "PruneWorkflows", mock.Anything, "count-ns", countPolicy, false)
+	})
+	t.Run("AgeBased", func(t *testing.T) {
+		rsp, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{Namespace: "age-ns"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rsp.Deleted)
+		repo.AssertCalled(t, "PruneWorkflows", mock.Anything, "age-ns", agePolicy, false)
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		rsp, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{Namespace: "age-ns", DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), rsp.Deleted)
+		repo.AssertCalled(t, "PruneWorkflows", mock.Anything, "age-ns", agePolicy, true)
+	})
+	t.Run("NoPolicy", func(t *testing.T) {
+		rsp, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{Namespace: "other-ns"})
+		require.NoError(t, err)
+		assert.Zero(t, rsp.Deleted)
+		repo.AssertNotCalled(t, "PruneWorkflows", mock.Anything, "other-ns", mock.Anything, mock.Anything)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		_, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{Namespace: "forbidden-ns"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("AllNamespaces", func(t *testing.T) {
+		rsp, err := w.PruneArchivedWorkflows(ctx, &workflowarchivepkg.PruneArchivedWorkflowsRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), rsp.Deleted)
+		repo.AssertNotCalled(t, "PruneWorkflows", mock.Anything, "forbidden-ns", mock.Anything, mock.Anything)
+	})
+}
+
+type testStreamArchivedWorkflowsServer struct {
+	grpc.ServerStream
+	// nolint:containedctx
+	ctx  context.Context
+	sent []string
+}
+
+func (s *testStreamArchivedWorkflowsServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStreamArchivedWorkflowsServer) Send(wf *v1alpha1.Workflow) error {
+	s.sent = append(s.sent, wf.Name)
+	return nil
+}
+
+func TestStreamArchivedWorkflows(t *testing.T) {
+	// the archive of "my-ns", oldest first, of which only the workflows labelled app=my-app match
+	var archived v1alpha1.Workflows
+	var matching []string
+	startedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := range 7 {
+		wf := v1alpha1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("wf-%d", i), Namespace: "my-ns", UID: types.UID(fmt.Sprintf("uid-%d", i)), Labels: map[string]string{"app": "my-app"}},
+			// pairs of workflows started at the same time
+			Status: v1alpha1.WorkflowStatus{StartedAt: metav1.NewTime(startedAt.Add(time.Duration(i/2) * time.Minute))},
+		}
+		if i%3 == 2 {
+			wf.Labels["app"] = "other-app"
+		} else {
+			matching = append(matching, wf.Name)
+		}
+		archived = append(archived, wf)
+	}
+	var pages []sutils.ListOptions
+	// onPage is called after each page is read
+	var onPage func()
+	repo := &mocks.WorkflowArchive{}
+	repo.On("ListWorkflows", mock.Anything, mock.Anything).Return(func(_ context.Context, options sutils.ListOptions) (v1alpha1.Workflows, error) {
+		pages = append(pages, options)
+		if options.Namespace != "my-ns" || !options.OrderBy.Ascending || options.OrderBy.Field != sutils.OrderByStartedAt {
+			return nil, fmt.Errorf("unexpected options %v", options)
+		}
+		selector := labels.NewSelector().Add(options.LabelRequirements...)
+		var wfs v1alpha1.Workflows
+		for _, wf := range archived {
+			after := options.After == nil || wf.Status.StartedAt.After(options.After.StartedAt) ||
+				(wf.Status.StartedAt.Time.Equal(options.After.StartedAt) && string(wf.UID) > options.After.UID)
+			if after && selector.Matches(labels.Set(wf.Labels)) {
+				wfs = append(wfs, wf)
+			}
+		}
+		page := wfs[min(options.Offset, len(wfs)):min(options.Offset+options.Limit, len(wfs))]
+		if onPage != nil {
+			onPage()
+		}
+		return page, nil
+	})
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Namespace == "my-ns"},
+		}, nil
+	})
+	w := NewWorkflowArchiveServer(repo, &mocks.OffloadNodeStatusRepo{}, nil, nil, nil)
+	ctx := context.WithValue(logging.TestContext(t.Context()), auth.KubeKey, kubeClient)
+	stream := func(t *testing.T, req *workflowarchivepkg.StreamArchivedWorkflowsRequest) ([]string, error) {
+		t.Helper()
+		pages = nil
+		s := &testStreamArchivedWorkflowsServer{ctx: ctx}
+		err := w.StreamArchivedWorkflows(req, s)
+		return s.sent, err
+	}
+	selector := &metav1.ListOptions{LabelSelector: "app=my-app"}
+
+	t.Run("AllPages", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: selector, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, matching, sent)
+		assert.Len(t, pages, 3)
+	})
+	t.Run("ExactPages", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: selector, PageSize: 5})
+		require.NoError(t, err)
+		assert.Equal(t, matching, sent)
+		// the second page is empty
+		assert.Len(t, pages, 2)
+	})
+	t.Run("DefaultPageSize", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: selector})
+		require.NoError(t, err)
+		assert.Equal(t, matching, sent)
+		require.Len(t, pages, 1)
+		assert.Equal(t, defaultStreamPageSize, pages[0].Limit)
+	})
+	t.Run("MaxPageSize", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: selector, PageSize: 1000000})
+		require.NoError(t, err)
+		assert.Equal(t, matching, sent)
+		require.Len(t, pages, 1)
+		assert.Equal(t, defaultStreamPageSize, pages[0].Limit)
+	})
+	t.Run("LimitAndContinue", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: &metav1.ListOptions{LabelSelector: "app=my-app", Limit: 3, Continue: "1"}, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, matching[1:4], sent)
+	})
+	t.Run("Unfiltered", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", PageSize: 3})
+		require.NoError(t, err)
+		assert.Len(t, sent, len(archived))
+	})
+	t.Run("DeletedWhileStreaming", func(t *testing.T) {
+		all := archived
+		defer func() { archived, onPage = all, nil }()
+		// the oldest workflow is deleted once the first page has been read
+		onPage = func() { archived, onPage = all[1:], nil }
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "my-ns", ListOptions: selector, PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, matching, sent, "no workflow is skipped")
+	})
+	t.Run("PermissionDenied", func(t *testing.T) {
+		sent, err := stream(t, &workflowarchivepkg.StreamArchivedWorkflowsRequest{Namespace: "other-ns"})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, sent)
+		assert.Empty(t, pages)
+	})
+}
+
+func TestFindWorkflowsByArtifact(t *testing.T) {
+	key := "path/to/my-file.tgz"
+	s3 := v1alpha1.Artifact{Name: "my-file", ArtifactLocation: v1alpha1.ArtifactLocation{S3: &v1alpha1.S3Artifact{Key: key}}}
+	archived := v1alpha1.Workflows{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "producer", Namespace: "my-ns"},
+			Status:     v1alpha1.WorkflowStatus{Nodes: v1alpha1.Nodes{"producer": {Outputs: &v1alpha1.Outputs{Artifacts: v1alpha1.Artifacts{s3}}}}},
+		},
+		{
+			// only the parameter has the same value, so it is not returned
+			ObjectMeta: metav1.ObjectMeta{Name: "parameter", Namespace: "my-ns"},
+			Spec:       v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{Parameters: []v1alpha1.Parameter{{Name: "file", Value: v1alpha1.AnyStringPtr(key)}}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "consumer", Namespace: "my-ns"},
+			Status:     v1alpha1.WorkflowStatus{Nodes: v1alpha1.Nodes{"consumer": {Inputs: &v1alpha1.Inputs{Artifacts: v1alpha1.Artifacts{s3}}}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "both", Namespace: "my-ns"},
+			Spec:       v1alpha1.WorkflowSpec{Arguments: v1alpha1.Arguments{Artifacts: v1alpha1.Artifacts{s3}}},
+			Status:     v1alpha1.WorkflowStatus{Nodes: v1alpha1.Nodes{"producer": {Outputs: &v1alpha1.Outputs{Artifacts: v1alpha1.Artifacts{s3}}}}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "my-ns"}},
+	}
+	// most recently started first, the last two at the same time
+	startedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := range archived {
+		archived[i].UID = types.UID(fmt.Sprintf("uid-%d", len(archived)-i))
+		archived[i].Status.StartedAt = metav1.NewTime(startedAt.Add(-time.Duration(min(i, 3)) * time.Minute))
+	}
+	var pages []sutils.ListOptions
+	repo := &mocks.WorkflowArchive{}
+	repo.On("ListWorkflowsContaining", mock.Anything, mock.Anything, key).Return(func(_ context.Context, options sutils.ListOptions, text string) (v1alpha1.Workflows, error) {
+		pages = append(pages, options)
+		var wfs v1alpha1.Workflows
+		for _, wf := range archived {
+			data, err := json.Marshal(wf)
+			if err != nil {
+				return nil, err
+			}
+			after := options.After == nil || wf.Status.StartedAt.Before(&metav1.Time{Time: options.After.StartedAt}) ||
+				(wf.Status.StartedAt.Time.Equal(options.After.StartedAt) && string(wf.UID) < options.After.UID)
+			if after && options.Namespace == wf.Namespace && strings.Contains(string(data), text) {
+				wfs = append(wfs, *wf.DeepCopy())
+			}
+		}
+		return wfs[:min(options.Limit, len(wfs))], nil
+	})
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: review.Spec.ResourceAttributes.Namespace == "my-ns"},
+		}, nil
+	})
+	w := NewWorkflowArchiveServer(repo, &mocks.OffloadNodeStatusRepo{}, nil, nil, nil)
+	ctx := context.WithValue(logging.TestContext(t.Context()), auth.KubeKey, kubeClient)
+	roles := func(resp *workflowarchivepkg.FindWorkflowsByArtifactResponse) map[string][]string {
+		roles := map[string][]string{}
+		for _, item := range resp.Items {
+			roles[item.Workflow.Name] = item.Roles
+		}
+		return roles
+	}
+
+	t.Run("Found", func(t *testing.T) {
+		pages = nil
+		list, err := w.FindWorkflowsByArtifact(ctx, &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "my-ns", Artifact: key})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"producer": {"producer"}, "consumer": {"consumer"}, "both": {"producer", "consumer"}}, roles(list))
+		require.Len(t, pages, 1)
+		assert.Equal(t, defaultFindByArtifactLimit, pages[0].Limit)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		pages = nil
+		list, err := w.FindWorkflowsByArtifact(ctx, &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "my-ns", Artifact: key, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"producer": {"producer"}, "consumer": {"consumer"}}, roles(list))
+		// the parameter match takes up room on the first page, so a second is read after it
+		require.Len(t, pages, 2)
+		assert.Equal(t, &sutils.Cursor{StartedAt: archived[1].Status.StartedAt.Time, UID: string(archived[1].UID)}, pages[1].After)
+	})
+	t.Run("NoArtifact", func(t *testing.T) {
+		_, err := w.FindWorkflowsByArtifact(ctx, &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "my-ns"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("LimitTooLarge", func(t *testing.T) {
+		_, err := w.FindWorkflowsByArtifact(ctx, &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "my-ns", Artifact: key, Limit: maxFindByArtifactLimit + 1})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("PermissionDenied", func(t *testing.T) {
+		pages = nil
+		_, err := w.FindWorkflowsByArtifact(ctx, &workflowarchivepkg.FindWorkflowsByArtifactRequest{Namespace: "other-ns", Artifact: key})
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, pages)
+	})
+}
+
+// pausedMaintenance is always paused for maintenance
+type pausedMaintenance struct{}
+
+func (pausedMaintenance) Check(context.Context) error {
+	return status.Error(codes.Unavailable, "the server is paused for maintenance")
+}
+
+func TestArchivedWorkflowServerMaintenance(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	w := NewWorkflowArchiveServer(&mocks.WorkflowArchive{}, &mocks.OffloadNodeStatusRepo{}, nil, nil, pausedMaintenance{})
+	_, err := w.ResubmitArchivedWorkflow(ctx, &workflowarchivepkg.ResubmitArchivedWorkflowRequest{Uid: "my-uid", Namespace: "my-ns"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = w.RetryArchivedWorkflow(ctx, &workflowarchivepkg.RetryArchivedWorkflowRequest{Uid: "my-uid", Namespace: "my-ns"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
@@ -0,0 +1,55 @@
+package workflowarchive
+
+import (
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// referencesArtifact returns whether the artifact is at the reference, which is either its key or, for artifacts that
+// are located by one, its URL.
+func referencesArtifact(artifact wfv1.Artifact, reference string) bool {
+	if key, err := artifact.GetKey(); err == nil && key == reference {
+		return true
+	}
+	switch {
+	case artifact.HTTP != nil:
+		return artifact.HTTP.URL == reference
+	case artifact.Artifactory != nil:
+		return artifact.Artifactory.URL == reference
+	case artifact.Git != nil:
+		return artifact.Git.Repo == reference
+	}
+	return false
+}
+
+func anyReferencesArtifact(artifacts wfv1.Artifacts, reference string) bool {
+	for _, artifact := range artifacts {
+		if referencesArtifact(artifact, reference) {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactRoles returns whether the workflow produced the artifact, as an output of one of its nodes, and whether it
+// consumed it, as an argument or an input of one of its nodes.
+func artifactRoles(wf *wfv1.Workflow, reference string) []string {
+	produced := false
+	consumed := anyReferencesArtifact(wf.Spec.Arguments.Artifacts, reference)
+	for _, node := range wf.Status.Nodes {
+		if node.Outputs != nil && anyReferencesArtifact(node.Outputs.Artifacts, reference) {
+			produced = true
+		}
+		if node.Inputs != nil && anyReferencesArtifact(node.Inputs.Artifacts, reference) {
+			consumed = true
+		}
+	}
+	var roles []string
+	if produced {
+		roles = append(roles, common.ArtifactRoleProducer)
+	}
+	if consumed {
+		roles = append(roles, common.ArtifactRoleConsumer)
+	}
+	return roles
+}
@@ -0,0 +1,28 @@
+package workflowarchive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+func Test_referencesArtifact(t *testing.T) {
+	for name, tt := range map[string]struct {
+		artifact wfv1.Artifact
+		want     bool
+	}{
+		"S3Key":          {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "my-ref"}}}, true},
+		"OtherS3Key":     {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "other-ref"}}}, false},
+		"HTTPURL":        {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{HTTP: &wfv1.HTTPArtifact{URL: "my-ref"}}}, true},
+		"ArtifactoryURL": {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Artifactory: &wfv1.ArtifactoryArtifact{URL: "my-ref"}}}, true},
+		"GitRepo":        {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Git: &wfv1.GitArtifact{Repo: "my-ref"}}}, true},
+		"Raw":            {wfv1.Artifact{ArtifactLocation: wfv1.ArtifactLocation{Raw: &wfv1.RawArtifact{Data: "my-ref"}}}, false},
+		"NoLocation":     {wfv1.Artifact{Name: "my-ref"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, referencesArtifact(tt.artifact, "my-ref"))
+		})
+	}
+}
@@ -37,7 +37,7 @@ func newPersistence(ctx context.Context, kubeClient kubernetes.Interface, wcConf
 			panic(err)
 		}
 		log := logging.RequireLoggerFromContext(ctx)
-		offloadNodeStatusRepo, err := persist.NewOffloadNodeStatusRepo(ctx, log, session, persistence.GetClusterName(), tableName)
+		offloadNodeStatusRepo, err := persist.NewOffloadNodeStatusRepo(ctx, log, session, persistence.GetClusterName(), tableName, persistence.NodeStatusOffloadCompression)
 		if err != nil {
 			panic(err)
 		}
@@ -30,8 +30,26 @@ func CanIArgo(ctx context.Context, kubeclientset kubernetes.Interface, verb, res
 	)
 }
 
+// CanIArgoNamed is CanIArgo for the named resource, honouring RBAC rules restricted to it by resourceNames, which
+// CanIArgo, checking the verb against every resource of the namespace, does not
+func CanIArgoNamed(ctx context.Context, kubeclientset kubernetes.Interface, verb, resource, namespace, name string) (bool, error) {
+	logging.RequireLoggerFromContext(ctx).
+		WithFields(logging.Fields{
+			"verb":      verb,
+			"resource":  resource,
+			"namespace": namespace,
+			"name":      name,
+		}).
+		Debug(ctx, "CanI named")
+	return canI(ctx, kubeclientset, []string{verb}, "argoproj.io", namespace, resource, name)
+}
+
 // CanI attempts to determine if a verb is actionable by a certain resource
 func CanI(ctx context.Context, kubeclientset kubernetes.Interface, verbs []string, group, namespace, resource string) (bool, error) {
+	return canI(ctx, kubeclientset, verbs, group, namespace, resource, "")
+}
+
+func canI(ctx context.Context, kubeclientset kubernetes.Interface, verbs []string, group, namespace, resource, name string) (bool, error) {
 	if len(verbs) == 0 {
 		return true, nil
 	}
@@ -43,6 +61,7 @@ func CanI(ctx context.Context, kubeclientset kubernetes.Interface, verbs []strin
 					Verb:      verb,
 					Group:     group,
 					Resource:  resource,
+					Name:      name,
 				},
 			},
 		}, metav1.CreateOptions{})
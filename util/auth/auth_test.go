@@ -35,3 +35,23 @@ func TestCanI(t *testing.T) {
 	require.NoError(t, err)
 	assert.False(t, notAllowed)
 }
+
+func TestCanIArgoNamed(t *testing.T) {
+	kubeClient := &kubefake.Clientset{}
+	kubeClient.AddReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (handled bool, ret runtime.Object, err error) {
+		attributes := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+		// only the named workflow may be deleted
+		allowed := attributes.Group == "argoproj.io" && attributes.Resource == "workflows" && attributes.Verb == "delete" && attributes.Name == "my-wf"
+		return true, &authorizationv1.SelfSubjectAccessReview{
+			Status: authorizationv1.SubjectAccessReviewStatus{Allowed: allowed},
+		}, nil
+	})
+
+	ctx := logging.TestContext(t.Context())
+	allowed, err := CanIArgoNamed(ctx, kubeClient, "delete", "workflows", "my-ns", "my-wf")
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	allowed, err = CanIArgo(ctx, kubeClient, "delete", "workflows", "my-ns", "my-wf")
+	require.NoError(t, err)
+	assert.False(t, allowed, "the name is not checked")
+}
@@ -100,6 +100,12 @@ func DecodeDecompressString(ctx context.Context, content string) (string, error)
 	return string(dBuf), nil
 }
 
+// DecodeDecompressReader returns a reader of the decompressed content of a compressed, base64 encoded string, so that
+// it can be read without holding all of it in memory
+func DecodeDecompressReader(content string) (io.ReadCloser, error) {
+	return GetGzipReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(content)))
+}
+
 // CompressContent will compress the byte array using zip writer
 func CompressContent(ctx context.Context, content []byte) []byte {
 	var buf bytes.Buffer
@@ -52,8 +52,23 @@ func PanicLoggerStreamServerInterceptor(log logging.Logger) grpc.StreamServerInt
 
 const (
 	ArgoVersionHeader = "argo-version"
+	// WarningHeader holds a warning that did not fail the request, one per value, as the Kubernetes API server's Warning header does
+	WarningHeader = "warning"
 )
 
+// SetWarningHeader returns the warnings to the client in warning headers. A failure to set the header, e.g. when the
+// server is called directly rather than over gRPC, is logged rather than failing the request.
+func SetWarningHeader(ctx context.Context, warnings ...string) {
+	if len(warnings) == 0 {
+		return
+	}
+	md := metadata.MD{}
+	md.Append(WarningHeader, warnings...)
+	if err := grpc.SetHeader(ctx, md); err != nil {
+		logging.RequireLoggerFromContext(ctx).WithError(err).WithField("header", WarningHeader).Warn(ctx, "Failed to set header")
+	}
+}
+
 var (
 	LastSeenServerVersion                  string
 	ErrorTranslationUnaryServerInterceptor = func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
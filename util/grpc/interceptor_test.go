@@ -32,6 +32,24 @@ func (mockServerTransportStream) SetTrailer(md metadata.MD) error { return nil }
 
 var _ grpc.ServerTransportStream = &mockServerTransportStream{}
 
+func TestSetWarningHeader(t *testing.T) {
+	t.Run("Warnings", func(t *testing.T) {
+		msts := &mockServerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(logging.TestContext(t.Context()), msts)
+		SetWarningHeader(ctx, "first", "second")
+		assert.Equal(t, metadata.Pairs(WarningHeader, "first", WarningHeader, "second"), msts.header)
+	})
+	t.Run("NoWarnings", func(t *testing.T) {
+		msts := &mockServerTransportStream{}
+		ctx := grpc.NewContextWithServerTransportStream(logging.TestContext(t.Context()), msts)
+		SetWarningHeader(ctx)
+		assert.Nil(t, msts.header)
+	})
+	t.Run("NoStream", func(t *testing.T) {
+		assert.NotPanics(t, func() { SetWarningHeader(logging.TestContext(t.Context()), "warning") })
+	})
+}
+
 func TestSetVersionHeaderUnaryServerInterceptor(t *testing.T) {
 	version := &wfv1.Version{Version: "v3.1.0"}
 	mockReturn := "successful return"
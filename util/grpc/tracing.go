@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"context"
+	"path"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+// metadataCarrier reads and writes trace context in gRPC metadata, which the gateway forwards HTTP headers as
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// namespaced is a request with a namespace, as most are
+type namespaced interface {
+	GetNamespace() string
+}
+
+// startServerSpan starts a span of the method, a child of the span of the W3C trace context in the incoming metadata, if any
+func startServerSpan(ctx context.Context, fullMethod string, req any) (context.Context, trace.Span) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = telemetry.TracePropagator.Extract(ctx, metadataCarrier(md))
+	}
+	namespace := ""
+	if r, ok := req.(namespaced); ok {
+		namespace = r.GetNamespace()
+	}
+	return telemetry.StartSpan(ctx, path.Base(fullMethod), namespace, trace.WithSpanKind(trace.SpanKindServer))
+}
+
+func endServerSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TracingUnaryServerInterceptor returns a new unary server interceptor that traces each request, continuing the trace of
+// the client if it sent W3C trace context
+func TracingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod, req)
+		defer func() { endServerSpan(span, err) }()
+		return handler(ctx, req)
+	}
+}
+
+// TracingStreamServerInterceptor returns a new streaming server interceptor that traces each stream, continuing the
+// trace of the client if it sent W3C trace context
+func TracingStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod, nil)
+		defer func() { endServerSpan(span, err) }()
+		return handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx, span: span})
+	}
+}
+
+// tracedServerStream records the namespace of the request on the span when it is received
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	span trace.Span
+}
+
+func (s *tracedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *tracedServerStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if r, ok := m.(namespaced); ok && err == nil && r.GetNamespace() != "" {
+		s.span.SetAttributes(attribute.String(telemetry.AttribSpanNamespace, r.GetNamespace()))
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
+)
+
+const (
+	parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanID  = "00f067aa0ba902b7"
+)
+
+type namespacedRequest struct{ namespace string }
+
+func (r *namespacedRequest) GetNamespace() string { return r.namespace }
+
+// recordSpans records the spans started until the test ends
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func incomingTraceContext(ctx context.Context) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("traceparent", "00-"+parentTraceID+"-"+parentSpanID+"-01"))
+}
+
+func TestTracingUnaryServerInterceptor(t *testing.T) {
+	recorder := recordSpans(t)
+	interceptor := TracingUnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/workflow.WorkflowService/CreateWorkflow"}
+
+	var handlerSpan trace.SpanContext
+	_, err := interceptor(incomingTraceContext(t.Context()), &namespacedRequest{namespace: "argo"}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		// spans started by the handler, e.g. around archive queries, are children of the request's span
+		_, span := telemetry.StartSpan(ctx, "ArchiveListWorkflows", "argo")
+		span.End()
+		handlerSpan = trace.SpanContextFromContext(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	child, server := spans[0], spans[1]
+	assert.Equal(t, "CreateWorkflow", server.Name())
+	assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+	assert.Equal(t, parentTraceID, server.SpanContext().TraceID().String())
+	assert.Equal(t, parentSpanID, server.Parent().SpanID().String())
+	assert.True(t, server.Parent().IsRemote())
+	assert.Contains(t, server.Attributes(), attribute.String(telemetry.AttribSpanOperation, "CreateWorkflow"))
+	assert.Contains(t, server.Attributes(), attribute.String(telemetry.AttribSpanNamespace, "argo"))
+	assert.Equal(t, server.SpanContext(), handlerSpan)
+	assert.Equal(t, "ArchiveListWorkflows", child.Name())
+	assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Equal(t, parentTraceID, child.SpanContext().TraceID().String())
+
+	t.Run("NoTraceContext", func(t *testing.T) {
+		recorder := recordSpans(t)
+		_, err := interceptor(t.Context(), "no namespace", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		})
+		require.Error(t, err)
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.False(t, spans[0].Parent().IsValid())
+		assert.Equal(t, otelcodes.Error, spans[0].Status().Code)
+		for _, kv := range spans[0].Attributes() {
+			assert.NotEqual(t, attribute.Key(telemetry.AttribSpanNamespace), kv.Key)
+		}
+	})
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context { return s.ctx }
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	m.(*namespacedRequest).namespace = "argo"
+	return nil
+}
+
+func TestTracingStreamServerInterceptor(t *testing.T) {
+	recorder := recordSpans(t)
+	interceptor := TracingStreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/workflow.WorkflowService/WatchWorkflows"}
+	stream := &fakeServerStream{ctx: incomingTraceContext(t.Context())}
+
+	err := interceptor(nil, stream, info, func(srv interface{}, ss grpc.ServerStream) error {
+		assert.True(t, trace.SpanContextFromContext(ss.Context()).IsValid())
+		return ss.RecvMsg(&namespacedRequest{})
+	})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "WatchWorkflows", spans[0].Name())
+	assert.Equal(t, parentSpanID, spans[0].Parent().SpanID().String())
+	assert.Contains(t, spans[0].Attributes(), attribute.String(telemetry.AttribSpanNamespace, "argo"))
+}
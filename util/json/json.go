@@ -3,6 +3,7 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io"
 
 	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
@@ -36,6 +37,33 @@ func (j *JSONMarshaler) Unmarshal(data []byte, v interface{}) error {
 	return json.Unmarshal(data, v)
 }
 
+// SSEMarshaler is a grpc-gateway Marshaler which writes streamed responses as Server-Sent Events, with each message
+// in a single `data:` field. Requests are decoded as JSON.
+type SSEMarshaler struct {
+	JSONMarshaler
+}
+
+var _ gwruntime.Delimited = &SSEMarshaler{}
+
+// ContentType implements gwruntime.Marshaler.
+func (s *SSEMarshaler) ContentType() string {
+	return "text/event-stream"
+}
+
+// Marshal implements gwruntime.Marshaler. Encoded JSON never contains a newline, so it always fits in one field.
+func (s *SSEMarshaler) Marshal(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte("data: "), data...), nil
+}
+
+// Delimiter implements gwruntime.Delimited, ending each event with a blank line.
+func (s *SSEMarshaler) Delimiter() []byte {
+	return []byte("\n\n")
+}
+
 // DisallowUnknownFields configures the JSON decoder to error out if unknown
 // fields come along, instead of dropping them by default.
 func DisallowUnknownFields(d *json.Decoder) *json.Decoder {
@@ -65,3 +93,32 @@ func IsJSON(j []byte) bool {
 	var js json.RawMessage
 	return json.Unmarshal(j, &js) == nil
 }
+
+// CountKeys returns the number of keys of the JSON object read from r, without unmarshalling their values. null has no
+// keys.
+func CountKeys(r io.Reader) (int, error) {
+	d := json.NewDecoder(r)
+	token, err := d.Token()
+	if err != nil {
+		return 0, err
+	}
+	if token == nil {
+		return 0, nil
+	}
+	if token != json.Delim('{') {
+		return 0, fmt.Errorf("expected a JSON object, got %v", token)
+	}
+	count := 0
+	for d.More() {
+		if _, err := d.Token(); err != nil {
+			return 0, err
+		}
+		var value json.RawMessage
+		if err := d.Decode(&value); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	_, err = d.Token()
+	return count, err
+}
@@ -1,10 +1,17 @@
 package json
 
 import (
+	"io"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // TestDisallowUnknownFields tests ability to disallow unknown fields
@@ -42,3 +49,54 @@ func TestIsJSON(t *testing.T) {
 	assert.False(t, IsJSON([]byte(`foo`)))
 	assert.False(t, IsJSON([]byte(`foo: bar`)))
 }
+
+func TestCountKeys(t *testing.T) {
+	for _, tt := range []struct {
+		json  string
+		count int
+	}{
+		{`null`, 0},
+		{`{}`, 0},
+		{`{"a": {"b": "c", "d": [1, 2]}, "e": null}`, 2},
+	} {
+		count, err := CountKeys(strings.NewReader(tt.json))
+		require.NoError(t, err, tt.json)
+		assert.Equal(t, tt.count, count, tt.json)
+	}
+	for _, invalid := range []string{`[]`, `"a"`, `{"a": `} {
+		_, err := CountKeys(strings.NewReader(invalid))
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestSSEMarshaler(t *testing.T) {
+	events := []*corev1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Name: "my-event"}, Message: "line 1\nline 2"},
+		{ObjectMeta: metav1.ObjectMeta{Name: "my-other-event"}},
+	}
+	mux := gwruntime.NewServeMux()
+	ctx := gwruntime.NewServerMetadataContext(t.Context(), gwruntime.ServerMetadata{})
+	w := httptest.NewRecorder()
+	recv := func() (proto.Message, error) {
+		if len(events) == 0 {
+			return nil, io.EOF
+		}
+		event := events[0]
+		events = events[1:]
+		return event, nil
+	}
+	gwruntime.ForwardResponseStream(ctx, mux, new(SSEMarshaler), w, httptest.NewRequest("GET", "/", nil), recv)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	frames := strings.Split(w.Body.String(), "\n\n")
+	require.Len(t, frames, 3)
+	assert.Empty(t, frames[2])
+	for i, name := range []string{"my-event", "my-other-event"} {
+		require.True(t, strings.HasPrefix(frames[i], "data: "))
+		assert.NotContains(t, frames[i], "\n")
+		var x struct {
+			Result corev1.Event `json:"result"`
+		}
+		require.NoError(t, Unmarshal([]byte(strings.TrimPrefix(frames[i], "data: ")), &x))
+		assert.Equal(t, name, x.Result.Name)
+	}
+}
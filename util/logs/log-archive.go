@@ -0,0 +1,139 @@
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// ArchiveManifestFile is the name of the file in a log archive that lists where each log file came from.
+const ArchiveManifestFile = "manifest.json"
+
+// ArchiveManifestEntry describes a single log file of a log archive.
+type ArchiveManifestEntry struct {
+	File      string `json:"file"`
+	NodeID    string `json:"nodeId,omitempty"`
+	NodeName  string `json:"nodeName,omitempty"`
+	PodName   string `json:"podName"`
+	Container string `json:"container"`
+	// why the logs could not be got, in which case the file is empty
+	Error string `json:"error,omitempty"`
+}
+
+// WorkflowLogArchive writes a tar.gz of the logs of the workflow's pods to w. Each container of each pod has its own
+// file, named "<node ID>/<container>.log", and ArchiveManifestFile lists the node, pod and container of every file.
+// If nodeID is not empty, only the logs of that node's pod are archived.
+func WorkflowLogArchive(ctx context.Context, kubeClient kubernetes.Interface, namespace, workflowName, nodeID string, w io.Writer) error {
+	podInterface := kubeClient.CoreV1().Pods(namespace)
+	list, err := podInterface.List(ctx, metav1.ListOptions{LabelSelector: common.LabelKeyWorkflow + "=" + workflowName})
+	if err != nil {
+		return err
+	}
+	var pods []corev1.Pod
+	for _, pod := range list.Items {
+		if nodeID == "" || pod.Annotations[common.AnnotationKeyNodeID] == nodeID {
+			pods = append(pods, pod)
+		}
+	}
+	if nodeID != "" && len(pods) == 0 {
+		return apierr.NewNotFound(corev1.Resource("pods"), nodeID)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	// a tar header needs the size of the file, so each container's logs are streamed to a spool file first, rather than
+	// read into memory, as they can be far larger than the server's memory
+	spool, err := os.CreateTemp("", "log-archive.")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = spool.Close()
+		_ = os.Remove(spool.Name())
+	}()
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifest := make([]ArchiveManifestEntry, 0)
+	for _, pod := range pods {
+		dir := pod.Annotations[common.AnnotationKeyNodeID]
+		if dir == "" {
+			// pods created before node IDs were annotated
+			dir = pod.Name
+		}
+		for _, c := range slices.Concat(pod.Spec.InitContainers, pod.Spec.Containers) {
+			entry := ArchiveManifestEntry{
+				File:      path.Join(dir, c.Name+".log"),
+				NodeID:    pod.Annotations[common.AnnotationKeyNodeID],
+				NodeName:  pod.Annotations[common.AnnotationKeyNodeName],
+				PodName:   pod.Name,
+				Container: c.Name,
+			}
+			// a container that has not started has no logs, which should not fail the whole archive
+			size, err := spoolLogs(ctx, podInterface, pod.Name, c.Name, spool)
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			if err := tw.WriteHeader(archiveFileHeader(entry.File, size)); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, io.NewSectionReader(spool, 0, size)); err != nil {
+				return err
+			}
+			manifest = append(manifest, entry)
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveFile(tw, ArchiveManifestFile, data); err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// spoolLogs streams the logs of the container to the spool file, replacing what it held, and returns their size. The
+// logs streamed before any error are kept.
+func spoolLogs(ctx context.Context, podInterface corev1client.PodInterface, podName, container string, spool *os.File) (int64, error) {
+	if err := spool.Truncate(0); err != nil {
+		return 0, err
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	stream, err := podInterface.GetLogs(podName, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = stream.Close() }()
+	return io.Copy(spool, stream)
+}
+
+func archiveFileHeader(name string, size int64) *tar.Header {
+	return &tar.Header{Name: name, Mode: 0o644, Size: size, Typeflag: tar.TypeReg}
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	if err := tw.WriteHeader(archiveFileHeader(name, int64(len(data)))); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
@@ -0,0 +1,68 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultLevelKey is the key of the level in JSON log lines, if none is requested
+const DefaultLevelKey = "level"
+
+// logLevels orders the names of log levels, from least to most severe
+var logLevels = map[string]int{
+	"trace":    0,
+	"debug":    1,
+	"info":     2,
+	"warn":     3,
+	"warning":  3,
+	"error":    4,
+	"fatal":    5,
+	"panic":    5,
+	"critical": 5,
+}
+
+// ValidateLevel returns an error if the level is neither empty nor a known log level
+func ValidateLevel(level string) error {
+	if _, ok := logLevels[strings.ToLower(level)]; level != "" && !ok {
+		return fmt.Errorf("unknown log level %q, must be one of trace, debug, info, warn, error or fatal", level)
+	}
+	return nil
+}
+
+// levelFilter matches the log lines whose level is at least the minimum level. Only JSON objects with a known level
+// can be filtered, so any other line, such as plain text, is matched.
+type levelFilter struct {
+	minLevel int
+	key      string
+}
+
+// newLevelFilter returns a filter for the minimum level, nil if there is no minimum level
+func newLevelFilter(minLevel, key string) (*levelFilter, error) {
+	if minLevel == "" {
+		return nil, nil
+	}
+	if err := ValidateLevel(minLevel); err != nil {
+		return nil, err
+	}
+	if key == "" {
+		key = DefaultLevelKey
+	}
+	return &levelFilter{minLevel: logLevels[strings.ToLower(minLevel)], key: key}, nil
+}
+
+func (f *levelFilter) matches(content string) bool {
+	if f == nil || !strings.HasPrefix(strings.TrimSpace(content), "{") {
+		return true
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(content), &fields); err != nil {
+		return true
+	}
+	level, ok := fields[f.key].(string)
+	if !ok {
+		return true
+	}
+	severity, ok := logLevels[strings.ToLower(level)]
+	return !ok || severity >= f.minLevel
+}
@@ -0,0 +1,45 @@
+package logs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevelFilter(t *testing.T) {
+	t.Run("None", func(t *testing.T) {
+		f, err := newLevelFilter("", "")
+		require.NoError(t, err)
+		assert.True(t, f.matches(`{"level":"debug"}`))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := newLevelFilter("loud", "")
+		require.EqualError(t, err, `unknown log level "loud", must be one of trace, debug, info, warn, error or fatal`)
+	})
+	t.Run("Mixed", func(t *testing.T) {
+		f, err := newLevelFilter("WARN", "")
+		require.NoError(t, err)
+		for content, matched := range map[string]bool{
+			`{"level":"debug","msg":"starting"}`:  false,
+			`{"level":"info","msg":"started"}`:    false,
+			`{"level":"warning","msg":"slow"}`:    true,
+			`{"level":"ERROR","msg":"failed"}`:    true,
+			`{"level":"fatal","msg":"exiting"}`:   true,
+			`{"msg":"no level"}`:                  true,
+			`{"level":"verbose","msg":"unknown"}`: true,
+			`{"level":30}`:                        true,
+			`{"level":"debug"`:                    true,
+			`plain text debug line`:               true,
+			``:                                    true,
+		} {
+			assert.Equal(t, matched, f.matches(content), content)
+		}
+	})
+	t.Run("Key", func(t *testing.T) {
+		f, err := newLevelFilter("error", "severity")
+		require.NoError(t, err)
+		assert.False(t, f.matches(`{"severity":"info","level":"error"}`))
+		assert.True(t, f.matches(`{"severity":"error","level":"info"}`))
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -35,6 +36,8 @@ type request interface {
 	GetLogOptions() *corev1.PodLogOptions
 	GetGrep() string
 	GetSelector() string
+	GetMinLevel() string
+	GetLevelKey() string
 }
 
 type sender interface {
@@ -44,6 +47,41 @@ type sender interface {
 const maxTokenLength = 1024 * 1024
 const startBufSize = 16 * 1024
 
+// completedWorkflowDrainTimeout is how long the log streams of pods still running once the workflow has completed can
+// go without a line before they are stopped. A stream can otherwise follow a pod that outlives its workflow forever.
+// The streams of pods that have terminated are not stopped, they end once drained.
+var completedWorkflowDrainTimeout = 10 * time.Second
+
+// podStream is the logs stream of a pod
+type podStream struct {
+	cancel context.CancelFunc
+	// done is closed when the stream has ended
+	done chan struct{}
+	// terminated is whether the pod was last seen succeeded or failed
+	terminated bool
+	// lastRead is when a line was last read from the stream, in Unix nanoseconds
+	lastRead atomic.Int64
+}
+
+// stopWhenIdle stops the stream once no line has been read from it for the timeout
+func (s *podStream) stopWhenIdle(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-timer.C:
+			idle := time.Since(time.Unix(0, s.lastRead.Load()))
+			if idle >= timeout {
+				s.cancel()
+				return
+			}
+			timer.Reset(timeout - idle)
+		}
+	}
+}
+
 func scanLinesOrGiveLong(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	advance, token, err = bufio.ScanLines(data, atEOF)
 	if advance > 0 || token != nil || err != nil {
@@ -62,9 +100,11 @@ func scanLinesOrGiveLong(data []byte, atEOF bool) (advance int, token []byte, er
 	return maxTokenLength, data[0:maxTokenLength], nil
 }
 
-func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, req request, sender sender) error {
+// WorkflowLogs streams the logs of the workflow's pods to the sender. If podFilter is not nil, only the logs of the pods
+// it returns true for are streamed.
+func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient kubernetes.Interface, req request, podFilter func(pod *corev1.Pod) bool, sender sender) error {
 	wfInterface := wfClient.ArgoprojV1alpha1().Workflows(req.GetNamespace())
-	_, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
+	wf, err := wfInterface.Get(ctx, req.GetName(), metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -74,6 +114,11 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		return fmt.Errorf("failed to compile %q: %w", req.GetGrep(), err)
 	}
 
+	levels, err := newLevelFilter(req.GetMinLevel(), req.GetLevelKey())
+	if err != nil {
+		return err
+	}
+
 	podInterface := kubeClient.CoreV1().Pods(req.GetNamespace())
 
 	ctx, logger := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"workflow": req.GetName(), "namespace": req.GetNamespace()}).InContext(ctx)
@@ -99,8 +144,10 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 
 	// Keep a track of those we are logging, we also have a mutex to guard reads. Even if we stop streaming, we
 	// keep a marker here so we don't start again.
-	streamedPods := make(map[types.UID]bool)
+	streamedPods := make(map[types.UID]*podStream)
 	var streamedPodsGuard sync.Mutex
+	// draining is whether the workflow has completed, guarded by streamedPodsGuard
+	draining := false
 	var wg sync.WaitGroup
 	// A non-blocking channel for log entries to go down.
 	unsortedEntries := make(chan logEntry, 128)
@@ -116,18 +163,34 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 
 	// this func start a stream if one is not already running
 	ensureWeAreStreaming := func(pod *corev1.Pod) {
+		if podFilter != nil && !podFilter(pod) {
+			return
+		}
 		streamedPodsGuard.Lock()
 		defer streamedPodsGuard.Unlock()
 		ctx, logger := logger.WithField("podName", pod.GetName()).InContext(ctx)
-		logger.WithFields(logging.Fields{"podPhase": pod.Status.Phase, "alreadyStreaming": streamedPods[pod.UID]}).Debug(ctx, "Ensuring pod logs stream")
-		if pod.Status.Phase != corev1.PodPending && !streamedPods[pod.UID] {
-			streamedPods[pod.UID] = true
+		terminated := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+		if s, ok := streamedPods[pod.UID]; ok {
+			s.terminated = terminated
+		}
+		logger.WithFields(logging.Fields{"podPhase": pod.Status.Phase, "alreadyStreaming": streamedPods[pod.UID] != nil}).Debug(ctx, "Ensuring pod logs stream")
+		if pod.Status.Phase != corev1.PodPending && streamedPods[pod.UID] == nil {
+			// each stream is stopped separately, so that streams can be given a while to drain once the workflow completes
+			streamCtx, cancel := context.WithCancel(ctx)
+			s := &podStream{cancel: cancel, done: make(chan struct{}), terminated: terminated}
+			s.lastRead.Store(time.Now().UnixNano())
+			streamedPods[pod.UID] = s
+			if draining && !terminated {
+				go s.stopWhenIdle(completedWorkflowDrainTimeout)
+			}
 			wg.Add(1)
 			go func(podName string) {
 				defer wg.Done()
+				defer close(s.done)
+				defer cancel()
 				logger.Debug(ctx, "Streaming pod logs")
 				defer logger.Debug(ctx, "Pod logs stream done")
-				stream, err := podInterface.GetLogs(podName, &podLogStreamOptions).Stream(ctx)
+				stream, err := podInterface.GetLogs(podName, &podLogStreamOptions).Stream(streamCtx)
 				if err != nil {
 					logger.WithError(err).Error(ctx, "Failed to get pod logs")
 					return
@@ -143,8 +206,9 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 				//avoid bufio.ErrTooLong error when encounters a very very long line
 				scanner.Split(scanLinesOrGiveLong)
 				for scanner.Scan() {
+					s.lastRead.Store(time.Now().UnixNano())
 					select {
-					case <-ctx.Done():
+					case <-streamCtx.Done():
 						return
 					default:
 						line := scanner.Text()
@@ -162,6 +226,10 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 							timestamp = time.Now()
 							content = line
 						}
+						// the level is read before any timestamp is prepended, which would make the line invalid JSON
+						if !levels.matches(content) {
+							continue
+						}
 						// You might ask - why don't we let the client do this? Well, it is because
 						// this is the same as how this works for `kubectl logs`
 						if req.GetLogOptions().Timestamps {
@@ -210,6 +278,19 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 		defer wfWatch.Stop()
 		// We never send anything on this channel apart from closing it to indicate we should stop waiting for new pods.
 		stopWatchingPods := make(chan struct{})
+		// Once the workflow is completed or deleted, no new pods will be started. The log streams of terminated pods end
+		// once drained, and those of pods still running are stopped once idle.
+		drainAndStopStreams := func() {
+			logger.WithField("timeout", completedWorkflowDrainTimeout).Debug(ctx, "Draining pod logs streams")
+			streamedPodsGuard.Lock()
+			defer streamedPodsGuard.Unlock()
+			draining = true
+			for _, s := range streamedPods {
+				if !s.terminated {
+					go s.stopWhenIdle(completedWorkflowDrainTimeout)
+				}
+			}
+		}
 		// The purpose of this watch is to make sure we do not exit until the workflow is completed or deleted.
 		// When that happens, it signals we are done by closing the stop channel.
 		wg.Add(1)
@@ -217,6 +298,10 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 			defer close(stopWatchingPods)
 			defer wg.Done()
 			defer logger.Debug(ctx, "Done watching workflow events")
+			if wf.Status.Fulfilled() {
+				drainAndStopStreams()
+				return
+			}
 			logger.Debug(ctx, "Watching for workflow events")
 			for {
 				select {
@@ -241,6 +326,7 @@ func WorkflowLogs(ctx context.Context, wfClient versioned.Interface, kubeClient
 					}
 					logger.WithFields(logging.Fields{"eventType": event.Type, "completed": wf.Status.Fulfilled()}).Debug(ctx, "Workflow event")
 					if event.Type == watch.Deleted || wf.Status.Fulfilled() {
+						drainAndStopStreams()
 						return
 					}
 				}
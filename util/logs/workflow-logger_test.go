@@ -0,0 +1,188 @@
+package logs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	workflowpkg "github.com/argoproj/argo-workflows/v3/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/workflow/common"
+)
+
+// followingKubeClient returns pod logs streams that, like a follow of a pod that never exits, only end when the
+// request is cancelled. The streams write "fake logs", then the lines, each after the interval, and end once written
+// if eof.
+type followingKubeClient struct {
+	*fake.Clientset
+	lines    int
+	interval time.Duration
+	eof      bool
+}
+
+func (c followingKubeClient) CoreV1() corev1client.CoreV1Interface {
+	return followingCoreV1{c.Clientset.CoreV1(), c}
+}
+
+type followingCoreV1 struct {
+	corev1client.CoreV1Interface
+	c followingKubeClient
+}
+
+func (c followingCoreV1) Pods(namespace string) corev1client.PodInterface {
+	return followingPods{c.CoreV1Interface.Pods(namespace), c.c}
+}
+
+type followingPods struct {
+	corev1client.PodInterface
+	c followingKubeClient
+}
+
+func (p followingPods) GetLogs(string, *corev1.PodLogOptions) *restclient.Request {
+	client := &fakerest.RESTClient{
+		Client: fakerest.CreateHTTPClient(func(request *http.Request) (*http.Response, error) {
+			r, w := io.Pipe()
+			go func() {
+				_, _ = w.Write([]byte("fake logs\n"))
+				for range p.c.lines {
+					time.Sleep(p.c.interval)
+					_, _ = w.Write([]byte("more fake logs\n"))
+				}
+				if p.c.eof {
+					_ = w.Close()
+					return
+				}
+				<-request.Context().Done()
+				_ = w.CloseWithError(request.Context().Err())
+			}()
+			return &http.Response{StatusCode: http.StatusOK, Body: r}, nil
+		}),
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+	}
+	return client.Request()
+}
+
+type testSender struct {
+	mu      sync.Mutex
+	entries []*workflowpkg.LogEntry
+}
+
+func (s *testSender) Send(entry *workflowpkg.LogEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func TestWorkflowLogsFollowCompletedWorkflow(t *testing.T) {
+	completedWorkflowDrainTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { completedWorkflowDrainTimeout = 10 * time.Second })
+
+	newClients := func(phase wfv1.WorkflowPhase, podPhase corev1.PodPhase) (*wffake.Clientset, followingKubeClient) {
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+			Status:     wfv1.WorkflowStatus{Phase: phase},
+		}
+		kubeClient := followingKubeClient{Clientset: fake.NewSimpleClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf-pod", Namespace: "my-ns", UID: "my-wf-pod-uid", Labels: map[string]string{common.LabelKeyWorkflow: "my-wf"}},
+			Status:     corev1.PodStatus{Phase: podPhase},
+		})}
+		return wffake.NewSimpleClientset(wf), kubeClient
+	}
+	req := &workflowpkg.WorkflowLogRequest{Name: "my-wf", Namespace: "my-ns", LogOptions: &corev1.PodLogOptions{Follow: true}}
+	// logs returns a channel that receives the result of following the workflow's logs
+	logs := func(ctx context.Context, wfClient *wffake.Clientset, kubeClient followingKubeClient, s *testSender) chan error {
+		done := make(chan error, 1)
+		go func() { done <- WorkflowLogs(ctx, wfClient, kubeClient, req, nil, s) }()
+		return done
+	}
+
+	t.Run("Completes", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		wfClient, kubeClient := newClients(wfv1.WorkflowRunning, corev1.PodRunning)
+		wfWatch := watch.NewFake()
+		wfClient.PrependWatchReactor("workflows", func(k8stesting.Action) (bool, watch.Interface, error) {
+			return true, wfWatch, nil
+		})
+		s := &testSender{}
+		done := logs(ctx, wfClient, kubeClient, s)
+		select {
+		case err := <-done:
+			t.Fatalf("logs stream ended before the workflow completed: %v", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+		// this blocks until the event is received
+		wfWatch.Modify(&wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"},
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded},
+		})
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("logs stream did not end after the workflow completed")
+		}
+		require.Len(t, s.entries, 1)
+		assert.Equal(t, "fake logs", s.entries[0].Content)
+	})
+	t.Run("AlreadyCompleted", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		wfClient, kubeClient := newClients(wfv1.WorkflowFailed, corev1.PodRunning)
+		s := &testSender{}
+		select {
+		case err := <-logs(ctx, wfClient, kubeClient, s):
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("logs stream did not end for a completed workflow")
+		}
+		require.Len(t, s.entries, 1)
+		assert.Equal(t, "fake logs", s.entries[0].Content)
+	})	// the logs of a pod still running are streamed for as long as lines keep coming
+	t.Run("StillWriting", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		wfClient, kubeClient := newClients(wfv1.WorkflowSucceeded, corev1.PodRunning)
+		kubeClient.lines = 5
+		kubeClient.interval = 50 * time.Millisecond
+		s := &testSender{}
+		select {
+		case err := <-logs(ctx, wfClient, kubeClient, s):
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("logs stream did not end once idle")
+		}
+		assert.Len(t, s.entries, 6)
+	})
+	// the logs of a terminated pod are streamed until they end, however long that takes
+	t.Run("Terminated", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		wfClient, kubeClient := newClients(wfv1.WorkflowSucceeded, corev1.PodSucceeded)
+		kubeClient.lines = 1
+		kubeClient.interval = 300 * time.Millisecond
+		kubeClient.eof = true
+		s := &testSender{}
+		select {
+		case err := <-logs(ctx, wfClient, kubeClient, s):
+			require.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("logs stream did not end once drained")
+		}
+		assert.Len(t, s.entries, 2)
+	})
+}
@@ -2,6 +2,7 @@ package sqldb
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
@@ -122,3 +123,11 @@ func ConfigureDBSession(session db.Session, dbPool *config.ConnectionPool) db.Se
 	}
 	return session
 }
+
+// Ping checks the database can be reached, honouring the context deadline where the driver supports it
+func Ping(ctx context.Context, session db.Session) error {
+	if driver, ok := session.Driver().(*sql.DB); ok {
+		return driver.PingContext(ctx)
+	}
+	return session.Ping()
+}
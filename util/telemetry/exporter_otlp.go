@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// OTLPConfig configures pushing metrics to an OpenTelemetry collector. Anything not configured here is taken from the
+// standard OTEL_EXPORTER_OTLP_* environment variables.
+type OTLPConfig struct {
+	// Endpoint is the host and port of the collector's OTLP gRPC receiver, e.g. otel-collector:4317
+	Endpoint string
+	// Interval between pushes, defaulting to 60s
+	Interval time.Duration
+	// Headers are sent with every push, e.g. for authentication
+	Headers map[string]string
+	// Insecure pushes without TLS
+	Insecure bool
+}
+
+// otlpMetricsReader returns a reader that periodically pushes the metrics to an OpenTelemetry collector
+func (config *Config) otlpMetricsReader(ctx context.Context) (metricsdk.Reader, error) {
+	opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithTemporalitySelector(config.Temporality)}
+	if config.OTLP.Endpoint != "" {
+		opts = append(opts, otlpmetricgrpc.WithEndpoint(config.OTLP.Endpoint))
+	}
+	if config.OTLP.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+	if len(config.OTLP.Headers) > 0 {
+		opts = append(opts, otlpmetricgrpc.WithHeaders(config.OTLP.Headers))
+	}
+	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var readerOpts []metricsdk.PeriodicReaderOption
+	if config.OTLP.Interval > 0 {
+		readerOpts = append(readerOpts, metricsdk.WithInterval(config.OTLP.Interval))
+	}
+	return metricsdk.NewPeriodicReader(exporter, readerOpts...), nil
+}
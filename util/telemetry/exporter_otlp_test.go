@@ -0,0 +1,87 @@
+//go:build !windows
+
+package telemetry
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+// stubOTLPReceiver records the metrics pushed to it
+type stubOTLPReceiver struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	mutex   sync.Mutex
+	names   map[string]bool
+	headers metadata.MD
+}
+
+func (r *stubOTLPReceiver) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.headers, _ = metadata.FromIncomingContext(ctx)
+	for _, resourceMetrics := range req.GetResourceMetrics() {
+		for _, scopeMetrics := range resourceMetrics.GetScopeMetrics() {
+			for _, m := range scopeMetrics.GetMetrics() {
+				r.names[m.GetName()] = true
+			}
+		}
+	}
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func (r *stubOTLPReceiver) received(name string) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.names[name]
+}
+
+func TestOTLPExporter(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	receiver := &stubOTLPReceiver{names: map[string]bool{}}
+	server := grpc.NewServer()
+	colmetricspb.RegisterMetricsServiceServer(server, receiver)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	config := Config{
+		Enabled: true,
+		// a registry of its own, so that this exporter does not collide with other tests' in the default registry
+		ExcludeGoCollectors: true,
+		OTLP: OTLPConfig{
+			Endpoint: listener.Addr().String(),
+			Interval: 100 * time.Millisecond,
+			Headers:  map[string]string{"x-api-key": "my-key"},
+			Insecure: true,
+		},
+	}
+	ctx := logging.TestContext(t.Context())
+	m, err := NewMetrics(ctx, testScopeName, testScopeName, &config)
+	require.NoError(t, err)
+	require.NoError(t, AddVersion(ctx, m))
+
+	require.Eventually(t, func() bool { return receiver.received(InstrumentVersion.Name()) }, 10*time.Second, 100*time.Millisecond)
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	assert.Equal(t, []string{"my-key"}, receiver.headers.Get("x-api-key"))
+
+	// the prometheus exporter is unaffected
+	families, err := m.gatherer.Gather()
+	require.NoError(t, err)
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, testScopeName+"_"+InstrumentVersion.Name())
+}
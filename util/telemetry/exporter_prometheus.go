@@ -24,17 +24,35 @@ const (
 	DefaultPrometheusServerPath = "/metrics"
 )
 
-func (config *Config) prometheusMetricsExporter(namespace string) (*prometheus.Exporter, error) {
+// prometheusMetricsExporter returns the exporter, and the gatherer to serve its metrics from
+func (config *Config) prometheusMetricsExporter(namespace string) (*prometheus.Exporter, promgo.Gatherer, error) {
 	// Use an exporter that mimics the legacy prometheus exporter
 	// We cannot namespace here, because custom metrics are not namespaced
 	// in the legacy version, so they cannot be here
-	return prometheus.New(
+	opts := []prometheus.Option{
 		prometheus.WithNamespace(namespace),
 		prometheus.WithoutCounterSuffixes(),
 		prometheus.WithoutUnits(),
 		prometheus.WithoutScopeInfo(),
 		prometheus.WithoutTargetInfo(),
-	)
+	}
+	gatherer := promgo.DefaultGatherer
+	if config.ExcludeGoCollectors {
+		// unlike the default registry, a new registry has no Go runtime (go_*) or process (process_*) collectors
+		registry := promgo.NewRegistry()
+		opts = append(opts, prometheus.WithRegisterer(registry))
+		gatherer = registry
+	}
+	exporter, err := prometheus.New(opts...)
+	return exporter, gatherer, err
+}
+
+// prometheusGatherer returns the gatherer of the prometheus metrics
+func (m *Metrics) prometheusGatherer() promgo.Gatherer {
+	if m.gatherer == nil {
+		return promgo.DefaultGatherer
+	}
+	return m.gatherer
 }
 
 func (config *Config) path() string {
@@ -51,8 +69,27 @@ func (config *Config) port() int {
 	return config.Port
 }
 
+// tlsMinVersion returns the minimum TLS version from the config, else the TLS_MIN_VERSION environment variable,
+// defaulting to TLS 1.2
+func (config *Config) tlsMinVersion() (uint16, error) {
+	version := int(config.TLSMinVersion)
+	if version == 0 {
+		var err error
+		version, err = env.GetInt("TLS_MIN_VERSION", tls.VersionTLS12)
+		if err != nil {
+			return 0, err
+		}
+	}
+	switch version {
+	case tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+		return uint16(version), nil
+	}
+	return 0, fmt.Errorf("unknown TLS min version %d, must be one of %d (v1.0), %d (v1.1), %d (v1.2) or %d (v1.3)", version, tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13)
+}
+
 // RunPrometheusServer starts a prometheus metrics server
-// If 'isDummy' is set to true, the dummy metrics server will be started. If it's false, the prometheus metrics server will be started
+// If 'isDummy' is set to true, the dummy metrics server will be started. If it's false, the prometheus metrics server will be started,
+// along with the text file writer if configured
 func (m *Metrics) RunPrometheusServer(ctx context.Context, isDummy bool) {
 	if !m.config.Enabled {
 		return
@@ -75,17 +112,18 @@ func (m *Metrics) RunPrometheusServer(ctx context.Context, isDummy bool) {
 			handlerOpts.ErrorHandling = promhttp.ContinueOnError
 		}
 		name = "prometheus metrics server"
-		mux.Handle(m.config.path(), promhttp.HandlerFor(promgo.DefaultGatherer, handlerOpts))
+		mux.Handle(m.config.path(), promhttp.HandlerFor(m.prometheusGatherer(), handlerOpts))
+		go m.runTextFileWriter(ctx)
 	}
 	srv := &http.Server{Addr: fmt.Sprintf(":%v", m.config.port()), Handler: mux}
 
 	if m.config.Secure {
-		tlsMinVersion, err := env.GetInt("TLS_MIN_VERSION", tls.VersionTLS12)
+		tlsMinVersion, err := m.config.tlsMinVersion()
 		if err != nil {
 			panic(err)
 		}
 		logger.Info(ctx, "Generating Self Signed TLS Certificates for Telemetry Servers")
-		tlsConfig, err := tlsutils.GenerateX509KeyPairTLSConfig(uint16(tlsMinVersion))
+		tlsConfig, err := tlsutils.GenerateX509KeyPairTLSConfig(tlsMinVersion)
 		if err != nil {
 			panic(err)
 		}
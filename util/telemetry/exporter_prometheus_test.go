@@ -4,6 +4,7 @@ package telemetry
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -106,3 +107,78 @@ func TestDummyPrometheusServer(t *testing.T) {
 	cancel() // cancel and wait for server shutdown to prevent port conflicts with subsequent tests
 	wg.Wait()
 }
+
+func TestTLSMinVersion(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		version, err := (&Config{}).tlsMinVersion()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS12), version)
+	})
+	t.Run("Env", func(t *testing.T) {
+		t.Setenv("TLS_MIN_VERSION", "772")
+		version, err := (&Config{}).tlsMinVersion()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS13), version)
+	})
+	t.Run("Config", func(t *testing.T) {
+		t.Setenv("TLS_MIN_VERSION", "772")
+		version, err := (&Config{TLSMinVersion: tls.VersionTLS11}).tlsMinVersion()
+		require.NoError(t, err)
+		assert.Equal(t, uint16(tls.VersionTLS11), version)
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := (&Config{TLSMinVersion: 12}).tlsMinVersion()
+		require.ErrorContains(t, err, "unknown TLS min version 12")
+	})
+	t.Run("InvalidEnv", func(t *testing.T) {
+		t.Setenv("TLS_MIN_VERSION", "1.2")
+		_, err := (&Config{}).tlsMinVersion()
+		require.Error(t, err)
+	})
+}
+
+func TestPrometheusServerExcludeGoCollectors(t *testing.T) {
+	scrape := func(t *testing.T, excludeGoCollectors bool) string {
+		var wg sync.WaitGroup
+		config := Config{
+			Enabled:             true,
+			Path:                DefaultPrometheusServerPath,
+			Port:                DefaultPrometheusServerPort,
+			ExcludeGoCollectors: excludeGoCollectors,
+			// earlier tests leave their exporters registered with the default registry
+			IgnoreErrors: true,
+		}
+		ctx, cancel := context.WithCancel(logging.TestContext(t.Context()))
+		defer cancel()
+		m, err := NewMetrics(ctx, testScopeName, testScopeName, &config)
+		require.NoError(t, err)
+		require.NoError(t, AddVersion(ctx, m))
+		wg.Add(1)
+		go func() {
+			m.RunPrometheusServer(ctx, false)
+			wg.Done()
+		}()
+		time.Sleep(1 * time.Second)
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d%s", DefaultPrometheusServerPort, DefaultPrometheusServerPath))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		bodyBytes, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		cancel() // cancel and wait for server shutdown to prevent port conflicts with subsequent tests
+		wg.Wait()
+		return string(bodyBytes)
+	}
+	t.Run("Included", func(t *testing.T) {
+		body := scrape(t, false)
+		assert.Regexp(t, `(?m)^go_gc_duration_seconds`, body)
+		assert.Regexp(t, `(?m)^process_`, body)
+	})
+	t.Run("Excluded", func(t *testing.T) {
+		body := scrape(t, true)
+		// the exporter's own metrics are prefixed, so these are only the default collectors
+		assert.NotRegexp(t, `(?m)^go_gc_`, body)
+		assert.NotRegexp(t, `(?m)^process_`, body)
+		assert.Contains(t, body, "argo_workflows_test_version")
+	})
+}
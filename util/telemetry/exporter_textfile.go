@@ -0,0 +1,76 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+const DefaultTextFileInterval = time.Minute
+
+// TextFileConfig configures writing the Prometheus metrics to a file, for scraping by the textfile collector of a
+// node_exporter where the metrics cannot be pulled from the server
+type TextFileConfig struct {
+	// Path of the file, not written if empty
+	Path string
+	// Interval between writes, DefaultTextFileInterval if zero
+	Interval time.Duration
+}
+
+func (config *Config) textFileInterval() time.Duration {
+	if config.TextFile.Interval == 0 {
+		return DefaultTextFileInterval
+	}
+	return config.TextFile.Interval
+}
+
+// runTextFileWriter writes the metrics served by the prometheus metrics server to the configured text file on each
+// interval until the context is done
+func (m *Metrics) runTextFileWriter(ctx context.Context) {
+	if !m.config.Enabled || m.config.TextFile.Path == "" {
+		return
+	}
+	logger := logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{"path": m.config.TextFile.Path, "interval": m.config.textFileInterval()})
+	logger.Info(ctx, "Starting prometheus metrics text file writer")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := m.writeTextFile(); err != nil {
+			logger.WithError(err).Warn(ctx, "Unable to write prometheus metrics text file")
+		}
+	}, m.config.textFileInterval())
+}
+
+// writeTextFile writes the metrics in the text exposition format to a temporary file that is then renamed to the
+// configured path, so that the textfile collector never reads a partially written file
+func (m *Metrics) writeTextFile() error {
+	families, err := m.prometheusGatherer().Gather()
+	if err != nil && !m.config.IgnoreErrors {
+		return err
+	}
+	path := m.config.TextFile.Path
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	for _, family := range families {
+		if _, err := expfmt.MetricFamilyToText(tmp, family); err != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("failed to write metric family %s: %w", family.GetName(), err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// the textfile collector requires the file to be readable by the node_exporter
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,74 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func TestWriteTextFile(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	path := filepath.Join(t.TempDir(), "argo_workflows.prom")
+	m, _, err := createTestMetrics(ctx, &Config{Enabled: true, ExcludeGoCollectors: true, TextFile: TextFileConfig{Path: path}})
+	require.NoError(t, err)
+	m.AddInt(ctx, nameTestingCounter, 2, InstAttribs{{Name: AttribErrorCause, Value: errorCauseTestingA}})
+
+	require.NoError(t, m.writeTextFile())
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(file)
+	require.NoError(t, err, "the file is in the text exposition format")
+	require.Contains(t, families, TestScopeName+"_"+nameTestingCounter)
+	counter := families[TestScopeName+"_"+nameTestingCounter]
+	require.Len(t, counter.GetMetric(), 1)
+	assert.InDelta(t, 2, counter.GetMetric()[0].GetCounter().GetValue(), 0)
+	assert.Contains(t, families, TestScopeName+"_version")
+	assert.NotContains(t, families, "go_goroutines")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "the temporary file is renamed")
+}
+
+func TestRunTextFileWriter(t *testing.T) {
+	t.Run("Interval", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		path := filepath.Join(t.TempDir(), "argo_workflows.prom")
+		m, _, err := createTestMetrics(ctx, &Config{Enabled: true, ExcludeGoCollectors: true, TextFile: TextFileConfig{Path: path, Interval: 10 * time.Millisecond}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithCancel(ctx)
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			m.runTextFileWriter(ctx)
+			wg.Done()
+		}()
+		assert.Eventually(t, func() bool {
+			_, err := os.Stat(path)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+		cancel()
+		wg.Wait()
+	})
+	t.Run("NoPath", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		m, _, err := createTestMetrics(ctx, &Config{Enabled: true, ExcludeGoCollectors: true})
+		require.NoError(t, err)
+		// returns immediately rather than running until the context is done
+		m.runTextFileWriter(ctx)
+	})
+}
@@ -14,6 +14,8 @@ type Instrument struct {
 	description string
 	otel        interface{}
 	userdata    interface{}
+	// series limits the series recorded, nil if they are unlimited
+	series *seriesLimit
 }
 
 func (m *Metrics) preCreateCheck(name string) error {
@@ -131,6 +133,7 @@ func (m *Metrics) CreateInstrument(instType instrumentType, name, desc, unit str
 		name:        name,
 		description: desc,
 		otel:        instPtr,
+		series:      m.newSeriesLimit(name),
 	})
 	return nil
 }
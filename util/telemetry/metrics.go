@@ -6,10 +6,10 @@ import (
 	"sync"
 	"time"
 
+	promgo "github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel"
 
 	"go.opentelemetry.io/contrib/instrumentation/runtime"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/metric"
 	metricsdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -25,13 +25,27 @@ type Config struct {
 	TTL          time.Duration
 	IgnoreErrors bool
 	Secure       bool
-	Modifiers    map[string]Modifier
-	Temporality  metricsdk.TemporalitySelector
+	// TLSMinVersion is the minimum TLS version of the secure server, taking precedence over the TLS_MIN_VERSION
+	// environment variable. Zero means unset.
+	TLSMinVersion uint16
+	// MaxSeries is the most series, distinct combinations of attribute values, recorded for each metric, zero for
+	// unlimited. Measurements of new series beyond it are dropped and counted by InstrumentMetricsDroppedSeries.
+	MaxSeries int
+	// ExcludeGoCollectors serves only the exporter's metrics, without the default Go runtime and process collectors
+	ExcludeGoCollectors bool
+	Modifiers           map[string]Modifier
+	Temporality         metricsdk.TemporalitySelector
+	// OTLP pushes the metrics to an OpenTelemetry collector if an endpoint is configured, alongside the Prometheus exporter
+	OTLP OTLPConfig
+	// TextFile writes the Prometheus metrics to a file on an interval, alongside the Prometheus server
+	TextFile TextFileConfig
 }
 
 type Metrics struct {
 	otelMeter *metric.Meter
 	config    *Config
+	// gatherer serves the prometheus metrics, nil if the prometheus exporter is not enabled
+	gatherer promgo.Gatherer
 
 	// Ensures mutual exclusion in instruments
 	mutex       sync.RWMutex
@@ -74,18 +88,20 @@ func NewMetrics(ctx context.Context, serviceName, prometheusName string, config
 	_, otlpEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_ENDPOINT`)
 	_, otlpMetricsEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_METRICS_ENDPOINT`)
 	logger := logging.RequireLoggerFromContext(ctx)
-	if otlpEnabled || otlpMetricsEnabled {
+	if config.OTLP.Endpoint != "" || otlpEnabled || otlpMetricsEnabled {
 		logger.Info(ctx, "Starting OTLP metrics exporter")
-		otelExporter, err := otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithTemporalitySelector(config.Temporality))
+		otlpReader, err := config.otlpMetricsReader(ctx)
 		if err != nil {
 			return nil, err
 		}
-		options = append(options, metricsdk.WithReader(metricsdk.NewPeriodicReader(otelExporter)))
+		options = append(options, metricsdk.WithReader(otlpReader))
 	}
 
+	var gatherer promgo.Gatherer
 	if config.Enabled {
 		logger.Info(ctx, "Starting Prometheus metrics exporter")
-		promExporter, err := config.prometheusMetricsExporter(prometheusName)
+		promExporter, promGatherer, err := config.prometheusMetricsExporter(prometheusName)
+		gatherer = promGatherer
 		if err != nil {
 			return nil, err
 		}
@@ -107,8 +123,14 @@ func NewMetrics(ctx context.Context, serviceName, prometheusName string, config
 	metrics := &Metrics{
 		otelMeter:   &meter,
 		config:      config,
+		gatherer:    gatherer,
 		instruments: make(map[string]*Instrument),
 	}
+	if config.limitsSeries() {
+		if err := metrics.CreateBuiltinInstrument(InstrumentMetricsDroppedSeries); err != nil {
+			return nil, err
+		}
+	}
 
 	return metrics, nil
 }
@@ -1,6 +1,18 @@
 // Code generated by util/telemetry/builder. DO NOT EDIT.
 package telemetry
 
+var InstrumentActiveWatches = BuiltinInstrument{
+	name:        "active_watches",
+	description: "A gauge of the number of watch streams currently open in the Argo Server",
+	unit:        "{watch}",
+	instType:    Int64UpDownCounter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribServerOperation,
+		},
+	},
+}
+
 var InstrumentCronworkflowsConcurrencypolicyTriggered = BuiltinInstrument{
 	name:        "cronworkflows_concurrencypolicy_triggered",
 	description: "A counter of the number of times a CronWorkflow has triggered its `concurrencyPolicy` to limit the number of workflows running",
@@ -141,6 +153,33 @@ var InstrumentLogMessages = BuiltinInstrument{
 	},
 }
 
+var InstrumentMetricsDroppedSeries = BuiltinInstrument{
+	name:        "metrics_dropped_series",
+	description: "A counter of measurements dropped because they would have added a series to a metric beyond its series limit",
+	unit:        "{measurement}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribMetricName,
+		},
+	},
+}
+
+var InstrumentOffloadHydrationFailures = BuiltinInstrument{
+	name:        "offload_hydration_failures",
+	description: "A counter of failures to hydrate offloaded node status in the Argo Server",
+	unit:        "{workflow}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribServerOperation,
+		},
+		{
+			name: AttribHydrationFailureCause,
+		},
+	},
+}
+
 var InstrumentOperationDurationSeconds = BuiltinInstrument{
 	name:        "operation_duration_seconds",
 	description: "A histogram of durations of operations",
@@ -383,6 +422,24 @@ var InstrumentWorkflowCondition = BuiltinInstrument{
 	},
 }
 
+var InstrumentWorkflowOperations = BuiltinInstrument{
+	name:        "workflow_operations",
+	description: "A counter of the operations on workflows performed by the Argo Server",
+	unit:        "{operation}",
+	instType:    Int64Counter,
+	attributes: []BuiltinAttribute{
+		{
+			name: AttribServerOperation,
+		},
+		{
+			name: AttribWorkflowNamespace,
+		},
+		{
+			name: AttribOperationOutcome,
+		},
+	},
+}
+
 var InstrumentWorkflowtemplateRuntime = BuiltinInstrument{
 	name:        "workflowtemplate_runtime",
 	description: "A histogram of the runtime of workflows using `workflowTemplateRef` only",
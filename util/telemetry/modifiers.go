@@ -10,6 +10,8 @@ type Modifier struct {
 	Disabled           bool
 	DisabledAttributes []string
 	HistogramBuckets   []float64
+	// MaxSeries overrides Config.MaxSeries for this metric, zero to use that
+	MaxSeries int
 }
 
 // Create an opentelemetry 'view' which disables whole metrics or aggregates across attributes
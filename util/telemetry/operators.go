@@ -19,11 +19,15 @@ func (m *Metrics) AddInt(ctx context.Context, name string, val int64, attribs In
 }
 
 func (i *Instrument) AddInt(ctx context.Context, val int64, attribs InstAttribs) {
+	options, ok := i.attributes(ctx, attribs)
+	if !ok {
+		return
+	}
 	switch inst := i.otel.(type) {
 	case *metric.Int64UpDownCounter:
-		(*inst).Add(ctx, val, i.attributes(ctx, attribs))
+		(*inst).Add(ctx, val, options)
 	case *metric.Int64Counter:
-		(*inst).Add(ctx, val, i.attributes(ctx, attribs))
+		(*inst).Add(ctx, val, options)
 	default:
 		logging.RequireLoggerFromContext(ctx).WithField("name", i.name).WithField("type", i.otel).Error(ctx, "Metrics addInt() to invalid type")
 	}
@@ -38,9 +42,13 @@ func (m *Metrics) Record(ctx context.Context, name string, val float64, attribs
 }
 
 func (i *Instrument) Record(ctx context.Context, val float64, attribs InstAttribs) {
+	options, ok := i.attributes(ctx, attribs)
+	if !ok {
+		return
+	}
 	switch inst := i.otel.(type) {
 	case *metric.Float64Histogram:
-		(*inst).Record(ctx, val, i.attributes(ctx, attribs))
+		(*inst).Record(ctx, val, options)
 	default:
 		logging.RequireLoggerFromContext(ctx).WithField("name", i.name).WithField("type", i.otel).Error(ctx, "Metrics record() to invalid type")
 	}
@@ -63,9 +71,13 @@ func (i *Instrument) RegisterCallback(m *Metrics, f metric.Callback) error {
 }
 
 func (i *Instrument) ObserveInt(ctx context.Context, o metric.Observer, val int64, attribs InstAttribs) {
+	options, ok := i.attributes(ctx, attribs)
+	if !ok {
+		return
+	}
 	switch inst := i.otel.(type) {
 	case *metric.Int64ObservableGauge:
-		o.ObserveInt64(*inst, val, i.attributes(ctx, attribs))
+		o.ObserveInt64(*inst, val, options)
 	default:
 		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{
 			"name": i.name,
@@ -75,11 +87,15 @@ func (i *Instrument) ObserveInt(ctx context.Context, o metric.Observer, val int6
 }
 
 func (i *Instrument) ObserveFloat(ctx context.Context, o metric.Observer, val float64, attribs InstAttribs) {
+	options, ok := i.attributes(ctx, attribs)
+	if !ok {
+		return
+	}
 	switch inst := i.otel.(type) {
 	case *metric.Float64ObservableGauge:
-		o.ObserveFloat64(*inst, val, i.attributes(ctx, attribs))
+		o.ObserveFloat64(*inst, val, options)
 	case *metric.Float64ObservableCounter:
-		o.ObserveFloat64(*inst, val, i.attributes(ctx, attribs))
+		o.ObserveFloat64(*inst, val, options)
 	default:
 		logging.RequireLoggerFromContext(ctx).WithFields(logging.Fields{
 			"name": i.name,
@@ -94,7 +110,17 @@ type InstAttrib struct {
 	Value interface{}
 }
 
-func (i *Instrument) attributes(ctx context.Context, labels InstAttribs) metric.MeasurementOption {
+// attributes returns the attributes to measure with, and false if the measurement is to be dropped as it would add a
+// series beyond the instrument's series limit
+func (i *Instrument) attributes(ctx context.Context, labels InstAttribs) (metric.MeasurementOption, bool) {
+	set := attribute.NewSet(i.attributeList(ctx, labels)...)
+	if !i.series.admit(ctx, set) {
+		return nil, false
+	}
+	return metric.WithAttributeSet(set), true
+}
+
+func (i *Instrument) attributeList(ctx context.Context, labels InstAttribs) []attribute.KeyValue {
 	attribs := make([]attribute.KeyValue, 0)
 	for _, label := range labels {
 		switch value := label.Value.(type) {
@@ -115,5 +141,5 @@ func (i *Instrument) attributes(ctx context.Context, labels InstAttribs) metric.
 			}).Error(ctx, "Attempt to use label of unhandled type in metric")
 		}
 	}
-	return metric.WithAttributes(attribs...)
+	return attribs
 }
@@ -0,0 +1,95 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// seriesLimit limits the series of an instrument, its distinct combinations of attribute values, to protect the metrics
+// backend from metrics with unbounded attribute values, such as workflow names in custom metrics
+type seriesLimit struct {
+	max    int
+	mutex  sync.Mutex
+	series map[attribute.Distinct]bool
+	// dropped is called for each measurement that is dropped as it would add a series beyond the limit
+	dropped func(ctx context.Context)
+}
+
+// maxSeries returns the series limit of the metric from its modifier, else the config, zero if it is unlimited
+func (config *Config) maxSeries(name string) int {
+	if modifier, ok := config.Modifiers[name]; ok && modifier.MaxSeries > 0 {
+		return modifier.MaxSeries
+	}
+	return config.MaxSeries
+}
+
+// limitsSeries returns whether any metric has a series limit
+func (config *Config) limitsSeries() bool {
+	if config.MaxSeries > 0 {
+		return true
+	}
+	for _, modifier := range config.Modifiers {
+		if modifier.MaxSeries > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// newSeriesLimit returns the series limit of the named instrument, nil if it is unlimited. The dropped series counter
+// is never limited, as it has at most one series per metric.
+func (m *Metrics) newSeriesLimit(name string) *seriesLimit {
+	limit := m.config.maxSeries(name)
+	if limit <= 0 || name == InstrumentMetricsDroppedSeries.Name() {
+		return nil
+	}
+	return &seriesLimit{
+		max:    limit,
+		series: make(map[attribute.Distinct]bool),
+		dropped: func(ctx context.Context) {
+			m.AddInt(ctx, InstrumentMetricsDroppedSeries.Name(), 1, InstAttribs{{Name: AttribMetricName, Value: name}})
+		},
+	}
+}
+
+// admit returns whether a measurement of the series is to be recorded: if the series is already recorded, or is new
+// and within the limit
+func (l *seriesLimit) admit(ctx context.Context, set attribute.Set) bool {
+	if l == nil {
+		return true
+	}
+	key := set.Equivalent()
+	l.mutex.Lock()
+	ok := l.series[key]
+	if !ok && len(l.series) < l.max {
+		l.series[key] = true
+		ok = true
+	}
+	l.mutex.Unlock()
+	if !ok {
+		l.dropped(ctx)
+	}
+	return ok
+}
+
+func (l *seriesLimit) forget(set attribute.Set) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	delete(l.series, set.Equivalent())
+}
+
+// ForgetSeries no longer counts the series of the attributes against the instrument's series limit, for observable
+// instruments that stop observing a series, e.g. custom metrics that have expired. The series of other instruments
+// are exported until the process restarts, so they are never forgotten.
+func (i *Instrument) ForgetSeries(ctx context.Context, attribs InstAttribs) {
+	switch i.otel.(type) {
+	case *metric.Float64ObservableGauge, *metric.Float64ObservableCounter, *metric.Int64ObservableGauge:
+		i.series.forget(attribute.NewSet(i.attributeList(ctx, attribs)...))
+	}
+}
@@ -0,0 +1,94 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func addTestingError(ctx context.Context, m *Metrics, cause string) {
+	m.AddInt(ctx, nameTestingCounter, 1, InstAttribs{{Name: AttribErrorCause, Value: cause}})
+}
+
+func TestSeriesLimit(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	// each error cause is a series of the testing counter
+	m, te, err := createTestMetrics(ctx, &Config{
+		Modifiers: map[string]Modifier{
+			nameTestingCounter: {MaxSeries: 1},
+		},
+	})
+	require.NoError(t, err)
+	addTestingError(ctx, m, errorCauseTestingA)
+	addTestingError(ctx, m, errorCauseTestingB)
+	addTestingError(ctx, m, errorCauseTestingB)
+	addTestingError(ctx, m, errorCauseTestingA)
+
+	attribsA := attribute.NewSet(attribute.String(AttribErrorCause, errorCauseTestingA))
+	val, err := te.GetInt64CounterValue(ctx, nameTestingCounter, &attribsA)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), val, "the series within the limit is still recorded")
+	attribsB := attribute.NewSet(attribute.String(AttribErrorCause, errorCauseTestingB))
+	_, err = te.GetInt64CounterValue(ctx, nameTestingCounter, &attribsB)
+	require.Error(t, err, "the series beyond the limit is dropped")
+
+	dropped := attribute.NewSet(attribute.String(AttribMetricName, nameTestingCounter))
+	val, err = te.GetInt64CounterValue(ctx, InstrumentMetricsDroppedSeries.Name(), &dropped)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), val)
+}
+
+func TestSeriesLimitDefault(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	m, te, err := createTestMetrics(ctx, &Config{
+		MaxSeries: 1,
+		Modifiers: map[string]Modifier{
+			nameTestingCounter: {MaxSeries: 2},
+		},
+	})
+	require.NoError(t, err)
+	addTestingError(ctx, m, errorCauseTestingA)
+	addTestingError(ctx, m, errorCauseTestingB)
+	m.TestingHistogramRecord(ctx, 1)
+
+	attribsB := attribute.NewSet(attribute.String(AttribErrorCause, errorCauseTestingB))
+	val, err := te.GetInt64CounterValue(ctx, nameTestingCounter, &attribsB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), val, "the modifier overrides the default limit")
+	histogram := attribute.NewSet()
+	_, err = te.GetFloat64HistogramData(ctx, nameTestingHistogram, &histogram)
+	require.NoError(t, err)
+	dropped := attribute.NewSet(attribute.String(AttribMetricName, nameTestingCounter))
+	_, err = te.GetInt64CounterValue(ctx, InstrumentMetricsDroppedSeries.Name(), &dropped)
+	require.Error(t, err, "nothing is dropped")
+}
+
+func TestSeriesLimitForget(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	m, _, err := createTestMetrics(ctx, &Config{MaxSeries: 1})
+	require.NoError(t, err)
+	require.NoError(t, m.CreateInstrument(Float64ObservableGauge, "testing_gauge", "Testing gauge", "{item}"))
+	gauge := m.GetInstrument("testing_gauge")
+	a := InstAttribs{{Name: AttribErrorCause, Value: errorCauseTestingA}}
+	b := InstAttribs{{Name: AttribErrorCause, Value: errorCauseTestingB}}
+
+	_, ok := gauge.attributes(ctx, a)
+	assert.True(t, ok)
+	_, ok = gauge.attributes(ctx, b)
+	assert.False(t, ok)
+	gauge.ForgetSeries(ctx, a)
+	_, ok = gauge.attributes(ctx, b)
+	assert.True(t, ok, "a forgotten series frees its place")
+
+	counter := m.GetInstrument(nameTestingCounter)
+	_, ok = counter.attributes(ctx, a)
+	assert.True(t, ok)
+	counter.ForgetSeries(ctx, a)
+	_, ok = counter.attributes(ctx, b)
+	assert.False(t, ok, "the series of counters are never forgotten")
+}
@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/argoproj/argo-workflows/v3"
+
+	// AttribSpanOperation is the operation a span is of, e.g. CreateWorkflow
+	AttribSpanOperation = "argo.operation"
+	// AttribSpanNamespace is the namespace a span's operation is in, if any
+	AttribSpanNamespace = "k8s.namespace.name"
+)
+
+// TracePropagator reads and writes W3C trace context, i.e. the traceparent and tracestate headers
+var TracePropagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// InitTracing sets the global tracer provider to one that pushes spans to an OpenTelemetry collector, if the
+// OTEL_EXPORTER_OTLP_ENDPOINT or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT environment variable is set, configured by the
+// standard OTEL_EXPORTER_OTLP_* environment variables. Otherwise spans are not recorded. The returned function flushes
+// and stops the provider.
+func InitTracing(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	_, otlpEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_ENDPOINT`)
+	_, otlpTracesEnabled := os.LookupEnv(`OTEL_EXPORTER_OTLP_TRACES_ENDPOINT`)
+	if !otlpEnabled && !otlpTracesEnabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	provider := tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exporter),
+		tracesdk.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span of the operation in the namespace, which may be empty, as a child of the span of the context,
+// if any. The span must be ended by the caller.
+func StartSpan(ctx context.Context, operation, namespace string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{attribute.String(AttribSpanOperation, operation)}
+	if namespace != "" {
+		attributes = append(attributes, attribute.String(AttribSpanNamespace, namespace))
+	}
+	return otel.Tracer(tracerName).Start(ctx, operation, append(opts, trace.WithAttributes(attributes...))...)
+}
@@ -60,6 +60,29 @@ const (
 	// the strategy for the pod, in case the pod is orphaned from its workflow
 	AnnotationKeyPodGCStrategy = workflow.WorkflowFullName + "/pod-gc-strategy"
 
+	// AnnotationKeyMaintenanceMessage is on the workflow controller's config map while the server is paused for
+	// maintenance, rejecting new workflows. The value is why, and may be empty.
+	AnnotationKeyMaintenanceMessage = workflow.WorkflowFullName + "/maintenance-message"
+
+	// AnnotationKeyCompletionWebhookClaim is on a completed workflow once a replica of the Argo Server has claimed
+	// posting it to the completion webhooks, so that the other replicas do not. The value is the replica's host name.
+	AnnotationKeyCompletionWebhookClaim = workflow.WorkflowFullName + "/completion-webhook-claim"
+
+	// The roles of an artifact in a workflow returned from FindWorkflowsByArtifact
+	ArtifactRoleProducer = "producer"
+	ArtifactRoleConsumer = "consumer"
+
+	// AnnotationKeySubmitReason is the free text reason given when the workflow was created or submitted
+	AnnotationKeySubmitReason = workflow.WorkflowFullName + "/submit-reason"
+	// AnnotationKeyCorrelationID is the ID given by the caller when the workflow was created or submitted, e.g. a trace ID
+	AnnotationKeyCorrelationID = workflow.WorkflowFullName + "/correlation-id"
+	// AnnotationKeyGitRepository is the git repository the workflow was created or submitted from
+	AnnotationKeyGitRepository = workflow.WorkflowFullName + "/git-repository"
+	// AnnotationKeyCIRunURL is the URL of the CI run that created or submitted the workflow
+	AnnotationKeyCIRunURL = workflow.WorkflowFullName + "/ci-run-url"
+	// AnnotationKeyStartAt is the time, in RFC3339 format, that a workflow submitted suspended is to be resumed at
+	AnnotationKeyStartAt = workflow.WorkflowFullName + "/start-at"
+
 	// LabelKeyControllerInstanceID is the label the controller will carry forward to workflows/pod labels
 	// for the purposes of workflow segregation
 	LabelKeyControllerInstanceID = workflow.WorkflowFullName + "/controller-instanceid"
@@ -92,6 +115,8 @@ const (
 	LabelKeyPhase = workflow.WorkflowFullName + "/phase"
 	// LabelKeyPreviousWorkflowName is a label applied to resubmitted workflows
 	LabelKeyPreviousWorkflowName = workflow.WorkflowFullName + "/resubmitted-from-workflow"
+	// LabelKeyRetriedWorkflow is a label applied to the pods a retry kept rather than deleted, in place of LabelKeyWorkflow
+	LabelKeyRetriedWorkflow = workflow.WorkflowFullName + "/retried-workflow"
 	// LabelKeyCronWorkflow is a label applied to Workflows that are started by a CronWorkflow
 	LabelKeyCronWorkflow = workflow.WorkflowFullName + "/cron-workflow"
 	// LabelKeyWorkflowTemplate is a label applied to Workflows that are submitted from Workflowtemplate
@@ -107,6 +132,12 @@ const (
 	// LabelKeyReportOutputsCompleted is a label applied to WorkflowTaskResults indicating whether all the outputs have been reported.
 	LabelKeyReportOutputsCompleted = workflow.WorkflowFullName + "/report-outputs-completed"
 
+	// LabelKeyClientUID is a label applied to Workflows created with a client-provided UID, so that creating a workflow with
+	// the same UID again returns the existing workflow. Kubernetes always generates metadata.uid itself.
+	LabelKeyClientUID = workflow.WorkflowFullName + "/client-uid"
+	// LabelKeyGitCommit is a label applied to Workflows created or submitted from a git commit, so that they can be selected by it
+	LabelKeyGitCommit = workflow.WorkflowFullName + "/git-commit"
+
 	// LabelKeyCronWorkflowCompleted is a label applied to the cron workflow when the configured stopping condition is achieved
 	LabelKeyCronWorkflowCompleted = workflow.CronWorkflowFullName + "/completed"
 
@@ -253,6 +284,9 @@ const (
 	// LocalVarRetriesLastMessage is a variable that references information about the last retry's failure message
 	LocalVarRetriesLastMessage = "lastRetry.message"
 
+	// RetriesExhaustedMessage is the message of a retry node that failed as its last retry failed
+	RetriesExhaustedMessage = "No more retries left"
+
 	KubeConfigDefaultMountPath    = "/kube/config"
 	KubeConfigDefaultVolumeName   = "kubeconfig"
 	ServiceAccountTokenMountPath  = "/var/run/secrets/kubernetes.io/serviceaccount" //nolint:gosec
@@ -78,6 +78,26 @@ func NewWorkflowFromWorkflowTemplate(templateName string, clusterScope bool) *wf
 	return wf
 }
 
+// SetWorkflowTemplateArguments materializes the default values of the template's arguments onto a workflow created
+// from it, so that they are visible on the workflow rather than only applied at runtime. A parameter's value is used if
+// set, otherwise its default. Parameters the workflow already has, those resolved from a valueFrom, and those without a
+// value or default are left alone.
+func SetWorkflowTemplateArguments(wf *wfv1.Workflow, tmpl wfv1.WorkflowSpecHolder) {
+	for _, param := range tmpl.GetWorkflowSpec().Arguments.Parameters {
+		if wf.Spec.Arguments.GetParameterByName(param.Name) != nil {
+			continue
+		}
+		if param.ValueFrom != nil || (param.Value == nil && param.Default == nil) {
+			continue
+		}
+		p := param.DeepCopy()
+		if p.Value == nil {
+			p.Value = p.Default
+		}
+		wf.Spec.Arguments.Parameters = append(wf.Spec.Arguments.Parameters, *p)
+	}
+}
+
 func toWorkflow(cronWf wfv1.CronWorkflow, objectMeta metav1.ObjectMeta) *wfv1.Workflow {
 	wf := &wfv1.Workflow{
 		TypeMeta: metav1.TypeMeta{
@@ -193,3 +193,41 @@ func TestConvertClusterWorkflowTemplateToWorkflow(t *testing.T) {
 	assert.Equal(t, wfTmpl.Name, wf.Spec.WorkflowTemplateRef.Name)
 	assert.True(t, wf.Spec.WorkflowTemplateRef.ClusterScope)
 }
+
+func TestSetWorkflowTemplateArguments(t *testing.T) {
+	var wfTmpl v1alpha1.WorkflowTemplate
+	v1alpha1.MustUnmarshal(`apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: my-wftmpl
+spec:
+  arguments:
+    parameters:
+      - name: value
+        value: my-value
+      - name: default
+        default: my-default
+        enum: [my-default, other]
+      - name: required
+      - name: value-from
+        default: my-default
+        valueFrom:
+          configMapKeyRef:
+            name: my-config
+            key: my-key
+      - name: overridden
+        value: my-value
+`, &wfTmpl)
+	wf := NewWorkflowFromWorkflowTemplate(wfTmpl.Name, false)
+	wf.Spec.Arguments.Parameters = []v1alpha1.Parameter{{Name: "overridden", Value: v1alpha1.AnyStringPtr("my-override")}}
+	SetWorkflowTemplateArguments(wf, &wfTmpl)
+	require.Len(t, wf.Spec.Arguments.Parameters, 3)
+	assert.Equal(t, "my-override", wf.Spec.Arguments.GetParameterByName("overridden").Value.String())
+	assert.Equal(t, "my-value", wf.Spec.Arguments.GetParameterByName("value").Value.String())
+	assert.Equal(t, "my-default", wf.Spec.Arguments.GetParameterByName("default").Value.String())
+	assert.Equal(t, []v1alpha1.AnyString{"my-default", "other"}, wf.Spec.Arguments.GetParameterByName("default").Enum)
+	assert.Nil(t, wf.Spec.Arguments.GetParameterByName("required"))
+	assert.Nil(t, wf.Spec.Arguments.GetParameterByName("value-from"))
+	// the template is not modified
+	assert.Nil(t, wfTmpl.Spec.Arguments.GetParameterByName("default").Value)
+}
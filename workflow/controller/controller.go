@@ -665,8 +665,8 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(ctx context.Cont
 		return
 	}
 	ttl := wfc.Config.Persistence.ArchiveTTL
-	if ttl == config.TTL(0) {
-		logger.Info(ctx, "Archived workflows TTL zero - so archived workflow GC disabled - you must restart the controller if you enable this")
+	if ttl == config.TTL(0) && wfc.Config.Persistence.ArchiveRetention == nil {
+		logger.Info(ctx, "Archived workflows TTL zero and no archive retention - so archived workflow GC disabled - you must restart the controller if you enable this")
 		return
 	}
 	logger.WithFields(logging.Fields{"ttl": ttl, "periodicity": periodicity}).Info(ctx, "Performing archived workflow GC")
@@ -678,10 +678,13 @@ func (wfc *WorkflowController) archivedWorkflowGarbageCollector(ctx context.Cont
 			return
 		case <-ticker.C:
 			logger.Info(ctx, "Performing archived workflow GC")
-			err := wfc.wfArchive.DeleteExpiredWorkflows(ctx, time.Duration(ttl))
-			if err != nil {
-				logger.WithField("err", err).Error(ctx, "Failed to delete archived workflows")
+			if ttl != config.TTL(0) {
+				err := wfc.wfArchive.DeleteExpiredWorkflows(ctx, time.Duration(ttl))
+				if err != nil {
+					logger.WithField("err", err).Error(ctx, "Failed to delete archived workflows")
+				}
 			}
+			wfc.pruneAllArchivedWorkflows(ctx)
 		}
 	}
 }
@@ -1035,7 +1038,7 @@ func (wfc *WorkflowController) addWorkflowInformerHandlers(ctx context.Context)
 		DeleteFunc: func(obj interface{}) {
 			wf, ok := obj.(*unstructured.Unstructured)
 			if ok { // maybe cache.DeletedFinalStateUnknown
-				wfc.metrics.DeleteRealtimeMetricsForWfUID(string(wf.GetUID()))
+				wfc.metrics.DeleteRealtimeMetricsForWfUID(ctx, string(wf.GetUID()))
 			}
 		},
 	})
@@ -1084,6 +1087,7 @@ func (wfc *WorkflowController) archiveWorkflowAux(ctx context.Context, obj inter
 	if err != nil {
 		return fmt.Errorf("failed to archive workflow: %w", err)
 	}
+	wfc.pruneArchivedWorkflows(ctx, wf.Namespace)
 	data, err := json.Marshal(map[string]interface{}{
 		"metadata": metav1.ObjectMeta{
 			Labels: map[string]string{
@@ -1112,6 +1116,38 @@ func (wfc *WorkflowController) archiveWorkflowAux(ctx context.Context, obj inter
 	return nil
 }
 
+// pruneArchivedWorkflows enforces the archive retention policy of the namespace. The workflow has already been archived,
+// so a failure is only logged, and the workflows will be pruned when the next one is archived.
+func (wfc *WorkflowController) pruneArchivedWorkflows(ctx context.Context, namespace string) {
+	if wfc.Config.Persistence == nil {
+		return
+	}
+	policy := wfc.Config.Persistence.ArchiveRetention.ForNamespace(namespace)
+	if policy.IsZero() {
+		return
+	}
+	_, err := wfc.wfArchive.PruneWorkflows(ctx, namespace, policy, false)
+	if err != nil {
+		logging.RequireLoggerFromContext(ctx).WithField("namespace", namespace).WithError(err).Error(ctx, "failed to prune archived workflows")
+	}
+}
+
+// pruneAllArchivedWorkflows enforces the archive retention policy of every namespace with archived workflows, so that
+// workflows past the max age are pruned even in namespaces where no workflow has been archived since.
+func (wfc *WorkflowController) pruneAllArchivedWorkflows(ctx context.Context) {
+	if wfc.Config.Persistence == nil || wfc.Config.Persistence.ArchiveRetention == nil {
+		return
+	}
+	namespaces, err := wfc.wfArchive.ListWorkflowNamespaces(ctx)
+	if err != nil {
+		logging.RequireLoggerFromContext(ctx).WithError(err).Error(ctx, "failed to list the namespaces of archived workflows to prune")
+		return
+	}
+	for _, namespace := range namespaces {
+		wfc.pruneArchivedWorkflows(ctx, namespace)
+	}
+}
+
 func (wfc *WorkflowController) instanceIDReq() labels.Requirement {
 	return util.InstanceIDRequirement(wfc.Config.InstanceID)
 }
@@ -1223,18 +1259,36 @@ func (wfc *WorkflowController) getMetricsServerConfig() *telemetry.Config {
 			Disabled:           modifier.Disabled,
 			DisabledAttributes: modifier.DisabledAttributes,
 			HistogramBuckets:   modifier.HistogramBuckets,
+			MaxSeries:          modifier.MaxSeries,
 		}
 	}
 
 	metricsConfig := telemetry.Config{
-		Enabled:      wfc.Config.MetricsConfig.Enabled == nil || *wfc.Config.MetricsConfig.Enabled,
-		Path:         wfc.Config.MetricsConfig.Path,
-		Port:         wfc.Config.MetricsConfig.Port,
-		TTL:          time.Duration(wfc.Config.MetricsConfig.MetricsTTL),
-		IgnoreErrors: wfc.Config.MetricsConfig.IgnoreErrors,
-		Secure:       wfc.Config.MetricsConfig.GetSecure(true),
-		Modifiers:    modifiers,
-		Temporality:  wfc.Config.MetricsConfig.GetTemporality(),
+		Enabled:             wfc.Config.MetricsConfig.Enabled == nil || *wfc.Config.MetricsConfig.Enabled,
+		Path:                wfc.Config.MetricsConfig.Path,
+		Port:                wfc.Config.MetricsConfig.Port,
+		TTL:                 time.Duration(wfc.Config.MetricsConfig.MetricsTTL),
+		IgnoreErrors:        wfc.Config.MetricsConfig.IgnoreErrors,
+		Secure:              wfc.Config.MetricsConfig.GetSecure(true),
+		TLSMinVersion:       wfc.Config.MetricsConfig.TLSMinVersion,
+		ExcludeGoCollectors: wfc.Config.MetricsConfig.ExcludeGoCollectors,
+		MaxSeries:           wfc.Config.MetricsConfig.MaxSeries,
+		Modifiers:           modifiers,
+		Temporality:         wfc.Config.MetricsConfig.GetTemporality(),
+	}
+	if otlp := wfc.Config.MetricsConfig.OTLP; otlp != nil {
+		metricsConfig.OTLP = telemetry.OTLPConfig{
+			Endpoint: otlp.Endpoint,
+			Interval: time.Duration(otlp.Interval),
+			Headers:  otlp.Headers,
+			Insecure: otlp.Insecure,
+		}
+	}
+	if textFile := wfc.Config.MetricsConfig.TextFile; textFile != nil {
+		metricsConfig.TextFile = telemetry.TextFileConfig{
+			Path:     textFile.Path,
+			Interval: time.Duration(textFile.Interval),
+		}
 	}
 	return &metricsConfig
 }
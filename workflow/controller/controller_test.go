@@ -7,6 +7,7 @@ import (
 
 	"github.com/argoproj/pkg/sync"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	authorizationv1 "k8s.io/api/authorization/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -26,6 +27,7 @@ import (
 
 	"github.com/argoproj/argo-workflows/v3/config"
 	"github.com/argoproj/argo-workflows/v3/persist/sqldb"
+	sqldbmocks "github.com/argoproj/argo-workflows/v3/persist/sqldb/mocks"
 	"github.com/argoproj/argo-workflows/v3/pkg/apis/workflow"
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	fakewfclientset "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
@@ -769,6 +771,52 @@ func TestWorkflowController_archivedWorkflowGarbageCollector(t *testing.T) {
 	controller.archivedWorkflowGarbageCollector(logging.TestContext(t.Context()))
 }
 
+func TestWorkflowController_archiveWorkflowPrunes(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	policy := config.ArchiveRetentionPolicy{MaxCount: 2}
+	controller.Config.Persistence = &config.PersistConfig{
+		Archive:          true,
+		ArchiveRetention: &config.ArchiveRetention{Namespaces: map[string]config.ArchiveRetentionPolicy{"my-ns": policy}},
+	}
+	wfArchive := &sqldbmocks.WorkflowArchive{}
+	wfArchive.On("ArchiveWorkflow", mock.Anything, mock.Anything).Return(nil)
+	wfArchive.On("PruneWorkflows", mock.Anything, "my-ns", policy, false).Return(int64(1), nil)
+	controller.wfArchive = wfArchive
+
+	un, err := util.ToUnstructured(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "my-ns"}})
+	require.NoError(t, err)
+	require.NoError(t, controller.archiveWorkflowAux(ctx, un))
+	wfArchive.AssertCalled(t, "PruneWorkflows", mock.Anything, "my-ns", policy, false)
+
+	// namespaces without a policy are not pruned
+	un, err = util.ToUnstructured(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf", Namespace: "other-ns"}})
+	require.NoError(t, err)
+	require.NoError(t, controller.archiveWorkflowAux(ctx, un))
+	wfArchive.AssertNumberOfCalls(t, "PruneWorkflows", 1)
+}
+
+func TestWorkflowController_pruneAllArchivedWorkflows(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx)
+	defer cancel()
+	policy := config.ArchiveRetentionPolicy{MaxAge: config.TTL(time.Hour)}
+	controller.Config.Persistence = &config.PersistConfig{
+		Archive:          true,
+		ArchiveRetention: &config.ArchiveRetention{ArchiveRetentionPolicy: policy, Namespaces: map[string]config.ArchiveRetentionPolicy{"unlimited-ns": {}}},
+	}
+	wfArchive := &sqldbmocks.WorkflowArchive{}
+	wfArchive.On("ListWorkflowNamespaces", mock.Anything).Return([]string{"my-ns", "other-ns", "unlimited-ns"}, nil)
+	wfArchive.On("PruneWorkflows", mock.Anything, mock.Anything, policy, false).Return(int64(1), nil)
+	controller.wfArchive = wfArchive
+
+	controller.pruneAllArchivedWorkflows(ctx)
+	wfArchive.AssertCalled(t, "PruneWorkflows", mock.Anything, "my-ns", policy, false)
+	wfArchive.AssertCalled(t, "PruneWorkflows", mock.Anything, "other-ns", policy, false)
+	wfArchive.AssertNumberOfCalls(t, "PruneWorkflows", 2)
+}
+
 const wfWithTmplRef = `
 apiVersion: argoproj.io/v1alpha1
 kind: Workflow
@@ -810,7 +810,7 @@ func (woc *wfOperationCtx) persistUpdates(ctx context.Context) {
 
 	// Make sure the workflow completed.
 	if woc.wf.Status.Fulfilled() {
-		woc.controller.metrics.CompleteRealtimeMetricsForWfUID(string(woc.wf.GetUID()))
+		woc.controller.metrics.CompleteRealtimeMetricsForWfUID(ctx, string(woc.wf.GetUID()))
 		if err := woc.deleteTaskResults(ctx); err != nil {
 			woc.log.WithError(err).Warn(ctx, "failed to delete task-results")
 		}
@@ -1111,7 +1111,7 @@ func (woc *wfOperationCtx) processNodeRetries(ctx context.Context, node *wfv1.No
 	}
 	if retryStrategy.Limit != nil && limit != nil && int32(len(childNodeIds)) > *limit {
 		woc.log.Info(ctx, "No more retries left. Failing...")
-		return woc.markNodePhase(ctx, node.Name, lastChildNode.Phase, "No more retries left"), true, nil
+		return woc.markNodePhase(ctx, node.Name, lastChildNode.Phase, common.RetriesExhaustedMessage), true, nil
 	}
 
 	if retryStrategy.Expression != "" && len(childNodeIds) > 0 {
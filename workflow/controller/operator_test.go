@@ -6547,7 +6547,7 @@ status:
       phase: Failed
 `)
 	ctx := logging.TestContext(t.Context())
-	wf, err := util.FormulateResubmitWorkflow(ctx, wf, true, nil)
+	wf, err := util.FormulateResubmitWorkflow(ctx, wf, util.ResubmitOpts{Memoized: true})
 	require.NoError(t, err)
 	cancel, controller := newController(logging.TestContext(t.Context()), wf)
 	defer cancel()
@@ -6594,7 +6594,7 @@ status:
       phase: Failed
 `)
 	ctx := logging.TestContext(t.Context())
-	wf, err := util.FormulateResubmitWorkflow(ctx, wf, true, []string{"message=modified"})
+	wf, err := util.FormulateResubmitWorkflow(ctx, wf, util.ResubmitOpts{Memoized: true, Parameters: []string{"message=modified"}})
 	require.NoError(t, err)
 	cancel, controller := newController(logging.TestContext(t.Context()), wf)
 	defer cancel()
@@ -6632,7 +6632,7 @@ status:
 `)
 
 	ctx := logging.TestContext(t.Context())
-	wf, _, err := util.FormulateRetryWorkflow(ctx, wf, false, "", []string{"message=modified"})
+	wf, _, err := util.FormulateRetryWorkflow(ctx, wf, util.RetryOpts{Parameters: []string{"message=modified"}})
 	require.NoError(t, err)
 	cancel, controller := newController(logging.TestContext(t.Context()), wf)
 	defer cancel()
@@ -6642,6 +6642,52 @@ status:
 	assert.Equal(t, "modified", wf.Spec.Arguments.Parameters[0].Value.String())
 }
 
+func TestRetryParamsOverrideFromConfigMap(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: my-wf
+  namespace: my-ns
+  labels:
+    workflows.argoproj.io/completed: true
+spec:
+  arguments:
+    parameters:
+    - name: message
+      value: default
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: busybox
+status:
+  phase: Failed
+  nodes:
+    my-wf:
+      id: my-wf
+      name: my-wf
+      phase: Failed
+`)
+
+	ctx := logging.TestContext(t.Context())
+	wf, _, err := util.FormulateRetryWorkflow(ctx, wf, util.RetryOpts{ParametersFrom: []wfv1.Parameter{{
+		Name:      "message",
+		ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: &apiv1.ConfigMapKeySelector{LocalObjectReference: apiv1.LocalObjectReference{Name: "my-cm"}, Key: "message"}},
+	}}})
+	require.NoError(t, err)
+	cancel, controller := newController(logging.TestContext(t.Context()), wf)
+	defer cancel()
+	err = controller.configMapInformer.GetIndexer().Add(&apiv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-cm", Namespace: "my-ns", Labels: map[string]string{common.LabelKeyConfigMapType: common.LabelValueTypeConfigMapParameter}},
+		Data:       map[string]string{"message": "from config map"},
+	})
+	require.NoError(t, err)
+
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+	assert.Equal(t, "from config map", woc.globalParams["workflow.parameters.message"])
+}
+
 func TestWorkflowOutputs(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(`
 metadata:
@@ -542,7 +542,15 @@ func (woc *wfOperationCtx) createWorkflowPod(ctx context.Context, nodeName strin
 			woc.log.WithFields(logging.Fields{"nodeName": nodeName, "podName": pod.Name}).Info(ctx, "Failed pod creation: already exists")
 			// get a reference to the currently existing Pod since the created pod returned before was nil.
 			if existing, err = woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Get(ctx, pod.Name, metav1.GetOptions{}); err == nil {
-				return existing, nil
+				if _, kept := existing.Labels[common.LabelKeyRetriedWorkflow]; !kept {
+					return existing, nil
+				}
+				// the pod was kept by a retry of the workflow, and is in the way of the node being re-run
+				woc.log.WithFields(logging.Fields{"nodeName": nodeName, "podName": pod.Name}).Info(ctx, "Deleting pod kept by retry")
+				err = woc.controller.kubeclientset.CoreV1().Pods(woc.wf.ObjectMeta.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+				if err == nil || apierr.IsNotFound(err) {
+					err = errorsutil.NewErrTransient("pod " + pod.Name + " kept by retry is being deleted")
+				}
 			}
 		}
 		if errorsutil.IsTransientErr(ctx, err) {
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiv1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -644,6 +645,33 @@ func Test_createWorkflowPod_rateLimited(t *testing.T) {
 	}
 }
 
+func Test_createWorkflowPod_keptByRetry(t *testing.T) {
+	wf := wfv1.MustUnmarshalWorkflow(helloWorldWf)
+	ctx := logging.TestContext(t.Context())
+	cancel, controller := newController(ctx, wf)
+	defer cancel()
+	pods := controller.kubeclientset.CoreV1().Pods(wf.Namespace)
+	_, err := pods.Create(ctx, &apiv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: wf.Name, Labels: map[string]string{common.LabelKeyRetriedWorkflow: wf.Name}},
+	}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	woc := newWorkflowOperationCtx(ctx, wf, controller)
+	woc.operate(ctx)
+	node := woc.wf.Status.Nodes[woc.wf.Name]
+	assert.Equal(t, wfv1.NodePending, node.Phase)
+	assert.Equal(t, "pod hello-world kept by retry is being deleted", node.Message)
+	_, err = pods.Get(ctx, wf.Name, metav1.GetOptions{})
+	require.True(t, apierr.IsNotFound(err))
+
+	woc = newWorkflowOperationCtx(ctx, woc.wf, controller)
+	woc.operate(ctx)
+	pod, err := pods.Get(ctx, wf.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, wf.Name, pod.Labels[common.LabelKeyWorkflow])
+	assert.NotContains(t, pod.Labels, common.LabelKeyRetriedWorkflow)
+}
+
 func Test_createWorkflowPod_containerName(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	woc := newWoc(ctx)
@@ -12,6 +12,7 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
+	"github.com/argoproj/argo-workflows/v3/util/telemetry"
 	waitutil "github.com/argoproj/argo-workflows/v3/util/wait"
 	"github.com/argoproj/argo-workflows/v3/workflow/packer"
 )
@@ -71,6 +72,8 @@ var readRetry = wait.Backoff{Steps: 5, Duration: 100 * time.Millisecond, Factor:
 var writeRetry = wait.Backoff{Steps: 5, Duration: 1 * time.Second, Factor: 2}
 
 func (h hydrator) Hydrate(ctx context.Context, wf *wfv1.Workflow) error {
+	ctx, span := telemetry.StartSpan(ctx, "Hydrate", wf.Namespace)
+	defer span.End()
 	log := logging.RequireLoggerFromContext(ctx)
 	err := packer.DecompressWorkflow(ctx, wf)
 	if err != nil {
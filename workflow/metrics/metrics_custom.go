@@ -279,7 +279,7 @@ func (m *Metrics) createCustomGauge(metricSpec *wfv1.Prometheus) error {
 	return inst.RegisterCallback(m.Metrics, customInst.customCallback)
 }
 
-func (m *Metrics) runCustomGC(ttl time.Duration) {
+func (m *Metrics) runCustomGC(ctx context.Context, ttl time.Duration) {
 	m.IterateROInstruments(func(baseMetric *telemetry.Instrument) {
 		ud := customUserData(baseMetric, false)
 		if ud == nil {
@@ -291,8 +291,10 @@ func (m *Metrics) runCustomGC(ttl time.Duration) {
 				switch {
 				case value.rtValueFunc != nil && value.completed:
 					delete(ud.values, key)
+					baseMetric.ForgetSeries(ctx, value.getLabels())
 				case value.rtValueFunc == nil:
 					delete(ud.values, key)
+					baseMetric.ForgetSeries(ctx, value.getLabels())
 				}
 			}
 		}
@@ -312,7 +314,7 @@ func (m *Metrics) customMetricsGC(ctx context.Context, ttl time.Duration) {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			m.runCustomGC(ttl)
+			m.runCustomGC(ctx, ttl)
 		}
 	}
 }
@@ -324,7 +326,7 @@ const (
 	Delete
 )
 
-func (m *Metrics) handleRealtimeMetricsForWfUID(key string, op operation) {
+func (m *Metrics) handleRealtimeMetricsForWfUID(ctx context.Context, key string, op operation) {
 	m.realtimeMutex.Lock()
 	defer m.realtimeMutex.Unlock()
 	if _, exists := m.realtimeWorkflows[key]; !exists {
@@ -340,7 +342,10 @@ func (m *Metrics) handleRealtimeMetricsForWfUID(key string, op operation) {
 				value.completed = true
 			}
 		case Delete:
-			delete(ud.values, metric.key)
+			if value, ok := ud.values[metric.key]; ok {
+				delete(ud.values, metric.key)
+				metric.inst.ForgetSeries(ctx, value.getLabels())
+			}
 		}
 		ud.mutex.Unlock()
 	}
@@ -349,10 +354,10 @@ func (m *Metrics) handleRealtimeMetricsForWfUID(key string, op operation) {
 	}
 }
 
-func (m *Metrics) CompleteRealtimeMetricsForWfUID(key string) {
-	m.handleRealtimeMetricsForWfUID(key, Complete)
+func (m *Metrics) CompleteRealtimeMetricsForWfUID(ctx context.Context, key string) {
+	m.handleRealtimeMetricsForWfUID(ctx, key, Complete)
 }
 
-func (m *Metrics) DeleteRealtimeMetricsForWfUID(key string) {
-	m.handleRealtimeMetricsForWfUID(key, Delete)
+func (m *Metrics) DeleteRealtimeMetricsForWfUID(ctx context.Context, key string) {
+	m.handleRealtimeMetricsForWfUID(ctx, key, Delete)
 }
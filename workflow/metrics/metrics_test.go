@@ -136,7 +136,7 @@ func TestRealtimeMetricGC(t *testing.T) {
 	assert.Len(t, userData.values, 1)
 
 	// simulate workflow is completed.
-	m.CompleteRealtimeMetricsForWfUID(wfKey)
+	m.CompleteRealtimeMetricsForWfUID(ctx, wfKey)
 	timeoutTime := time.Now().Add(time.Second * 2)
 	// Ensure we get at least one TTL run
 	for time.Now().Before(timeoutTime) {
@@ -182,7 +182,7 @@ func TestRealTimeMetricDeletion(t *testing.T) {
 	require.NoError(t, err)
 
 	// We've not yet fed a metric in for 123
-	m.DeleteRealtimeMetricsForWfUID("123")
+	m.DeleteRealtimeMetricsForWfUID(ctx, "123")
 	assert.Empty(t, m.realtimeWorkflows["123"])
 
 	const key string = `metric`
@@ -205,7 +205,7 @@ func TestRealTimeMetricDeletion(t *testing.T) {
 	baseCm := m.GetCustomMetric(key)
 	assert.NotNil(t, baseCm)
 
-	m.DeleteRealtimeMetricsForWfUID("456")
+	m.DeleteRealtimeMetricsForWfUID(ctx, "456")
 	assert.Empty(t, m.realtimeWorkflows["456"])
 
 	cm := customUserData(baseCm, true)
@@ -213,7 +213,7 @@ func TestRealTimeMetricDeletion(t *testing.T) {
 	assert.Len(t, cm.values, 1)
 	assert.Len(t, m.realtimeWorkflows["123"], 1)
 
-	m.DeleteRealtimeMetricsForWfUID("123")
+	m.DeleteRealtimeMetricsForWfUID(ctx, "123")
 	assert.Empty(t, m.realtimeWorkflows["123"])
 	assert.Empty(t, cm.values)
 
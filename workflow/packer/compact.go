@@ -0,0 +1,80 @@
+package packer
+
+import (
+	"reflect"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// CompactWorkflow removes the parts of each node that can be derived from the workflow, to shrink bulk exports: its ID,
+// which is its key, the workflow's name at the start of its name, and its display name if it is the last part of its
+// name. ExpandWorkflow restores them, recognising compacted nodes by their missing ID, as every node's ID must be its
+// key to be compacted. Unusual node status that would not be restored exactly is left as it is, in which case false is
+// returned.
+func CompactWorkflow(wf *wfv1.Workflow) bool {
+	if len(wf.Status.Nodes) == 0 {
+		return false
+	}
+	compacted := make(wfv1.Nodes, len(wf.Status.Nodes))
+	for id, node := range wf.Status.Nodes {
+		if node.ID != id {
+			return false
+		}
+		compacted[id] = compactNode(wf.Name, id, node)
+	}
+	for id, node := range compacted {
+		if !reflect.DeepEqual(expandNode(wf.Name, id, node), wf.Status.Nodes[id]) {
+			return false
+		}
+	}
+	wf.Status.Nodes = compacted
+	return true
+}
+
+// ExpandWorkflow restores the node status of a workflow compacted by CompactWorkflow. Other workflows, whose nodes
+// all have IDs, are unchanged.
+func ExpandWorkflow(wf *wfv1.Workflow) {
+	if !isCompacted(wf.Status.Nodes) {
+		return
+	}
+	for id, node := range wf.Status.Nodes {
+		wf.Status.Nodes[id] = expandNode(wf.Name, id, node)
+	}
+}
+
+func isCompacted(nodes wfv1.Nodes) bool {
+	for _, node := range nodes {
+		if node.ID == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func compactNode(wfName, id string, node wfv1.NodeStatus) wfv1.NodeStatus {
+	node.ID = ""
+	node.Name = strings.TrimPrefix(node.Name, wfName)
+	if node.DisplayName == displayName(wfName, node.Name) {
+		node.DisplayName = ""
+	}
+	return node
+}
+
+func expandNode(wfName, id string, node wfv1.NodeStatus) wfv1.NodeStatus {
+	node.ID = id
+	if node.DisplayName == "" {
+		node.DisplayName = displayName(wfName, node.Name)
+	}
+	node.Name = wfName + node.Name
+	return node
+}
+
+// displayName returns the usual display name of a node, given its name without the workflow's name, e.g. "step" for
+// "[0].step", or the workflow's name for the root node
+func displayName(wfName, name string) string {
+	if name == "" {
+		return wfName
+	}
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,84 @@
+package packer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func newCompactableWorkflow() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf.v1", Labels: map[string]string{"team": "a"}},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowSucceeded,
+			Nodes: wfv1.Nodes{
+				"my-wf.v1":            {ID: "my-wf.v1", Name: "my-wf.v1", DisplayName: "my-wf.v1", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeSucceeded, Children: []string{"my-wf.v1-1234"}},
+				"my-wf.v1-1234":       {ID: "my-wf.v1-1234", Name: "my-wf.v1[0]", DisplayName: "[0]", Type: wfv1.NodeTypeStepGroup, BoundaryID: "my-wf.v1", Children: []string{"my-wf.v1-5678"}},
+				"my-wf.v1-5678":       {ID: "my-wf.v1-5678", Name: "my-wf.v1[0].step", DisplayName: "step", Type: wfv1.NodeTypePod, BoundaryID: "my-wf.v1", TemplateName: "main", Outputs: &wfv1.Outputs{Parameters: []wfv1.Parameter{{Name: "p", Value: wfv1.AnyStringPtr("v")}}}},
+				"my-wf.v1-renamed-90": {ID: "my-wf.v1-renamed-90", Name: "my-wf.v1[0].other", DisplayName: "renamed", Type: wfv1.NodeTypePod},
+			},
+		},
+	}
+}
+
+func TestCompactWorkflow(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		wf := newCompactableWorkflow()
+		original := wf.DeepCopy()
+		require.True(t, CompactWorkflow(wf))
+		assert.Equal(t, wfv1.NodeStatus{Name: "[0].step", Type: wfv1.NodeTypePod, BoundaryID: "my-wf.v1", TemplateName: "main", Outputs: original.Status.Nodes["my-wf.v1-5678"].Outputs}, wf.Status.Nodes["my-wf.v1-5678"])
+		// a display name that is not the last part of the name is kept
+		assert.Equal(t, "renamed", wf.Status.Nodes["my-wf.v1-renamed-90"].DisplayName)
+
+		data, err := json.Marshal(wf)
+		require.NoError(t, err)
+		originalData, err := json.Marshal(original)
+		require.NoError(t, err)
+		assert.Less(t, len(data), len(originalData))
+		exported := &wfv1.Workflow{}
+		require.NoError(t, json.Unmarshal(data, exported))
+		ExpandWorkflow(exported)
+		assert.Equal(t, original, exported)
+	})
+	t.Run("Compressed", func(t *testing.T) {
+		ctx := logging.TestContext(t.Context())
+		wf := newCompactableWorkflow()
+		original := wf.DeepCopy()
+		require.True(t, CompactWorkflow(wf))
+		require.NoError(t, CompressWorkflow(ctx, wf))
+		require.NoError(t, DecompressWorkflow(ctx, wf))
+		ExpandWorkflow(wf)
+		assert.Equal(t, original, wf)
+	})
+	t.Run("NotRestorable", func(t *testing.T) {
+		wf := newCompactableWorkflow()
+		wf.Status.Nodes["other"] = wfv1.NodeStatus{ID: "other", Name: "another-wf[0].step", DisplayName: "step"}
+		original := wf.DeepCopy()
+		assert.False(t, CompactWorkflow(wf))
+		assert.Equal(t, original, wf)
+	})
+	t.Run("IDNotKey", func(t *testing.T) {
+		wf := newCompactableWorkflow()
+		wf.Status.Nodes["other"] = wfv1.NodeStatus{ID: "another", Name: "my-wf.v1[0].another", DisplayName: "another"}
+		original := wf.DeepCopy()
+		assert.False(t, CompactWorkflow(wf))
+		assert.Equal(t, original, wf)
+	})
+	t.Run("NoNodes", func(t *testing.T) {
+		wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "my-wf"}}
+		assert.False(t, CompactWorkflow(wf))
+	})
+}
+
+func TestExpandWorkflow(t *testing.T) {
+	wf := newCompactableWorkflow()
+	original := wf.DeepCopy()
+	ExpandWorkflow(wf)
+	assert.Equal(t, original, wf)
+}
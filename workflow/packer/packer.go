@@ -10,6 +10,7 @@ import (
 
 	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	"github.com/argoproj/argo-workflows/v3/util/file"
+	jsonutil "github.com/argoproj/argo-workflows/v3/util/json"
 )
 
 const envVarName = "MAX_WORKFLOW_SIZE"
@@ -40,6 +41,16 @@ func DecompressWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
 	return nil
 }
 
+// CompressedNodeCount returns the number of nodes of compressed node status, without unmarshalling them
+func CompressedNodeCount(compressedNodes string) (int, error) {
+	reader, err := file.DecodeDecompressReader(compressedNodes)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = reader.Close() }()
+	return jsonutil.CountKeys(reader)
+}
+
 // getSize return the entire workflow json string size
 func getSize(wf *wfv1.Workflow) (int, error) {
 	nodeContent, err := json.Marshal(wf)
@@ -71,14 +82,25 @@ func CompressWorkflowIfNeeded(ctx context.Context, wf *wfv1.Workflow) error {
 	return compressWorkflow(ctx, wf)
 }
 
-func compressWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
-	nodes := wf.Status.Nodes
-	nodeContent, err := json.Marshal(nodes)
+// CompressWorkflow compresses the node status into status.compressedNodes regardless of the workflow size.
+func CompressWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
+	if len(wf.Status.Nodes) == 0 {
+		return nil
+	}
+	nodeContent, err := json.Marshal(wf.Status.Nodes)
 	if err != nil {
 		return err
 	}
 	wf.Status.CompressedNodes = file.CompressEncodeString(ctx, string(nodeContent))
 	wf.Status.Nodes = nil
+	return nil
+}
+
+func compressWorkflow(ctx context.Context, wf *wfv1.Workflow) error {
+	nodes := wf.Status.Nodes
+	if err := CompressWorkflow(ctx, wf); err != nil {
+		return err
+	}
 	// still too large?
 	large, err := IsLargeWorkflow(wf)
 	if err != nil {
@@ -54,6 +54,22 @@ func TestDecompressWorkflow(t *testing.T) {
 		assert.NotEmpty(t, wf.Status.Nodes)
 		assert.Empty(t, wf.Status.CompressedNodes)
 	})
+	t.Run("CompressSmallWorkflow", func(t *testing.T) {
+		wf := &wfv1.Workflow{
+			Status: wfv1.WorkflowStatus{
+				Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{Name: "foo"}},
+			},
+		}
+		original := wf.DeepCopy()
+		err := CompressWorkflow(ctx, wf)
+		require.NoError(t, err)
+		assert.Empty(t, wf.Status.Nodes)
+		assert.NotEmpty(t, wf.Status.CompressedNodes)
+
+		err = DecompressWorkflow(ctx, wf)
+		require.NoError(t, err)
+		assert.Equal(t, original, wf)
+	})
 	t.Run("TooLargeToCompressWorkflow", func(t *testing.T) {
 		wf := &wfv1.Workflow{
 			Spec: wfv1.WorkflowSpec{Entrypoint: "main"},
@@ -70,3 +86,14 @@ func TestDecompressWorkflow(t *testing.T) {
 		assert.Empty(t, wf.Status.CompressedNodes)
 	})
 }
+
+func TestCompressedNodeCount(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	wf := &wfv1.Workflow{Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"foo": wfv1.NodeStatus{}, "bar": wfv1.NodeStatus{}}}}
+	require.NoError(t, CompressWorkflow(ctx, wf))
+	count, err := CompressedNodeCount(wf.Status.CompressedNodes)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	_, err = CompressedNodeCount("not-compressed")
+	require.Error(t, err)
+}
@@ -0,0 +1,194 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v3/errors"
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+)
+
+// failedEntrypointName is the name of the entrypoint of a workflow resubmitted with only its failed nodes
+const failedEntrypointName = "resubmit-failed"
+
+var invalidTaskNameChars = regexp.MustCompile(`[^a-zA-Z0-9-]+`)
+
+// PruneResubmitToFailedNodes changes newWF, the resubmission of wf, to only run the nodes that failed in wf, which must
+// have its nodes hydrated. Its entrypoint becomes a DAG with a task for each failed node, run with the node's inputs,
+// and its templates are pruned to those these tasks, its exit handler and its hooks need.
+// A failed pod that was retried is resubmitted once, as its retry node, and not at all if a later attempt succeeded.
+// Templates of a workflow template that wf references are not part of its spec, so cannot be pruned.
+func PruneResubmitToFailedNodes(newWF *wfv1.Workflow, wf *wfv1.Workflow) error {
+	failed := failedNodes(wf)
+	if len(failed) == 0 {
+		return errors.Errorf(errors.CodeBadRequest, "workflow %s has no failed nodes to resubmit", wf.Name)
+	}
+	roots := []string{newWF.Spec.OnExit}
+	for _, hook := range newWF.Spec.Hooks {
+		roots = append(roots, hook.Template)
+	}
+	dag := &wfv1.DAGTemplate{}
+	taskNames := map[string]string{}
+	used := map[string]bool{}
+	for _, node := range failed {
+		task := wfv1.DAGTask{Name: failedTaskName(node, used)}
+		if node.Inputs != nil {
+			task.Arguments = wfv1.Arguments{Parameters: node.Inputs.Parameters, Artifacts: node.Inputs.Artifacts}
+		}
+		scope, scopeName := node.GetTemplateScope()
+		switch {
+		case node.TemplateRef != nil:
+			task.TemplateRef = node.TemplateRef
+		case node.TemplateName == "":
+			return errors.Errorf(errors.CodeBadRequest, "failed node %s cannot be resubmitted on its own as its template is inline", node.Name)
+		case scope != wfv1.ResourceScopeLocal:
+			// the template was resolved within a workflow template that another template referenced
+			task.TemplateRef = &wfv1.TemplateRef{Name: scopeName, Template: node.TemplateName, ClusterScope: scope == wfv1.ResourceScopeCluster}
+		default:
+			task.Template = node.TemplateName
+			roots = append(roots, node.TemplateName)
+		}
+		taskNames[node.ID] = task.Name
+		dag.Tasks = append(dag.Tasks, task)
+	}
+	// a failed node that ran after another, e.g. because that one could continue on failure, runs after it again
+	for i, node := range failed {
+		for _, upstream := range failed {
+			if upstream.ID != node.ID && isDescendant(wf.Status.Nodes, upstream.ID, node.ID) {
+				dag.Tasks[i].Dependencies = append(dag.Tasks[i].Dependencies, taskNames[upstream.ID])
+			}
+		}
+	}
+
+	entrypoint := failedEntrypointName
+	for i := 1; newWF.GetTemplateByName(entrypoint) != nil; i++ {
+		entrypoint = fmt.Sprintf("%s-%d", failedEntrypointName, i)
+	}
+	if newWF.Spec.WorkflowTemplateRef == nil {
+		referenced := referencedTemplates(newWF.Spec.Templates, roots)
+		var templates []wfv1.Template
+		for _, tmpl := range newWF.Spec.Templates {
+			if referenced[tmpl.Name] {
+				templates = append(templates, tmpl)
+			}
+		}
+		newWF.Spec.Templates = templates
+	}
+	newWF.Spec.Templates = append(newWF.Spec.Templates, wfv1.Template{Name: entrypoint, DAG: dag})
+	newWF.Spec.Entrypoint = entrypoint
+	return nil
+}
+
+// failedNodes returns the failed pods of the workflow, or their retry node if they were retried, sorted by name.
+// The exit handler is not part of what failed.
+func failedNodes(wf *wfv1.Workflow) []wfv1.NodeStatus {
+	parents := map[string]string{}
+	for id, node := range wf.Status.Nodes {
+		for _, child := range node.Children {
+			parents[child] = id
+		}
+	}
+	onExitNodeName := wf.Name + ".onExit"
+	seen := map[string]bool{}
+	var failed []wfv1.NodeStatus
+	for id, node := range wf.Status.Nodes {
+		if node.Type != wfv1.NodeTypePod || !node.FailedOrError() || strings.HasPrefix(node.Name, onExitNodeName) {
+			continue
+		}
+		node.ID = id
+		if parent, ok := wf.Status.Nodes[parents[id]]; ok && parent.Type == wfv1.NodeTypeRetry {
+			if !parent.FailedOrError() {
+				continue
+			}
+			parent.ID = parents[id]
+			node = parent
+		}
+		if seen[node.ID] {
+			continue
+		}
+		seen[node.ID] = true
+		failed = append(failed, node)
+	}
+	sort.Slice(failed, func(i, j int) bool {
+		return failed[i].Name < failed[j].Name
+	})
+	return failed
+}
+
+// failedTaskName returns a unique, valid, task name for the node, from its display name
+func failedTaskName(node wfv1.NodeStatus, used map[string]bool) string {
+	name := strings.Trim(invalidTaskNameChars.ReplaceAllString(node.DisplayName, "-"), "-")
+	if name == "" {
+		name = "failed"
+	}
+	unique := name
+	for i := 1; used[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// isDescendant returns whether the node with ID descendantID can be reached from the node with ID id by its children
+func isDescendant(nodes wfv1.Nodes, id, descendantID string) bool {
+	visited := map[string]bool{}
+	queue := nodes[id].Children
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = queue[1:]
+		if child == descendantID {
+			return true
+		}
+		if visited[child] {
+			continue
+		}
+		visited[child] = true
+		queue = append(queue, nodes[child].Children...)
+	}
+	return false
+}
+
+// referencedTemplates returns the names of the templates that the named templates are, or run, directly or indirectly
+func referencedTemplates(templates []wfv1.Template, names []string) map[string]bool {
+	byName := map[string]*wfv1.Template{}
+	for i := range templates {
+		byName[templates[i].Name] = &templates[i]
+	}
+	referenced := map[string]bool{}
+	var add func(name string)
+	var visit func(tmpl *wfv1.Template)
+	add = func(name string) {
+		if tmpl, ok := byName[name]; ok && !referenced[name] {
+			referenced[name] = true
+			visit(tmpl)
+		}
+	}
+	run := func(template string, inline *wfv1.Template, onExit string, hooks wfv1.LifecycleHooks) {
+		add(template)
+		add(onExit)
+		for _, hook := range hooks {
+			add(hook.Template)
+		}
+		if inline != nil {
+			visit(inline)
+		}
+	}
+	visit = func(tmpl *wfv1.Template) {
+		for _, parallelSteps := range tmpl.Steps {
+			for _, step := range parallelSteps.Steps {
+				run(step.Template, step.Inline, step.OnExit, step.Hooks)
+			}
+		}
+		if tmpl.DAG != nil {
+			for _, task := range tmpl.DAG.Tasks {
+				run(task.Template, task.Inline, task.OnExit, task.Hooks)
+			}
+		}
+	}
+	for _, name := range names {
+		add(name)
+	}
+	return referenced
+}
@@ -0,0 +1,139 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wfv1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v3/util/logging"
+)
+
+func partiallyFailedDAGWorkflow() *wfv1.Workflow {
+	message := &wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("hello")}}}
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			OnExit:     "exit-handler",
+			Templates: []wfv1.Template{
+				{Name: "main", DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{
+					{Name: "A", Template: "echo"},
+					{Name: "B", Template: "flaky", Depends: "A", Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("{{tasks.A.outputs.result}}")}}}},
+					{Name: "C", Template: "flaky", Depends: "A", Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("world")}}}},
+					{Name: "D", Template: "report", Depends: "B && C"},
+				}}},
+				{Name: "echo", Container: &corev1.Container{Image: "alpine"}},
+				{Name: "flaky", Inputs: wfv1.Inputs{Parameters: []wfv1.Parameter{{Name: "message"}}}, RetryStrategy: &wfv1.RetryStrategy{}, Container: &corev1.Container{Image: "alpine"}},
+				{Name: "report", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{{Name: "echo", Template: "echo"}}}}},
+				{Name: "exit-handler", Container: &corev1.Container{Image: "alpine"}},
+			},
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowFailed,
+			Nodes: wfv1.Nodes{
+				"my-wf":      {ID: "my-wf", Name: "my-wf", DisplayName: "my-wf", Type: wfv1.NodeTypeDAG, TemplateName: "main", Phase: wfv1.NodeFailed, Children: []string{"my-wf-a"}},
+				"my-wf-a":    {ID: "my-wf-a", Name: "my-wf.A", DisplayName: "A", Type: wfv1.NodeTypePod, TemplateName: "echo", Phase: wfv1.NodeSucceeded, Children: []string{"my-wf-b", "my-wf-c"}},
+				"my-wf-b":    {ID: "my-wf-b", Name: "my-wf.B", DisplayName: "B", Type: wfv1.NodeTypeRetry, TemplateName: "flaky", Phase: wfv1.NodeFailed, Inputs: message, Children: []string{"my-wf-b-0", "my-wf-b-1"}},
+				"my-wf-b-0":  {ID: "my-wf-b-0", Name: "my-wf.B(0)", DisplayName: "B(0)", Type: wfv1.NodeTypePod, TemplateName: "flaky", Phase: wfv1.NodeFailed, Inputs: message},
+				"my-wf-b-1":  {ID: "my-wf-b-1", Name: "my-wf.B(1)", DisplayName: "B(1)", Type: wfv1.NodeTypePod, TemplateName: "flaky", Phase: wfv1.NodeFailed, Inputs: message, Children: []string{"my-wf-d"}},
+				"my-wf-c":    {ID: "my-wf-c", Name: "my-wf.C", DisplayName: "C", Type: wfv1.NodeTypeRetry, TemplateName: "flaky", Phase: wfv1.NodeSucceeded, Children: []string{"my-wf-c-0", "my-wf-c-1"}},
+				"my-wf-c-0":  {ID: "my-wf-c-0", Name: "my-wf.C(0)", DisplayName: "C(0)", Type: wfv1.NodeTypePod, TemplateName: "flaky", Phase: wfv1.NodeFailed},
+				"my-wf-c-1":  {ID: "my-wf-c-1", Name: "my-wf.C(1)", DisplayName: "C(1)", Type: wfv1.NodeTypePod, TemplateName: "flaky", Phase: wfv1.NodeSucceeded, Children: []string{"my-wf-d"}},
+				"my-wf-d":    {ID: "my-wf-d", Name: "my-wf.D", DisplayName: "D", Type: wfv1.NodeTypeSkipped, TemplateName: "report", Phase: wfv1.NodeOmitted},
+				"my-wf-exit": {ID: "my-wf-exit", Name: "my-wf.onExit", DisplayName: "my-wf.onExit", Type: wfv1.NodeTypePod, TemplateName: "exit-handler", Phase: wfv1.NodeFailed},
+			},
+		},
+	}
+}
+
+func TestPruneResubmitToFailedNodes(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	t.Run("PartiallyFailedDAG", func(t *testing.T) {
+		wf := partiallyFailedDAGWorkflow()
+		newWF, err := FormulateResubmitWorkflow(ctx, wf, ResubmitOpts{})
+		require.NoError(t, err)
+		require.NoError(t, PruneResubmitToFailedNodes(newWF, wf))
+
+		assert.Equal(t, "resubmit-failed", newWF.Spec.Entrypoint)
+		assert.Equal(t, "exit-handler", newWF.Spec.OnExit)
+		var names []string
+		for _, tmpl := range newWF.Spec.Templates {
+			names = append(names, tmpl.Name)
+		}
+		// only B failed, and it only needs its own template, plus the exit handler
+		assert.Equal(t, []string{"flaky", "exit-handler", "resubmit-failed"}, names)
+		entrypoint := newWF.GetTemplateByName("resubmit-failed")
+		require.NotNil(t, entrypoint.DAG)
+		assert.Equal(t, []wfv1.DAGTask{{
+			Name:      "B",
+			Template:  "flaky",
+			Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("hello")}}},
+		}}, entrypoint.DAG.Tasks)
+		// the original workflow is untouched
+		assert.Equal(t, "main", wf.Spec.Entrypoint)
+		assert.Len(t, wf.Spec.Templates, 5)
+	})
+	t.Run("Dependencies", func(t *testing.T) {
+		// X could continue on failure, so Y ran after it, and failed too
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+			Spec:       wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main"}, {Name: "echo"}}},
+			Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+				"x": {ID: "x", Name: "my-wf[0].x", DisplayName: "x", Type: wfv1.NodeTypePod, TemplateName: "echo", Phase: wfv1.NodeFailed, Children: []string{"1"}},
+				"1": {ID: "1", Name: "my-wf[1]", DisplayName: "[1]", Type: wfv1.NodeTypeStepGroup, Phase: wfv1.NodeFailed, Children: []string{"y"}},
+				"y": {ID: "y", Name: "my-wf[1].y", DisplayName: "y", Type: wfv1.NodeTypePod, TemplateName: "echo", Phase: wfv1.NodeError},
+			}},
+		}
+		newWF := wf.DeepCopy()
+		require.NoError(t, PruneResubmitToFailedNodes(newWF, wf))
+		tasks := newWF.GetTemplateByName("resubmit-failed").DAG.Tasks
+		require.Len(t, tasks, 2)
+		assert.Equal(t, "x", tasks[0].Name)
+		assert.Empty(t, tasks[0].Dependencies)
+		assert.Equal(t, "y", tasks[1].Name)
+		assert.Equal(t, []string{"x"}, tasks[1].Dependencies)
+	})
+	t.Run("WorkflowTemplateScope", func(t *testing.T) {
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+			Spec:       wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main"}}},
+			Status: wfv1.WorkflowStatus{Nodes: wfv1.Nodes{
+				"x": {ID: "x", Name: "my-wf.x", DisplayName: "x", Type: wfv1.NodeTypePod, TemplateName: "echo", TemplateScope: "namespaced/my-wftmpl", Phase: wfv1.NodeFailed},
+			}},
+		}
+		newWF := wf.DeepCopy()
+		require.NoError(t, PruneResubmitToFailedNodes(newWF, wf))
+		tasks := newWF.GetTemplateByName("resubmit-failed").DAG.Tasks
+		require.Len(t, tasks, 1)
+		assert.Empty(t, tasks[0].Template)
+		assert.Equal(t, &wfv1.TemplateRef{Name: "my-wftmpl", Template: "echo"}, tasks[0].TemplateRef)
+		assert.Len(t, newWF.Spec.Templates, 1)
+	})
+	t.Run("NoFailedNodes", func(t *testing.T) {
+		wf := &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+			Status:     wfv1.WorkflowStatus{Nodes: wfv1.Nodes{"my-wf": {ID: "my-wf", Name: "my-wf", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded}}},
+		}
+		require.EqualError(t, PruneResubmitToFailedNodes(wf.DeepCopy(), wf), "workflow my-wf has no failed nodes to resubmit")
+	})
+}
+
+func TestReferencedTemplates(t *testing.T) {
+	templates := []wfv1.Template{
+		{Name: "main", Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{
+			{Name: "a", Template: "a", Hooks: wfv1.LifecycleHooks{wfv1.ExitLifecycleEvent: {Template: "hook"}}},
+			{Name: "inline", Inline: &wfv1.Template{DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{{Name: "b", Template: "b", OnExit: "exit"}}}}},
+		}}}},
+		{Name: "a"},
+		{Name: "b"},
+		{Name: "hook"},
+		{Name: "exit"},
+		{Name: "unused"},
+	}
+	assert.Equal(t, map[string]bool{"main": true, "a": true, "b": true, "hook": true, "exit": true}, referencedTemplates(templates, []string{"main"}))
+	assert.Equal(t, map[string]bool{"a": true}, referencedTemplates(templates, []string{"a", "not-found", ""}))
+}
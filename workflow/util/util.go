@@ -29,7 +29,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers/internalinterfaces"
 	"k8s.io/client-go/tools/cache"
@@ -45,6 +47,7 @@ import (
 	"github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/typed/workflow/v1alpha1"
 	cmdutil "github.com/argoproj/argo-workflows/v3/util/cmd"
 	errorsutil "github.com/argoproj/argo-workflows/v3/util/errors"
+	argointstr "github.com/argoproj/argo-workflows/v3/util/intstr"
 	"github.com/argoproj/argo-workflows/v3/util/logging"
 	"github.com/argoproj/argo-workflows/v3/util/retry"
 	unstructutil "github.com/argoproj/argo-workflows/v3/util/unstructured"
@@ -260,6 +263,10 @@ func ApplySubmitOpts(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
 		wf.Spec.Priority = opts.Priority
 	}
 
+	if opts.StartSuspended || opts.StartAt != nil {
+		wf.Spec.Suspend = ptr.To(true)
+	}
+
 	wfLabels := wf.GetLabels()
 	if wfLabels == nil {
 		wfLabels = make(map[string]string)
@@ -287,8 +294,11 @@ func ApplySubmitOpts(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
 			wfAnnotations[k] = v
 		}
 	}
+	if opts.StartAt != nil {
+		wfAnnotations[common.AnnotationKeyStartAt] = opts.StartAt.UTC().Format(time.RFC3339)
+	}
 	wf.SetAnnotations(wfAnnotations)
-	err := overrideParameters(wf, opts.Parameters)
+	err := overrideParameters(wf, opts.Parameters, nil)
 	if err != nil {
 		return err
 	}
@@ -304,8 +314,10 @@ func ApplySubmitOpts(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
 	return nil
 }
 
-func overrideParameters(wf *wfv1.Workflow, parameters []string) error {
-	if len(parameters) > 0 {
+// overrideParameters overrides the workflow's arguments with the parameters, of the form NAME=VALUE, and the
+// parameters whose values are read from a config map when the workflow runs
+func overrideParameters(wf *wfv1.Workflow, parameters []string, parametersFrom []wfv1.Parameter) error {
+	if len(parameters) > 0 || len(parametersFrom) > 0 {
 		newParams := make([]wfv1.Parameter, 0)
 		passedParams := make(map[string]bool)
 		for _, paramStr := range parameters {
@@ -317,6 +329,19 @@ func overrideParameters(wf *wfv1.Workflow, parameters []string) error {
 			newParams = append(newParams, param)
 			passedParams[param.Name] = true
 		}
+		for _, param := range parametersFrom {
+			if err := validateParameterFrom(param); err != nil {
+				return err
+			}
+			if passedParams[param.Name] {
+				return fmt.Errorf("parameter %q is overridden more than once", param.Name)
+			}
+			newParams = append(newParams, wfv1.Parameter{
+				Name:      param.Name,
+				ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: param.ValueFrom.ConfigMapKeyRef, Default: param.ValueFrom.Default},
+			})
+			passedParams[param.Name] = true
+		}
 		for _, param := range wf.Spec.Arguments.Parameters {
 			if _, ok := passedParams[param.Name]; ok {
 				// this parameter was overridden via command line
@@ -332,6 +357,95 @@ func overrideParameters(wf *wfv1.Workflow, parameters []string) error {
 	return nil
 }
 
+// validateParameterFrom checks the parameter's value is read from a config map key, which is the only source the
+// controller resolves for the arguments of a workflow
+func validateParameterFrom(param wfv1.Parameter) error {
+	if param.Name == "" {
+		return fmt.Errorf("parameter overridden from a config map must have a name")
+	}
+	if param.Value != nil {
+		return fmt.Errorf("parameter %q overridden from a config map must not have a value", param.Name)
+	}
+	if param.ValueFrom == nil || param.ValueFrom.ConfigMapKeyRef == nil || param.ValueFrom.ConfigMapKeyRef.Name == "" || param.ValueFrom.ConfigMapKeyRef.Key == "" {
+		return fmt.Errorf("parameter %q must be overridden from a config map, with valueFrom.configMapKeyRef.name and key", param.Name)
+	}
+	return nil
+}
+
+// MaxRetryLimitOverride is the largest retry limit that can be set on a template when retrying a workflow
+const MaxRetryLimitOverride = 100
+
+// OverrideRetryLimits raises the retryStrategy.limit of templates, given in the form TEMPLATE=LIMIT, so that a
+// retried workflow gets more attempts. The templates must already have a retry strategy and the limit can only be raised.
+func OverrideRetryLimits(wf *wfv1.Workflow, retryLimits []string) error {
+	for _, retryLimitStr := range retryLimits {
+		parts := strings.SplitN(retryLimitStr, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf(errors.CodeBadRequest, "expected retry limit of the form: TEMPLATE=LIMIT. Received: %s", retryLimitStr)
+		}
+		name := parts[0]
+		limit, err := strconv.Atoi(parts[1])
+		if err != nil || limit < 1 || limit > MaxRetryLimitOverride {
+			return errors.Errorf(errors.CodeBadRequest, "retry limit for template %s must be an integer between 1 and %d. Received: %s", name, MaxRetryLimitOverride, parts[1])
+		}
+		templates := [][]wfv1.Template{wf.Spec.Templates}
+		if wf.Status.StoredWorkflowSpec != nil {
+			templates = append(templates, wf.Status.StoredWorkflowSpec.Templates)
+		}
+		found := false
+		for _, tmpls := range templates {
+			for i := range tmpls {
+				tmpl := &tmpls[i]
+				if tmpl.Name != name {
+					continue
+				}
+				found = true
+				if tmpl.RetryStrategy == nil {
+					return errors.Errorf(errors.CodeBadRequest, "template %s has no retryStrategy", name)
+				}
+				if current, err := argointstr.Int(tmpl.RetryStrategy.Limit); err == nil && current != nil && *current > limit {
+					return errors.Errorf(errors.CodeBadRequest, "retry limit for template %s cannot be lowered from %d to %d", name, *current, limit)
+				}
+				tmpl.RetryStrategy.Limit = ptr.To(intstr.FromInt(limit))
+			}
+		}
+		if !found {
+			return errors.Errorf(errors.CodeBadRequest, "template %s not found", name)
+		}
+	}
+	return nil
+}
+
+// OverrideRetryEntrypoint changes the entrypoint of a retried workflow, and returns the pods to delete. Nodes are named
+// after their path from the entrypoint, so none of the existing nodes can be reused: they are all removed, so that the
+// workflow runs again from the new entrypoint. Nothing is changed if the entrypoint is empty or the same.
+func OverrideRetryEntrypoint(wf *wfv1.Workflow, entrypoint string) ([]string, error) {
+	if entrypoint == "" || entrypoint == wf.Spec.Entrypoint {
+		return nil, nil
+	}
+	isEntrypoint := func(tmpl wfv1.Template) bool { return tmpl.Name == entrypoint }
+	found := slices.ContainsFunc(wf.Spec.Templates, isEntrypoint)
+	if wf.Status.StoredWorkflowSpec != nil {
+		found = found || slices.ContainsFunc(wf.Status.StoredWorkflowSpec.Templates, isEntrypoint)
+	}
+	if !found {
+		return nil, errors.Errorf(errors.CodeBadRequest, "entrypoint template %s not found", entrypoint)
+	}
+	wf.Spec.Entrypoint = entrypoint
+	if wf.Status.StoredWorkflowSpec != nil {
+		wf.Status.StoredWorkflowSpec.Entrypoint = entrypoint
+	}
+	deletedPods := make(map[string]bool)
+	podsToDelete := []string{}
+	for _, n := range wf.Status.Nodes {
+		if n.Type == wfv1.NodeTypePod {
+			deletedPods, podsToDelete = deletePodNodeDuringRetryWorkflow(wf, n, deletedPods, podsToDelete)
+		}
+	}
+	wf.Status.Nodes = wfv1.Nodes{}
+	return podsToDelete, nil
+}
+
 func ReadParametersFile(file string, opts *wfv1.SubmitOpts) error {
 	var body []byte
 	var err error
@@ -637,8 +751,20 @@ func RandSuffix() string {
 	return randString(5)
 }
 
-// FormulateResubmitWorkflow formulate a new workflow from a previous workflow, optionally re-using successful nodes
-func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized bool, parameters []string) (*wfv1.Workflow, error) {
+// ResubmitOpts are the options of FormulateResubmitWorkflow
+type ResubmitOpts struct {
+	// Memoized re-uses the successful nodes of the previous workflow
+	Memoized bool
+	// Parameters override the parameters of the workflow, each of the form name=value
+	Parameters []string
+	// ParametersFrom override parameters with values read from a config map key when the workflow runs
+	ParametersFrom []wfv1.Parameter
+	// ServiceAccount is the service account to run the new workflow as, rather than that of the previous workflow
+	ServiceAccount string
+}
+
+// FormulateResubmitWorkflow formulate a new workflow from a previous workflow, optionally re-using successful nodes.
+func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, opts ResubmitOpts) (*wfv1.Workflow, error) {
 	log := logging.RequireLoggerFromContext(ctx)
 	newWF := wfv1.Workflow{}
 	newWF.TypeMeta = wf.TypeMeta
@@ -652,7 +778,7 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 	// When resubmitting workflow with memoized nodes, we need to use a predetermined workflow name
 	// in order to formulate the node statuses. Which means we cannot reuse metadata.generateName
 	// The following simulates the behavior of generateName
-	if memoized {
+	if opts.Memoized {
 		switch wf.Status.Phase {
 		case wfv1.WorkflowFailed, wfv1.WorkflowError:
 		default:
@@ -700,18 +826,25 @@ func FormulateResubmitWorkflow(ctx context.Context, wf *wfv1.Workflow, memoized
 	// Setting OwnerReference from original Workflow
 	newWF.OwnerReferences = append(newWF.OwnerReferences, wf.OwnerReferences...)
 
+	if opts.ServiceAccount != "" {
+		if errs := validation.IsDNS1123Subdomain(opts.ServiceAccount); len(errs) > 0 {
+			return nil, errors.Errorf(errors.CodeBadRequest, "invalid service account name %q: %s", opts.ServiceAccount, strings.Join(errs, "; "))
+		}
+		newWF.Spec.ServiceAccountName = opts.ServiceAccount
+	}
+
 	// Override parameters
-	if parameters != nil {
-		if _, ok := wf.Labels[common.LabelKeyPreviousWorkflowName]; ok || memoized {
+	if opts.Parameters != nil || opts.ParametersFrom != nil {
+		if _, ok := wf.Labels[common.LabelKeyPreviousWorkflowName]; ok || opts.Memoized {
 			log.Warn(ctx, "Overriding parameters on memoized or resubmitted workflows may have unexpected results")
 		}
-		err := overrideParameters(&newWF, parameters)
+		err := overrideParameters(&newWF, opts.Parameters, opts.ParametersFrom)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if !memoized {
+	if !opts.Memoized {
 		return &newWF, nil
 	}
 
@@ -808,7 +941,7 @@ func deletePodNodeDuringRetryWorkflow(wf *wfv1.Workflow, node wfv1.NodeStatus, d
 	return deletedPods, podsToDelete
 }
 
-func createNewRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, parameters []string) (*wfv1.Workflow, error) {
+func createNewRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, parameters []string, parametersFrom []wfv1.Parameter) (*wfv1.Workflow, error) {
 	log := logging.RequireLoggerFromContext(ctx)
 	newWF := wf.DeepCopy()
 
@@ -832,11 +965,11 @@ func createNewRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, parameters [
 		newWF.Spec.ActiveDeadlineSeconds = nil
 	}
 	// Override parameters
-	if parameters != nil {
+	if parameters != nil || parametersFrom != nil {
 		if _, ok := wf.Labels[common.LabelKeyPreviousWorkflowName]; ok {
 			log.Warn(ctx, "Overriding parameters on resubmitted workflows may have unexpected results")
 		}
-		err := overrideParameters(newWF, parameters)
+		err := overrideParameters(newWF, parameters, parametersFrom)
 		if err != nil {
 			return nil, err
 		}
@@ -844,6 +977,24 @@ func createNewRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, parameters [
 	return newWF, nil
 }
 
+// ClearAllOutputParameters can be given to FormulateRetryWorkflow to clear all of the workflow's output parameters
+const ClearAllOutputParameters = "*"
+
+// clearWorkflowOutputParameters removes the named parameters from the workflow's outputs. Names that are not outputs
+// are ignored, as the node that sets them may never have run.
+func clearWorkflowOutputParameters(wf *wfv1.Workflow, names []string) {
+	if len(names) == 0 || wf.Status.Outputs == nil {
+		return
+	}
+	if slices.Contains(names, ClearAllOutputParameters) {
+		wf.Status.Outputs.Parameters = nil
+		return
+	}
+	wf.Status.Outputs.Parameters = slices.DeleteFunc(wf.Status.Outputs.Parameters, func(p wfv1.Parameter) bool {
+		return slices.Contains(names, p.Name)
+	})
+}
+
 type dagNode struct {
 	n        *wfv1.NodeStatus
 	parent   *dagNode
@@ -1139,6 +1290,40 @@ func dagSortedNodes(nodes []*dagNode, rootNodeName string) []*dagNode {
 	return sortedNodes
 }
 
+// RetryOpts are the options of FormulateRetryWorkflow
+type RetryOpts struct {
+	// RestartSuccessful resets the successful nodes matching the NodeFieldSelector too
+	RestartSuccessful bool
+	NodeFieldSelector string
+	// Parameters override the parameters of the workflow, each of the form name=value
+	Parameters []string
+	// ParametersFrom override parameters with values read from a config map key, by the controller when it runs the
+	// workflow, as for the arguments of a submitted workflow
+	ParametersFrom []wfv1.Parameter
+	// ClearOutputParameters are the named output parameters removed from the outputs of the workflow, whether or not
+	// the nodes that set them are reset, with ClearAllOutputParameters removing every one. A node that is re-run sets
+	// its output parameters again.
+	ClearOutputParameters []string
+}
+
+// ValidateRetryable returns an error if the workflow cannot be retried with the options. Only failed or errored workflows
+// can be retried, and succeeded workflows only to restart the successful nodes matching a node field selector.
+func ValidateRetryable(wf *wfv1.Workflow, opts RetryOpts) error {
+	switch wf.Status.Phase {
+	case wfv1.WorkflowFailed, wfv1.WorkflowError:
+		return nil
+	case wfv1.WorkflowSucceeded:
+		if opts.RestartSuccessful && opts.NodeFieldSelector != "" {
+			return nil
+		}
+		return errors.Errorf(errors.CodeBadRequest, "workflow %s has succeeded, to retry it set the options restartSuccessful and nodeFieldSelector", wf.Name)
+	case wfv1.WorkflowUnknown:
+		return errors.Errorf(errors.CodeBadRequest, "workflow %s has not started, only failed or errored workflows can be retried", wf.Name)
+	default:
+		return errors.Errorf(errors.CodeBadRequest, "workflow %s cannot be retried in phase %s, only failed or errored workflows can be retried", wf.Name, wf.Status.Phase)
+	}
+}
+
 // FormulateRetryWorkflow attempts to retry a workflow
 // The logic is as follows:
 // create a DAG
@@ -1146,16 +1331,9 @@ func dagSortedNodes(nodes []*dagNode, rootNodeName string) []*dagNode {
 // iterate through all must delete nodes: iterator $node
 // obtain singular path to each $node
 // reset all "reset points" to $node
-func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, restartSuccessful bool, nodeFieldSelector string, parameters []string) (*wfv1.Workflow, []string, error) {
-
-	switch wf.Status.Phase {
-	case wfv1.WorkflowFailed, wfv1.WorkflowError:
-	case wfv1.WorkflowSucceeded:
-		if !restartSuccessful || len(nodeFieldSelector) <= 0 {
-			return nil, nil, errors.Errorf(errors.CodeBadRequest, "To retry a succeeded workflow, set the options restartSuccessful and nodeFieldSelector")
-		}
-	default:
-		return nil, nil, errors.Errorf(errors.CodeBadRequest, "Cannot retry a workflow in phase %s", wf.Status.Phase)
+func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, opts RetryOpts) (*wfv1.Workflow, []string, error) {
+	if err := ValidateRetryable(wf, opts); err != nil {
+		return nil, nil, err
 	}
 
 	onExitNodeName := wf.Name + ".onExit"
@@ -1165,12 +1343,13 @@ func FormulateRetryWorkflow(ctx context.Context, wf *wfv1.Workflow, restartSucce
 		logging.RequireLoggerFromContext(ctx).WithPanic().WithError(err).Error(ctx, "Failed to decompress workflow")
 	}
 
-	newWf, err := createNewRetryWorkflow(ctx, wf, parameters)
+	newWf, err := createNewRetryWorkflow(ctx, wf, opts.Parameters, opts.ParametersFrom)
 	if err != nil {
 		return nil, nil, err
 	}
+	clearWorkflowOutputParameters(newWf, opts.ClearOutputParameters)
 
-	deleteNodesMap, err := getNodeIDsToReset(restartSuccessful, nodeFieldSelector, wf.Status.Nodes)
+	deleteNodesMap, err := getNodeIDsToReset(opts.RestartSuccessful, opts.NodeFieldSelector, wf.Status.Nodes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/argoproj/argo-workflows/v3/util/logging"
 
@@ -17,6 +18,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/yaml"
 
 	"github.com/argoproj/argo-workflows/v3/server/auth"
@@ -77,7 +80,7 @@ func TestResubmitWorkflowWithOnExit(t *testing.T) {
 		Phase: wfv1.NodeSucceeded,
 	}
 	wf.Status.Nodes.Set(ctx, onExitID, onExitNode)
-	newWF, err := FormulateResubmitWorkflow(ctx, &wf, true, nil)
+	newWF, err := FormulateResubmitWorkflow(ctx, &wf, ResubmitOpts{Memoized: true})
 	require.NoError(t, err)
 	newWFOnExitName := newWF.Name + ".onExit"
 	newWFOneExitID := newWF.NodeID(newWFOnExitName)
@@ -497,6 +500,29 @@ func TestUpdateSuspendedNode(t *testing.T) {
 	require.EqualError(t, err, "cannot set output parameters because node is not expecting any raw parameters")
 }
 
+func TestSetWorkflowNodeMessage(t *testing.T) {
+	wfIf := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("")
+	ctx := logging.TestContext(t.Context())
+	_, err := wfIf.Create(ctx, wfv1.MustUnmarshalWorkflow(susWorkflow), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	err = SetWorkflow(ctx, wfIf, hydratorfake.Noop, "suspend-template", "displayName=approve", SetOperationValues{Phase: wfv1.NodeFailed, Message: "rejected by the on-call engineer"})
+	require.NoError(t, err)
+
+	wf, err := wfIf.Get(ctx, "suspend-template", metav1.GetOptions{})
+	require.NoError(t, err)
+	node := wf.Status.Nodes["suspend-template-kgfn7-2667278707"]
+	assert.Equal(t, wfv1.NodeFailed, node.Phase)
+	assert.Equal(t, "rejected by the on-call engineer", node.Message)
+	// only the selected node is annotated
+	assert.Empty(t, wf.Status.Message)
+	for id, n := range wf.Status.Nodes {
+		if id != node.ID {
+			assert.Empty(t, n.Message)
+		}
+	}
+}
+
 func TestSelectorMatchesNode(t *testing.T) {
 	tests := map[string]struct {
 		selector string
@@ -613,6 +639,56 @@ func TestApplySubmitOpts(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "abc", wf.Spec.PodPriorityClassName)
 	})
+	t.Run("StartSuspended", func(t *testing.T) {
+		wf := &wfv1.Workflow{}
+		err := ApplySubmitOpts(wf, &wfv1.SubmitOpts{StartSuspended: true})
+		require.NoError(t, err)
+		require.NotNil(t, wf.Spec.Suspend)
+		assert.True(t, *wf.Spec.Suspend)
+	})
+	t.Run("StartAt", func(t *testing.T) {
+		wf := &wfv1.Workflow{}
+		startAt := metav1.NewTime(time.Date(2030, 1, 2, 3, 4, 5, 0, time.FixedZone("", 3600)))
+		err := ApplySubmitOpts(wf, &wfv1.SubmitOpts{StartAt: &startAt})
+		require.NoError(t, err)
+		require.NotNil(t, wf.Spec.Suspend)
+		assert.True(t, *wf.Spec.Suspend)
+		assert.Equal(t, "2030-01-02T02:04:05Z", wf.Annotations[common.AnnotationKeyStartAt])
+	})
+}
+
+func TestOverrideParametersFrom(t *testing.T) {
+	configMapKeyRef := &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "my-cm"}, Key: "my-key"}
+	newWf := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			Spec:   wfv1.WorkflowSpec{Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("default")}}}},
+			Status: wfv1.WorkflowStatus{StoredWorkflowSpec: &wfv1.WorkflowSpec{}},
+		}
+	}
+	t.Run("ConfigMapKey", func(t *testing.T) {
+		wf := newWf()
+		require.NoError(t, overrideParameters(wf, []string{"other=value"}, []wfv1.Parameter{{Name: "message", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef}}}))
+		expected := []wfv1.Parameter{
+			{Name: "other", Value: wfv1.AnyStringPtr("value")},
+			{Name: "message", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef}},
+		}
+		assert.Equal(t, expected, wf.Spec.Arguments.Parameters)
+		assert.Equal(t, expected, wf.Status.StoredWorkflowSpec.Arguments.Parameters)
+	})
+	for name, tt := range map[string]struct {
+		param wfv1.Parameter
+		err   string
+	}{
+		"NoName":         {wfv1.Parameter{ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef}}, "parameter overridden from a config map must have a name"},
+		"Value":          {wfv1.Parameter{Name: "message", Value: wfv1.AnyStringPtr("value"), ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef}}, `parameter "message" overridden from a config map must not have a value`},
+		"NoConfigMap":    {wfv1.Parameter{Name: "message", ValueFrom: &wfv1.ValueFrom{Path: "/tmp/message"}}, `parameter "message" must be overridden from a config map, with valueFrom.configMapKeyRef.name and key`},
+		"NoKey":          {wfv1.Parameter{Name: "message", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "my-cm"}}}}, `parameter "message" must be overridden from a config map, with valueFrom.configMapKeyRef.name and key`},
+		"AlsoOverridden": {wfv1.Parameter{Name: "other", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef}}, `parameter "other" is overridden more than once`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, overrideParameters(newWf(), []string{"other=value"}, []wfv1.Parameter{tt.param}), tt.err)
+		})
+	}
 }
 
 func TestReadParametersFile(t *testing.T) {
@@ -652,7 +728,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 				},
 			},
 		}
-		wf, err := FormulateResubmitWorkflow(ctx, wf, false, nil)
+		wf, err := FormulateResubmitWorkflow(ctx, wf, ResubmitOpts{})
 		require.NoError(t, err)
 		assert.Contains(t, wf.GetLabels(), common.LabelKeyControllerInstanceID)
 		assert.Contains(t, wf.GetLabels(), common.LabelKeyClusterWorkflowTemplate)
@@ -688,7 +764,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 			Email:             "bar.at.example.com",
 			PreferredUsername: "bar",
 		})
-		wf, err := FormulateResubmitWorkflow(ctx, wf, false, nil)
+		wf, err := FormulateResubmitWorkflow(ctx, wf, ResubmitOpts{})
 		require.NoError(t, err)
 		assert.Equal(t, "yyyy-yyyy-yyyy-yyyy", wf.Labels[common.LabelKeyCreator])
 		assert.Equal(t, "bar.at.example.com", wf.Labels[common.LabelKeyCreatorEmail])
@@ -710,7 +786,7 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 				},
 			},
 		}
-		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, false, nil)
+		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{})
 		require.NoError(t, err)
 		assert.Emptyf(t, wf.Labels[common.LabelKeyCreator], "should not %s label when a workflow is resubmitted by an unauthenticated request", common.LabelKeyCreator)
 		assert.Emptyf(t, wf.Labels[common.LabelKeyCreatorEmail], "should not %s label when a workflow is resubmitted by an unauthenticated request", common.LabelKeyCreatorEmail)
@@ -725,10 +801,46 @@ func TestFormulateResubmitWorkflow(t *testing.T) {
 				},
 			}},
 		}
-		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, false, []string{"message=modified"})
+		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{Parameters: []string{"message=modified"}})
 		require.NoError(t, err)
 		assert.Equal(t, "modified", wf.Spec.Arguments.Parameters[0].Value.String())
 	})
+	t.Run("OverrideParamsFromConfigMap", func(t *testing.T) {
+		wf := &wfv1.Workflow{
+			Spec: wfv1.WorkflowSpec{Arguments: wfv1.Arguments{
+				Parameters: []wfv1.Parameter{
+					{Name: "message", Value: wfv1.AnyStringPtr("default")},
+					{Name: "other", Value: wfv1.AnyStringPtr("default")},
+				},
+			}},
+		}
+		configMapKeyRef := &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "my-cm"}, Key: "message"}
+		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{ParametersFrom: []wfv1.Parameter{
+			{Name: "message", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef, Default: wfv1.AnyStringPtr("fallback")}},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []wfv1.Parameter{
+			{Name: "message", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: configMapKeyRef, Default: wfv1.AnyStringPtr("fallback")}},
+			{Name: "other", Value: wfv1.AnyStringPtr("default")},
+		}, wf.Spec.Arguments.Parameters)
+	})
+	t.Run("OverrideServiceAccount", func(t *testing.T) {
+		wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{ServiceAccountName: "original"}}
+		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{ServiceAccount: "other-sa"})
+		require.NoError(t, err)
+		assert.Equal(t, "other-sa", wf.Spec.ServiceAccountName)
+	})
+	t.Run("KeepServiceAccount", func(t *testing.T) {
+		wf := &wfv1.Workflow{Spec: wfv1.WorkflowSpec{ServiceAccountName: "original"}}
+		wf, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, "original", wf.Spec.ServiceAccountName)
+	})
+	t.Run("InvalidServiceAccount", func(t *testing.T) {
+		wf := &wfv1.Workflow{}
+		_, err := FormulateResubmitWorkflow(logging.TestContext(t.Context()), wf, ResubmitOpts{ServiceAccount: "Not_Valid"})
+		require.ErrorContains(t, err, `invalid service account name "Not_Valid"`)
+	})
 }
 
 var deepDeleteOfNodes = `
@@ -889,7 +1001,7 @@ func TestDeepDeleteNodes(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	wf, err := wfIf.Create(ctx, origWf, metav1.CreateOptions{})
 	require.NoError(t, err)
-	newWf, _, err := FormulateRetryWorkflow(ctx, wf, false, "", nil)
+	newWf, _, err := FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 	require.NoError(t, err)
 	newWfBytes, err := yaml.Marshal(newWf)
 	require.NoError(t, err)
@@ -1019,7 +1131,7 @@ func TestRetryExitHandler(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	wf, err := wfIf.Create(ctx, origWf, metav1.CreateOptions{})
 	require.NoError(t, err)
-	newWf, _, err := FormulateRetryWorkflow(ctx, wf, false, "", nil)
+	newWf, _, err := FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 	require.NoError(t, err)
 	newWfBytes, err := yaml.Marshal(newWf)
 	require.NoError(t, err)
@@ -1027,6 +1139,87 @@ func TestRetryExitHandler(t *testing.T) {
 	assert.NotContains(t, string(newWfBytes), "retry-script-6xt68-3924170365")
 }
 
+func TestOverrideRetryLimits(t *testing.T) {
+	newWf := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			Spec: wfv1.WorkflowSpec{Templates: []wfv1.Template{
+				{Name: "flaky", RetryStrategy: &wfv1.RetryStrategy{Limit: ptr.To(intstr.FromInt(2))}},
+				{Name: "steady"},
+			}},
+			Status: wfv1.WorkflowStatus{StoredWorkflowSpec: &wfv1.WorkflowSpec{Templates: []wfv1.Template{
+				{Name: "flaky", RetryStrategy: &wfv1.RetryStrategy{Limit: ptr.To(intstr.FromInt(2))}},
+				{Name: "referenced", RetryStrategy: &wfv1.RetryStrategy{Limit: ptr.To(intstr.FromString("{{inputs.parameters.limit}}"))}},
+			}}},
+		}
+	}
+	t.Run("Raised", func(t *testing.T) {
+		wf := newWf()
+		require.NoError(t, OverrideRetryLimits(wf, []string{"flaky=5", "referenced=4"}))
+		assert.Equal(t, intstr.FromInt(5), *wf.Spec.Templates[0].RetryStrategy.Limit)
+		assert.Equal(t, intstr.FromInt(5), *wf.Status.StoredWorkflowSpec.Templates[0].RetryStrategy.Limit)
+		assert.Equal(t, intstr.FromInt(4), *wf.Status.StoredWorkflowSpec.Templates[1].RetryStrategy.Limit)
+	})
+	for name, tt := range map[string]struct {
+		retryLimit string
+		err        string
+	}{
+		"Malformed":       {"flaky", "expected retry limit of the form: TEMPLATE=LIMIT. Received: flaky"},
+		"NotInteger":      {"flaky=many", "retry limit for template flaky must be an integer between 1 and 100. Received: many"},
+		"TooLarge":        {"flaky=101", "retry limit for template flaky must be an integer between 1 and 100. Received: 101"},
+		"Lowered":         {"flaky=1", "retry limit for template flaky cannot be lowered from 2 to 1"},
+		"NoRetryStrategy": {"steady=3", "template steady has no retryStrategy"},
+		"NotFound":        {"missing=3", "template missing not found"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, OverrideRetryLimits(newWf(), []string{tt.retryLimit}), tt.err)
+		})
+	}
+}
+
+func TestOverrideRetryEntrypoint(t *testing.T) {
+	newWf := func() *wfv1.Workflow {
+		return &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-wf"},
+			Spec:       wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main"}, {Name: "echo"}}},
+			Status: wfv1.WorkflowStatus{
+				StoredWorkflowSpec: &wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main"}, {Name: "referenced"}}},
+				Nodes: wfv1.Nodes{
+					"my-wf":   {ID: "my-wf", Name: "my-wf", Type: wfv1.NodeTypeSteps, TemplateName: "main", Phase: wfv1.NodeFailed, Children: []string{"my-wf-1"}},
+					"my-wf-1": {ID: "my-wf-1", Name: "my-wf[0].echo", Type: wfv1.NodeTypePod, TemplateName: "echo", Phase: wfv1.NodeSucceeded},
+				},
+			},
+		}
+	}
+	t.Run("Unchanged", func(t *testing.T) {
+		for _, entrypoint := range []string{"", "main"} {
+			wf := newWf()
+			podsToDelete, err := OverrideRetryEntrypoint(wf, entrypoint)
+			require.NoError(t, err)
+			assert.Empty(t, podsToDelete)
+			assert.Len(t, wf.Status.Nodes, 2)
+		}
+	})
+	t.Run("Changed", func(t *testing.T) {
+		wf := newWf()
+		podsToDelete, err := OverrideRetryEntrypoint(wf, "echo")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"my-wf-echo-3327348234"}, podsToDelete)
+		assert.Equal(t, "echo", wf.Spec.Entrypoint)
+		assert.Equal(t, "echo", wf.Status.StoredWorkflowSpec.Entrypoint)
+		assert.Empty(t, wf.Status.Nodes)
+	})
+	t.Run("StoredWorkflowSpec", func(t *testing.T) {
+		wf := newWf()
+		_, err := OverrideRetryEntrypoint(wf, "referenced")
+		require.NoError(t, err)
+		assert.Equal(t, "referenced", wf.Status.StoredWorkflowSpec.Entrypoint)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := OverrideRetryEntrypoint(newWf(), "missing")
+		require.EqualError(t, err, "entrypoint template missing not found")
+	})
+}
+
 func TestFormulateRetryWorkflow(t *testing.T) {
 	ctx := logging.TestContext(t.Context())
 	wfClient := argofake.NewSimpleClientset().ArgoprojV1alpha1().Workflows("my-ns")
@@ -1044,7 +1237,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, _, err = FormulateRetryWorkflow(ctx, wf, false, "", nil)
+		wf, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 		require.NoError(t, err)
 		assert.Len(t, wf.Status.Nodes, 1)
 	})
@@ -1075,7 +1268,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, _, err = FormulateRetryWorkflow(ctx, wf, true, "id=suspended", nil)
+		wf, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=suspended"})
 		require.NoError(t, err)
 		require.Len(t, wf.Status.Nodes, 2)
 		assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["wf-with-skipped-and-suspended-nodes"].Phase)
@@ -1099,7 +1292,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, _, err = FormulateRetryWorkflow(ctx, wf, true, "id=3", nil)
+		wf, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=3"})
 		require.NoError(t, err)
 		// Node #3, #4 are deleted and will be recreated so only 3 nodes left in wf.Status.Nodes
 		require.Len(t, wf.Status.Nodes, 3)
@@ -1126,7 +1319,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, _, err = FormulateRetryWorkflow(ctx, wf, true, "", nil)
+		wf, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true})
 		require.NoError(t, err)
 		// Node #2, #3, and #4 are deleted and will be recreated so only 2 nodes left in wf.Status.Nodes
 		require.Len(t, wf.Status.Nodes, 4)
@@ -1152,7 +1345,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 					"override-param-wf": {ID: "override-param-wf", Name: "override-param-wf", Phase: wfv1.NodeSucceeded, Type: wfv1.NodeTypeDAG},
 				}},
 		}
-		wf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, false, "", []string{"message=modified"})
+		wf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{Parameters: []string{"message=modified"}})
 		require.NoError(t, err)
 		assert.Equal(t, "modified", wf.Spec.Arguments.Parameters[0].Value.String())
 
@@ -1180,13 +1373,49 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 					}},
 				}},
 		}
-		wf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, false, "", []string{"message=modified"})
+		wf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{Parameters: []string{"message=modified"}})
 		require.NoError(t, err)
 		assert.Equal(t, "modified", wf.Spec.Arguments.Parameters[0].Value.String())
 		assert.Equal(t, "modified", wf.Status.StoredWorkflowSpec.Arguments.Parameters[0].Value.String())
 
 	})
 
+	t.Run("ClearOutputParameters", func(t *testing.T) {
+		newWf := func() *wfv1.Workflow {
+			return &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "outputs-wf", Labels: map[string]string{}},
+				Status: wfv1.WorkflowStatus{
+					Phase: wfv1.WorkflowFailed,
+					Nodes: map[string]wfv1.NodeStatus{
+						"outputs-wf": {ID: "outputs-wf", Name: "outputs-wf", Phase: wfv1.NodeFailed, Type: wfv1.NodeTypeDAG},
+					},
+					Outputs: &wfv1.Outputs{Parameters: []wfv1.Parameter{
+						{Name: "stale", Value: wfv1.AnyStringPtr("old")},
+						{Name: "kept", Value: wfv1.AnyStringPtr("fresh")},
+						{Name: "also-stale", Value: wfv1.AnyStringPtr("old")},
+					}},
+				},
+			}
+		}
+		t.Run("Named", func(t *testing.T) {
+			wf := newWf()
+			retried, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{ClearOutputParameters: []string{"stale", "also-stale", "missing"}})
+			require.NoError(t, err)
+			assert.Equal(t, []wfv1.Parameter{{Name: "kept", Value: wfv1.AnyStringPtr("fresh")}}, retried.Status.Outputs.Parameters)
+			assert.Len(t, wf.Status.Outputs.Parameters, 3, "the original workflow is unchanged")
+		})
+		t.Run("All", func(t *testing.T) {
+			retried, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), newWf(), RetryOpts{ClearOutputParameters: []string{ClearAllOutputParameters}})
+			require.NoError(t, err)
+			assert.Empty(t, retried.Status.Outputs.Parameters)
+		})
+		t.Run("None", func(t *testing.T) {
+			retried, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), newWf(), RetryOpts{})
+			require.NoError(t, err)
+			assert.Len(t, retried.Status.Outputs.Parameters, 3)
+		})
+	})
+
 	t.Run("Fail on running workflow", func(t *testing.T) {
 		wf := &wfv1.Workflow{
 			ObjectMeta: metav1.ObjectMeta{
@@ -1200,7 +1429,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		_, _, err = FormulateRetryWorkflow(ctx, wf, false, "", nil)
+		_, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 		require.Error(t, err)
 	})
 
@@ -1217,7 +1446,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		_, _, err = FormulateRetryWorkflow(ctx, wf, false, "", nil)
+		_, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 		require.Error(t, err)
 	})
 
@@ -1237,7 +1466,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		_, _, err = FormulateRetryWorkflow(ctx, wf, false, "", nil)
+		_, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 		require.Error(t, err)
 	})
 
@@ -1259,7 +1488,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, _, err = FormulateRetryWorkflow(ctx, wf, true, "id=4", nil)
+		wf, _, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=4"})
 		require.NoError(t, err)
 		// Node #4 is deleted and will be recreated so only 4 nodes left in wf.Status.Nodes
 		require.Len(t, wf.Status.Nodes, 4)
@@ -1289,7 +1518,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, false, "", nil)
+		wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, RetryOpts{})
 		require.NoError(t, err)
 		require.Len(t, wf.Status.Nodes, 4)
 		assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes["1"].Phase)
@@ -1315,7 +1544,7 @@ func TestFormulateRetryWorkflow(t *testing.T) {
 		}
 		_, err := wfClient.Create(ctx, wf, metav1.CreateOptions{})
 		require.NoError(t, err)
-		wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, true, "id=3", nil)
+		wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=3"})
 		require.NoError(t, err)
 		require.Len(t, wf.Status.Nodes, 2)
 		assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes["1"].Phase)
@@ -1863,14 +2092,14 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 	wf := wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
 
 	// Retry top individual pod node
-	wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step1", nil)
+	wf, podsToDelete, err := FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step1"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 1)
 	assert.Len(t, podsToDelete, 6)
 
 	// Retry top individual suspend node
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step2", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step2"})
 	require.NoError(t, err)
 	require.Len(t, wf.Status.Nodes, 2)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -1879,7 +2108,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the starting on first DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2"})
 	require.NoError(t, err)
 
 	assert.Len(t, wf.Status.Nodes, 9)
@@ -1897,7 +2126,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the starting on second DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 10)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -1916,7 +2145,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the first individual node (suspended node) connecting to the second DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step1", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step1"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 11)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -1936,7 +2165,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the second individual node (pod node) connecting to the second DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step2", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step2"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 12)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -1958,7 +2187,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the third individual node (pod node) connecting to the second DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step3", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step1.dag3-step3"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 13)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -1981,7 +2210,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the last individual node (suspend node) connecting to the second DAG in one of the branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step2", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step3-middle2.dag2-branch2-step2"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 14)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -2004,7 +2233,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the node that connects the two branches
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step4", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step4"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 15)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -2014,7 +2243,7 @@ func TestRetryWorkflowWithNestedDAGsWithSuspendNodes(t *testing.T) {
 
 	// Retry the last node (failing node)
 	wf = wfv1.MustUnmarshalWorkflow(retryWorkflowWithNestedDAGsWithSuspendNodes)
-	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, true, "name=fail-two-nested-dag-suspend.dag1-step5-tofail", nil)
+	wf, podsToDelete, err = FormulateRetryWorkflow(ctx, wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "name=fail-two-nested-dag-suspend.dag1-step5-tofail"})
 	require.NoError(t, err)
 	assert.Len(t, wf.Status.Nodes, 16)
 	assert.Equal(t, wfv1.NodeRunning, wf.Status.Nodes["fail-two-nested-dag-suspend"].Phase)
@@ -2279,7 +2508,7 @@ func TestStepsRetryWorkflow(t *testing.T) {
 			succeeded[node.ID] = true
 		}
 	}
-	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, true, selectorStr, []string{})
+	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: selectorStr})
 	require.NoError(err)
 	assert.Len(podsToDelete, 1)
 	assert.Len(newWf.Status.Nodes, 5)
@@ -2573,7 +2802,7 @@ func TestDAGDiamondRetryWorkflow(t *testing.T) {
 			succeeded[node.ID] = true
 		}
 	}
-	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, true, selectorStr, []string{})
+	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: selectorStr})
 
 	require.NoError(err)
 	assert.Len(podsToDelete, 2)
@@ -3000,7 +3229,7 @@ func TestOnExitWorkflowRetry(t *testing.T) {
 	}
 
 	selectorStr := "id=work-avoidance-trkkq-4183398008"
-	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, true, selectorStr, []string{})
+	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: selectorStr})
 	require.NoError(err)
 	assert.Len(newWf.Status.Nodes, 6)
 	assert.Len(podsToDelete, 2)
@@ -3153,7 +3382,7 @@ func TestOnExitWorkflow(t *testing.T) {
 	assert := assert.New(t)
 	wf := wfv1.MustUnmarshalWorkflow(onExitWorkflow)
 
-	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, false, "", []string{})
+	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{})
 	require.NoError(err)
 	assert.Len(podsToDelete, 1)
 	assert.Len(newWf.Status.Nodes, 1)
@@ -3903,7 +4132,7 @@ func TestNestedDAG(t *testing.T) {
 		}
 	}
 
-	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, true, "id=dag-nested-zxlc2-744943701", []string{})
+	newWf, podsToDelete, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=dag-nested-zxlc2-744943701"})
 	require.NoError(err)
 	assert.Len(podsToDelete, 2)
 
@@ -4176,7 +4405,7 @@ status:
 func TestRegressions(t *testing.T) {
 	t.Run("exit handler", func(t *testing.T) {
 		wf := wfv1.MustUnmarshalWorkflow(onExitPanic)
-		newWf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, true, "id=exit-handlers-n7s4n-975057257", []string{})
+		newWf, _, err := FormulateRetryWorkflow(logging.TestContext(t.Context()), wf, RetryOpts{RestartSuccessful: true, NodeFieldSelector: "id=exit-handlers-n7s4n-975057257"})
 		require.NoError(t, err)
 		// we can't really handle exit handlers granually yet
 		assert.Empty(t, newWf.Status.Nodes)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -192,6 +193,12 @@ func ValidateWorkflow(ctx context.Context, wftmplGetter templateresolution.Workf
 	if err != nil {
 		return err
 	}
+	if hasWorkflowTemplateRef {
+		err = validateArgumentsEnum("spec.arguments.", wf.Spec.Arguments, "spec.workflowTemplateRef.arguments.parameters.", wfSpecHolder.GetWorkflowSpec().Arguments.Parameters)
+		if err != nil {
+			return err
+		}
+	}
 	if len(wfArgs.Parameters) > 0 {
 		tctx.globalParams[common.GlobalVarWorkflowParameters] = placeholderGenerator.NextPlaceholder()
 		tctx.globalParams[common.GlobalVarWorkflowParametersJSON] = placeholderGenerator.NextPlaceholder()
@@ -255,10 +262,16 @@ func ValidateWorkflow(ctx context.Context, wftmplGetter templateresolution.Workf
 		if hasWorkflowTemplateRef {
 			tmpl = &wfv1.WorkflowStep{TemplateRef: wfTmplRef}
 		}
-		_, err = tctx.validateTemplateHolder(ctx, tmpl, tmplCtx, args, opts.WorkflowTemplateValidation)
+		resolvedTmpl, err := tctx.validateTemplateHolder(ctx, tmpl, tmplCtx, args, opts.WorkflowTemplateValidation)
 		if err != nil {
 			return err
 		}
+		if resolvedTmpl != nil && !opts.WorkflowTemplateValidation {
+			err = validateArgumentsEnum("spec.arguments.", wfArgs, fmt.Sprintf("templates.%s.inputs.parameters.", resolvedTmpl.Name), resolvedTmpl.Inputs.Parameters)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	// Validate OnExit hooks
@@ -933,6 +946,25 @@ func validateArgumentsValues(prefix string, arguments wfv1.Arguments, allowEmpty
 	return nil
 }
 
+// validateArgumentsEnum ensures that the argument parameter values are present in the enum of the parameters
+// they are passed to, which may be declared elsewhere (e.g. a referenced workflow template or the entrypoint's inputs).
+// Values which still contain unresolved expressions are not checked.
+func validateArgumentsEnum(prefix string, arguments wfv1.Arguments, enumPrefix string, enumParams []wfv1.Parameter) error {
+	for _, enumParam := range enumParams {
+		if len(enumParam.Enum) == 0 {
+			continue
+		}
+		param := arguments.GetParameterByName(enumParam.Name)
+		if param == nil || param.Value == nil || strings.Contains(param.Value.String(), "{{") {
+			continue
+		}
+		if !slices.Contains(enumParam.Enum, *param.Value) {
+			return errors.Errorf(errors.CodeBadRequest, "%s%s.value should be present in %s%s.enum list", prefix, param.Name, enumPrefix, enumParam.Name)
+		}
+	}
+	return nil
+}
+
 func (tctx *templateValidationCtx) validateSteps(ctx context.Context, scope map[string]interface{}, tmplCtx *templateresolution.TemplateContext, tmpl *wfv1.Template, workflowTemplateValidation bool) error {
 	err := validateNonLeaf(tmpl)
 	if err != nil {
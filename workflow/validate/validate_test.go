@@ -2,6 +2,7 @@ package validate
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -2736,6 +2737,96 @@ func TestWorkflowTemplateWithEnumValueWithoutValue(t *testing.T) {
 	require.EqualError(t, err, "spec.arguments.message.value or spec.arguments.message.valueFrom is required")
 }
 
+var workflowTemplateWithEnumArgument = `
+apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: template-with-enum-argument
+spec:
+  entrypoint: argosay
+  arguments:
+    parameters:
+      - name: message
+        value: one
+        enum:
+          - one
+          - two
+  templates:
+    - name: argosay
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, '{{workflow.parameters.message}}']
+`
+
+var workflowWithEnumArgumentTemplateRef = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: enum-ref-
+spec:
+  arguments:
+    parameters:
+      - name: message
+        value: %s
+  workflowTemplateRef:
+    name: template-with-enum-argument
+`
+
+func TestWorkflowTemplateRefWithArgumentEnum(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	require.NoError(t, createWorkflowTemplateFromSpec(ctx, workflowTemplateWithEnumArgument))
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validate(ctx, fmt.Sprintf(workflowWithEnumArgumentTemplateRef, "two")))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		err := validate(ctx, fmt.Sprintf(workflowWithEnumArgumentTemplateRef, "three"))
+		require.EqualError(t, err, "spec.arguments.message.value should be present in spec.workflowTemplateRef.arguments.parameters.message.enum list")
+	})
+	t.Run("Lint", func(t *testing.T) {
+		wf := unmarshalWf(fmt.Sprintf(workflowWithEnumArgumentTemplateRef, "three"))
+		err := ValidateWorkflow(ctx, wftmplGetter, cwftmplGetter, wf, nil, ValidateOpts{Lint: true})
+		require.EqualError(t, err, "spec.arguments.message.value should be present in spec.workflowTemplateRef.arguments.parameters.message.enum list")
+	})
+}
+
+var workflowWithEnumInput = `
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: enum-input-
+spec:
+  entrypoint: argosay
+  arguments:
+    parameters:
+      - name: message
+        value: %s
+  templates:
+    - name: argosay
+      inputs:
+        parameters:
+          - name: message
+            enum:
+              - one
+              - two
+      container:
+        image: argoproj/argosay:v2
+        args: [echo, '{{inputs.parameters.message}}']
+`
+
+func TestWorkflowWithEntrypointInputEnum(t *testing.T) {
+	ctx := logging.TestContext(t.Context())
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, validate(ctx, fmt.Sprintf(workflowWithEnumInput, "one")))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		err := validate(ctx, fmt.Sprintf(workflowWithEnumInput, "three"))
+		require.EqualError(t, err, "spec.arguments.message.value should be present in templates.argosay.inputs.parameters.message.enum list")
+	})
+	t.Run("Unresolved", func(t *testing.T) {
+		require.NoError(t, validate(ctx, fmt.Sprintf(workflowWithEnumInput, "'{{workflow.name}}'")))
+	})
+}
+
 var resourceManifestWithExpressions = `
 apiVersion: v1
 kind: Pod